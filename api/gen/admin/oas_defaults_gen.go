@@ -0,0 +1,15 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+// setDefaults set default value of fields.
+func (s *GeneratePromoCodesReq) setDefaults() {
+	{
+		val := int(1)
+		s.MaxRedemptions.SetTo(val)
+	}
+	{
+		val := int(1)
+		s.PerUserLimit.SetTo(val)
+	}
+}
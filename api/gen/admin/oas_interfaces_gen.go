@@ -0,0 +1,118 @@
+// Code generated by ogen, DO NOT EDIT.
+package api
+
+type AdjustUserBalanceRes interface {
+	adjustUserBalanceRes()
+}
+
+type ApproveAdjustmentRes interface {
+	approveAdjustmentRes()
+}
+
+type ClawbackOrderRes interface {
+	clawbackOrderRes()
+}
+
+type CreateCampaignRes interface {
+	createCampaignRes()
+}
+
+type CreateWebhookRes interface {
+	createWebhookRes()
+}
+
+type DisableWebhookRes interface {
+	disableWebhookRes()
+}
+
+type DryRunCampaignsRes interface {
+	dryRunCampaignsRes()
+}
+
+type ExportPromoCodesRes interface {
+	exportPromoCodesRes()
+}
+
+type GeneratePromoCodesRes interface {
+	generatePromoCodesRes()
+}
+
+type GetCampaignsRes interface {
+	getCampaignsRes()
+}
+
+type GetUserAdjustmentsRes interface {
+	getUserAdjustmentsRes()
+}
+
+type GetUserBalanceRes interface {
+	getUserBalanceRes()
+}
+
+type GetUserOrdersRes interface {
+	getUserOrdersRes()
+}
+
+type GetUserTransfersRes interface {
+	getUserTransfersRes()
+}
+
+type GetUserWithdrawalsRes interface {
+	getUserWithdrawalsRes()
+}
+
+type GetWebhookDeliveriesRes interface {
+	getWebhookDeliveriesRes()
+}
+
+type GetWebhooksRes interface {
+	getWebhooksRes()
+}
+
+type InvalidateOrderRes interface {
+	invalidateOrderRes()
+}
+
+type IssuePasswordResetRes interface {
+	issuePasswordResetRes()
+}
+
+type RefundWithdrawalRes interface {
+	refundWithdrawalRes()
+}
+
+type RejectAdjustmentRes interface {
+	rejectAdjustmentRes()
+}
+
+type RepollOrderRes interface {
+	repollOrderRes()
+}
+
+type ReverseAdjustmentRes interface {
+	reverseAdjustmentRes()
+}
+
+type ReverseTransferRes interface {
+	reverseTransferRes()
+}
+
+type SearchUsersRes interface {
+	searchUsersRes()
+}
+
+type SetUserRoleRes interface {
+	setUserRoleRes()
+}
+
+type SetUserShopRes interface {
+	setUserShopRes()
+}
+
+type UnlockUserRes interface {
+	unlockUserRes()
+}
+
+type UpdateCampaignRes interface {
+	updateCampaignRes()
+}
@@ -0,0 +1,3475 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// AdjustUserBalanceBadRequest is response for AdjustUserBalance operation.
+type AdjustUserBalanceBadRequest struct{}
+
+func (*AdjustUserBalanceBadRequest) adjustUserBalanceRes() {}
+
+// AdjustUserBalanceForbidden is response for AdjustUserBalance operation.
+type AdjustUserBalanceForbidden struct{}
+
+func (*AdjustUserBalanceForbidden) adjustUserBalanceRes() {}
+
+// AdjustUserBalanceInternalServerError is response for AdjustUserBalance operation.
+type AdjustUserBalanceInternalServerError struct{}
+
+func (*AdjustUserBalanceInternalServerError) adjustUserBalanceRes() {}
+
+// AdjustUserBalanceNotFound is response for AdjustUserBalance operation.
+type AdjustUserBalanceNotFound struct{}
+
+func (*AdjustUserBalanceNotFound) adjustUserBalanceRes() {}
+
+// AdjustUserBalancePaymentRequired is response for AdjustUserBalance operation.
+type AdjustUserBalancePaymentRequired struct{}
+
+func (*AdjustUserBalancePaymentRequired) adjustUserBalanceRes() {}
+
+type AdjustUserBalanceReq struct {
+	Amount float64          `json:"amount"`
+	Reason AdjustmentReason `json:"reason"`
+	Note   OptString        `json:"note"`
+}
+
+// GetAmount returns the value of Amount.
+func (s *AdjustUserBalanceReq) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetReason returns the value of Reason.
+func (s *AdjustUserBalanceReq) GetReason() AdjustmentReason {
+	return s.Reason
+}
+
+// GetNote returns the value of Note.
+func (s *AdjustUserBalanceReq) GetNote() OptString {
+	return s.Note
+}
+
+// SetAmount sets the value of Amount.
+func (s *AdjustUserBalanceReq) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetReason sets the value of Reason.
+func (s *AdjustUserBalanceReq) SetReason(val AdjustmentReason) {
+	s.Reason = val
+}
+
+// SetNote sets the value of Note.
+func (s *AdjustUserBalanceReq) SetNote(val OptString) {
+	s.Note = val
+}
+
+// AdjustUserBalanceUnauthorized is response for AdjustUserBalance operation.
+type AdjustUserBalanceUnauthorized struct{}
+
+func (*AdjustUserBalanceUnauthorized) adjustUserBalanceRes() {}
+
+// Ref: #/Adjustment
+type Adjustment struct {
+	ID         int64            `json:"id"`
+	UserID     int              `json:"user_id"`
+	Amount     float64          `json:"amount"`
+	Reason     AdjustmentReason `json:"reason"`
+	Note       string           `json:"note"`
+	Status     AdjustmentStatus `json:"status"`
+	OperatorID OptInt           `json:"operator_id"`
+	ApproverID OptInt           `json:"approver_id"`
+	ReversalOf OptInt64         `json:"reversal_of"`
+	CreatedAt  time.Time        `json:"created_at"`
+	ResolvedAt OptDateTime      `json:"resolved_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Adjustment) GetID() int64 {
+	return s.ID
+}
+
+// GetUserID returns the value of UserID.
+func (s *Adjustment) GetUserID() int {
+	return s.UserID
+}
+
+// GetAmount returns the value of Amount.
+func (s *Adjustment) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetReason returns the value of Reason.
+func (s *Adjustment) GetReason() AdjustmentReason {
+	return s.Reason
+}
+
+// GetNote returns the value of Note.
+func (s *Adjustment) GetNote() string {
+	return s.Note
+}
+
+// GetStatus returns the value of Status.
+func (s *Adjustment) GetStatus() AdjustmentStatus {
+	return s.Status
+}
+
+// GetOperatorID returns the value of OperatorID.
+func (s *Adjustment) GetOperatorID() OptInt {
+	return s.OperatorID
+}
+
+// GetApproverID returns the value of ApproverID.
+func (s *Adjustment) GetApproverID() OptInt {
+	return s.ApproverID
+}
+
+// GetReversalOf returns the value of ReversalOf.
+func (s *Adjustment) GetReversalOf() OptInt64 {
+	return s.ReversalOf
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Adjustment) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetResolvedAt returns the value of ResolvedAt.
+func (s *Adjustment) GetResolvedAt() OptDateTime {
+	return s.ResolvedAt
+}
+
+// SetID sets the value of ID.
+func (s *Adjustment) SetID(val int64) {
+	s.ID = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *Adjustment) SetUserID(val int) {
+	s.UserID = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Adjustment) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetReason sets the value of Reason.
+func (s *Adjustment) SetReason(val AdjustmentReason) {
+	s.Reason = val
+}
+
+// SetNote sets the value of Note.
+func (s *Adjustment) SetNote(val string) {
+	s.Note = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Adjustment) SetStatus(val AdjustmentStatus) {
+	s.Status = val
+}
+
+// SetOperatorID sets the value of OperatorID.
+func (s *Adjustment) SetOperatorID(val OptInt) {
+	s.OperatorID = val
+}
+
+// SetApproverID sets the value of ApproverID.
+func (s *Adjustment) SetApproverID(val OptInt) {
+	s.ApproverID = val
+}
+
+// SetReversalOf sets the value of ReversalOf.
+func (s *Adjustment) SetReversalOf(val OptInt64) {
+	s.ReversalOf = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Adjustment) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetResolvedAt sets the value of ResolvedAt.
+func (s *Adjustment) SetResolvedAt(val OptDateTime) {
+	s.ResolvedAt = val
+}
+
+func (*Adjustment) adjustUserBalanceRes() {}
+func (*Adjustment) approveAdjustmentRes() {}
+func (*Adjustment) rejectAdjustmentRes()  {}
+func (*Adjustment) reverseAdjustmentRes() {}
+
+// Ref: #/AdjustmentReason
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonGoodwill     AdjustmentReason = "goodwill"
+	AdjustmentReasonCompensation AdjustmentReason = "compensation"
+	AdjustmentReasonCorrection   AdjustmentReason = "correction"
+	AdjustmentReasonOther        AdjustmentReason = "other"
+	AdjustmentReasonReversal     AdjustmentReason = "reversal"
+)
+
+// AllValues returns all AdjustmentReason values.
+func (AdjustmentReason) AllValues() []AdjustmentReason {
+	return []AdjustmentReason{
+		AdjustmentReasonGoodwill,
+		AdjustmentReasonCompensation,
+		AdjustmentReasonCorrection,
+		AdjustmentReasonOther,
+		AdjustmentReasonReversal,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s AdjustmentReason) MarshalText() ([]byte, error) {
+	switch s {
+	case AdjustmentReasonGoodwill:
+		return []byte(s), nil
+	case AdjustmentReasonCompensation:
+		return []byte(s), nil
+	case AdjustmentReasonCorrection:
+		return []byte(s), nil
+	case AdjustmentReasonOther:
+		return []byte(s), nil
+	case AdjustmentReasonReversal:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *AdjustmentReason) UnmarshalText(data []byte) error {
+	switch AdjustmentReason(data) {
+	case AdjustmentReasonGoodwill:
+		*s = AdjustmentReasonGoodwill
+		return nil
+	case AdjustmentReasonCompensation:
+		*s = AdjustmentReasonCompensation
+		return nil
+	case AdjustmentReasonCorrection:
+		*s = AdjustmentReasonCorrection
+		return nil
+	case AdjustmentReasonOther:
+		*s = AdjustmentReasonOther
+		return nil
+	case AdjustmentReasonReversal:
+		*s = AdjustmentReasonReversal
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/AdjustmentStatus
+type AdjustmentStatus string
+
+const (
+	AdjustmentStatusPending  AdjustmentStatus = "pending"
+	AdjustmentStatusApplied  AdjustmentStatus = "applied"
+	AdjustmentStatusRejected AdjustmentStatus = "rejected"
+	AdjustmentStatusReversed AdjustmentStatus = "reversed"
+)
+
+// AllValues returns all AdjustmentStatus values.
+func (AdjustmentStatus) AllValues() []AdjustmentStatus {
+	return []AdjustmentStatus{
+		AdjustmentStatusPending,
+		AdjustmentStatusApplied,
+		AdjustmentStatusRejected,
+		AdjustmentStatusReversed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s AdjustmentStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case AdjustmentStatusPending:
+		return []byte(s), nil
+	case AdjustmentStatusApplied:
+		return []byte(s), nil
+	case AdjustmentStatusRejected:
+		return []byte(s), nil
+	case AdjustmentStatusReversed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *AdjustmentStatus) UnmarshalText(data []byte) error {
+	switch AdjustmentStatus(data) {
+	case AdjustmentStatusPending:
+		*s = AdjustmentStatusPending
+		return nil
+	case AdjustmentStatusApplied:
+		*s = AdjustmentStatusApplied
+		return nil
+	case AdjustmentStatusRejected:
+		*s = AdjustmentStatusRejected
+		return nil
+	case AdjustmentStatusReversed:
+		*s = AdjustmentStatusReversed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// ApproveAdjustmentConflict is response for ApproveAdjustment operation.
+type ApproveAdjustmentConflict struct{}
+
+func (*ApproveAdjustmentConflict) approveAdjustmentRes() {}
+
+// ApproveAdjustmentForbidden is response for ApproveAdjustment operation.
+type ApproveAdjustmentForbidden struct{}
+
+func (*ApproveAdjustmentForbidden) approveAdjustmentRes() {}
+
+// ApproveAdjustmentInternalServerError is response for ApproveAdjustment operation.
+type ApproveAdjustmentInternalServerError struct{}
+
+func (*ApproveAdjustmentInternalServerError) approveAdjustmentRes() {}
+
+// ApproveAdjustmentNotFound is response for ApproveAdjustment operation.
+type ApproveAdjustmentNotFound struct{}
+
+func (*ApproveAdjustmentNotFound) approveAdjustmentRes() {}
+
+// ApproveAdjustmentPaymentRequired is response for ApproveAdjustment operation.
+type ApproveAdjustmentPaymentRequired struct{}
+
+func (*ApproveAdjustmentPaymentRequired) approveAdjustmentRes() {}
+
+// ApproveAdjustmentUnauthorized is response for ApproveAdjustment operation.
+type ApproveAdjustmentUnauthorized struct{}
+
+func (*ApproveAdjustmentUnauthorized) approveAdjustmentRes() {}
+
+// Ref: #/Balance
+type Balance struct {
+	Current   float64 `json:"current"`
+	Withdrawn float64 `json:"withdrawn"`
+}
+
+// GetCurrent returns the value of Current.
+func (s *Balance) GetCurrent() float64 {
+	return s.Current
+}
+
+// GetWithdrawn returns the value of Withdrawn.
+func (s *Balance) GetWithdrawn() float64 {
+	return s.Withdrawn
+}
+
+// SetCurrent sets the value of Current.
+func (s *Balance) SetCurrent(val float64) {
+	s.Current = val
+}
+
+// SetWithdrawn sets the value of Withdrawn.
+func (s *Balance) SetWithdrawn(val float64) {
+	s.Withdrawn = val
+}
+
+func (*Balance) getUserBalanceRes() {}
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// Ref: #/Campaign
+type Campaign struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	Kind       CampaignKind `json:"kind"`
+	Value      float64      `json:"value"`
+	StartsAt   OptDateTime  `json:"starts_at"`
+	EndsAt     OptDateTime  `json:"ends_at"`
+	FirstOrder bool         `json:"first_order"`
+	MinAccrual float64      `json:"min_accrual"`
+	Channel    string       `json:"channel"`
+	Active     bool         `json:"active"`
+	CreatedBy  OptInt       `json:"created_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Campaign) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Campaign) GetName() string {
+	return s.Name
+}
+
+// GetKind returns the value of Kind.
+func (s *Campaign) GetKind() CampaignKind {
+	return s.Kind
+}
+
+// GetValue returns the value of Value.
+func (s *Campaign) GetValue() float64 {
+	return s.Value
+}
+
+// GetStartsAt returns the value of StartsAt.
+func (s *Campaign) GetStartsAt() OptDateTime {
+	return s.StartsAt
+}
+
+// GetEndsAt returns the value of EndsAt.
+func (s *Campaign) GetEndsAt() OptDateTime {
+	return s.EndsAt
+}
+
+// GetFirstOrder returns the value of FirstOrder.
+func (s *Campaign) GetFirstOrder() bool {
+	return s.FirstOrder
+}
+
+// GetMinAccrual returns the value of MinAccrual.
+func (s *Campaign) GetMinAccrual() float64 {
+	return s.MinAccrual
+}
+
+// GetChannel returns the value of Channel.
+func (s *Campaign) GetChannel() string {
+	return s.Channel
+}
+
+// GetActive returns the value of Active.
+func (s *Campaign) GetActive() bool {
+	return s.Active
+}
+
+// GetCreatedBy returns the value of CreatedBy.
+func (s *Campaign) GetCreatedBy() OptInt {
+	return s.CreatedBy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Campaign) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Campaign) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Campaign) SetName(val string) {
+	s.Name = val
+}
+
+// SetKind sets the value of Kind.
+func (s *Campaign) SetKind(val CampaignKind) {
+	s.Kind = val
+}
+
+// SetValue sets the value of Value.
+func (s *Campaign) SetValue(val float64) {
+	s.Value = val
+}
+
+// SetStartsAt sets the value of StartsAt.
+func (s *Campaign) SetStartsAt(val OptDateTime) {
+	s.StartsAt = val
+}
+
+// SetEndsAt sets the value of EndsAt.
+func (s *Campaign) SetEndsAt(val OptDateTime) {
+	s.EndsAt = val
+}
+
+// SetFirstOrder sets the value of FirstOrder.
+func (s *Campaign) SetFirstOrder(val bool) {
+	s.FirstOrder = val
+}
+
+// SetMinAccrual sets the value of MinAccrual.
+func (s *Campaign) SetMinAccrual(val float64) {
+	s.MinAccrual = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *Campaign) SetChannel(val string) {
+	s.Channel = val
+}
+
+// SetActive sets the value of Active.
+func (s *Campaign) SetActive(val bool) {
+	s.Active = val
+}
+
+// SetCreatedBy sets the value of CreatedBy.
+func (s *Campaign) SetCreatedBy(val OptInt) {
+	s.CreatedBy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Campaign) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*Campaign) createCampaignRes() {}
+func (*Campaign) updateCampaignRes() {}
+
+// Ref: #/CampaignBonus
+type CampaignBonus struct {
+	CampaignID int64   `json:"campaign_id"`
+	Name       string  `json:"name"`
+	Amount     float64 `json:"amount"`
+}
+
+// GetCampaignID returns the value of CampaignID.
+func (s *CampaignBonus) GetCampaignID() int64 {
+	return s.CampaignID
+}
+
+// GetName returns the value of Name.
+func (s *CampaignBonus) GetName() string {
+	return s.Name
+}
+
+// GetAmount returns the value of Amount.
+func (s *CampaignBonus) GetAmount() float64 {
+	return s.Amount
+}
+
+// SetCampaignID sets the value of CampaignID.
+func (s *CampaignBonus) SetCampaignID(val int64) {
+	s.CampaignID = val
+}
+
+// SetName sets the value of Name.
+func (s *CampaignBonus) SetName(val string) {
+	s.Name = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *CampaignBonus) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// Campaign that adds a bonus to processed orders matching all its conditions. Percent value is a
+// share of the order accrual, fixed value is points.
+// Ref: #/CampaignInput
+type CampaignInput struct {
+	Name  string       `json:"name"`
+	Kind  CampaignKind `json:"kind"`
+	Value float64      `json:"value"`
+	// Orders uploaded before this time do not match.
+	StartsAt OptDateTime `json:"starts_at"`
+	// Orders uploaded at or after this time do not match.
+	EndsAt OptDateTime `json:"ends_at"`
+	// Only the first processed order of a user matches.
+	FirstOrder OptBool `json:"first_order"`
+	// Minimum order accrual in points.
+	MinAccrual OptFloat64 `json:"min_accrual"`
+	// Upload channel of the order, e.g. app.
+	Channel OptString `json:"channel"`
+	Active  OptBool   `json:"active"`
+}
+
+// GetName returns the value of Name.
+func (s *CampaignInput) GetName() string {
+	return s.Name
+}
+
+// GetKind returns the value of Kind.
+func (s *CampaignInput) GetKind() CampaignKind {
+	return s.Kind
+}
+
+// GetValue returns the value of Value.
+func (s *CampaignInput) GetValue() float64 {
+	return s.Value
+}
+
+// GetStartsAt returns the value of StartsAt.
+func (s *CampaignInput) GetStartsAt() OptDateTime {
+	return s.StartsAt
+}
+
+// GetEndsAt returns the value of EndsAt.
+func (s *CampaignInput) GetEndsAt() OptDateTime {
+	return s.EndsAt
+}
+
+// GetFirstOrder returns the value of FirstOrder.
+func (s *CampaignInput) GetFirstOrder() OptBool {
+	return s.FirstOrder
+}
+
+// GetMinAccrual returns the value of MinAccrual.
+func (s *CampaignInput) GetMinAccrual() OptFloat64 {
+	return s.MinAccrual
+}
+
+// GetChannel returns the value of Channel.
+func (s *CampaignInput) GetChannel() OptString {
+	return s.Channel
+}
+
+// GetActive returns the value of Active.
+func (s *CampaignInput) GetActive() OptBool {
+	return s.Active
+}
+
+// SetName sets the value of Name.
+func (s *CampaignInput) SetName(val string) {
+	s.Name = val
+}
+
+// SetKind sets the value of Kind.
+func (s *CampaignInput) SetKind(val CampaignKind) {
+	s.Kind = val
+}
+
+// SetValue sets the value of Value.
+func (s *CampaignInput) SetValue(val float64) {
+	s.Value = val
+}
+
+// SetStartsAt sets the value of StartsAt.
+func (s *CampaignInput) SetStartsAt(val OptDateTime) {
+	s.StartsAt = val
+}
+
+// SetEndsAt sets the value of EndsAt.
+func (s *CampaignInput) SetEndsAt(val OptDateTime) {
+	s.EndsAt = val
+}
+
+// SetFirstOrder sets the value of FirstOrder.
+func (s *CampaignInput) SetFirstOrder(val OptBool) {
+	s.FirstOrder = val
+}
+
+// SetMinAccrual sets the value of MinAccrual.
+func (s *CampaignInput) SetMinAccrual(val OptFloat64) {
+	s.MinAccrual = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *CampaignInput) SetChannel(val OptString) {
+	s.Channel = val
+}
+
+// SetActive sets the value of Active.
+func (s *CampaignInput) SetActive(val OptBool) {
+	s.Active = val
+}
+
+// Ref: #/CampaignKind
+type CampaignKind string
+
+const (
+	CampaignKindPercent CampaignKind = "percent"
+	CampaignKindFixed   CampaignKind = "fixed"
+)
+
+// AllValues returns all CampaignKind values.
+func (CampaignKind) AllValues() []CampaignKind {
+	return []CampaignKind{
+		CampaignKindPercent,
+		CampaignKindFixed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s CampaignKind) MarshalText() ([]byte, error) {
+	switch s {
+	case CampaignKindPercent:
+		return []byte(s), nil
+	case CampaignKindFixed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *CampaignKind) UnmarshalText(data []byte) error {
+	switch CampaignKind(data) {
+	case CampaignKindPercent:
+		*s = CampaignKindPercent
+		return nil
+	case CampaignKindFixed:
+		*s = CampaignKindFixed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/Clawback
+type Clawback struct {
+	Order     string    `json:"order"`
+	UserID    int       `json:"user_id"`
+	Amount    float64   `json:"amount"`
+	Debt      float64   `json:"debt"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetOrder returns the value of Order.
+func (s *Clawback) GetOrder() string {
+	return s.Order
+}
+
+// GetUserID returns the value of UserID.
+func (s *Clawback) GetUserID() int {
+	return s.UserID
+}
+
+// GetAmount returns the value of Amount.
+func (s *Clawback) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetDebt returns the value of Debt.
+func (s *Clawback) GetDebt() float64 {
+	return s.Debt
+}
+
+// GetReason returns the value of Reason.
+func (s *Clawback) GetReason() string {
+	return s.Reason
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Clawback) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetOrder sets the value of Order.
+func (s *Clawback) SetOrder(val string) {
+	s.Order = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *Clawback) SetUserID(val int) {
+	s.UserID = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Clawback) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetDebt sets the value of Debt.
+func (s *Clawback) SetDebt(val float64) {
+	s.Debt = val
+}
+
+// SetReason sets the value of Reason.
+func (s *Clawback) SetReason(val string) {
+	s.Reason = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Clawback) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*Clawback) clawbackOrderRes() {}
+
+// ClawbackOrderBadRequest is response for ClawbackOrder operation.
+type ClawbackOrderBadRequest struct{}
+
+func (*ClawbackOrderBadRequest) clawbackOrderRes() {}
+
+// ClawbackOrderConflict is response for ClawbackOrder operation.
+type ClawbackOrderConflict struct{}
+
+func (*ClawbackOrderConflict) clawbackOrderRes() {}
+
+// ClawbackOrderForbidden is response for ClawbackOrder operation.
+type ClawbackOrderForbidden struct{}
+
+func (*ClawbackOrderForbidden) clawbackOrderRes() {}
+
+// ClawbackOrderInternalServerError is response for ClawbackOrder operation.
+type ClawbackOrderInternalServerError struct{}
+
+func (*ClawbackOrderInternalServerError) clawbackOrderRes() {}
+
+// ClawbackOrderNotFound is response for ClawbackOrder operation.
+type ClawbackOrderNotFound struct{}
+
+func (*ClawbackOrderNotFound) clawbackOrderRes() {}
+
+type ClawbackOrderReq struct {
+	Reason string `json:"reason"`
+}
+
+// GetReason returns the value of Reason.
+func (s *ClawbackOrderReq) GetReason() string {
+	return s.Reason
+}
+
+// SetReason sets the value of Reason.
+func (s *ClawbackOrderReq) SetReason(val string) {
+	s.Reason = val
+}
+
+// ClawbackOrderUnauthorized is response for ClawbackOrder operation.
+type ClawbackOrderUnauthorized struct{}
+
+func (*ClawbackOrderUnauthorized) clawbackOrderRes() {}
+
+// CreateCampaignBadRequest is response for CreateCampaign operation.
+type CreateCampaignBadRequest struct{}
+
+func (*CreateCampaignBadRequest) createCampaignRes() {}
+
+// CreateCampaignForbidden is response for CreateCampaign operation.
+type CreateCampaignForbidden struct{}
+
+func (*CreateCampaignForbidden) createCampaignRes() {}
+
+// CreateCampaignInternalServerError is response for CreateCampaign operation.
+type CreateCampaignInternalServerError struct{}
+
+func (*CreateCampaignInternalServerError) createCampaignRes() {}
+
+// CreateCampaignUnauthorized is response for CreateCampaign operation.
+type CreateCampaignUnauthorized struct{}
+
+func (*CreateCampaignUnauthorized) createCampaignRes() {}
+
+// CreateWebhookBadRequest is response for CreateWebhook operation.
+type CreateWebhookBadRequest struct{}
+
+func (*CreateWebhookBadRequest) createWebhookRes() {}
+
+// CreateWebhookForbidden is response for CreateWebhook operation.
+type CreateWebhookForbidden struct{}
+
+func (*CreateWebhookForbidden) createWebhookRes() {}
+
+// CreateWebhookInternalServerError is response for CreateWebhook operation.
+type CreateWebhookInternalServerError struct{}
+
+func (*CreateWebhookInternalServerError) createWebhookRes() {}
+
+// CreateWebhookUnauthorized is response for CreateWebhook operation.
+type CreateWebhookUnauthorized struct{}
+
+func (*CreateWebhookUnauthorized) createWebhookRes() {}
+
+// DisableWebhookForbidden is response for DisableWebhook operation.
+type DisableWebhookForbidden struct{}
+
+func (*DisableWebhookForbidden) disableWebhookRes() {}
+
+// DisableWebhookInternalServerError is response for DisableWebhook operation.
+type DisableWebhookInternalServerError struct{}
+
+func (*DisableWebhookInternalServerError) disableWebhookRes() {}
+
+// DisableWebhookNotFound is response for DisableWebhook operation.
+type DisableWebhookNotFound struct{}
+
+func (*DisableWebhookNotFound) disableWebhookRes() {}
+
+// DisableWebhookUnauthorized is response for DisableWebhook operation.
+type DisableWebhookUnauthorized struct{}
+
+func (*DisableWebhookUnauthorized) disableWebhookRes() {}
+
+// DryRunCampaignsBadRequest is response for DryRunCampaigns operation.
+type DryRunCampaignsBadRequest struct{}
+
+func (*DryRunCampaignsBadRequest) dryRunCampaignsRes() {}
+
+// DryRunCampaignsForbidden is response for DryRunCampaigns operation.
+type DryRunCampaignsForbidden struct{}
+
+func (*DryRunCampaignsForbidden) dryRunCampaignsRes() {}
+
+// DryRunCampaignsInternalServerError is response for DryRunCampaigns operation.
+type DryRunCampaignsInternalServerError struct{}
+
+func (*DryRunCampaignsInternalServerError) dryRunCampaignsRes() {}
+
+// DryRunCampaignsNotFound is response for DryRunCampaigns operation.
+type DryRunCampaignsNotFound struct{}
+
+func (*DryRunCampaignsNotFound) dryRunCampaignsRes() {}
+
+type DryRunCampaignsOKApplicationJSON []CampaignBonus
+
+func (*DryRunCampaignsOKApplicationJSON) dryRunCampaignsRes() {}
+
+type DryRunCampaignsReq struct {
+	UserID     OptInt           `json:"user_id"`
+	Accrual    float64          `json:"accrual"`
+	Channel    OptString        `json:"channel"`
+	UploadedAt OptDateTime      `json:"uploaded_at"`
+	Campaign   OptCampaignInput `json:"campaign"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *DryRunCampaignsReq) GetUserID() OptInt {
+	return s.UserID
+}
+
+// GetAccrual returns the value of Accrual.
+func (s *DryRunCampaignsReq) GetAccrual() float64 {
+	return s.Accrual
+}
+
+// GetChannel returns the value of Channel.
+func (s *DryRunCampaignsReq) GetChannel() OptString {
+	return s.Channel
+}
+
+// GetUploadedAt returns the value of UploadedAt.
+func (s *DryRunCampaignsReq) GetUploadedAt() OptDateTime {
+	return s.UploadedAt
+}
+
+// GetCampaign returns the value of Campaign.
+func (s *DryRunCampaignsReq) GetCampaign() OptCampaignInput {
+	return s.Campaign
+}
+
+// SetUserID sets the value of UserID.
+func (s *DryRunCampaignsReq) SetUserID(val OptInt) {
+	s.UserID = val
+}
+
+// SetAccrual sets the value of Accrual.
+func (s *DryRunCampaignsReq) SetAccrual(val float64) {
+	s.Accrual = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *DryRunCampaignsReq) SetChannel(val OptString) {
+	s.Channel = val
+}
+
+// SetUploadedAt sets the value of UploadedAt.
+func (s *DryRunCampaignsReq) SetUploadedAt(val OptDateTime) {
+	s.UploadedAt = val
+}
+
+// SetCampaign sets the value of Campaign.
+func (s *DryRunCampaignsReq) SetCampaign(val OptCampaignInput) {
+	s.Campaign = val
+}
+
+// DryRunCampaignsUnauthorized is response for DryRunCampaigns operation.
+type DryRunCampaignsUnauthorized struct{}
+
+func (*DryRunCampaignsUnauthorized) dryRunCampaignsRes() {}
+
+// ExportPromoCodesForbidden is response for ExportPromoCodes operation.
+type ExportPromoCodesForbidden struct{}
+
+func (*ExportPromoCodesForbidden) exportPromoCodesRes() {}
+
+// ExportPromoCodesInternalServerError is response for ExportPromoCodes operation.
+type ExportPromoCodesInternalServerError struct{}
+
+func (*ExportPromoCodesInternalServerError) exportPromoCodesRes() {}
+
+// ExportPromoCodesNotFound is response for ExportPromoCodes operation.
+type ExportPromoCodesNotFound struct{}
+
+func (*ExportPromoCodesNotFound) exportPromoCodesRes() {}
+
+type ExportPromoCodesOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportPromoCodesOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportPromoCodesOK) exportPromoCodesRes() {}
+
+// ExportPromoCodesUnauthorized is response for ExportPromoCodes operation.
+type ExportPromoCodesUnauthorized struct{}
+
+func (*ExportPromoCodesUnauthorized) exportPromoCodesRes() {}
+
+// GeneratePromoCodesBadRequest is response for GeneratePromoCodes operation.
+type GeneratePromoCodesBadRequest struct{}
+
+func (*GeneratePromoCodesBadRequest) generatePromoCodesRes() {}
+
+// GeneratePromoCodesConflict is response for GeneratePromoCodes operation.
+type GeneratePromoCodesConflict struct{}
+
+func (*GeneratePromoCodesConflict) generatePromoCodesRes() {}
+
+// GeneratePromoCodesForbidden is response for GeneratePromoCodes operation.
+type GeneratePromoCodesForbidden struct{}
+
+func (*GeneratePromoCodesForbidden) generatePromoCodesRes() {}
+
+// GeneratePromoCodesInternalServerError is response for GeneratePromoCodes operation.
+type GeneratePromoCodesInternalServerError struct{}
+
+func (*GeneratePromoCodesInternalServerError) generatePromoCodesRes() {}
+
+type GeneratePromoCodesReq struct {
+	Name   string    `json:"name"`
+	Count  OptInt    `json:"count"`
+	Prefix OptString `json:"prefix"`
+	Code   OptString `json:"code"`
+	Points float64   `json:"points"`
+	// Total redemptions of a code, 0 for unlimited.
+	MaxRedemptions OptInt `json:"max_redemptions"`
+	// Redemptions of a code by one user, 0 for unlimited.
+	PerUserLimit OptInt      `json:"per_user_limit"`
+	ExpiresAt    OptDateTime `json:"expires_at"`
+}
+
+// GetName returns the value of Name.
+func (s *GeneratePromoCodesReq) GetName() string {
+	return s.Name
+}
+
+// GetCount returns the value of Count.
+func (s *GeneratePromoCodesReq) GetCount() OptInt {
+	return s.Count
+}
+
+// GetPrefix returns the value of Prefix.
+func (s *GeneratePromoCodesReq) GetPrefix() OptString {
+	return s.Prefix
+}
+
+// GetCode returns the value of Code.
+func (s *GeneratePromoCodesReq) GetCode() OptString {
+	return s.Code
+}
+
+// GetPoints returns the value of Points.
+func (s *GeneratePromoCodesReq) GetPoints() float64 {
+	return s.Points
+}
+
+// GetMaxRedemptions returns the value of MaxRedemptions.
+func (s *GeneratePromoCodesReq) GetMaxRedemptions() OptInt {
+	return s.MaxRedemptions
+}
+
+// GetPerUserLimit returns the value of PerUserLimit.
+func (s *GeneratePromoCodesReq) GetPerUserLimit() OptInt {
+	return s.PerUserLimit
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *GeneratePromoCodesReq) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// SetName sets the value of Name.
+func (s *GeneratePromoCodesReq) SetName(val string) {
+	s.Name = val
+}
+
+// SetCount sets the value of Count.
+func (s *GeneratePromoCodesReq) SetCount(val OptInt) {
+	s.Count = val
+}
+
+// SetPrefix sets the value of Prefix.
+func (s *GeneratePromoCodesReq) SetPrefix(val OptString) {
+	s.Prefix = val
+}
+
+// SetCode sets the value of Code.
+func (s *GeneratePromoCodesReq) SetCode(val OptString) {
+	s.Code = val
+}
+
+// SetPoints sets the value of Points.
+func (s *GeneratePromoCodesReq) SetPoints(val float64) {
+	s.Points = val
+}
+
+// SetMaxRedemptions sets the value of MaxRedemptions.
+func (s *GeneratePromoCodesReq) SetMaxRedemptions(val OptInt) {
+	s.MaxRedemptions = val
+}
+
+// SetPerUserLimit sets the value of PerUserLimit.
+func (s *GeneratePromoCodesReq) SetPerUserLimit(val OptInt) {
+	s.PerUserLimit = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *GeneratePromoCodesReq) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// GeneratePromoCodesUnauthorized is response for GeneratePromoCodes operation.
+type GeneratePromoCodesUnauthorized struct{}
+
+func (*GeneratePromoCodesUnauthorized) generatePromoCodesRes() {}
+
+// GetCampaignsForbidden is response for GetCampaigns operation.
+type GetCampaignsForbidden struct{}
+
+func (*GetCampaignsForbidden) getCampaignsRes() {}
+
+// GetCampaignsInternalServerError is response for GetCampaigns operation.
+type GetCampaignsInternalServerError struct{}
+
+func (*GetCampaignsInternalServerError) getCampaignsRes() {}
+
+type GetCampaignsOKApplicationJSON []Campaign
+
+func (*GetCampaignsOKApplicationJSON) getCampaignsRes() {}
+
+// GetCampaignsUnauthorized is response for GetCampaigns operation.
+type GetCampaignsUnauthorized struct{}
+
+func (*GetCampaignsUnauthorized) getCampaignsRes() {}
+
+// GetUserAdjustmentsForbidden is response for GetUserAdjustments operation.
+type GetUserAdjustmentsForbidden struct{}
+
+func (*GetUserAdjustmentsForbidden) getUserAdjustmentsRes() {}
+
+// GetUserAdjustmentsInternalServerError is response for GetUserAdjustments operation.
+type GetUserAdjustmentsInternalServerError struct{}
+
+func (*GetUserAdjustmentsInternalServerError) getUserAdjustmentsRes() {}
+
+// GetUserAdjustmentsNoContent is response for GetUserAdjustments operation.
+type GetUserAdjustmentsNoContent struct{}
+
+func (*GetUserAdjustmentsNoContent) getUserAdjustmentsRes() {}
+
+// GetUserAdjustmentsNotFound is response for GetUserAdjustments operation.
+type GetUserAdjustmentsNotFound struct{}
+
+func (*GetUserAdjustmentsNotFound) getUserAdjustmentsRes() {}
+
+type GetUserAdjustmentsOKApplicationJSON []Adjustment
+
+func (*GetUserAdjustmentsOKApplicationJSON) getUserAdjustmentsRes() {}
+
+// GetUserAdjustmentsUnauthorized is response for GetUserAdjustments operation.
+type GetUserAdjustmentsUnauthorized struct{}
+
+func (*GetUserAdjustmentsUnauthorized) getUserAdjustmentsRes() {}
+
+// GetUserBalanceForbidden is response for GetUserBalance operation.
+type GetUserBalanceForbidden struct{}
+
+func (*GetUserBalanceForbidden) getUserBalanceRes() {}
+
+// GetUserBalanceInternalServerError is response for GetUserBalance operation.
+type GetUserBalanceInternalServerError struct{}
+
+func (*GetUserBalanceInternalServerError) getUserBalanceRes() {}
+
+// GetUserBalanceNoContent is response for GetUserBalance operation.
+type GetUserBalanceNoContent struct{}
+
+func (*GetUserBalanceNoContent) getUserBalanceRes() {}
+
+// GetUserBalanceNotFound is response for GetUserBalance operation.
+type GetUserBalanceNotFound struct{}
+
+func (*GetUserBalanceNotFound) getUserBalanceRes() {}
+
+// GetUserBalanceUnauthorized is response for GetUserBalance operation.
+type GetUserBalanceUnauthorized struct{}
+
+func (*GetUserBalanceUnauthorized) getUserBalanceRes() {}
+
+// GetUserOrdersForbidden is response for GetUserOrders operation.
+type GetUserOrdersForbidden struct{}
+
+func (*GetUserOrdersForbidden) getUserOrdersRes() {}
+
+// GetUserOrdersInternalServerError is response for GetUserOrders operation.
+type GetUserOrdersInternalServerError struct{}
+
+func (*GetUserOrdersInternalServerError) getUserOrdersRes() {}
+
+// GetUserOrdersNoContent is response for GetUserOrders operation.
+type GetUserOrdersNoContent struct{}
+
+func (*GetUserOrdersNoContent) getUserOrdersRes() {}
+
+// GetUserOrdersNotFound is response for GetUserOrders operation.
+type GetUserOrdersNotFound struct{}
+
+func (*GetUserOrdersNotFound) getUserOrdersRes() {}
+
+type GetUserOrdersOKApplicationJSON []GetUserOrdersOKItem
+
+func (*GetUserOrdersOKApplicationJSON) getUserOrdersRes() {}
+
+type GetUserOrdersOKItem struct {
+	Number     OptString   `json:"number"`
+	Status     OptString   `json:"status"`
+	Accrual    OptFloat64  `json:"accrual"`
+	UploadedAt OptDateTime `json:"uploaded_at"`
+}
+
+// GetNumber returns the value of Number.
+func (s *GetUserOrdersOKItem) GetNumber() OptString {
+	return s.Number
+}
+
+// GetStatus returns the value of Status.
+func (s *GetUserOrdersOKItem) GetStatus() OptString {
+	return s.Status
+}
+
+// GetAccrual returns the value of Accrual.
+func (s *GetUserOrdersOKItem) GetAccrual() OptFloat64 {
+	return s.Accrual
+}
+
+// GetUploadedAt returns the value of UploadedAt.
+func (s *GetUserOrdersOKItem) GetUploadedAt() OptDateTime {
+	return s.UploadedAt
+}
+
+// SetNumber sets the value of Number.
+func (s *GetUserOrdersOKItem) SetNumber(val OptString) {
+	s.Number = val
+}
+
+// SetStatus sets the value of Status.
+func (s *GetUserOrdersOKItem) SetStatus(val OptString) {
+	s.Status = val
+}
+
+// SetAccrual sets the value of Accrual.
+func (s *GetUserOrdersOKItem) SetAccrual(val OptFloat64) {
+	s.Accrual = val
+}
+
+// SetUploadedAt sets the value of UploadedAt.
+func (s *GetUserOrdersOKItem) SetUploadedAt(val OptDateTime) {
+	s.UploadedAt = val
+}
+
+// GetUserOrdersUnauthorized is response for GetUserOrders operation.
+type GetUserOrdersUnauthorized struct{}
+
+func (*GetUserOrdersUnauthorized) getUserOrdersRes() {}
+
+// GetUserTransfersForbidden is response for GetUserTransfers operation.
+type GetUserTransfersForbidden struct{}
+
+func (*GetUserTransfersForbidden) getUserTransfersRes() {}
+
+// GetUserTransfersInternalServerError is response for GetUserTransfers operation.
+type GetUserTransfersInternalServerError struct{}
+
+func (*GetUserTransfersInternalServerError) getUserTransfersRes() {}
+
+// GetUserTransfersNoContent is response for GetUserTransfers operation.
+type GetUserTransfersNoContent struct{}
+
+func (*GetUserTransfersNoContent) getUserTransfersRes() {}
+
+// GetUserTransfersNotFound is response for GetUserTransfers operation.
+type GetUserTransfersNotFound struct{}
+
+func (*GetUserTransfersNotFound) getUserTransfersRes() {}
+
+type GetUserTransfersOKApplicationJSON []Transfer
+
+func (*GetUserTransfersOKApplicationJSON) getUserTransfersRes() {}
+
+// GetUserTransfersUnauthorized is response for GetUserTransfers operation.
+type GetUserTransfersUnauthorized struct{}
+
+func (*GetUserTransfersUnauthorized) getUserTransfersRes() {}
+
+// GetUserWithdrawalsForbidden is response for GetUserWithdrawals operation.
+type GetUserWithdrawalsForbidden struct{}
+
+func (*GetUserWithdrawalsForbidden) getUserWithdrawalsRes() {}
+
+// GetUserWithdrawalsInternalServerError is response for GetUserWithdrawals operation.
+type GetUserWithdrawalsInternalServerError struct{}
+
+func (*GetUserWithdrawalsInternalServerError) getUserWithdrawalsRes() {}
+
+// GetUserWithdrawalsNoContent is response for GetUserWithdrawals operation.
+type GetUserWithdrawalsNoContent struct{}
+
+func (*GetUserWithdrawalsNoContent) getUserWithdrawalsRes() {}
+
+// GetUserWithdrawalsNotFound is response for GetUserWithdrawals operation.
+type GetUserWithdrawalsNotFound struct{}
+
+func (*GetUserWithdrawalsNotFound) getUserWithdrawalsRes() {}
+
+type GetUserWithdrawalsOKApplicationJSON []Withdrawal
+
+func (*GetUserWithdrawalsOKApplicationJSON) getUserWithdrawalsRes() {}
+
+// GetUserWithdrawalsUnauthorized is response for GetUserWithdrawals operation.
+type GetUserWithdrawalsUnauthorized struct{}
+
+func (*GetUserWithdrawalsUnauthorized) getUserWithdrawalsRes() {}
+
+// GetWebhookDeliveriesForbidden is response for GetWebhookDeliveries operation.
+type GetWebhookDeliveriesForbidden struct{}
+
+func (*GetWebhookDeliveriesForbidden) getWebhookDeliveriesRes() {}
+
+// GetWebhookDeliveriesInternalServerError is response for GetWebhookDeliveries operation.
+type GetWebhookDeliveriesInternalServerError struct{}
+
+func (*GetWebhookDeliveriesInternalServerError) getWebhookDeliveriesRes() {}
+
+// GetWebhookDeliveriesNotFound is response for GetWebhookDeliveries operation.
+type GetWebhookDeliveriesNotFound struct{}
+
+func (*GetWebhookDeliveriesNotFound) getWebhookDeliveriesRes() {}
+
+type GetWebhookDeliveriesOKApplicationJSON []WebhookDelivery
+
+func (*GetWebhookDeliveriesOKApplicationJSON) getWebhookDeliveriesRes() {}
+
+// GetWebhookDeliveriesUnauthorized is response for GetWebhookDeliveries operation.
+type GetWebhookDeliveriesUnauthorized struct{}
+
+func (*GetWebhookDeliveriesUnauthorized) getWebhookDeliveriesRes() {}
+
+// GetWebhooksForbidden is response for GetWebhooks operation.
+type GetWebhooksForbidden struct{}
+
+func (*GetWebhooksForbidden) getWebhooksRes() {}
+
+// GetWebhooksInternalServerError is response for GetWebhooks operation.
+type GetWebhooksInternalServerError struct{}
+
+func (*GetWebhooksInternalServerError) getWebhooksRes() {}
+
+type GetWebhooksOKApplicationJSON []Webhook
+
+func (*GetWebhooksOKApplicationJSON) getWebhooksRes() {}
+
+// GetWebhooksUnauthorized is response for GetWebhooks operation.
+type GetWebhooksUnauthorized struct{}
+
+func (*GetWebhooksUnauthorized) getWebhooksRes() {}
+
+// InvalidateOrderConflict is response for InvalidateOrder operation.
+type InvalidateOrderConflict struct{}
+
+func (*InvalidateOrderConflict) invalidateOrderRes() {}
+
+// InvalidateOrderForbidden is response for InvalidateOrder operation.
+type InvalidateOrderForbidden struct{}
+
+func (*InvalidateOrderForbidden) invalidateOrderRes() {}
+
+// InvalidateOrderInternalServerError is response for InvalidateOrder operation.
+type InvalidateOrderInternalServerError struct{}
+
+func (*InvalidateOrderInternalServerError) invalidateOrderRes() {}
+
+// InvalidateOrderNotFound is response for InvalidateOrder operation.
+type InvalidateOrderNotFound struct{}
+
+func (*InvalidateOrderNotFound) invalidateOrderRes() {}
+
+// InvalidateOrderOK is response for InvalidateOrder operation.
+type InvalidateOrderOK struct{}
+
+func (*InvalidateOrderOK) invalidateOrderRes() {}
+
+// InvalidateOrderUnauthorized is response for InvalidateOrder operation.
+type InvalidateOrderUnauthorized struct{}
+
+func (*InvalidateOrderUnauthorized) invalidateOrderRes() {}
+
+// IssuePasswordResetBadRequest is response for IssuePasswordReset operation.
+type IssuePasswordResetBadRequest struct{}
+
+func (*IssuePasswordResetBadRequest) issuePasswordResetRes() {}
+
+// IssuePasswordResetForbidden is response for IssuePasswordReset operation.
+type IssuePasswordResetForbidden struct{}
+
+func (*IssuePasswordResetForbidden) issuePasswordResetRes() {}
+
+// IssuePasswordResetInternalServerError is response for IssuePasswordReset operation.
+type IssuePasswordResetInternalServerError struct{}
+
+func (*IssuePasswordResetInternalServerError) issuePasswordResetRes() {}
+
+// IssuePasswordResetNotFound is response for IssuePasswordReset operation.
+type IssuePasswordResetNotFound struct{}
+
+func (*IssuePasswordResetNotFound) issuePasswordResetRes() {}
+
+type IssuePasswordResetOK struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetToken returns the value of Token.
+func (s *IssuePasswordResetOK) GetToken() string {
+	return s.Token
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *IssuePasswordResetOK) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetToken sets the value of Token.
+func (s *IssuePasswordResetOK) SetToken(val string) {
+	s.Token = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *IssuePasswordResetOK) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+func (*IssuePasswordResetOK) issuePasswordResetRes() {}
+
+// IssuePasswordResetUnauthorized is response for IssuePasswordReset operation.
+type IssuePasswordResetUnauthorized struct{}
+
+func (*IssuePasswordResetUnauthorized) issuePasswordResetRes() {}
+
+// Ref: #/Login
+type Login struct {
+	Login string `json:"login"`
+}
+
+// GetLogin returns the value of Login.
+func (s *Login) GetLogin() string {
+	return s.Login
+}
+
+// SetLogin sets the value of Login.
+func (s *Login) SetLogin(val string) {
+	s.Login = val
+}
+
+// NewOptAdjustUserBalanceReq returns new OptAdjustUserBalanceReq with value set to v.
+func NewOptAdjustUserBalanceReq(v AdjustUserBalanceReq) OptAdjustUserBalanceReq {
+	return OptAdjustUserBalanceReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAdjustUserBalanceReq is optional AdjustUserBalanceReq.
+type OptAdjustUserBalanceReq struct {
+	Value AdjustUserBalanceReq
+	Set   bool
+}
+
+// IsSet returns true if OptAdjustUserBalanceReq was set.
+func (o OptAdjustUserBalanceReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAdjustUserBalanceReq) Reset() {
+	var v AdjustUserBalanceReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAdjustUserBalanceReq) SetTo(v AdjustUserBalanceReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAdjustUserBalanceReq) Get() (v AdjustUserBalanceReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAdjustUserBalanceReq) Or(d AdjustUserBalanceReq) AdjustUserBalanceReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptCampaignInput returns new OptCampaignInput with value set to v.
+func NewOptCampaignInput(v CampaignInput) OptCampaignInput {
+	return OptCampaignInput{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCampaignInput is optional CampaignInput.
+type OptCampaignInput struct {
+	Value CampaignInput
+	Set   bool
+}
+
+// IsSet returns true if OptCampaignInput was set.
+func (o OptCampaignInput) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCampaignInput) Reset() {
+	var v CampaignInput
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCampaignInput) SetTo(v CampaignInput) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCampaignInput) Get() (v CampaignInput, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCampaignInput) Or(d CampaignInput) CampaignInput {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptClawbackOrderReq returns new OptClawbackOrderReq with value set to v.
+func NewOptClawbackOrderReq(v ClawbackOrderReq) OptClawbackOrderReq {
+	return OptClawbackOrderReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptClawbackOrderReq is optional ClawbackOrderReq.
+type OptClawbackOrderReq struct {
+	Value ClawbackOrderReq
+	Set   bool
+}
+
+// IsSet returns true if OptClawbackOrderReq was set.
+func (o OptClawbackOrderReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptClawbackOrderReq) Reset() {
+	var v ClawbackOrderReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptClawbackOrderReq) SetTo(v ClawbackOrderReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptClawbackOrderReq) Get() (v ClawbackOrderReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptClawbackOrderReq) Or(d ClawbackOrderReq) ClawbackOrderReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptLogin returns new OptLogin with value set to v.
+func NewOptLogin(v Login) OptLogin {
+	return OptLogin{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptLogin is optional Login.
+type OptLogin struct {
+	Value Login
+	Set   bool
+}
+
+// IsSet returns true if OptLogin was set.
+func (o OptLogin) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptLogin) Reset() {
+	var v Login
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptLogin) SetTo(v Login) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptLogin) Get() (v Login, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptLogin) Or(d Login) Login {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRefundWithdrawalReq returns new OptRefundWithdrawalReq with value set to v.
+func NewOptRefundWithdrawalReq(v RefundWithdrawalReq) OptRefundWithdrawalReq {
+	return OptRefundWithdrawalReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRefundWithdrawalReq is optional RefundWithdrawalReq.
+type OptRefundWithdrawalReq struct {
+	Value RefundWithdrawalReq
+	Set   bool
+}
+
+// IsSet returns true if OptRefundWithdrawalReq was set.
+func (o OptRefundWithdrawalReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRefundWithdrawalReq) Reset() {
+	var v RefundWithdrawalReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRefundWithdrawalReq) SetTo(v RefundWithdrawalReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRefundWithdrawalReq) Get() (v RefundWithdrawalReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRefundWithdrawalReq) Or(d RefundWithdrawalReq) RefundWithdrawalReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptReverseAdjustmentReq returns new OptReverseAdjustmentReq with value set to v.
+func NewOptReverseAdjustmentReq(v ReverseAdjustmentReq) OptReverseAdjustmentReq {
+	return OptReverseAdjustmentReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptReverseAdjustmentReq is optional ReverseAdjustmentReq.
+type OptReverseAdjustmentReq struct {
+	Value ReverseAdjustmentReq
+	Set   bool
+}
+
+// IsSet returns true if OptReverseAdjustmentReq was set.
+func (o OptReverseAdjustmentReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptReverseAdjustmentReq) Reset() {
+	var v ReverseAdjustmentReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptReverseAdjustmentReq) SetTo(v ReverseAdjustmentReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptReverseAdjustmentReq) Get() (v ReverseAdjustmentReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptReverseAdjustmentReq) Or(d ReverseAdjustmentReq) ReverseAdjustmentReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptReverseTransferReq returns new OptReverseTransferReq with value set to v.
+func NewOptReverseTransferReq(v ReverseTransferReq) OptReverseTransferReq {
+	return OptReverseTransferReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptReverseTransferReq is optional ReverseTransferReq.
+type OptReverseTransferReq struct {
+	Value ReverseTransferReq
+	Set   bool
+}
+
+// IsSet returns true if OptReverseTransferReq was set.
+func (o OptReverseTransferReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptReverseTransferReq) Reset() {
+	var v ReverseTransferReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptReverseTransferReq) SetTo(v ReverseTransferReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptReverseTransferReq) Get() (v ReverseTransferReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptReverseTransferReq) Or(d ReverseTransferReq) ReverseTransferReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSetUserRoleReq returns new OptSetUserRoleReq with value set to v.
+func NewOptSetUserRoleReq(v SetUserRoleReq) OptSetUserRoleReq {
+	return OptSetUserRoleReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSetUserRoleReq is optional SetUserRoleReq.
+type OptSetUserRoleReq struct {
+	Value SetUserRoleReq
+	Set   bool
+}
+
+// IsSet returns true if OptSetUserRoleReq was set.
+func (o OptSetUserRoleReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSetUserRoleReq) Reset() {
+	var v SetUserRoleReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSetUserRoleReq) SetTo(v SetUserRoleReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSetUserRoleReq) Get() (v SetUserRoleReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSetUserRoleReq) Or(d SetUserRoleReq) SetUserRoleReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSetUserShopReq returns new OptSetUserShopReq with value set to v.
+func NewOptSetUserShopReq(v SetUserShopReq) OptSetUserShopReq {
+	return OptSetUserShopReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSetUserShopReq is optional SetUserShopReq.
+type OptSetUserShopReq struct {
+	Value SetUserShopReq
+	Set   bool
+}
+
+// IsSet returns true if OptSetUserShopReq was set.
+func (o OptSetUserShopReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSetUserShopReq) Reset() {
+	var v SetUserShopReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSetUserShopReq) SetTo(v SetUserShopReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSetUserShopReq) Get() (v SetUserShopReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSetUserShopReq) Or(d SetUserShopReq) SetUserShopReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/PromoBatch
+type PromoBatch struct {
+	ID        int64       `json:"id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	Codes     []PromoCode `json:"codes"`
+}
+
+// GetID returns the value of ID.
+func (s *PromoBatch) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *PromoBatch) GetName() string {
+	return s.Name
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PromoBatch) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetCodes returns the value of Codes.
+func (s *PromoBatch) GetCodes() []PromoCode {
+	return s.Codes
+}
+
+// SetID sets the value of ID.
+func (s *PromoBatch) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *PromoBatch) SetName(val string) {
+	s.Name = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PromoBatch) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetCodes sets the value of Codes.
+func (s *PromoBatch) SetCodes(val []PromoCode) {
+	s.Codes = val
+}
+
+func (*PromoBatch) generatePromoCodesRes() {}
+
+// Ref: #/PromoCode
+type PromoCode struct {
+	Code           string      `json:"code"`
+	Points         float64     `json:"points"`
+	MaxRedemptions int         `json:"max_redemptions"`
+	PerUserLimit   int         `json:"per_user_limit"`
+	Redemptions    int         `json:"redemptions"`
+	ExpiresAt      OptDateTime `json:"expires_at"`
+}
+
+// GetCode returns the value of Code.
+func (s *PromoCode) GetCode() string {
+	return s.Code
+}
+
+// GetPoints returns the value of Points.
+func (s *PromoCode) GetPoints() float64 {
+	return s.Points
+}
+
+// GetMaxRedemptions returns the value of MaxRedemptions.
+func (s *PromoCode) GetMaxRedemptions() int {
+	return s.MaxRedemptions
+}
+
+// GetPerUserLimit returns the value of PerUserLimit.
+func (s *PromoCode) GetPerUserLimit() int {
+	return s.PerUserLimit
+}
+
+// GetRedemptions returns the value of Redemptions.
+func (s *PromoCode) GetRedemptions() int {
+	return s.Redemptions
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *PromoCode) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// SetCode sets the value of Code.
+func (s *PromoCode) SetCode(val string) {
+	s.Code = val
+}
+
+// SetPoints sets the value of Points.
+func (s *PromoCode) SetPoints(val float64) {
+	s.Points = val
+}
+
+// SetMaxRedemptions sets the value of MaxRedemptions.
+func (s *PromoCode) SetMaxRedemptions(val int) {
+	s.MaxRedemptions = val
+}
+
+// SetPerUserLimit sets the value of PerUserLimit.
+func (s *PromoCode) SetPerUserLimit(val int) {
+	s.PerUserLimit = val
+}
+
+// SetRedemptions sets the value of Redemptions.
+func (s *PromoCode) SetRedemptions(val int) {
+	s.Redemptions = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *PromoCode) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// RefundWithdrawalBadRequest is response for RefundWithdrawal operation.
+type RefundWithdrawalBadRequest struct{}
+
+func (*RefundWithdrawalBadRequest) refundWithdrawalRes() {}
+
+// RefundWithdrawalConflict is response for RefundWithdrawal operation.
+type RefundWithdrawalConflict struct{}
+
+func (*RefundWithdrawalConflict) refundWithdrawalRes() {}
+
+// RefundWithdrawalForbidden is response for RefundWithdrawal operation.
+type RefundWithdrawalForbidden struct{}
+
+func (*RefundWithdrawalForbidden) refundWithdrawalRes() {}
+
+// RefundWithdrawalInternalServerError is response for RefundWithdrawal operation.
+type RefundWithdrawalInternalServerError struct{}
+
+func (*RefundWithdrawalInternalServerError) refundWithdrawalRes() {}
+
+// RefundWithdrawalNotFound is response for RefundWithdrawal operation.
+type RefundWithdrawalNotFound struct{}
+
+func (*RefundWithdrawalNotFound) refundWithdrawalRes() {}
+
+type RefundWithdrawalReq struct {
+	// Sum to refund, the whole remaining withdrawn sum if omitted.
+	Sum    OptFloat64 `json:"sum"`
+	Reason string     `json:"reason"`
+}
+
+// GetSum returns the value of Sum.
+func (s *RefundWithdrawalReq) GetSum() OptFloat64 {
+	return s.Sum
+}
+
+// GetReason returns the value of Reason.
+func (s *RefundWithdrawalReq) GetReason() string {
+	return s.Reason
+}
+
+// SetSum sets the value of Sum.
+func (s *RefundWithdrawalReq) SetSum(val OptFloat64) {
+	s.Sum = val
+}
+
+// SetReason sets the value of Reason.
+func (s *RefundWithdrawalReq) SetReason(val string) {
+	s.Reason = val
+}
+
+// RefundWithdrawalUnauthorized is response for RefundWithdrawal operation.
+type RefundWithdrawalUnauthorized struct{}
+
+func (*RefundWithdrawalUnauthorized) refundWithdrawalRes() {}
+
+// RejectAdjustmentConflict is response for RejectAdjustment operation.
+type RejectAdjustmentConflict struct{}
+
+func (*RejectAdjustmentConflict) rejectAdjustmentRes() {}
+
+// RejectAdjustmentForbidden is response for RejectAdjustment operation.
+type RejectAdjustmentForbidden struct{}
+
+func (*RejectAdjustmentForbidden) rejectAdjustmentRes() {}
+
+// RejectAdjustmentInternalServerError is response for RejectAdjustment operation.
+type RejectAdjustmentInternalServerError struct{}
+
+func (*RejectAdjustmentInternalServerError) rejectAdjustmentRes() {}
+
+// RejectAdjustmentNotFound is response for RejectAdjustment operation.
+type RejectAdjustmentNotFound struct{}
+
+func (*RejectAdjustmentNotFound) rejectAdjustmentRes() {}
+
+// RejectAdjustmentUnauthorized is response for RejectAdjustment operation.
+type RejectAdjustmentUnauthorized struct{}
+
+func (*RejectAdjustmentUnauthorized) rejectAdjustmentRes() {}
+
+// RepollOrderAccepted is response for RepollOrder operation.
+type RepollOrderAccepted struct{}
+
+func (*RepollOrderAccepted) repollOrderRes() {}
+
+// RepollOrderConflict is response for RepollOrder operation.
+type RepollOrderConflict struct{}
+
+func (*RepollOrderConflict) repollOrderRes() {}
+
+// RepollOrderForbidden is response for RepollOrder operation.
+type RepollOrderForbidden struct{}
+
+func (*RepollOrderForbidden) repollOrderRes() {}
+
+// RepollOrderInternalServerError is response for RepollOrder operation.
+type RepollOrderInternalServerError struct{}
+
+func (*RepollOrderInternalServerError) repollOrderRes() {}
+
+// RepollOrderNotFound is response for RepollOrder operation.
+type RepollOrderNotFound struct{}
+
+func (*RepollOrderNotFound) repollOrderRes() {}
+
+// RepollOrderUnauthorized is response for RepollOrder operation.
+type RepollOrderUnauthorized struct{}
+
+func (*RepollOrderUnauthorized) repollOrderRes() {}
+
+// ReverseAdjustmentConflict is response for ReverseAdjustment operation.
+type ReverseAdjustmentConflict struct{}
+
+func (*ReverseAdjustmentConflict) reverseAdjustmentRes() {}
+
+// ReverseAdjustmentForbidden is response for ReverseAdjustment operation.
+type ReverseAdjustmentForbidden struct{}
+
+func (*ReverseAdjustmentForbidden) reverseAdjustmentRes() {}
+
+// ReverseAdjustmentInternalServerError is response for ReverseAdjustment operation.
+type ReverseAdjustmentInternalServerError struct{}
+
+func (*ReverseAdjustmentInternalServerError) reverseAdjustmentRes() {}
+
+// ReverseAdjustmentNotFound is response for ReverseAdjustment operation.
+type ReverseAdjustmentNotFound struct{}
+
+func (*ReverseAdjustmentNotFound) reverseAdjustmentRes() {}
+
+// ReverseAdjustmentPaymentRequired is response for ReverseAdjustment operation.
+type ReverseAdjustmentPaymentRequired struct{}
+
+func (*ReverseAdjustmentPaymentRequired) reverseAdjustmentRes() {}
+
+type ReverseAdjustmentReq struct {
+	Note OptString `json:"note"`
+}
+
+// GetNote returns the value of Note.
+func (s *ReverseAdjustmentReq) GetNote() OptString {
+	return s.Note
+}
+
+// SetNote sets the value of Note.
+func (s *ReverseAdjustmentReq) SetNote(val OptString) {
+	s.Note = val
+}
+
+// ReverseAdjustmentUnauthorized is response for ReverseAdjustment operation.
+type ReverseAdjustmentUnauthorized struct{}
+
+func (*ReverseAdjustmentUnauthorized) reverseAdjustmentRes() {}
+
+// ReverseTransferConflict is response for ReverseTransfer operation.
+type ReverseTransferConflict struct{}
+
+func (*ReverseTransferConflict) reverseTransferRes() {}
+
+// ReverseTransferForbidden is response for ReverseTransfer operation.
+type ReverseTransferForbidden struct{}
+
+func (*ReverseTransferForbidden) reverseTransferRes() {}
+
+// ReverseTransferInternalServerError is response for ReverseTransfer operation.
+type ReverseTransferInternalServerError struct{}
+
+func (*ReverseTransferInternalServerError) reverseTransferRes() {}
+
+// ReverseTransferNotFound is response for ReverseTransfer operation.
+type ReverseTransferNotFound struct{}
+
+func (*ReverseTransferNotFound) reverseTransferRes() {}
+
+// ReverseTransferPaymentRequired is response for ReverseTransfer operation.
+type ReverseTransferPaymentRequired struct{}
+
+func (*ReverseTransferPaymentRequired) reverseTransferRes() {}
+
+type ReverseTransferReq struct {
+	Note OptString `json:"note"`
+}
+
+// GetNote returns the value of Note.
+func (s *ReverseTransferReq) GetNote() OptString {
+	return s.Note
+}
+
+// SetNote sets the value of Note.
+func (s *ReverseTransferReq) SetNote(val OptString) {
+	s.Note = val
+}
+
+// ReverseTransferUnauthorized is response for ReverseTransfer operation.
+type ReverseTransferUnauthorized struct{}
+
+func (*ReverseTransferUnauthorized) reverseTransferRes() {}
+
+// Ref: #/Role
+type Role string
+
+const (
+	RoleUser    Role = "user"
+	RoleSupport Role = "support"
+	RoleAdmin   Role = "admin"
+	RoleService Role = "service"
+)
+
+// AllValues returns all Role values.
+func (Role) AllValues() []Role {
+	return []Role{
+		RoleUser,
+		RoleSupport,
+		RoleAdmin,
+		RoleService,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Role) MarshalText() ([]byte, error) {
+	switch s {
+	case RoleUser:
+		return []byte(s), nil
+	case RoleSupport:
+		return []byte(s), nil
+	case RoleAdmin:
+		return []byte(s), nil
+	case RoleService:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Role) UnmarshalText(data []byte) error {
+	switch Role(data) {
+	case RoleUser:
+		*s = RoleUser
+		return nil
+	case RoleSupport:
+		*s = RoleSupport
+		return nil
+	case RoleAdmin:
+		*s = RoleAdmin
+		return nil
+	case RoleService:
+		*s = RoleService
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// SearchUsersForbidden is response for SearchUsers operation.
+type SearchUsersForbidden struct{}
+
+func (*SearchUsersForbidden) searchUsersRes() {}
+
+// SearchUsersInternalServerError is response for SearchUsers operation.
+type SearchUsersInternalServerError struct{}
+
+func (*SearchUsersInternalServerError) searchUsersRes() {}
+
+type SearchUsersOKApplicationJSON []User
+
+func (*SearchUsersOKApplicationJSON) searchUsersRes() {}
+
+// SearchUsersUnauthorized is response for SearchUsers operation.
+type SearchUsersUnauthorized struct{}
+
+func (*SearchUsersUnauthorized) searchUsersRes() {}
+
+// SetUserRoleBadRequest is response for SetUserRole operation.
+type SetUserRoleBadRequest struct{}
+
+func (*SetUserRoleBadRequest) setUserRoleRes() {}
+
+// SetUserRoleForbidden is response for SetUserRole operation.
+type SetUserRoleForbidden struct{}
+
+func (*SetUserRoleForbidden) setUserRoleRes() {}
+
+// SetUserRoleInternalServerError is response for SetUserRole operation.
+type SetUserRoleInternalServerError struct{}
+
+func (*SetUserRoleInternalServerError) setUserRoleRes() {}
+
+// SetUserRoleNotFound is response for SetUserRole operation.
+type SetUserRoleNotFound struct{}
+
+func (*SetUserRoleNotFound) setUserRoleRes() {}
+
+// SetUserRoleOK is response for SetUserRole operation.
+type SetUserRoleOK struct{}
+
+func (*SetUserRoleOK) setUserRoleRes() {}
+
+type SetUserRoleReq struct {
+	Login string `json:"login"`
+	Role  Role   `json:"role"`
+}
+
+// GetLogin returns the value of Login.
+func (s *SetUserRoleReq) GetLogin() string {
+	return s.Login
+}
+
+// GetRole returns the value of Role.
+func (s *SetUserRoleReq) GetRole() Role {
+	return s.Role
+}
+
+// SetLogin sets the value of Login.
+func (s *SetUserRoleReq) SetLogin(val string) {
+	s.Login = val
+}
+
+// SetRole sets the value of Role.
+func (s *SetUserRoleReq) SetRole(val Role) {
+	s.Role = val
+}
+
+// SetUserRoleUnauthorized is response for SetUserRole operation.
+type SetUserRoleUnauthorized struct{}
+
+func (*SetUserRoleUnauthorized) setUserRoleRes() {}
+
+// SetUserShopBadRequest is response for SetUserShop operation.
+type SetUserShopBadRequest struct{}
+
+func (*SetUserShopBadRequest) setUserShopRes() {}
+
+// SetUserShopConflict is response for SetUserShop operation.
+type SetUserShopConflict struct{}
+
+func (*SetUserShopConflict) setUserShopRes() {}
+
+// SetUserShopForbidden is response for SetUserShop operation.
+type SetUserShopForbidden struct{}
+
+func (*SetUserShopForbidden) setUserShopRes() {}
+
+// SetUserShopInternalServerError is response for SetUserShop operation.
+type SetUserShopInternalServerError struct{}
+
+func (*SetUserShopInternalServerError) setUserShopRes() {}
+
+// SetUserShopNotFound is response for SetUserShop operation.
+type SetUserShopNotFound struct{}
+
+func (*SetUserShopNotFound) setUserShopRes() {}
+
+// SetUserShopOK is response for SetUserShop operation.
+type SetUserShopOK struct{}
+
+func (*SetUserShopOK) setUserShopRes() {}
+
+type SetUserShopReq struct {
+	Login string `json:"login"`
+	Shop  string `json:"shop"`
+}
+
+// GetLogin returns the value of Login.
+func (s *SetUserShopReq) GetLogin() string {
+	return s.Login
+}
+
+// GetShop returns the value of Shop.
+func (s *SetUserShopReq) GetShop() string {
+	return s.Shop
+}
+
+// SetLogin sets the value of Login.
+func (s *SetUserShopReq) SetLogin(val string) {
+	s.Login = val
+}
+
+// SetShop sets the value of Shop.
+func (s *SetUserShopReq) SetShop(val string) {
+	s.Shop = val
+}
+
+// SetUserShopUnauthorized is response for SetUserShop operation.
+type SetUserShopUnauthorized struct{}
+
+func (*SetUserShopUnauthorized) setUserShopRes() {}
+
+// Ref: #/Transfer
+type Transfer struct {
+	ID             int64          `json:"id"`
+	SenderID       int            `json:"sender_id"`
+	SenderLogin    string         `json:"sender_login"`
+	RecipientID    int            `json:"recipient_id"`
+	RecipientLogin string         `json:"recipient_login"`
+	Amount         float64        `json:"amount"`
+	Status         TransferStatus `json:"status"`
+	ReversalNote   OptString      `json:"reversal_note"`
+	ReversedBy     OptInt         `json:"reversed_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ReversedAt     OptDateTime    `json:"reversed_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Transfer) GetID() int64 {
+	return s.ID
+}
+
+// GetSenderID returns the value of SenderID.
+func (s *Transfer) GetSenderID() int {
+	return s.SenderID
+}
+
+// GetSenderLogin returns the value of SenderLogin.
+func (s *Transfer) GetSenderLogin() string {
+	return s.SenderLogin
+}
+
+// GetRecipientID returns the value of RecipientID.
+func (s *Transfer) GetRecipientID() int {
+	return s.RecipientID
+}
+
+// GetRecipientLogin returns the value of RecipientLogin.
+func (s *Transfer) GetRecipientLogin() string {
+	return s.RecipientLogin
+}
+
+// GetAmount returns the value of Amount.
+func (s *Transfer) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetStatus returns the value of Status.
+func (s *Transfer) GetStatus() TransferStatus {
+	return s.Status
+}
+
+// GetReversalNote returns the value of ReversalNote.
+func (s *Transfer) GetReversalNote() OptString {
+	return s.ReversalNote
+}
+
+// GetReversedBy returns the value of ReversedBy.
+func (s *Transfer) GetReversedBy() OptInt {
+	return s.ReversedBy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Transfer) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetReversedAt returns the value of ReversedAt.
+func (s *Transfer) GetReversedAt() OptDateTime {
+	return s.ReversedAt
+}
+
+// SetID sets the value of ID.
+func (s *Transfer) SetID(val int64) {
+	s.ID = val
+}
+
+// SetSenderID sets the value of SenderID.
+func (s *Transfer) SetSenderID(val int) {
+	s.SenderID = val
+}
+
+// SetSenderLogin sets the value of SenderLogin.
+func (s *Transfer) SetSenderLogin(val string) {
+	s.SenderLogin = val
+}
+
+// SetRecipientID sets the value of RecipientID.
+func (s *Transfer) SetRecipientID(val int) {
+	s.RecipientID = val
+}
+
+// SetRecipientLogin sets the value of RecipientLogin.
+func (s *Transfer) SetRecipientLogin(val string) {
+	s.RecipientLogin = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Transfer) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Transfer) SetStatus(val TransferStatus) {
+	s.Status = val
+}
+
+// SetReversalNote sets the value of ReversalNote.
+func (s *Transfer) SetReversalNote(val OptString) {
+	s.ReversalNote = val
+}
+
+// SetReversedBy sets the value of ReversedBy.
+func (s *Transfer) SetReversedBy(val OptInt) {
+	s.ReversedBy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Transfer) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetReversedAt sets the value of ReversedAt.
+func (s *Transfer) SetReversedAt(val OptDateTime) {
+	s.ReversedAt = val
+}
+
+func (*Transfer) reverseTransferRes() {}
+
+type TransferStatus string
+
+const (
+	TransferStatusCompleted TransferStatus = "completed"
+	TransferStatusReversed  TransferStatus = "reversed"
+)
+
+// AllValues returns all TransferStatus values.
+func (TransferStatus) AllValues() []TransferStatus {
+	return []TransferStatus{
+		TransferStatusCompleted,
+		TransferStatusReversed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransferStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case TransferStatusCompleted:
+		return []byte(s), nil
+	case TransferStatusReversed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransferStatus) UnmarshalText(data []byte) error {
+	switch TransferStatus(data) {
+	case TransferStatusCompleted:
+		*s = TransferStatusCompleted
+		return nil
+	case TransferStatusReversed:
+		*s = TransferStatusReversed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// UnlockUserBadRequest is response for UnlockUser operation.
+type UnlockUserBadRequest struct{}
+
+func (*UnlockUserBadRequest) unlockUserRes() {}
+
+// UnlockUserForbidden is response for UnlockUser operation.
+type UnlockUserForbidden struct{}
+
+func (*UnlockUserForbidden) unlockUserRes() {}
+
+// UnlockUserInternalServerError is response for UnlockUser operation.
+type UnlockUserInternalServerError struct{}
+
+func (*UnlockUserInternalServerError) unlockUserRes() {}
+
+// UnlockUserOK is response for UnlockUser operation.
+type UnlockUserOK struct{}
+
+func (*UnlockUserOK) unlockUserRes() {}
+
+// UnlockUserUnauthorized is response for UnlockUser operation.
+type UnlockUserUnauthorized struct{}
+
+func (*UnlockUserUnauthorized) unlockUserRes() {}
+
+// UpdateCampaignBadRequest is response for UpdateCampaign operation.
+type UpdateCampaignBadRequest struct{}
+
+func (*UpdateCampaignBadRequest) updateCampaignRes() {}
+
+// UpdateCampaignForbidden is response for UpdateCampaign operation.
+type UpdateCampaignForbidden struct{}
+
+func (*UpdateCampaignForbidden) updateCampaignRes() {}
+
+// UpdateCampaignInternalServerError is response for UpdateCampaign operation.
+type UpdateCampaignInternalServerError struct{}
+
+func (*UpdateCampaignInternalServerError) updateCampaignRes() {}
+
+// UpdateCampaignNotFound is response for UpdateCampaign operation.
+type UpdateCampaignNotFound struct{}
+
+func (*UpdateCampaignNotFound) updateCampaignRes() {}
+
+// UpdateCampaignUnauthorized is response for UpdateCampaign operation.
+type UpdateCampaignUnauthorized struct{}
+
+func (*UpdateCampaignUnauthorized) updateCampaignRes() {}
+
+// Ref: #/User
+type User struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+	Role  Role   `json:"role"`
+}
+
+// GetID returns the value of ID.
+func (s *User) GetID() int {
+	return s.ID
+}
+
+// GetLogin returns the value of Login.
+func (s *User) GetLogin() string {
+	return s.Login
+}
+
+// GetRole returns the value of Role.
+func (s *User) GetRole() Role {
+	return s.Role
+}
+
+// SetID sets the value of ID.
+func (s *User) SetID(val int) {
+	s.ID = val
+}
+
+// SetLogin sets the value of Login.
+func (s *User) SetLogin(val string) {
+	s.Login = val
+}
+
+// SetRole sets the value of Role.
+func (s *User) SetRole(val Role) {
+	s.Role = val
+}
+
+// Ref: #/Webhook
+type Webhook struct {
+	ID     int64          `json:"id"`
+	URL    string         `json:"url"`
+	Shop   string         `json:"shop"`
+	Events []WebhookEvent `json:"events"`
+	Active bool           `json:"active"`
+	// Key of the payload signature, returned only on registration.
+	Secret    OptString `json:"secret"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Webhook) GetID() int64 {
+	return s.ID
+}
+
+// GetURL returns the value of URL.
+func (s *Webhook) GetURL() string {
+	return s.URL
+}
+
+// GetShop returns the value of Shop.
+func (s *Webhook) GetShop() string {
+	return s.Shop
+}
+
+// GetEvents returns the value of Events.
+func (s *Webhook) GetEvents() []WebhookEvent {
+	return s.Events
+}
+
+// GetActive returns the value of Active.
+func (s *Webhook) GetActive() bool {
+	return s.Active
+}
+
+// GetSecret returns the value of Secret.
+func (s *Webhook) GetSecret() OptString {
+	return s.Secret
+}
+
+// GetCreatedBy returns the value of CreatedBy.
+func (s *Webhook) GetCreatedBy() int {
+	return s.CreatedBy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Webhook) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Webhook) SetID(val int64) {
+	s.ID = val
+}
+
+// SetURL sets the value of URL.
+func (s *Webhook) SetURL(val string) {
+	s.URL = val
+}
+
+// SetShop sets the value of Shop.
+func (s *Webhook) SetShop(val string) {
+	s.Shop = val
+}
+
+// SetEvents sets the value of Events.
+func (s *Webhook) SetEvents(val []WebhookEvent) {
+	s.Events = val
+}
+
+// SetActive sets the value of Active.
+func (s *Webhook) SetActive(val bool) {
+	s.Active = val
+}
+
+// SetSecret sets the value of Secret.
+func (s *Webhook) SetSecret(val OptString) {
+	s.Secret = val
+}
+
+// SetCreatedBy sets the value of CreatedBy.
+func (s *Webhook) SetCreatedBy(val int) {
+	s.CreatedBy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Webhook) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*Webhook) createWebhookRes()  {}
+func (*Webhook) disableWebhookRes() {}
+
+// Ref: #/WebhookAttempt
+type WebhookAttempt struct {
+	Attempt int `json:"attempt"`
+	// Response status code, 0 when no response is received.
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetAttempt returns the value of Attempt.
+func (s *WebhookAttempt) GetAttempt() int {
+	return s.Attempt
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *WebhookAttempt) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetError returns the value of Error.
+func (s *WebhookAttempt) GetError() string {
+	return s.Error
+}
+
+// GetDurationMs returns the value of DurationMs.
+func (s *WebhookAttempt) GetDurationMs() int64 {
+	return s.DurationMs
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WebhookAttempt) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetAttempt sets the value of Attempt.
+func (s *WebhookAttempt) SetAttempt(val int) {
+	s.Attempt = val
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *WebhookAttempt) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetError sets the value of Error.
+func (s *WebhookAttempt) SetError(val string) {
+	s.Error = val
+}
+
+// SetDurationMs sets the value of DurationMs.
+func (s *WebhookAttempt) SetDurationMs(val int64) {
+	s.DurationMs = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WebhookAttempt) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/WebhookDelivery
+type WebhookDelivery struct {
+	ID            int64                 `json:"id"`
+	EventID       int64                 `json:"event_id"`
+	Event         WebhookEvent          `json:"event"`
+	Order         string                `json:"order"`
+	Amount        float64               `json:"amount"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	LastError     string                `json:"last_error"`
+	CreatedAt     time.Time             `json:"created_at"`
+	DeliveredAt   OptDateTime           `json:"delivered_at"`
+	History       []WebhookAttempt      `json:"history"`
+}
+
+// GetID returns the value of ID.
+func (s *WebhookDelivery) GetID() int64 {
+	return s.ID
+}
+
+// GetEventID returns the value of EventID.
+func (s *WebhookDelivery) GetEventID() int64 {
+	return s.EventID
+}
+
+// GetEvent returns the value of Event.
+func (s *WebhookDelivery) GetEvent() WebhookEvent {
+	return s.Event
+}
+
+// GetOrder returns the value of Order.
+func (s *WebhookDelivery) GetOrder() string {
+	return s.Order
+}
+
+// GetAmount returns the value of Amount.
+func (s *WebhookDelivery) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetStatus returns the value of Status.
+func (s *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	return s.Status
+}
+
+// GetAttempts returns the value of Attempts.
+func (s *WebhookDelivery) GetAttempts() int {
+	return s.Attempts
+}
+
+// GetNextAttemptAt returns the value of NextAttemptAt.
+func (s *WebhookDelivery) GetNextAttemptAt() time.Time {
+	return s.NextAttemptAt
+}
+
+// GetLastError returns the value of LastError.
+func (s *WebhookDelivery) GetLastError() string {
+	return s.LastError
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WebhookDelivery) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetDeliveredAt returns the value of DeliveredAt.
+func (s *WebhookDelivery) GetDeliveredAt() OptDateTime {
+	return s.DeliveredAt
+}
+
+// GetHistory returns the value of History.
+func (s *WebhookDelivery) GetHistory() []WebhookAttempt {
+	return s.History
+}
+
+// SetID sets the value of ID.
+func (s *WebhookDelivery) SetID(val int64) {
+	s.ID = val
+}
+
+// SetEventID sets the value of EventID.
+func (s *WebhookDelivery) SetEventID(val int64) {
+	s.EventID = val
+}
+
+// SetEvent sets the value of Event.
+func (s *WebhookDelivery) SetEvent(val WebhookEvent) {
+	s.Event = val
+}
+
+// SetOrder sets the value of Order.
+func (s *WebhookDelivery) SetOrder(val string) {
+	s.Order = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *WebhookDelivery) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetStatus sets the value of Status.
+func (s *WebhookDelivery) SetStatus(val WebhookDeliveryStatus) {
+	s.Status = val
+}
+
+// SetAttempts sets the value of Attempts.
+func (s *WebhookDelivery) SetAttempts(val int) {
+	s.Attempts = val
+}
+
+// SetNextAttemptAt sets the value of NextAttemptAt.
+func (s *WebhookDelivery) SetNextAttemptAt(val time.Time) {
+	s.NextAttemptAt = val
+}
+
+// SetLastError sets the value of LastError.
+func (s *WebhookDelivery) SetLastError(val string) {
+	s.LastError = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WebhookDelivery) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetDeliveredAt sets the value of DeliveredAt.
+func (s *WebhookDelivery) SetDeliveredAt(val OptDateTime) {
+	s.DeliveredAt = val
+}
+
+// SetHistory sets the value of History.
+func (s *WebhookDelivery) SetHistory(val []WebhookAttempt) {
+	s.History = val
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// AllValues returns all WebhookDeliveryStatus values.
+func (WebhookDeliveryStatus) AllValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookDeliveryStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookDeliveryStatusPending:
+		return []byte(s), nil
+	case WebhookDeliveryStatusDelivered:
+		return []byte(s), nil
+	case WebhookDeliveryStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookDeliveryStatus) UnmarshalText(data []byte) error {
+	switch WebhookDeliveryStatus(data) {
+	case WebhookDeliveryStatusPending:
+		*s = WebhookDeliveryStatusPending
+		return nil
+	case WebhookDeliveryStatusDelivered:
+		*s = WebhookDeliveryStatusDelivered
+		return nil
+	case WebhookDeliveryStatusFailed:
+		*s = WebhookDeliveryStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/WebhookEvent
+type WebhookEvent string
+
+const (
+	WebhookEventAccrual    WebhookEvent = "accrual"
+	WebhookEventWithdrawal WebhookEvent = "withdrawal"
+)
+
+// AllValues returns all WebhookEvent values.
+func (WebhookEvent) AllValues() []WebhookEvent {
+	return []WebhookEvent{
+		WebhookEventAccrual,
+		WebhookEventWithdrawal,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookEvent) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookEventAccrual:
+		return []byte(s), nil
+	case WebhookEventWithdrawal:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookEvent) UnmarshalText(data []byte) error {
+	switch WebhookEvent(data) {
+	case WebhookEventAccrual:
+		*s = WebhookEventAccrual
+		return nil
+	case WebhookEventWithdrawal:
+		*s = WebhookEventWithdrawal
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/WebhookInput
+type WebhookInput struct {
+	// Http or https address events are sent to.
+	URL string `json:"url"`
+	// Order number prefix of the shop. Service accounts register webhooks only for the shop they are
+	// bound to, an empty prefix for all orders is allowed only for administrators.
+	Shop   OptString      `json:"shop"`
+	Events []WebhookEvent `json:"events"`
+}
+
+// GetURL returns the value of URL.
+func (s *WebhookInput) GetURL() string {
+	return s.URL
+}
+
+// GetShop returns the value of Shop.
+func (s *WebhookInput) GetShop() OptString {
+	return s.Shop
+}
+
+// GetEvents returns the value of Events.
+func (s *WebhookInput) GetEvents() []WebhookEvent {
+	return s.Events
+}
+
+// SetURL sets the value of URL.
+func (s *WebhookInput) SetURL(val string) {
+	s.URL = val
+}
+
+// SetShop sets the value of Shop.
+func (s *WebhookInput) SetShop(val OptString) {
+	s.Shop = val
+}
+
+// SetEvents sets the value of Events.
+func (s *WebhookInput) SetEvents(val []WebhookEvent) {
+	s.Events = val
+}
+
+// Ref: #/Withdrawal
+type Withdrawal struct {
+	Order       string           `json:"order"`
+	Sum         float64          `json:"sum"`
+	Refunded    float64          `json:"refunded"`
+	Status      WithdrawalStatus `json:"status"`
+	ProcessedAt time.Time        `json:"processed_at"`
+}
+
+// GetOrder returns the value of Order.
+func (s *Withdrawal) GetOrder() string {
+	return s.Order
+}
+
+// GetSum returns the value of Sum.
+func (s *Withdrawal) GetSum() float64 {
+	return s.Sum
+}
+
+// GetRefunded returns the value of Refunded.
+func (s *Withdrawal) GetRefunded() float64 {
+	return s.Refunded
+}
+
+// GetStatus returns the value of Status.
+func (s *Withdrawal) GetStatus() WithdrawalStatus {
+	return s.Status
+}
+
+// GetProcessedAt returns the value of ProcessedAt.
+func (s *Withdrawal) GetProcessedAt() time.Time {
+	return s.ProcessedAt
+}
+
+// SetOrder sets the value of Order.
+func (s *Withdrawal) SetOrder(val string) {
+	s.Order = val
+}
+
+// SetSum sets the value of Sum.
+func (s *Withdrawal) SetSum(val float64) {
+	s.Sum = val
+}
+
+// SetRefunded sets the value of Refunded.
+func (s *Withdrawal) SetRefunded(val float64) {
+	s.Refunded = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Withdrawal) SetStatus(val WithdrawalStatus) {
+	s.Status = val
+}
+
+// SetProcessedAt sets the value of ProcessedAt.
+func (s *Withdrawal) SetProcessedAt(val time.Time) {
+	s.ProcessedAt = val
+}
+
+func (*Withdrawal) refundWithdrawalRes() {}
+
+// Ref: #/WithdrawalStatus
+type WithdrawalStatus string
+
+const (
+	WithdrawalStatusWITHDRAWN         WithdrawalStatus = "WITHDRAWN"
+	WithdrawalStatusPARTIALLYREFUNDED WithdrawalStatus = "PARTIALLY_REFUNDED"
+	WithdrawalStatusREFUNDED          WithdrawalStatus = "REFUNDED"
+)
+
+// AllValues returns all WithdrawalStatus values.
+func (WithdrawalStatus) AllValues() []WithdrawalStatus {
+	return []WithdrawalStatus{
+		WithdrawalStatusWITHDRAWN,
+		WithdrawalStatusPARTIALLYREFUNDED,
+		WithdrawalStatusREFUNDED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WithdrawalStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case WithdrawalStatusWITHDRAWN:
+		return []byte(s), nil
+	case WithdrawalStatusPARTIALLYREFUNDED:
+		return []byte(s), nil
+	case WithdrawalStatusREFUNDED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WithdrawalStatus) UnmarshalText(data []byte) error {
+	switch WithdrawalStatus(data) {
+	case WithdrawalStatusWITHDRAWN:
+		*s = WithdrawalStatusWITHDRAWN
+		return nil
+	case WithdrawalStatusPARTIALLYREFUNDED:
+		*s = WithdrawalStatusPARTIALLYREFUNDED
+		return nil
+	case WithdrawalStatusREFUNDED:
+		*s = WithdrawalStatusREFUNDED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
@@ -0,0 +1,168 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// AdjustUserBalance implements adjustUserBalance operation.
+	//
+	// POST /api/admin/users/{userId}/balance/adjustments
+	AdjustUserBalance(ctx context.Context, req OptAdjustUserBalanceReq, params AdjustUserBalanceParams) (AdjustUserBalanceRes, error)
+	// ApproveAdjustment implements approveAdjustment operation.
+	//
+	// POST /api/admin/adjustments/{id}/approve
+	ApproveAdjustment(ctx context.Context, params ApproveAdjustmentParams) (ApproveAdjustmentRes, error)
+	// ClawbackOrder implements clawbackOrder operation.
+	//
+	// Revokes the accrual of a processed order and debits it from the user balance, which may become
+	// negative. Repeated calls for the same order return the first clawback. Available to operators and
+	// shop service accounts.
+	//
+	// POST /api/admin/orders/{number}/clawback
+	ClawbackOrder(ctx context.Context, req OptClawbackOrderReq, params ClawbackOrderParams) (ClawbackOrderRes, error)
+	// CreateCampaign implements createCampaign operation.
+	//
+	// POST /api/admin/campaigns
+	CreateCampaign(ctx context.Context, req *CampaignInput) (CreateCampaignRes, error)
+	// CreateWebhook implements createWebhook operation.
+	//
+	// Registers a webhook of a partner shop. Events are sent as POST requests with a JSON body {"id":1,
+	// "type":"accrual","order":"...","amount":10.5,"created_at":"..."}, where id is the same in all
+	// delivery attempts. The X-Gophermart-Signature header contains sha256=<hex HMAC-SHA256 of
+	// "<timestamp>.<body>"> made with the webhook secret, the timestamp is sent in the
+	// X-Gophermart-Timestamp header. A delivery succeeds when the shop responds with 2xx, otherwise it
+	// is retried with exponential backoff. The secret is returned only in this response.
+	//
+	// POST /api/admin/webhooks
+	CreateWebhook(ctx context.Context, req *WebhookInput) (CreateWebhookRes, error)
+	// DisableWebhook implements disableWebhook operation.
+	//
+	// Disables the webhook, undelivered events are not sent to it anymore.
+	//
+	// DELETE /api/admin/webhooks/{id}
+	DisableWebhook(ctx context.Context, params DisableWebhookParams) (DisableWebhookRes, error)
+	// DryRunCampaigns implements dryRunCampaigns operation.
+	//
+	// POST /api/admin/campaigns/dry-run
+	DryRunCampaigns(ctx context.Context, req *DryRunCampaignsReq) (DryRunCampaignsRes, error)
+	// ExportPromoCodes implements exportPromoCodes operation.
+	//
+	// GET /api/admin/promo-codes/{id}/export
+	ExportPromoCodes(ctx context.Context, params ExportPromoCodesParams) (ExportPromoCodesRes, error)
+	// GeneratePromoCodes implements generatePromoCodes operation.
+	//
+	// POST /api/admin/promo-codes
+	GeneratePromoCodes(ctx context.Context, req *GeneratePromoCodesReq) (GeneratePromoCodesRes, error)
+	// GetCampaigns implements getCampaigns operation.
+	//
+	// GET /api/admin/campaigns
+	GetCampaigns(ctx context.Context) (GetCampaignsRes, error)
+	// GetUserAdjustments implements getUserAdjustments operation.
+	//
+	// GET /api/admin/users/{userId}/balance/adjustments
+	GetUserAdjustments(ctx context.Context, params GetUserAdjustmentsParams) (GetUserAdjustmentsRes, error)
+	// GetUserBalance implements getUserBalance operation.
+	//
+	// GET /api/admin/users/{userId}/balance
+	GetUserBalance(ctx context.Context, params GetUserBalanceParams) (GetUserBalanceRes, error)
+	// GetUserOrders implements getUserOrders operation.
+	//
+	// GET /api/admin/users/{userId}/orders
+	GetUserOrders(ctx context.Context, params GetUserOrdersParams) (GetUserOrdersRes, error)
+	// GetUserTransfers implements getUserTransfers operation.
+	//
+	// GET /api/admin/users/{userId}/balance/transfers
+	GetUserTransfers(ctx context.Context, params GetUserTransfersParams) (GetUserTransfersRes, error)
+	// GetUserWithdrawals implements getUserWithdrawals operation.
+	//
+	// GET /api/admin/users/{userId}/withdrawals
+	GetUserWithdrawals(ctx context.Context, params GetUserWithdrawalsParams) (GetUserWithdrawalsRes, error)
+	// GetWebhookDeliveries implements getWebhookDeliveries operation.
+	//
+	// Latest deliveries of events to the webhook with their attempts, newest first.
+	//
+	// GET /api/admin/webhooks/{id}/deliveries
+	GetWebhookDeliveries(ctx context.Context, params GetWebhookDeliveriesParams) (GetWebhookDeliveriesRes, error)
+	// GetWebhooks implements getWebhooks operation.
+	//
+	// Registered webhooks of partner shops. Shop service accounts see only webhooks they registered.
+	//
+	// GET /api/admin/webhooks
+	GetWebhooks(ctx context.Context) (GetWebhooksRes, error)
+	// InvalidateOrder implements invalidateOrder operation.
+	//
+	// POST /api/admin/orders/{number}/invalidate
+	InvalidateOrder(ctx context.Context, params InvalidateOrderParams) (InvalidateOrderRes, error)
+	// IssuePasswordReset implements issuePasswordReset operation.
+	//
+	// POST /api/admin/users/password-reset
+	IssuePasswordReset(ctx context.Context, req OptLogin) (IssuePasswordResetRes, error)
+	// RefundWithdrawal implements refundWithdrawal operation.
+	//
+	// Refunds points withdrawn for the order back to the user balance, for example when the shop cancels
+	// the order. Available to operators and shop service accounts.
+	//
+	// POST /api/admin/withdrawals/{order}/refund
+	RefundWithdrawal(ctx context.Context, req OptRefundWithdrawalReq, params RefundWithdrawalParams) (RefundWithdrawalRes, error)
+	// RejectAdjustment implements rejectAdjustment operation.
+	//
+	// POST /api/admin/adjustments/{id}/reject
+	RejectAdjustment(ctx context.Context, params RejectAdjustmentParams) (RejectAdjustmentRes, error)
+	// RepollOrder implements repollOrder operation.
+	//
+	// POST /api/admin/orders/{number}/repoll
+	RepollOrder(ctx context.Context, params RepollOrderParams) (RepollOrderRes, error)
+	// ReverseAdjustment implements reverseAdjustment operation.
+	//
+	// POST /api/admin/adjustments/{id}/reverse
+	ReverseAdjustment(ctx context.Context, req OptReverseAdjustmentReq, params ReverseAdjustmentParams) (ReverseAdjustmentRes, error)
+	// ReverseTransfer implements reverseTransfer operation.
+	//
+	// POST /api/admin/transfers/{id}/reverse
+	ReverseTransfer(ctx context.Context, req OptReverseTransferReq, params ReverseTransferParams) (ReverseTransferRes, error)
+	// SearchUsers implements searchUsers operation.
+	//
+	// GET /api/admin/users
+	SearchUsers(ctx context.Context, params SearchUsersParams) (SearchUsersRes, error)
+	// SetUserRole implements setUserRole operation.
+	//
+	// POST /api/admin/users/role
+	SetUserRole(ctx context.Context, req OptSetUserRoleReq) (SetUserRoleRes, error)
+	// SetUserShop implements setUserShop operation.
+	//
+	// POST /api/admin/users/shop
+	SetUserShop(ctx context.Context, req OptSetUserShopReq) (SetUserShopRes, error)
+	// UnlockUser implements unlockUser operation.
+	//
+	// POST /api/admin/users/unlock
+	UnlockUser(ctx context.Context, req OptLogin) (UnlockUserRes, error)
+	// UpdateCampaign implements updateCampaign operation.
+	//
+	// PUT /api/admin/campaigns/{id}
+	UpdateCampaign(ctx context.Context, req *CampaignInput, params UpdateCampaignParams) (UpdateCampaignRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
@@ -0,0 +1,237 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// AdjustUserBalance implements adjustUserBalance operation.
+//
+// POST /api/admin/users/{userId}/balance/adjustments
+func (UnimplementedHandler) AdjustUserBalance(ctx context.Context, req OptAdjustUserBalanceReq, params AdjustUserBalanceParams) (r AdjustUserBalanceRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ApproveAdjustment implements approveAdjustment operation.
+//
+// POST /api/admin/adjustments/{id}/approve
+func (UnimplementedHandler) ApproveAdjustment(ctx context.Context, params ApproveAdjustmentParams) (r ApproveAdjustmentRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ClawbackOrder implements clawbackOrder operation.
+//
+// Revokes the accrual of a processed order and debits it from the user balance, which may become
+// negative. Repeated calls for the same order return the first clawback. Available to operators and
+// shop service accounts.
+//
+// POST /api/admin/orders/{number}/clawback
+func (UnimplementedHandler) ClawbackOrder(ctx context.Context, req OptClawbackOrderReq, params ClawbackOrderParams) (r ClawbackOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateCampaign implements createCampaign operation.
+//
+// POST /api/admin/campaigns
+func (UnimplementedHandler) CreateCampaign(ctx context.Context, req *CampaignInput) (r CreateCampaignRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateWebhook implements createWebhook operation.
+//
+// Registers a webhook of a partner shop. Events are sent as POST requests with a JSON body {"id":1,
+// "type":"accrual","order":"...","amount":10.5,"created_at":"..."}, where id is the same in all
+// delivery attempts. The X-Gophermart-Signature header contains sha256=<hex HMAC-SHA256 of
+// "<timestamp>.<body>"> made with the webhook secret, the timestamp is sent in the
+// X-Gophermart-Timestamp header. A delivery succeeds when the shop responds with 2xx, otherwise it
+// is retried with exponential backoff. The secret is returned only in this response.
+//
+// POST /api/admin/webhooks
+func (UnimplementedHandler) CreateWebhook(ctx context.Context, req *WebhookInput) (r CreateWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DisableWebhook implements disableWebhook operation.
+//
+// Disables the webhook, undelivered events are not sent to it anymore.
+//
+// DELETE /api/admin/webhooks/{id}
+func (UnimplementedHandler) DisableWebhook(ctx context.Context, params DisableWebhookParams) (r DisableWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DryRunCampaigns implements dryRunCampaigns operation.
+//
+// POST /api/admin/campaigns/dry-run
+func (UnimplementedHandler) DryRunCampaigns(ctx context.Context, req *DryRunCampaignsReq) (r DryRunCampaignsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportPromoCodes implements exportPromoCodes operation.
+//
+// GET /api/admin/promo-codes/{id}/export
+func (UnimplementedHandler) ExportPromoCodes(ctx context.Context, params ExportPromoCodesParams) (r ExportPromoCodesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GeneratePromoCodes implements generatePromoCodes operation.
+//
+// POST /api/admin/promo-codes
+func (UnimplementedHandler) GeneratePromoCodes(ctx context.Context, req *GeneratePromoCodesReq) (r GeneratePromoCodesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetCampaigns implements getCampaigns operation.
+//
+// GET /api/admin/campaigns
+func (UnimplementedHandler) GetCampaigns(ctx context.Context) (r GetCampaignsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserAdjustments implements getUserAdjustments operation.
+//
+// GET /api/admin/users/{userId}/balance/adjustments
+func (UnimplementedHandler) GetUserAdjustments(ctx context.Context, params GetUserAdjustmentsParams) (r GetUserAdjustmentsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserBalance implements getUserBalance operation.
+//
+// GET /api/admin/users/{userId}/balance
+func (UnimplementedHandler) GetUserBalance(ctx context.Context, params GetUserBalanceParams) (r GetUserBalanceRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserOrders implements getUserOrders operation.
+//
+// GET /api/admin/users/{userId}/orders
+func (UnimplementedHandler) GetUserOrders(ctx context.Context, params GetUserOrdersParams) (r GetUserOrdersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserTransfers implements getUserTransfers operation.
+//
+// GET /api/admin/users/{userId}/balance/transfers
+func (UnimplementedHandler) GetUserTransfers(ctx context.Context, params GetUserTransfersParams) (r GetUserTransfersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetUserWithdrawals implements getUserWithdrawals operation.
+//
+// GET /api/admin/users/{userId}/withdrawals
+func (UnimplementedHandler) GetUserWithdrawals(ctx context.Context, params GetUserWithdrawalsParams) (r GetUserWithdrawalsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetWebhookDeliveries implements getWebhookDeliveries operation.
+//
+// Latest deliveries of events to the webhook with their attempts, newest first.
+//
+// GET /api/admin/webhooks/{id}/deliveries
+func (UnimplementedHandler) GetWebhookDeliveries(ctx context.Context, params GetWebhookDeliveriesParams) (r GetWebhookDeliveriesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetWebhooks implements getWebhooks operation.
+//
+// Registered webhooks of partner shops. Shop service accounts see only webhooks they registered.
+//
+// GET /api/admin/webhooks
+func (UnimplementedHandler) GetWebhooks(ctx context.Context) (r GetWebhooksRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// InvalidateOrder implements invalidateOrder operation.
+//
+// POST /api/admin/orders/{number}/invalidate
+func (UnimplementedHandler) InvalidateOrder(ctx context.Context, params InvalidateOrderParams) (r InvalidateOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// IssuePasswordReset implements issuePasswordReset operation.
+//
+// POST /api/admin/users/password-reset
+func (UnimplementedHandler) IssuePasswordReset(ctx context.Context, req OptLogin) (r IssuePasswordResetRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RefundWithdrawal implements refundWithdrawal operation.
+//
+// Refunds points withdrawn for the order back to the user balance, for example when the shop cancels
+// the order. Available to operators and shop service accounts.
+//
+// POST /api/admin/withdrawals/{order}/refund
+func (UnimplementedHandler) RefundWithdrawal(ctx context.Context, req OptRefundWithdrawalReq, params RefundWithdrawalParams) (r RefundWithdrawalRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RejectAdjustment implements rejectAdjustment operation.
+//
+// POST /api/admin/adjustments/{id}/reject
+func (UnimplementedHandler) RejectAdjustment(ctx context.Context, params RejectAdjustmentParams) (r RejectAdjustmentRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RepollOrder implements repollOrder operation.
+//
+// POST /api/admin/orders/{number}/repoll
+func (UnimplementedHandler) RepollOrder(ctx context.Context, params RepollOrderParams) (r RepollOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ReverseAdjustment implements reverseAdjustment operation.
+//
+// POST /api/admin/adjustments/{id}/reverse
+func (UnimplementedHandler) ReverseAdjustment(ctx context.Context, req OptReverseAdjustmentReq, params ReverseAdjustmentParams) (r ReverseAdjustmentRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ReverseTransfer implements reverseTransfer operation.
+//
+// POST /api/admin/transfers/{id}/reverse
+func (UnimplementedHandler) ReverseTransfer(ctx context.Context, req OptReverseTransferReq, params ReverseTransferParams) (r ReverseTransferRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchUsers implements searchUsers operation.
+//
+// GET /api/admin/users
+func (UnimplementedHandler) SearchUsers(ctx context.Context, params SearchUsersParams) (r SearchUsersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetUserRole implements setUserRole operation.
+//
+// POST /api/admin/users/role
+func (UnimplementedHandler) SetUserRole(ctx context.Context, req OptSetUserRoleReq) (r SetUserRoleRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetUserShop implements setUserShop operation.
+//
+// POST /api/admin/users/shop
+func (UnimplementedHandler) SetUserShop(ctx context.Context, req OptSetUserShopReq) (r SetUserShopRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UnlockUser implements unlockUser operation.
+//
+// POST /api/admin/users/unlock
+func (UnimplementedHandler) UnlockUser(ctx context.Context, req OptLogin) (r UnlockUserRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateCampaign implements updateCampaign operation.
+//
+// PUT /api/admin/campaigns/{id}
+func (UnimplementedHandler) UpdateCampaign(ctx context.Context, req *CampaignInput, params UpdateCampaignParams) (r UpdateCampaignRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
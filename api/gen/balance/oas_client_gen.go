@@ -15,6 +15,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/ogen-go/ogen/conv"
 	ht "github.com/ogen-go/ogen/http"
 	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/ogen-go/ogen/otelogen"
@@ -23,14 +24,58 @@ import (
 
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
+	// CaptureHold invokes captureHold operation.
+	//
+	// Deducts held points for the order once it is confirmed. Without a sum the whole hold is deducted,
+	// a partial capture releases the rest. The deduction follows the same per-order limits as a
+	// withdrawal. Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/holds/{id}/capture
+	CaptureHold(ctx context.Context, request OptCaptureHoldReq, params CaptureHoldParams) (CaptureHoldRes, error)
+	// CreateHold invokes createHold operation.
+	//
+	// Reserves points for the order at the start of checkout. Held points are included in the current
+	// balance but are not available for other withdrawals until the hold is captured, released or
+	// expires. Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/holds
+	CreateHold(ctx context.Context, request *CreateHoldReq) (CreateHoldRes, error)
 	// DeductPoints invokes deductPoints operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/balance/withdraw
 	DeductPoints(ctx context.Context, request OptDeductPointsReq) (DeductPointsRes, error)
+	// GetAdjustments invokes getAdjustments operation.
+	//
+	// GET /api/user/balance/adjustments
+	GetAdjustments(ctx context.Context) (GetAdjustmentsRes, error)
 	// GetBalance invokes getBalance operation.
 	//
 	// GET /api/user/balance
 	GetBalance(ctx context.Context) (GetBalanceRes, error)
+	// GetTransfers invokes getTransfers operation.
+	//
+	// Returns transfers sent and received by the user.
+	//
+	// GET /api/user/balance/transfers
+	GetTransfers(ctx context.Context) (GetTransfersRes, error)
+	// ReleaseHold invokes releaseHold operation.
+	//
+	// Releases held points without deducting them.
+	//
+	// POST /api/user/balance/holds/{id}/release
+	ReleaseHold(ctx context.Context, params ReleaseHoldParams) (ReleaseHoldRes, error)
+	// TransferPoints invokes transferPoints operation.
+	//
+	// Transfers points to another user. The sender is debited and the recipient is credited in one
+	// transaction. Transfers are limited by a minimum amount and a daily total and count per sender.
+	// Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/transfer
+	TransferPoints(ctx context.Context, request *TransferPointsReq) (TransferPointsRes, error)
 }
 
 // Client implements OAS client.
@@ -83,8 +128,251 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 	return u
 }
 
+// CaptureHold invokes captureHold operation.
+//
+// Deducts held points for the order once it is confirmed. Without a sum the whole hold is deducted,
+// a partial capture releases the rest. The deduction follows the same per-order limits as a
+// withdrawal. Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/holds/{id}/capture
+func (c *Client) CaptureHold(ctx context.Context, request OptCaptureHoldReq, params CaptureHoldParams) (CaptureHoldRes, error) {
+	res, err := c.sendCaptureHold(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendCaptureHold(ctx context.Context, request OptCaptureHoldReq, params CaptureHoldParams) (res CaptureHoldRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("captureHold"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/api/user/balance/holds/{id}/capture"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "CaptureHold",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/user/balance/holds/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/capture"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeCaptureHoldRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "CaptureHold", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeCaptureHoldResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// CreateHold invokes createHold operation.
+//
+// Reserves points for the order at the start of checkout. Held points are included in the current
+// balance but are not available for other withdrawals until the hold is captured, released or
+// expires. Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/holds
+func (c *Client) CreateHold(ctx context.Context, request *CreateHoldReq) (CreateHoldRes, error) {
+	res, err := c.sendCreateHold(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendCreateHold(ctx context.Context, request *CreateHoldReq) (res CreateHoldRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("createHold"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/api/user/balance/holds"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "CreateHold",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/user/balance/holds"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeCreateHoldRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "CreateHold", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeCreateHoldResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // DeductPoints invokes deductPoints operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/balance/withdraw
 func (c *Client) DeductPoints(ctx context.Context, request OptDeductPointsReq) (DeductPointsRes, error) {
 	res, err := c.sendDeductPoints(ctx, request)
@@ -189,19 +477,19 @@ func (c *Client) sendDeductPoints(ctx context.Context, request OptDeductPointsRe
 	return result, nil
 }
 
-// GetBalance invokes getBalance operation.
+// GetAdjustments invokes getAdjustments operation.
 //
-// GET /api/user/balance
-func (c *Client) GetBalance(ctx context.Context) (GetBalanceRes, error) {
-	res, err := c.sendGetBalance(ctx)
+// GET /api/user/balance/adjustments
+func (c *Client) GetAdjustments(ctx context.Context) (GetAdjustmentsRes, error) {
+	res, err := c.sendGetAdjustments(ctx)
 	return res, err
 }
 
-func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err error) {
+func (c *Client) sendGetAdjustments(ctx context.Context) (res GetAdjustmentsRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getBalance"),
+		otelogen.OperationID("getAdjustments"),
 		semconv.HTTPMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/api/user/balance"),
+		semconv.HTTPRouteKey.String("/api/user/balance/adjustments"),
 	}
 
 	// Run stopwatch.
@@ -216,7 +504,7 @@ func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err err
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "GetBalance",
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetAdjustments",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -234,7 +522,7 @@ func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err err
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/api/user/balance"
+	pathParts[0] = "/api/user/balance/adjustments"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
@@ -248,7 +536,7 @@ func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err err
 		var satisfied bitset
 		{
 			stage = "Security:BearerAuth"
-			switch err := c.securityBearerAuth(ctx, "GetBalance", r); {
+			switch err := c.securityBearerAuth(ctx, "GetAdjustments", r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -284,7 +572,449 @@ func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err err
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeGetBalanceResponse(resp)
+	result, err := decodeGetAdjustmentsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetBalance invokes getBalance operation.
+//
+// GET /api/user/balance
+func (c *Client) GetBalance(ctx context.Context) (GetBalanceRes, error) {
+	res, err := c.sendGetBalance(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetBalance(ctx context.Context) (res GetBalanceRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getBalance"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/api/user/balance"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetBalance",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/user/balance"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetBalance", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetBalanceResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetTransfers invokes getTransfers operation.
+//
+// Returns transfers sent and received by the user.
+//
+// GET /api/user/balance/transfers
+func (c *Client) GetTransfers(ctx context.Context) (GetTransfersRes, error) {
+	res, err := c.sendGetTransfers(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetTransfers(ctx context.Context) (res GetTransfersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getTransfers"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/api/user/balance/transfers"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetTransfers",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/user/balance/transfers"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetTransfers", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetTransfersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ReleaseHold invokes releaseHold operation.
+//
+// Releases held points without deducting them.
+//
+// POST /api/user/balance/holds/{id}/release
+func (c *Client) ReleaseHold(ctx context.Context, params ReleaseHoldParams) (ReleaseHoldRes, error) {
+	res, err := c.sendReleaseHold(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendReleaseHold(ctx context.Context, params ReleaseHoldParams) (res ReleaseHoldRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("releaseHold"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/api/user/balance/holds/{id}/release"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "ReleaseHold",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/user/balance/holds/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/release"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "ReleaseHold", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeReleaseHoldResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// TransferPoints invokes transferPoints operation.
+//
+// Transfers points to another user. The sender is debited and the recipient is credited in one
+// transaction. Transfers are limited by a minimum amount and a daily total and count per sender.
+// Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/transfer
+func (c *Client) TransferPoints(ctx context.Context, request *TransferPointsReq) (TransferPointsRes, error) {
+	res, err := c.sendTransferPoints(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendTransferPoints(ctx context.Context, request *TransferPointsReq) (res TransferPointsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("transferPoints"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/api/user/balance/transfer"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "TransferPoints",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/user/balance/transfer"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeTransferPointsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "TransferPoints", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeTransferPointsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -1,10 +1,34 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type CaptureHoldRes interface {
+	captureHoldRes()
+}
+
+type CreateHoldRes interface {
+	createHoldRes()
+}
+
 type DeductPointsRes interface {
 	deductPointsRes()
 }
 
+type GetAdjustmentsRes interface {
+	getAdjustmentsRes()
+}
+
 type GetBalanceRes interface {
 	getBalanceRes()
 }
+
+type GetTransfersRes interface {
+	getTransfersRes()
+}
+
+type ReleaseHoldRes interface {
+	releaseHoldRes()
+}
+
+type TransferPointsRes interface {
+	transferPointsRes()
+}
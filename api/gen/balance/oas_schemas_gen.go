@@ -2,6 +2,12 @@
 
 package api
 
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
 type BearerAuth struct {
 	Token string
 }
@@ -16,6 +22,110 @@ func (s *BearerAuth) SetToken(val string) {
 	s.Token = val
 }
 
+// CaptureHoldBadRequest is response for CaptureHold operation.
+type CaptureHoldBadRequest struct{}
+
+func (*CaptureHoldBadRequest) captureHoldRes() {}
+
+// CaptureHoldConflict is response for CaptureHold operation.
+type CaptureHoldConflict struct{}
+
+func (*CaptureHoldConflict) captureHoldRes() {}
+
+// CaptureHoldInternalServerError is response for CaptureHold operation.
+type CaptureHoldInternalServerError struct{}
+
+func (*CaptureHoldInternalServerError) captureHoldRes() {}
+
+// CaptureHoldNotFound is response for CaptureHold operation.
+type CaptureHoldNotFound struct{}
+
+func (*CaptureHoldNotFound) captureHoldRes() {}
+
+// CaptureHoldPaymentRequired is response for CaptureHold operation.
+type CaptureHoldPaymentRequired struct{}
+
+func (*CaptureHoldPaymentRequired) captureHoldRes() {}
+
+type CaptureHoldReq struct {
+	Sum OptFloat64 `json:"sum"`
+}
+
+// GetSum returns the value of Sum.
+func (s *CaptureHoldReq) GetSum() OptFloat64 {
+	return s.Sum
+}
+
+// SetSum sets the value of Sum.
+func (s *CaptureHoldReq) SetSum(val OptFloat64) {
+	s.Sum = val
+}
+
+// CaptureHoldUnauthorized is response for CaptureHold operation.
+type CaptureHoldUnauthorized struct{}
+
+func (*CaptureHoldUnauthorized) captureHoldRes() {}
+
+// CreateHoldBadRequest is response for CreateHold operation.
+type CreateHoldBadRequest struct{}
+
+func (*CreateHoldBadRequest) createHoldRes() {}
+
+// CreateHoldInternalServerError is response for CreateHold operation.
+type CreateHoldInternalServerError struct{}
+
+func (*CreateHoldInternalServerError) createHoldRes() {}
+
+// CreateHoldPaymentRequired is response for CreateHold operation.
+type CreateHoldPaymentRequired struct{}
+
+func (*CreateHoldPaymentRequired) createHoldRes() {}
+
+type CreateHoldReq struct {
+	Order string  `json:"order"`
+	Sum   float64 `json:"sum"`
+}
+
+// GetOrder returns the value of Order.
+func (s *CreateHoldReq) GetOrder() string {
+	return s.Order
+}
+
+// GetSum returns the value of Sum.
+func (s *CreateHoldReq) GetSum() float64 {
+	return s.Sum
+}
+
+// SetOrder sets the value of Order.
+func (s *CreateHoldReq) SetOrder(val string) {
+	s.Order = val
+}
+
+// SetSum sets the value of Sum.
+func (s *CreateHoldReq) SetSum(val float64) {
+	s.Sum = val
+}
+
+// CreateHoldUnauthorized is response for CreateHold operation.
+type CreateHoldUnauthorized struct{}
+
+func (*CreateHoldUnauthorized) createHoldRes() {}
+
+// CreateHoldUnprocessableEntity is response for CreateHold operation.
+type CreateHoldUnprocessableEntity struct{}
+
+func (*CreateHoldUnprocessableEntity) createHoldRes() {}
+
+// DeductPointsBadRequest is response for DeductPoints operation.
+type DeductPointsBadRequest struct{}
+
+func (*DeductPointsBadRequest) deductPointsRes() {}
+
+// DeductPointsConflict is response for DeductPoints operation.
+type DeductPointsConflict struct{}
+
+func (*DeductPointsConflict) deductPointsRes() {}
+
 // DeductPointsInternalServerError is response for DeductPoints operation.
 type DeductPointsInternalServerError struct{}
 
@@ -66,6 +176,94 @@ type DeductPointsUnprocessableEntity struct{}
 
 func (*DeductPointsUnprocessableEntity) deductPointsRes() {}
 
+// GetAdjustmentsInternalServerError is response for GetAdjustments operation.
+type GetAdjustmentsInternalServerError struct{}
+
+func (*GetAdjustmentsInternalServerError) getAdjustmentsRes() {}
+
+// GetAdjustmentsNoContent is response for GetAdjustments operation.
+type GetAdjustmentsNoContent struct{}
+
+func (*GetAdjustmentsNoContent) getAdjustmentsRes() {}
+
+type GetAdjustmentsOKApplicationJSON []GetAdjustmentsOKItem
+
+func (*GetAdjustmentsOKApplicationJSON) getAdjustmentsRes() {}
+
+type GetAdjustmentsOKItem struct {
+	ID         OptInt64    `json:"id"`
+	Amount     OptFloat64  `json:"amount"`
+	Reason     OptString   `json:"reason"`
+	Status     OptString   `json:"status"`
+	ReversalOf OptInt64    `json:"reversal_of"`
+	CreatedAt  OptDateTime `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *GetAdjustmentsOKItem) GetID() OptInt64 {
+	return s.ID
+}
+
+// GetAmount returns the value of Amount.
+func (s *GetAdjustmentsOKItem) GetAmount() OptFloat64 {
+	return s.Amount
+}
+
+// GetReason returns the value of Reason.
+func (s *GetAdjustmentsOKItem) GetReason() OptString {
+	return s.Reason
+}
+
+// GetStatus returns the value of Status.
+func (s *GetAdjustmentsOKItem) GetStatus() OptString {
+	return s.Status
+}
+
+// GetReversalOf returns the value of ReversalOf.
+func (s *GetAdjustmentsOKItem) GetReversalOf() OptInt64 {
+	return s.ReversalOf
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *GetAdjustmentsOKItem) GetCreatedAt() OptDateTime {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *GetAdjustmentsOKItem) SetID(val OptInt64) {
+	s.ID = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *GetAdjustmentsOKItem) SetAmount(val OptFloat64) {
+	s.Amount = val
+}
+
+// SetReason sets the value of Reason.
+func (s *GetAdjustmentsOKItem) SetReason(val OptString) {
+	s.Reason = val
+}
+
+// SetStatus sets the value of Status.
+func (s *GetAdjustmentsOKItem) SetStatus(val OptString) {
+	s.Status = val
+}
+
+// SetReversalOf sets the value of ReversalOf.
+func (s *GetAdjustmentsOKItem) SetReversalOf(val OptInt64) {
+	s.ReversalOf = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *GetAdjustmentsOKItem) SetCreatedAt(val OptDateTime) {
+	s.CreatedAt = val
+}
+
+// GetAdjustmentsUnauthorized is response for GetAdjustments operation.
+type GetAdjustmentsUnauthorized struct{}
+
+func (*GetAdjustmentsUnauthorized) getAdjustmentsRes() {}
+
 // GetBalanceInternalServerError is response for GetBalance operation.
 type GetBalanceInternalServerError struct{}
 
@@ -101,6 +299,16 @@ func (*GetBalanceNoContent) getBalanceRes() {}
 type GetBalanceOK struct {
 	Current   OptFloat64 `json:"current"`
 	Withdrawn OptFloat64 `json:"withdrawn"`
+	// Points available for withdrawal, the current balance without held points.
+	Available OptFloat64 `json:"available"`
+	// Points held for orders that are not confirmed yet.
+	Held OptFloat64 `json:"held"`
+	// Points that expire within the configured period.
+	ExpiringSoon OptFloat64 `json:"expiring_soon"`
+	// Expiring soon points by expiration day.
+	Expiring []GetBalanceOKExpiringItem `json:"expiring"`
+	// Loyalty tier, absent if tiers are not configured.
+	Tier OptGetBalanceOKTier `json:"tier"`
 }
 
 // GetCurrent returns the value of Current.
@@ -113,6 +321,31 @@ func (s *GetBalanceOK) GetWithdrawn() OptFloat64 {
 	return s.Withdrawn
 }
 
+// GetAvailable returns the value of Available.
+func (s *GetBalanceOK) GetAvailable() OptFloat64 {
+	return s.Available
+}
+
+// GetHeld returns the value of Held.
+func (s *GetBalanceOK) GetHeld() OptFloat64 {
+	return s.Held
+}
+
+// GetExpiringSoon returns the value of ExpiringSoon.
+func (s *GetBalanceOK) GetExpiringSoon() OptFloat64 {
+	return s.ExpiringSoon
+}
+
+// GetExpiring returns the value of Expiring.
+func (s *GetBalanceOK) GetExpiring() []GetBalanceOKExpiringItem {
+	return s.Expiring
+}
+
+// GetTier returns the value of Tier.
+func (s *GetBalanceOK) GetTier() OptGetBalanceOKTier {
+	return s.Tier
+}
+
 // SetCurrent sets the value of Current.
 func (s *GetBalanceOK) SetCurrent(val OptFloat64) {
 	s.Current = val
@@ -123,13 +356,400 @@ func (s *GetBalanceOK) SetWithdrawn(val OptFloat64) {
 	s.Withdrawn = val
 }
 
+// SetAvailable sets the value of Available.
+func (s *GetBalanceOK) SetAvailable(val OptFloat64) {
+	s.Available = val
+}
+
+// SetHeld sets the value of Held.
+func (s *GetBalanceOK) SetHeld(val OptFloat64) {
+	s.Held = val
+}
+
+// SetExpiringSoon sets the value of ExpiringSoon.
+func (s *GetBalanceOK) SetExpiringSoon(val OptFloat64) {
+	s.ExpiringSoon = val
+}
+
+// SetExpiring sets the value of Expiring.
+func (s *GetBalanceOK) SetExpiring(val []GetBalanceOKExpiringItem) {
+	s.Expiring = val
+}
+
+// SetTier sets the value of Tier.
+func (s *GetBalanceOK) SetTier(val OptGetBalanceOKTier) {
+	s.Tier = val
+}
+
 func (*GetBalanceOK) getBalanceRes() {}
 
+type GetBalanceOKExpiringItem struct {
+	Sum       OptFloat64  `json:"sum"`
+	ExpiresAt OptDateTime `json:"expires_at"`
+}
+
+// GetSum returns the value of Sum.
+func (s *GetBalanceOKExpiringItem) GetSum() OptFloat64 {
+	return s.Sum
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *GetBalanceOKExpiringItem) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// SetSum sets the value of Sum.
+func (s *GetBalanceOKExpiringItem) SetSum(val OptFloat64) {
+	s.Sum = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *GetBalanceOKExpiringItem) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// Loyalty tier, absent if tiers are not configured.
+type GetBalanceOKTier struct {
+	// Current tier, empty until the lowest tier is reached.
+	Name       OptString  `json:"name"`
+	Multiplier OptFloat64 `json:"multiplier"`
+	// Points accrued or spent over the rolling window.
+	Progress      OptFloat64 `json:"progress"`
+	NextTier      OptString  `json:"next_tier"`
+	NextThreshold OptFloat64 `json:"next_threshold"`
+	// Points left to reach the next tier.
+	ToNext OptFloat64 `json:"to_next"`
+}
+
+// GetName returns the value of Name.
+func (s *GetBalanceOKTier) GetName() OptString {
+	return s.Name
+}
+
+// GetMultiplier returns the value of Multiplier.
+func (s *GetBalanceOKTier) GetMultiplier() OptFloat64 {
+	return s.Multiplier
+}
+
+// GetProgress returns the value of Progress.
+func (s *GetBalanceOKTier) GetProgress() OptFloat64 {
+	return s.Progress
+}
+
+// GetNextTier returns the value of NextTier.
+func (s *GetBalanceOKTier) GetNextTier() OptString {
+	return s.NextTier
+}
+
+// GetNextThreshold returns the value of NextThreshold.
+func (s *GetBalanceOKTier) GetNextThreshold() OptFloat64 {
+	return s.NextThreshold
+}
+
+// GetToNext returns the value of ToNext.
+func (s *GetBalanceOKTier) GetToNext() OptFloat64 {
+	return s.ToNext
+}
+
+// SetName sets the value of Name.
+func (s *GetBalanceOKTier) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetMultiplier sets the value of Multiplier.
+func (s *GetBalanceOKTier) SetMultiplier(val OptFloat64) {
+	s.Multiplier = val
+}
+
+// SetProgress sets the value of Progress.
+func (s *GetBalanceOKTier) SetProgress(val OptFloat64) {
+	s.Progress = val
+}
+
+// SetNextTier sets the value of NextTier.
+func (s *GetBalanceOKTier) SetNextTier(val OptString) {
+	s.NextTier = val
+}
+
+// SetNextThreshold sets the value of NextThreshold.
+func (s *GetBalanceOKTier) SetNextThreshold(val OptFloat64) {
+	s.NextThreshold = val
+}
+
+// SetToNext sets the value of ToNext.
+func (s *GetBalanceOKTier) SetToNext(val OptFloat64) {
+	s.ToNext = val
+}
+
 // GetBalanceUnauthorized is response for GetBalance operation.
 type GetBalanceUnauthorized struct{}
 
 func (*GetBalanceUnauthorized) getBalanceRes() {}
 
+// GetTransfersInternalServerError is response for GetTransfers operation.
+type GetTransfersInternalServerError struct{}
+
+func (*GetTransfersInternalServerError) getTransfersRes() {}
+
+// GetTransfersNoContent is response for GetTransfers operation.
+type GetTransfersNoContent struct{}
+
+func (*GetTransfersNoContent) getTransfersRes() {}
+
+type GetTransfersOKApplicationJSON []Transfer
+
+func (*GetTransfersOKApplicationJSON) getTransfersRes() {}
+
+// GetTransfersUnauthorized is response for GetTransfers operation.
+type GetTransfersUnauthorized struct{}
+
+func (*GetTransfersUnauthorized) getTransfersRes() {}
+
+// Ref: #/Hold
+type Hold struct {
+	ID    int64  `json:"id"`
+	Order string `json:"order"`
+	// Held points.
+	Amount float64 `json:"amount"`
+	// Deducted points.
+	Captured   float64     `json:"captured"`
+	Status     HoldStatus  `json:"status"`
+	CreatedAt  time.Time   `json:"created_at"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	ResolvedAt OptDateTime `json:"resolved_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Hold) GetID() int64 {
+	return s.ID
+}
+
+// GetOrder returns the value of Order.
+func (s *Hold) GetOrder() string {
+	return s.Order
+}
+
+// GetAmount returns the value of Amount.
+func (s *Hold) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetCaptured returns the value of Captured.
+func (s *Hold) GetCaptured() float64 {
+	return s.Captured
+}
+
+// GetStatus returns the value of Status.
+func (s *Hold) GetStatus() HoldStatus {
+	return s.Status
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Hold) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *Hold) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// GetResolvedAt returns the value of ResolvedAt.
+func (s *Hold) GetResolvedAt() OptDateTime {
+	return s.ResolvedAt
+}
+
+// SetID sets the value of ID.
+func (s *Hold) SetID(val int64) {
+	s.ID = val
+}
+
+// SetOrder sets the value of Order.
+func (s *Hold) SetOrder(val string) {
+	s.Order = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Hold) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetCaptured sets the value of Captured.
+func (s *Hold) SetCaptured(val float64) {
+	s.Captured = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Hold) SetStatus(val HoldStatus) {
+	s.Status = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Hold) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *Hold) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+// SetResolvedAt sets the value of ResolvedAt.
+func (s *Hold) SetResolvedAt(val OptDateTime) {
+	s.ResolvedAt = val
+}
+
+func (*Hold) captureHoldRes() {}
+func (*Hold) createHoldRes()  {}
+func (*Hold) releaseHoldRes() {}
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusReleased HoldStatus = "released"
+	HoldStatusExpired  HoldStatus = "expired"
+)
+
+// AllValues returns all HoldStatus values.
+func (HoldStatus) AllValues() []HoldStatus {
+	return []HoldStatus{
+		HoldStatusActive,
+		HoldStatusCaptured,
+		HoldStatusReleased,
+		HoldStatusExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s HoldStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case HoldStatusActive:
+		return []byte(s), nil
+	case HoldStatusCaptured:
+		return []byte(s), nil
+	case HoldStatusReleased:
+		return []byte(s), nil
+	case HoldStatusExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *HoldStatus) UnmarshalText(data []byte) error {
+	switch HoldStatus(data) {
+	case HoldStatusActive:
+		*s = HoldStatusActive
+		return nil
+	case HoldStatusCaptured:
+		*s = HoldStatusCaptured
+		return nil
+	case HoldStatusReleased:
+		*s = HoldStatusReleased
+		return nil
+	case HoldStatusExpired:
+		*s = HoldStatusExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// NewOptCaptureHoldReq returns new OptCaptureHoldReq with value set to v.
+func NewOptCaptureHoldReq(v CaptureHoldReq) OptCaptureHoldReq {
+	return OptCaptureHoldReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptCaptureHoldReq is optional CaptureHoldReq.
+type OptCaptureHoldReq struct {
+	Value CaptureHoldReq
+	Set   bool
+}
+
+// IsSet returns true if OptCaptureHoldReq was set.
+func (o OptCaptureHoldReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptCaptureHoldReq) Reset() {
+	var v CaptureHoldReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptCaptureHoldReq) SetTo(v CaptureHoldReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptCaptureHoldReq) Get() (v CaptureHoldReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptCaptureHoldReq) Or(d CaptureHoldReq) CaptureHoldReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptDeductPointsReq returns new OptDeductPointsReq with value set to v.
 func NewOptDeductPointsReq(v DeductPointsReq) OptDeductPointsReq {
 	return OptDeductPointsReq{
@@ -221,3 +841,378 @@ func (o OptFloat64) Or(d float64) float64 {
 	}
 	return d
 }
+
+// NewOptGetBalanceOKTier returns new OptGetBalanceOKTier with value set to v.
+func NewOptGetBalanceOKTier(v GetBalanceOKTier) OptGetBalanceOKTier {
+	return OptGetBalanceOKTier{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptGetBalanceOKTier is optional GetBalanceOKTier.
+type OptGetBalanceOKTier struct {
+	Value GetBalanceOKTier
+	Set   bool
+}
+
+// IsSet returns true if OptGetBalanceOKTier was set.
+func (o OptGetBalanceOKTier) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGetBalanceOKTier) Reset() {
+	var v GetBalanceOKTier
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptGetBalanceOKTier) SetTo(v GetBalanceOKTier) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetBalanceOKTier) Get() (v GetBalanceOKTier, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptGetBalanceOKTier) Or(d GetBalanceOKTier) GetBalanceOKTier {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// ReleaseHoldConflict is response for ReleaseHold operation.
+type ReleaseHoldConflict struct{}
+
+func (*ReleaseHoldConflict) releaseHoldRes() {}
+
+// ReleaseHoldInternalServerError is response for ReleaseHold operation.
+type ReleaseHoldInternalServerError struct{}
+
+func (*ReleaseHoldInternalServerError) releaseHoldRes() {}
+
+// ReleaseHoldNotFound is response for ReleaseHold operation.
+type ReleaseHoldNotFound struct{}
+
+func (*ReleaseHoldNotFound) releaseHoldRes() {}
+
+// ReleaseHoldUnauthorized is response for ReleaseHold operation.
+type ReleaseHoldUnauthorized struct{}
+
+func (*ReleaseHoldUnauthorized) releaseHoldRes() {}
+
+// Ref: #/Transfer
+type Transfer struct {
+	ID        int64             `json:"id"`
+	Direction TransferDirection `json:"direction"`
+	// Login of the other user.
+	Counterparty string         `json:"counterparty"`
+	Amount       float64        `json:"amount"`
+	Status       TransferStatus `json:"status"`
+	CreatedAt    time.Time      `json:"created_at"`
+	ReversedAt   OptDateTime    `json:"reversed_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Transfer) GetID() int64 {
+	return s.ID
+}
+
+// GetDirection returns the value of Direction.
+func (s *Transfer) GetDirection() TransferDirection {
+	return s.Direction
+}
+
+// GetCounterparty returns the value of Counterparty.
+func (s *Transfer) GetCounterparty() string {
+	return s.Counterparty
+}
+
+// GetAmount returns the value of Amount.
+func (s *Transfer) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetStatus returns the value of Status.
+func (s *Transfer) GetStatus() TransferStatus {
+	return s.Status
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Transfer) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetReversedAt returns the value of ReversedAt.
+func (s *Transfer) GetReversedAt() OptDateTime {
+	return s.ReversedAt
+}
+
+// SetID sets the value of ID.
+func (s *Transfer) SetID(val int64) {
+	s.ID = val
+}
+
+// SetDirection sets the value of Direction.
+func (s *Transfer) SetDirection(val TransferDirection) {
+	s.Direction = val
+}
+
+// SetCounterparty sets the value of Counterparty.
+func (s *Transfer) SetCounterparty(val string) {
+	s.Counterparty = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Transfer) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Transfer) SetStatus(val TransferStatus) {
+	s.Status = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Transfer) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetReversedAt sets the value of ReversedAt.
+func (s *Transfer) SetReversedAt(val OptDateTime) {
+	s.ReversedAt = val
+}
+
+func (*Transfer) transferPointsRes() {}
+
+type TransferDirection string
+
+const (
+	TransferDirectionSent     TransferDirection = "sent"
+	TransferDirectionReceived TransferDirection = "received"
+)
+
+// AllValues returns all TransferDirection values.
+func (TransferDirection) AllValues() []TransferDirection {
+	return []TransferDirection{
+		TransferDirectionSent,
+		TransferDirectionReceived,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransferDirection) MarshalText() ([]byte, error) {
+	switch s {
+	case TransferDirectionSent:
+		return []byte(s), nil
+	case TransferDirectionReceived:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransferDirection) UnmarshalText(data []byte) error {
+	switch TransferDirection(data) {
+	case TransferDirectionSent:
+		*s = TransferDirectionSent
+		return nil
+	case TransferDirectionReceived:
+		*s = TransferDirectionReceived
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// TransferPointsBadRequest is response for TransferPoints operation.
+type TransferPointsBadRequest struct{}
+
+func (*TransferPointsBadRequest) transferPointsRes() {}
+
+// TransferPointsConflict is response for TransferPoints operation.
+type TransferPointsConflict struct{}
+
+func (*TransferPointsConflict) transferPointsRes() {}
+
+// TransferPointsInternalServerError is response for TransferPoints operation.
+type TransferPointsInternalServerError struct{}
+
+func (*TransferPointsInternalServerError) transferPointsRes() {}
+
+// TransferPointsPaymentRequired is response for TransferPoints operation.
+type TransferPointsPaymentRequired struct{}
+
+func (*TransferPointsPaymentRequired) transferPointsRes() {}
+
+type TransferPointsReq struct {
+	// Login of the recipient.
+	Recipient string  `json:"recipient"`
+	Amount    float64 `json:"amount"`
+}
+
+// GetRecipient returns the value of Recipient.
+func (s *TransferPointsReq) GetRecipient() string {
+	return s.Recipient
+}
+
+// GetAmount returns the value of Amount.
+func (s *TransferPointsReq) GetAmount() float64 {
+	return s.Amount
+}
+
+// SetRecipient sets the value of Recipient.
+func (s *TransferPointsReq) SetRecipient(val string) {
+	s.Recipient = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *TransferPointsReq) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// TransferPointsUnauthorized is response for TransferPoints operation.
+type TransferPointsUnauthorized struct{}
+
+func (*TransferPointsUnauthorized) transferPointsRes() {}
+
+type TransferStatus string
+
+const (
+	TransferStatusCompleted TransferStatus = "completed"
+	TransferStatusReversed  TransferStatus = "reversed"
+)
+
+// AllValues returns all TransferStatus values.
+func (TransferStatus) AllValues() []TransferStatus {
+	return []TransferStatus{
+		TransferStatusCompleted,
+		TransferStatusReversed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransferStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case TransferStatusCompleted:
+		return []byte(s), nil
+	case TransferStatusReversed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransferStatus) UnmarshalText(data []byte) error {
+	switch TransferStatus(data) {
+	case TransferStatusCompleted:
+		*s = TransferStatusCompleted
+		return nil
+	case TransferStatusReversed:
+		*s = TransferStatusReversed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
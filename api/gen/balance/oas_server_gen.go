@@ -8,14 +8,58 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// CaptureHold implements captureHold operation.
+	//
+	// Deducts held points for the order once it is confirmed. Without a sum the whole hold is deducted,
+	// a partial capture releases the rest. The deduction follows the same per-order limits as a
+	// withdrawal. Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/holds/{id}/capture
+	CaptureHold(ctx context.Context, req OptCaptureHoldReq, params CaptureHoldParams) (CaptureHoldRes, error)
+	// CreateHold implements createHold operation.
+	//
+	// Reserves points for the order at the start of checkout. Held points are included in the current
+	// balance but are not available for other withdrawals until the hold is captured, released or
+	// expires. Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/holds
+	CreateHold(ctx context.Context, req *CreateHoldReq) (CreateHoldRes, error)
 	// DeductPoints implements deductPoints operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/balance/withdraw
 	DeductPoints(ctx context.Context, req OptDeductPointsReq) (DeductPointsRes, error)
+	// GetAdjustments implements getAdjustments operation.
+	//
+	// GET /api/user/balance/adjustments
+	GetAdjustments(ctx context.Context) (GetAdjustmentsRes, error)
 	// GetBalance implements getBalance operation.
 	//
 	// GET /api/user/balance
 	GetBalance(ctx context.Context) (GetBalanceRes, error)
+	// GetTransfers implements getTransfers operation.
+	//
+	// Returns transfers sent and received by the user.
+	//
+	// GET /api/user/balance/transfers
+	GetTransfers(ctx context.Context) (GetTransfersRes, error)
+	// ReleaseHold implements releaseHold operation.
+	//
+	// Releases held points without deducting them.
+	//
+	// POST /api/user/balance/holds/{id}/release
+	ReleaseHold(ctx context.Context, params ReleaseHoldParams) (ReleaseHoldRes, error)
+	// TransferPoints implements transferPoints operation.
+	//
+	// Transfers points to another user. The sender is debited and the recipient is credited in one
+	// transaction. Transfers are limited by a minimum amount and a daily total and count per sender.
+	// Supports the Idempotency-Key header.
+	//
+	// POST /api/user/balance/transfer
+	TransferPoints(ctx context.Context, req *TransferPointsReq) (TransferPointsRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -13,16 +13,78 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// CaptureHold implements captureHold operation.
+//
+// Deducts held points for the order once it is confirmed. Without a sum the whole hold is deducted,
+// a partial capture releases the rest. The deduction follows the same per-order limits as a
+// withdrawal. Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/holds/{id}/capture
+func (UnimplementedHandler) CaptureHold(ctx context.Context, req OptCaptureHoldReq, params CaptureHoldParams) (r CaptureHoldRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateHold implements createHold operation.
+//
+// Reserves points for the order at the start of checkout. Held points are included in the current
+// balance but are not available for other withdrawals until the hold is captured, released or
+// expires. Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/holds
+func (UnimplementedHandler) CreateHold(ctx context.Context, req *CreateHoldReq) (r CreateHoldRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // DeductPoints implements deductPoints operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/balance/withdraw
 func (UnimplementedHandler) DeductPoints(ctx context.Context, req OptDeductPointsReq) (r DeductPointsRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// GetAdjustments implements getAdjustments operation.
+//
+// GET /api/user/balance/adjustments
+func (UnimplementedHandler) GetAdjustments(ctx context.Context) (r GetAdjustmentsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetBalance implements getBalance operation.
 //
 // GET /api/user/balance
 func (UnimplementedHandler) GetBalance(ctx context.Context) (r GetBalanceRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// GetTransfers implements getTransfers operation.
+//
+// Returns transfers sent and received by the user.
+//
+// GET /api/user/balance/transfers
+func (UnimplementedHandler) GetTransfers(ctx context.Context) (r GetTransfersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ReleaseHold implements releaseHold operation.
+//
+// Releases held points without deducting them.
+//
+// POST /api/user/balance/holds/{id}/release
+func (UnimplementedHandler) ReleaseHold(ctx context.Context, params ReleaseHoldParams) (r ReleaseHoldRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// TransferPoints implements transferPoints operation.
+//
+// Transfers points to another user. The sender is debited and the recipient is credited in one
+// transaction. Transfers are limited by a minimum amount and a daily total and count per sender.
+// Supports the Idempotency-Key header.
+//
+// POST /api/user/balance/transfer
+func (UnimplementedHandler) TransferPoints(ctx context.Context, req *TransferPointsReq) (r TransferPointsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,225 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"time"
+)
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// GetNotificationsInternalServerError is response for GetNotifications operation.
+type GetNotificationsInternalServerError struct{}
+
+func (*GetNotificationsInternalServerError) getNotificationsRes() {}
+
+// GetNotificationsNoContent is response for GetNotifications operation.
+type GetNotificationsNoContent struct{}
+
+func (*GetNotificationsNoContent) getNotificationsRes() {}
+
+type GetNotificationsOKApplicationJSON []GetNotificationsOKItem
+
+func (*GetNotificationsOKApplicationJSON) getNotificationsRes() {}
+
+type GetNotificationsOKItem struct {
+	ID        OptInt64    `json:"id"`
+	Kind      OptString   `json:"kind"`
+	Message   OptString   `json:"message"`
+	CreatedAt OptDateTime `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *GetNotificationsOKItem) GetID() OptInt64 {
+	return s.ID
+}
+
+// GetKind returns the value of Kind.
+func (s *GetNotificationsOKItem) GetKind() OptString {
+	return s.Kind
+}
+
+// GetMessage returns the value of Message.
+func (s *GetNotificationsOKItem) GetMessage() OptString {
+	return s.Message
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *GetNotificationsOKItem) GetCreatedAt() OptDateTime {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *GetNotificationsOKItem) SetID(val OptInt64) {
+	s.ID = val
+}
+
+// SetKind sets the value of Kind.
+func (s *GetNotificationsOKItem) SetKind(val OptString) {
+	s.Kind = val
+}
+
+// SetMessage sets the value of Message.
+func (s *GetNotificationsOKItem) SetMessage(val OptString) {
+	s.Message = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *GetNotificationsOKItem) SetCreatedAt(val OptDateTime) {
+	s.CreatedAt = val
+}
+
+// GetNotificationsUnauthorized is response for GetNotifications operation.
+type GetNotificationsUnauthorized struct{}
+
+func (*GetNotificationsUnauthorized) getNotificationsRes() {}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
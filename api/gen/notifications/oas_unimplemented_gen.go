@@ -0,0 +1,21 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// GetNotifications implements getNotifications operation.
+//
+// GET /api/user/notifications
+func (UnimplementedHandler) GetNotifications(ctx context.Context) (r GetNotificationsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,15 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"github.com/go-faster/errors"
+)
+
+func (s GetNotificationsOKApplicationJSON) Validate() error {
+	alias := ([]GetNotificationsOKItem)(s)
+	if alias == nil {
+		return errors.New("nil is invalid value")
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/ogen-go/ogen/conv"
 	ht "github.com/ogen-go/ogen/http"
 	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/ogen-go/ogen/otelogen"
@@ -29,8 +30,12 @@ type Invoker interface {
 	GetOrders(ctx context.Context) (GetOrdersRes, error)
 	// LoadOrder invokes loadOrder operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/orders
-	LoadOrder(ctx context.Context, request LoadOrderReq) (LoadOrderRes, error)
+	LoadOrder(ctx context.Context, request LoadOrderReq, params LoadOrderParams) (LoadOrderRes, error)
 }
 
 // Client implements OAS client.
@@ -188,13 +193,17 @@ func (c *Client) sendGetOrders(ctx context.Context) (res GetOrdersRes, err error
 
 // LoadOrder invokes loadOrder operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/orders
-func (c *Client) LoadOrder(ctx context.Context, request LoadOrderReq) (LoadOrderRes, error) {
-	res, err := c.sendLoadOrder(ctx, request)
+func (c *Client) LoadOrder(ctx context.Context, request LoadOrderReq, params LoadOrderParams) (LoadOrderRes, error) {
+	res, err := c.sendLoadOrder(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendLoadOrder(ctx context.Context, request LoadOrderReq) (res LoadOrderRes, err error) {
+func (c *Client) sendLoadOrder(ctx context.Context, request LoadOrderReq, params LoadOrderParams) (res LoadOrderRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		otelogen.OperationID("loadOrder"),
 		semconv.HTTPMethodKey.String("POST"),
@@ -243,6 +252,23 @@ func (c *Client) sendLoadOrder(ctx context.Context, request LoadOrderReq) (res L
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "X-Client-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.XClientKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
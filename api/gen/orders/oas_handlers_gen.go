@@ -155,6 +155,10 @@ func (s *Server) handleGetOrdersRequest(args [0]string, argsEscaped bool, w http
 
 // handleLoadOrderRequest handles loadOrder operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/orders
 func (s *Server) handleLoadOrderRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	otelAttrs := []attribute.KeyValue{
@@ -237,6 +241,16 @@ func (s *Server) handleLoadOrderRequest(args [0]string, argsEscaped bool, w http
 			return
 		}
 	}
+	params, err := decodeLoadOrderParams(args, argsEscaped, r)
+	if err != nil {
+		err = &ogenerrors.DecodeParamsError{
+			OperationContext: opErrContext,
+			Err:              err,
+		}
+		recordError("DecodeParams", err)
+		s.cfg.ErrorHandler(ctx, w, r, err)
+		return
+	}
 	request, close, err := s.decodeLoadOrderRequest(r)
 	if err != nil {
 		err = &ogenerrors.DecodeRequestError{
@@ -261,13 +275,18 @@ func (s *Server) handleLoadOrderRequest(args [0]string, argsEscaped bool, w http
 			OperationSummary: "",
 			OperationID:      "loadOrder",
 			Body:             request,
-			Params:           middleware.Parameters{},
-			Raw:              r,
+			Params: middleware.Parameters{
+				{
+					Name: "X-Client-Key",
+					In:   "header",
+				}: params.XClientKey,
+			},
+			Raw: r,
 		}
 
 		type (
 			Request  = LoadOrderReq
-			Params   = struct{}
+			Params   = LoadOrderParams
 			Response = LoadOrderRes
 		)
 		response, err = middleware.HookMiddleware[
@@ -277,14 +296,14 @@ func (s *Server) handleLoadOrderRequest(args [0]string, argsEscaped bool, w http
 		](
 			m,
 			mreq,
-			nil,
+			unpackLoadOrderParams,
 			func(ctx context.Context, request Request, params Params) (response Response, err error) {
-				response, err = s.h.LoadOrder(ctx, request)
+				response, err = s.h.LoadOrder(ctx, request, params)
 				return response, err
 			},
 		)
 	} else {
-		response, err = s.h.LoadOrder(ctx, request)
+		response, err = s.h.LoadOrder(ctx, request, params)
 	}
 	if err != nil {
 		recordError("Internal", err)
@@ -0,0 +1,103 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ogen-go/ogen/conv"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/uri"
+	"github.com/ogen-go/ogen/validate"
+)
+
+// LoadOrderParams is parameters of loadOrder operation.
+type LoadOrderParams struct {
+	// Key of the client the order is uploaded through, e.g. the mobile app. The key is issued by the
+	// operator and determines the upload channel that campaigns may require. Orders with an unknown key
+	// have no channel.
+	XClientKey OptString
+}
+
+func unpackLoadOrderParams(packed middleware.Parameters) (params LoadOrderParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Client-Key",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XClientKey = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeLoadOrderParams(args [0]string, argsEscaped bool, r *http.Request) (params LoadOrderParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Client-Key.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Client-Key",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXClientKeyVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXClientKeyVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XClientKey.SetTo(paramsDotXClientKeyVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XClientKey.Get(); ok {
+					if err := func() error {
+						if err := (validate.String{
+							MinLength:    0,
+							MinLengthSet: false,
+							MaxLength:    256,
+							MaxLengthSet: true,
+							Email:        false,
+							Hostname:     false,
+							Regex:        nil,
+						}).Validate(string(value)); err != nil {
+							return errors.Wrap(err, "string")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Client-Key",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
@@ -14,8 +14,12 @@ type Handler interface {
 	GetOrders(ctx context.Context) (GetOrdersRes, error)
 	// LoadOrder implements loadOrder operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/orders
-	LoadOrder(ctx context.Context, req LoadOrderReq) (LoadOrderRes, error)
+	LoadOrder(ctx context.Context, req LoadOrderReq, params LoadOrderParams) (LoadOrderRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
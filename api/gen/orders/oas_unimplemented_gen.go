@@ -22,7 +22,11 @@ func (UnimplementedHandler) GetOrders(ctx context.Context) (r GetOrdersRes, _ er
 
 // LoadOrder implements loadOrder operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/orders
-func (UnimplementedHandler) LoadOrder(ctx context.Context, req LoadOrderReq) (r LoadOrderRes, _ error) {
+func (UnimplementedHandler) LoadOrder(ctx context.Context, req LoadOrderReq, params LoadOrderParams) (r LoadOrderRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
@@ -0,0 +1,326 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// ChangePasswordBadRequest is response for ChangePassword operation.
+type ChangePasswordBadRequest struct{}
+
+func (*ChangePasswordBadRequest) changePasswordRes() {}
+
+// ChangePasswordInternalServerError is response for ChangePassword operation.
+type ChangePasswordInternalServerError struct{}
+
+func (*ChangePasswordInternalServerError) changePasswordRes() {}
+
+type ChangePasswordOK struct {
+	Data OptChangePasswordOKData `json:"data"`
+}
+
+// GetData returns the value of Data.
+func (s *ChangePasswordOK) GetData() OptChangePasswordOKData {
+	return s.Data
+}
+
+// SetData sets the value of Data.
+func (s *ChangePasswordOK) SetData(val OptChangePasswordOKData) {
+	s.Data = val
+}
+
+func (*ChangePasswordOK) changePasswordRes() {}
+
+type ChangePasswordOKData struct {
+	Token OptString `json:"token"`
+}
+
+// GetToken returns the value of Token.
+func (s *ChangePasswordOKData) GetToken() OptString {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *ChangePasswordOKData) SetToken(val OptString) {
+	s.Token = val
+}
+
+type ChangePasswordReq struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// GetOldPassword returns the value of OldPassword.
+func (s *ChangePasswordReq) GetOldPassword() string {
+	return s.OldPassword
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *ChangePasswordReq) GetNewPassword() string {
+	return s.NewPassword
+}
+
+// SetOldPassword sets the value of OldPassword.
+func (s *ChangePasswordReq) SetOldPassword(val string) {
+	s.OldPassword = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *ChangePasswordReq) SetNewPassword(val string) {
+	s.NewPassword = val
+}
+
+// ChangePasswordUnauthorized is response for ChangePassword operation.
+type ChangePasswordUnauthorized struct{}
+
+func (*ChangePasswordUnauthorized) changePasswordRes() {}
+
+// ChangePasswordUnprocessableEntity is response for ChangePassword operation.
+type ChangePasswordUnprocessableEntity struct{}
+
+func (*ChangePasswordUnprocessableEntity) changePasswordRes() {}
+
+// NewOptChangePasswordOKData returns new OptChangePasswordOKData with value set to v.
+func NewOptChangePasswordOKData(v ChangePasswordOKData) OptChangePasswordOKData {
+	return OptChangePasswordOKData{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptChangePasswordOKData is optional ChangePasswordOKData.
+type OptChangePasswordOKData struct {
+	Value ChangePasswordOKData
+	Set   bool
+}
+
+// IsSet returns true if OptChangePasswordOKData was set.
+func (o OptChangePasswordOKData) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptChangePasswordOKData) Reset() {
+	var v ChangePasswordOKData
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptChangePasswordOKData) SetTo(v ChangePasswordOKData) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptChangePasswordOKData) Get() (v ChangePasswordOKData, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptChangePasswordOKData) Or(d ChangePasswordOKData) ChangePasswordOKData {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptChangePasswordReq returns new OptChangePasswordReq with value set to v.
+func NewOptChangePasswordReq(v ChangePasswordReq) OptChangePasswordReq {
+	return OptChangePasswordReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptChangePasswordReq is optional ChangePasswordReq.
+type OptChangePasswordReq struct {
+	Value ChangePasswordReq
+	Set   bool
+}
+
+// IsSet returns true if OptChangePasswordReq was set.
+func (o OptChangePasswordReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptChangePasswordReq) Reset() {
+	var v ChangePasswordReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptChangePasswordReq) SetTo(v ChangePasswordReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptChangePasswordReq) Get() (v ChangePasswordReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptChangePasswordReq) Or(d ChangePasswordReq) ChangePasswordReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptResetPasswordReq returns new OptResetPasswordReq with value set to v.
+func NewOptResetPasswordReq(v ResetPasswordReq) OptResetPasswordReq {
+	return OptResetPasswordReq{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptResetPasswordReq is optional ResetPasswordReq.
+type OptResetPasswordReq struct {
+	Value ResetPasswordReq
+	Set   bool
+}
+
+// IsSet returns true if OptResetPasswordReq was set.
+func (o OptResetPasswordReq) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptResetPasswordReq) Reset() {
+	var v ResetPasswordReq
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptResetPasswordReq) SetTo(v ResetPasswordReq) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptResetPasswordReq) Get() (v ResetPasswordReq, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptResetPasswordReq) Or(d ResetPasswordReq) ResetPasswordReq {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// ResetPasswordBadRequest is response for ResetPassword operation.
+type ResetPasswordBadRequest struct{}
+
+func (*ResetPasswordBadRequest) resetPasswordRes() {}
+
+// ResetPasswordInternalServerError is response for ResetPassword operation.
+type ResetPasswordInternalServerError struct{}
+
+func (*ResetPasswordInternalServerError) resetPasswordRes() {}
+
+// ResetPasswordOK is response for ResetPassword operation.
+type ResetPasswordOK struct{}
+
+func (*ResetPasswordOK) resetPasswordRes() {}
+
+type ResetPasswordReq struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// GetToken returns the value of Token.
+func (s *ResetPasswordReq) GetToken() string {
+	return s.Token
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *ResetPasswordReq) GetNewPassword() string {
+	return s.NewPassword
+}
+
+// SetToken sets the value of Token.
+func (s *ResetPasswordReq) SetToken(val string) {
+	s.Token = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *ResetPasswordReq) SetNewPassword(val string) {
+	s.NewPassword = val
+}
+
+// ResetPasswordUnauthorized is response for ResetPassword operation.
+type ResetPasswordUnauthorized struct{}
+
+func (*ResetPasswordUnauthorized) resetPasswordRes() {}
+
+// ResetPasswordUnprocessableEntity is response for ResetPassword operation.
+type ResetPasswordUnprocessableEntity struct{}
+
+func (*ResetPasswordUnprocessableEntity) resetPasswordRes() {}
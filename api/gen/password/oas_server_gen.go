@@ -0,0 +1,40 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// ChangePassword implements changePassword operation.
+	//
+	// POST /api/user/password
+	ChangePassword(ctx context.Context, req OptChangePasswordReq) (ChangePasswordRes, error)
+	// ResetPassword implements resetPassword operation.
+	//
+	// POST /api/user/password/reset
+	ResetPassword(ctx context.Context, req OptResetPasswordReq) (ResetPasswordRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
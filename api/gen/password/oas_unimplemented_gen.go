@@ -0,0 +1,28 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// ChangePassword implements changePassword operation.
+//
+// POST /api/user/password
+func (UnimplementedHandler) ChangePassword(ctx context.Context, req OptChangePasswordReq) (r ChangePasswordRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ResetPassword implements resetPassword operation.
+//
+// POST /api/user/password/reset
+func (UnimplementedHandler) ResetPassword(ctx context.Context, req OptResetPasswordReq) (r ResetPasswordRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
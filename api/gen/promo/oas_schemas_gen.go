@@ -0,0 +1,103 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"time"
+)
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// RedeemPromoCodeBadRequest is response for RedeemPromoCode operation.
+type RedeemPromoCodeBadRequest struct{}
+
+func (*RedeemPromoCodeBadRequest) redeemPromoCodeRes() {}
+
+// RedeemPromoCodeConflict is response for RedeemPromoCode operation.
+type RedeemPromoCodeConflict struct{}
+
+func (*RedeemPromoCodeConflict) redeemPromoCodeRes() {}
+
+// RedeemPromoCodeGone is response for RedeemPromoCode operation.
+type RedeemPromoCodeGone struct{}
+
+func (*RedeemPromoCodeGone) redeemPromoCodeRes() {}
+
+// RedeemPromoCodeInternalServerError is response for RedeemPromoCode operation.
+type RedeemPromoCodeInternalServerError struct{}
+
+func (*RedeemPromoCodeInternalServerError) redeemPromoCodeRes() {}
+
+// RedeemPromoCodeNotFound is response for RedeemPromoCode operation.
+type RedeemPromoCodeNotFound struct{}
+
+func (*RedeemPromoCodeNotFound) redeemPromoCodeRes() {}
+
+type RedeemPromoCodeOK struct {
+	Code       string    `json:"code"`
+	Amount     float64   `json:"amount"`
+	RedeemedAt time.Time `json:"redeemed_at"`
+}
+
+// GetCode returns the value of Code.
+func (s *RedeemPromoCodeOK) GetCode() string {
+	return s.Code
+}
+
+// GetAmount returns the value of Amount.
+func (s *RedeemPromoCodeOK) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetRedeemedAt returns the value of RedeemedAt.
+func (s *RedeemPromoCodeOK) GetRedeemedAt() time.Time {
+	return s.RedeemedAt
+}
+
+// SetCode sets the value of Code.
+func (s *RedeemPromoCodeOK) SetCode(val string) {
+	s.Code = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *RedeemPromoCodeOK) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetRedeemedAt sets the value of RedeemedAt.
+func (s *RedeemPromoCodeOK) SetRedeemedAt(val time.Time) {
+	s.RedeemedAt = val
+}
+
+func (*RedeemPromoCodeOK) redeemPromoCodeRes() {}
+
+type RedeemPromoCodeReq struct {
+	Code string `json:"code"`
+}
+
+// GetCode returns the value of Code.
+func (s *RedeemPromoCodeReq) GetCode() string {
+	return s.Code
+}
+
+// SetCode sets the value of Code.
+func (s *RedeemPromoCodeReq) SetCode(val string) {
+	s.Code = val
+}
+
+// RedeemPromoCodeUnauthorized is response for RedeemPromoCode operation.
+type RedeemPromoCodeUnauthorized struct{}
+
+func (*RedeemPromoCodeUnauthorized) redeemPromoCodeRes() {}
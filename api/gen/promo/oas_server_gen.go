@@ -0,0 +1,39 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// RedeemPromoCode implements redeemPromoCode operation.
+	//
+	// Credits the points of a promo code to the user balance. The code is case-insensitive. Supports the
+	// Idempotency-Key header.
+	//
+	// POST /api/user/promo
+	RedeemPromoCode(ctx context.Context, req *RedeemPromoCodeReq) (RedeemPromoCodeRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
@@ -0,0 +1,24 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// RedeemPromoCode implements redeemPromoCode operation.
+//
+// Credits the points of a promo code to the user balance. The code is case-insensitive. Supports the
+// Idempotency-Key header.
+//
+// POST /api/user/promo
+func (UnimplementedHandler) RedeemPromoCode(ctx context.Context, req *RedeemPromoCodeReq) (r RedeemPromoCodeRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,217 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// GetReferralsInternalServerError is response for GetReferrals operation.
+type GetReferralsInternalServerError struct{}
+
+func (*GetReferralsInternalServerError) getReferralsRes() {}
+
+type GetReferralsOK struct {
+	Code      string                        `json:"code"`
+	Referrals []GetReferralsOKReferralsItem `json:"referrals"`
+}
+
+// GetCode returns the value of Code.
+func (s *GetReferralsOK) GetCode() string {
+	return s.Code
+}
+
+// GetReferrals returns the value of Referrals.
+func (s *GetReferralsOK) GetReferrals() []GetReferralsOKReferralsItem {
+	return s.Referrals
+}
+
+// SetCode sets the value of Code.
+func (s *GetReferralsOK) SetCode(val string) {
+	s.Code = val
+}
+
+// SetReferrals sets the value of Referrals.
+func (s *GetReferralsOK) SetReferrals(val []GetReferralsOKReferralsItem) {
+	s.Referrals = val
+}
+
+func (*GetReferralsOK) getReferralsRes() {}
+
+type GetReferralsOKReferralsItem struct {
+	Login string `json:"login"`
+	// Pending until the first processed order, rewarded when points are credited, rejected when the
+	// referral limit is exceeded.
+	Status GetReferralsOKReferralsItemStatus `json:"status"`
+	// Points for the referrer.
+	Bonus      float64     `json:"bonus"`
+	CreatedAt  time.Time   `json:"created_at"`
+	RewardedAt OptDateTime `json:"rewarded_at"`
+}
+
+// GetLogin returns the value of Login.
+func (s *GetReferralsOKReferralsItem) GetLogin() string {
+	return s.Login
+}
+
+// GetStatus returns the value of Status.
+func (s *GetReferralsOKReferralsItem) GetStatus() GetReferralsOKReferralsItemStatus {
+	return s.Status
+}
+
+// GetBonus returns the value of Bonus.
+func (s *GetReferralsOKReferralsItem) GetBonus() float64 {
+	return s.Bonus
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *GetReferralsOKReferralsItem) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetRewardedAt returns the value of RewardedAt.
+func (s *GetReferralsOKReferralsItem) GetRewardedAt() OptDateTime {
+	return s.RewardedAt
+}
+
+// SetLogin sets the value of Login.
+func (s *GetReferralsOKReferralsItem) SetLogin(val string) {
+	s.Login = val
+}
+
+// SetStatus sets the value of Status.
+func (s *GetReferralsOKReferralsItem) SetStatus(val GetReferralsOKReferralsItemStatus) {
+	s.Status = val
+}
+
+// SetBonus sets the value of Bonus.
+func (s *GetReferralsOKReferralsItem) SetBonus(val float64) {
+	s.Bonus = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *GetReferralsOKReferralsItem) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetRewardedAt sets the value of RewardedAt.
+func (s *GetReferralsOKReferralsItem) SetRewardedAt(val OptDateTime) {
+	s.RewardedAt = val
+}
+
+// Pending until the first processed order, rewarded when points are credited, rejected when the
+// referral limit is exceeded.
+type GetReferralsOKReferralsItemStatus string
+
+const (
+	GetReferralsOKReferralsItemStatusPending  GetReferralsOKReferralsItemStatus = "pending"
+	GetReferralsOKReferralsItemStatusRewarded GetReferralsOKReferralsItemStatus = "rewarded"
+	GetReferralsOKReferralsItemStatusRejected GetReferralsOKReferralsItemStatus = "rejected"
+)
+
+// AllValues returns all GetReferralsOKReferralsItemStatus values.
+func (GetReferralsOKReferralsItemStatus) AllValues() []GetReferralsOKReferralsItemStatus {
+	return []GetReferralsOKReferralsItemStatus{
+		GetReferralsOKReferralsItemStatusPending,
+		GetReferralsOKReferralsItemStatusRewarded,
+		GetReferralsOKReferralsItemStatusRejected,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetReferralsOKReferralsItemStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case GetReferralsOKReferralsItemStatusPending:
+		return []byte(s), nil
+	case GetReferralsOKReferralsItemStatusRewarded:
+		return []byte(s), nil
+	case GetReferralsOKReferralsItemStatusRejected:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetReferralsOKReferralsItemStatus) UnmarshalText(data []byte) error {
+	switch GetReferralsOKReferralsItemStatus(data) {
+	case GetReferralsOKReferralsItemStatusPending:
+		*s = GetReferralsOKReferralsItemStatusPending
+		return nil
+	case GetReferralsOKReferralsItemStatusRewarded:
+		*s = GetReferralsOKReferralsItemStatusRewarded
+		return nil
+	case GetReferralsOKReferralsItemStatusRejected:
+		*s = GetReferralsOKReferralsItemStatusRejected
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// GetReferralsUnauthorized is response for GetReferrals operation.
+type GetReferralsUnauthorized struct{}
+
+func (*GetReferralsUnauthorized) getReferralsRes() {}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
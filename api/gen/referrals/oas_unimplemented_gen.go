@@ -0,0 +1,24 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// GetReferrals implements getReferrals operation.
+//
+// Returns the referral code of the user and the users who signed up with it. Both users get bonus
+// points once the first order of the invited user is processed.
+//
+// GET /api/user/referrals
+func (UnimplementedHandler) GetReferrals(ctx context.Context) (r GetReferralsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
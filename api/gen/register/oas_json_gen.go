@@ -256,11 +256,18 @@ func (s *RegisterUserReq) encodeFields(e *jx.Encoder) {
 		e.FieldStart("password")
 		e.Str(s.Password)
 	}
+	{
+		if s.ReferralCode.Set {
+			e.FieldStart("referral_code")
+			s.ReferralCode.Encode(e)
+		}
+	}
 }
 
-var jsonFieldsNameOfRegisterUserReq = [2]string{
+var jsonFieldsNameOfRegisterUserReq = [3]string{
 	0: "login",
 	1: "password",
+	2: "referral_code",
 }
 
 // Decode decodes RegisterUserReq from json.
@@ -296,6 +303,16 @@ func (s *RegisterUserReq) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"password\"")
 			}
+		case "referral_code":
+			if err := func() error {
+				s.ReferralCode.Reset()
+				if err := s.ReferralCode.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"referral_code\"")
+			}
 		default:
 			return d.Skip()
 		}
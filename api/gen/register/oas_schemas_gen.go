@@ -188,6 +188,8 @@ func (s *RegisterUserOKData) SetToken(val OptString) {
 type RegisterUserReq struct {
 	Login    string `json:"login"`
 	Password string `json:"password"`
+	// Referral code of the user who invited the new user.
+	ReferralCode OptString `json:"referral_code"`
 }
 
 // GetLogin returns the value of Login.
@@ -200,6 +202,11 @@ func (s *RegisterUserReq) GetPassword() string {
 	return s.Password
 }
 
+// GetReferralCode returns the value of ReferralCode.
+func (s *RegisterUserReq) GetReferralCode() OptString {
+	return s.ReferralCode
+}
+
 // SetLogin sets the value of Login.
 func (s *RegisterUserReq) SetLogin(val string) {
 	s.Login = val
@@ -209,3 +216,8 @@ func (s *RegisterUserReq) SetLogin(val string) {
 func (s *RegisterUserReq) SetPassword(val string) {
 	s.Password = val
 }
+
+// SetReferralCode sets the value of ReferralCode.
+func (s *RegisterUserReq) SetReferralCode(val OptString) {
+	s.ReferralCode = val
+}
@@ -0,0 +1,255 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"io"
+
+	"github.com/go-faster/errors"
+)
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// GetStatementBadRequest is response for GetStatement operation.
+type GetStatementBadRequest struct{}
+
+func (*GetStatementBadRequest) getStatementRes() {}
+
+type GetStatementFormat string
+
+const (
+	GetStatementFormatCsv GetStatementFormat = "csv"
+	GetStatementFormatPdf GetStatementFormat = "pdf"
+)
+
+// AllValues returns all GetStatementFormat values.
+func (GetStatementFormat) AllValues() []GetStatementFormat {
+	return []GetStatementFormat{
+		GetStatementFormatCsv,
+		GetStatementFormatPdf,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetStatementFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case GetStatementFormatCsv:
+		return []byte(s), nil
+	case GetStatementFormatPdf:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetStatementFormat) UnmarshalText(data []byte) error {
+	switch GetStatementFormat(data) {
+	case GetStatementFormatCsv:
+		*s = GetStatementFormatCsv
+		return nil
+	case GetStatementFormatPdf:
+		*s = GetStatementFormatPdf
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// GetStatementInternalServerError is response for GetStatement operation.
+type GetStatementInternalServerError struct{}
+
+func (*GetStatementInternalServerError) getStatementRes() {}
+
+type GetStatementOKApplicationPdf struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetStatementOKApplicationPdf) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetStatementOKApplicationPdfHeaders wraps GetStatementOKApplicationPdf with response headers.
+type GetStatementOKApplicationPdfHeaders struct {
+	ContentDisposition OptString
+	Response           GetStatementOKApplicationPdf
+}
+
+// GetContentDisposition returns the value of ContentDisposition.
+func (s *GetStatementOKApplicationPdfHeaders) GetContentDisposition() OptString {
+	return s.ContentDisposition
+}
+
+// GetResponse returns the value of Response.
+func (s *GetStatementOKApplicationPdfHeaders) GetResponse() GetStatementOKApplicationPdf {
+	return s.Response
+}
+
+// SetContentDisposition sets the value of ContentDisposition.
+func (s *GetStatementOKApplicationPdfHeaders) SetContentDisposition(val OptString) {
+	s.ContentDisposition = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetStatementOKApplicationPdfHeaders) SetResponse(val GetStatementOKApplicationPdf) {
+	s.Response = val
+}
+
+func (*GetStatementOKApplicationPdfHeaders) getStatementRes() {}
+
+type GetStatementOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetStatementOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// GetStatementOKTextCsvHeaders wraps GetStatementOKTextCsv with response headers.
+type GetStatementOKTextCsvHeaders struct {
+	ContentDisposition OptString
+	Response           GetStatementOKTextCsv
+}
+
+// GetContentDisposition returns the value of ContentDisposition.
+func (s *GetStatementOKTextCsvHeaders) GetContentDisposition() OptString {
+	return s.ContentDisposition
+}
+
+// GetResponse returns the value of Response.
+func (s *GetStatementOKTextCsvHeaders) GetResponse() GetStatementOKTextCsv {
+	return s.Response
+}
+
+// SetContentDisposition sets the value of ContentDisposition.
+func (s *GetStatementOKTextCsvHeaders) SetContentDisposition(val OptString) {
+	s.ContentDisposition = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetStatementOKTextCsvHeaders) SetResponse(val GetStatementOKTextCsv) {
+	s.Response = val
+}
+
+func (*GetStatementOKTextCsvHeaders) getStatementRes() {}
+
+// GetStatementUnauthorized is response for GetStatement operation.
+type GetStatementUnauthorized struct{}
+
+func (*GetStatementUnauthorized) getStatementRes() {}
+
+// NewOptGetStatementFormat returns new OptGetStatementFormat with value set to v.
+func NewOptGetStatementFormat(v GetStatementFormat) OptGetStatementFormat {
+	return OptGetStatementFormat{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptGetStatementFormat is optional GetStatementFormat.
+type OptGetStatementFormat struct {
+	Value GetStatementFormat
+	Set   bool
+}
+
+// IsSet returns true if OptGetStatementFormat was set.
+func (o OptGetStatementFormat) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGetStatementFormat) Reset() {
+	var v GetStatementFormat
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptGetStatementFormat) SetTo(v GetStatementFormat) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetStatementFormat) Get() (v GetStatementFormat, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptGetStatementFormat) Or(d GetStatementFormat) GetStatementFormat {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
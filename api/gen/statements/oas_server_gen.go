@@ -0,0 +1,40 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// GetStatement implements getStatement operation.
+	//
+	// Returns the monthly statement of the user: the opening balance, every balance transaction in the
+	// month with the balance after it, the credited and debited totals, and the closing balance. The
+	// statement is streamed as it is generated.
+	//
+	// GET /api/user/statements
+	GetStatement(ctx context.Context, params GetStatementParams) (GetStatementRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
@@ -0,0 +1,25 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// GetStatement implements getStatement operation.
+//
+// Returns the monthly statement of the user: the opening balance, every balance transaction in the
+// month with the balance after it, the credited and debited totals, and the closing balance. The
+// statement is streamed as it is generated.
+//
+// GET /api/user/statements
+func (UnimplementedHandler) GetStatement(ctx context.Context, params GetStatementParams) (r GetStatementRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
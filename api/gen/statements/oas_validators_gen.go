@@ -0,0 +1,18 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"github.com/go-faster/errors"
+)
+
+func (s GetStatementFormat) Validate() error {
+	switch s {
+	case "csv":
+		return nil
+	case "pdf":
+		return nil
+	default:
+		return errors.Errorf("invalid value: %v", s)
+	}
+}
@@ -0,0 +1,354 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// GetTransactionsBadRequest is response for GetTransactions operation.
+type GetTransactionsBadRequest struct{}
+
+func (*GetTransactionsBadRequest) getTransactionsRes() {}
+
+// GetTransactionsInternalServerError is response for GetTransactions operation.
+type GetTransactionsInternalServerError struct{}
+
+func (*GetTransactionsInternalServerError) getTransactionsRes() {}
+
+// GetTransactionsNoContent is response for GetTransactions operation.
+type GetTransactionsNoContent struct{}
+
+func (*GetTransactionsNoContent) getTransactionsRes() {}
+
+type GetTransactionsOKApplicationJSON []GetTransactionsOKItem
+
+func (*GetTransactionsOKApplicationJSON) getTransactionsRes() {}
+
+type GetTransactionsOKItem struct {
+	ID   int64                     `json:"id"`
+	Type GetTransactionsOKItemType `json:"type"`
+	// Balance change, negative for deductions.
+	Amount float64 `json:"amount"`
+	// Related order number.
+	Order OptString `json:"order"`
+	// Balance after the movement.
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *GetTransactionsOKItem) GetID() int64 {
+	return s.ID
+}
+
+// GetType returns the value of Type.
+func (s *GetTransactionsOKItem) GetType() GetTransactionsOKItemType {
+	return s.Type
+}
+
+// GetAmount returns the value of Amount.
+func (s *GetTransactionsOKItem) GetAmount() float64 {
+	return s.Amount
+}
+
+// GetOrder returns the value of Order.
+func (s *GetTransactionsOKItem) GetOrder() OptString {
+	return s.Order
+}
+
+// GetBalance returns the value of Balance.
+func (s *GetTransactionsOKItem) GetBalance() float64 {
+	return s.Balance
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *GetTransactionsOKItem) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *GetTransactionsOKItem) SetID(val int64) {
+	s.ID = val
+}
+
+// SetType sets the value of Type.
+func (s *GetTransactionsOKItem) SetType(val GetTransactionsOKItemType) {
+	s.Type = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *GetTransactionsOKItem) SetAmount(val float64) {
+	s.Amount = val
+}
+
+// SetOrder sets the value of Order.
+func (s *GetTransactionsOKItem) SetOrder(val OptString) {
+	s.Order = val
+}
+
+// SetBalance sets the value of Balance.
+func (s *GetTransactionsOKItem) SetBalance(val float64) {
+	s.Balance = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *GetTransactionsOKItem) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+type GetTransactionsOKItemType string
+
+const (
+	GetTransactionsOKItemTypeAccrual    GetTransactionsOKItemType = "accrual"
+	GetTransactionsOKItemTypeCampaign   GetTransactionsOKItemType = "campaign"
+	GetTransactionsOKItemTypePromo      GetTransactionsOKItemType = "promo"
+	GetTransactionsOKItemTypeReferral   GetTransactionsOKItemType = "referral"
+	GetTransactionsOKItemTypeTransfer   GetTransactionsOKItemType = "transfer"
+	GetTransactionsOKItemTypeAdjustment GetTransactionsOKItemType = "adjustment"
+	GetTransactionsOKItemTypeRefund     GetTransactionsOKItemType = "refund"
+	GetTransactionsOKItemTypeWithdrawal GetTransactionsOKItemType = "withdrawal"
+	GetTransactionsOKItemTypeClawback   GetTransactionsOKItemType = "clawback"
+	GetTransactionsOKItemTypeExpiration GetTransactionsOKItemType = "expiration"
+)
+
+// AllValues returns all GetTransactionsOKItemType values.
+func (GetTransactionsOKItemType) AllValues() []GetTransactionsOKItemType {
+	return []GetTransactionsOKItemType{
+		GetTransactionsOKItemTypeAccrual,
+		GetTransactionsOKItemTypeCampaign,
+		GetTransactionsOKItemTypePromo,
+		GetTransactionsOKItemTypeReferral,
+		GetTransactionsOKItemTypeTransfer,
+		GetTransactionsOKItemTypeAdjustment,
+		GetTransactionsOKItemTypeRefund,
+		GetTransactionsOKItemTypeWithdrawal,
+		GetTransactionsOKItemTypeClawback,
+		GetTransactionsOKItemTypeExpiration,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetTransactionsOKItemType) MarshalText() ([]byte, error) {
+	switch s {
+	case GetTransactionsOKItemTypeAccrual:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeCampaign:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypePromo:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeReferral:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeTransfer:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeAdjustment:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeRefund:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeWithdrawal:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeClawback:
+		return []byte(s), nil
+	case GetTransactionsOKItemTypeExpiration:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetTransactionsOKItemType) UnmarshalText(data []byte) error {
+	switch GetTransactionsOKItemType(data) {
+	case GetTransactionsOKItemTypeAccrual:
+		*s = GetTransactionsOKItemTypeAccrual
+		return nil
+	case GetTransactionsOKItemTypeCampaign:
+		*s = GetTransactionsOKItemTypeCampaign
+		return nil
+	case GetTransactionsOKItemTypePromo:
+		*s = GetTransactionsOKItemTypePromo
+		return nil
+	case GetTransactionsOKItemTypeReferral:
+		*s = GetTransactionsOKItemTypeReferral
+		return nil
+	case GetTransactionsOKItemTypeTransfer:
+		*s = GetTransactionsOKItemTypeTransfer
+		return nil
+	case GetTransactionsOKItemTypeAdjustment:
+		*s = GetTransactionsOKItemTypeAdjustment
+		return nil
+	case GetTransactionsOKItemTypeRefund:
+		*s = GetTransactionsOKItemTypeRefund
+		return nil
+	case GetTransactionsOKItemTypeWithdrawal:
+		*s = GetTransactionsOKItemTypeWithdrawal
+		return nil
+	case GetTransactionsOKItemTypeClawback:
+		*s = GetTransactionsOKItemTypeClawback
+		return nil
+	case GetTransactionsOKItemTypeExpiration:
+		*s = GetTransactionsOKItemTypeExpiration
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// GetTransactionsUnauthorized is response for GetTransactions operation.
+type GetTransactionsUnauthorized struct{}
+
+func (*GetTransactionsUnauthorized) getTransactionsRes() {}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
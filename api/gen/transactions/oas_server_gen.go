@@ -0,0 +1,40 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// GetTransactions implements getTransactions operation.
+	//
+	// Returns balance movements of the user in chronological order: accruals, withdrawals, refunds,
+	// adjustments, transfers, bonuses and expired points. To get the next page pass the id of the last
+	// movement as after.
+	//
+	// GET /api/user/transactions
+	GetTransactions(ctx context.Context, params GetTransactionsParams) (GetTransactionsRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
@@ -0,0 +1,25 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// UnimplementedHandler is no-op Handler which returns http.ErrNotImplemented.
+type UnimplementedHandler struct{}
+
+var _ Handler = UnimplementedHandler{}
+
+// GetTransactions implements getTransactions operation.
+//
+// Returns balance movements of the user in chronological order: accruals, withdrawals, refunds,
+// adjustments, transfers, bonuses and expired points. To get the next page pass the id of the last
+// movement as after.
+//
+// GET /api/user/transactions
+func (UnimplementedHandler) GetTransactions(ctx context.Context, params GetTransactionsParams) (r GetTransactionsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
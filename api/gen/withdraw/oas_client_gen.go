@@ -25,6 +25,10 @@ import (
 type Invoker interface {
 	// DeductPoints invokes deductPoints operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/balance/withdraw
 	DeductPoints(ctx context.Context, request OptDeductPointsReq) (DeductPointsRes, error)
 }
@@ -81,6 +85,10 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 
 // DeductPoints invokes deductPoints operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/balance/withdraw
 func (c *Client) DeductPoints(ctx context.Context, request OptDeductPointsReq) (DeductPointsRes, error) {
 	res, err := c.sendDeductPoints(ctx, request)
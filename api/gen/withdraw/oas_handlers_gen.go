@@ -22,6 +22,10 @@ import (
 
 // handleDeductPointsRequest handles deductPoints operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/balance/withdraw
 func (s *Server) handleDeductPointsRequest(args [0]string, argsEscaped bool, w http.ResponseWriter, r *http.Request) {
 	otelAttrs := []attribute.KeyValue{
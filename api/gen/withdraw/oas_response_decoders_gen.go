@@ -13,12 +13,18 @@ func decodeDeductPointsResponse(resp *http.Response) (res DeductPointsRes, _ err
 	case 200:
 		// Code 200.
 		return &DeductPointsOK{}, nil
+	case 400:
+		// Code 400.
+		return &DeductPointsBadRequest{}, nil
 	case 401:
 		// Code 401.
 		return &DeductPointsUnauthorized{}, nil
 	case 402:
 		// Code 402.
 		return &DeductPointsPaymentRequired{}, nil
+	case 409:
+		// Code 409.
+		return &DeductPointsConflict{}, nil
 	case 422:
 		// Code 422.
 		return &DeductPointsUnprocessableEntity{}, nil
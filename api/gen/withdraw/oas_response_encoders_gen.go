@@ -18,6 +18,12 @@ func encodeDeductPointsResponse(response DeductPointsRes, w http.ResponseWriter,
 
 		return nil
 
+	case *DeductPointsBadRequest:
+		w.WriteHeader(400)
+		span.SetStatus(codes.Error, http.StatusText(400))
+
+		return nil
+
 	case *DeductPointsUnauthorized:
 		w.WriteHeader(401)
 		span.SetStatus(codes.Error, http.StatusText(401))
@@ -30,6 +36,12 @@ func encodeDeductPointsResponse(response DeductPointsRes, w http.ResponseWriter,
 
 		return nil
 
+	case *DeductPointsConflict:
+		w.WriteHeader(409)
+		span.SetStatus(codes.Error, http.StatusText(409))
+
+		return nil
+
 	case *DeductPointsUnprocessableEntity:
 		w.WriteHeader(422)
 		span.SetStatus(codes.Error, http.StatusText(422))
@@ -16,6 +16,16 @@ func (s *BearerAuth) SetToken(val string) {
 	s.Token = val
 }
 
+// DeductPointsBadRequest is response for DeductPoints operation.
+type DeductPointsBadRequest struct{}
+
+func (*DeductPointsBadRequest) deductPointsRes() {}
+
+// DeductPointsConflict is response for DeductPoints operation.
+type DeductPointsConflict struct{}
+
+func (*DeductPointsConflict) deductPointsRes() {}
+
 // DeductPointsInternalServerError is response for DeductPoints operation.
 type DeductPointsInternalServerError struct{}
 
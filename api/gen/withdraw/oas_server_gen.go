@@ -10,6 +10,10 @@ import (
 type Handler interface {
 	// DeductPoints implements deductPoints operation.
 	//
+	// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+	// the same key, a key reused with another request returns 422, a key of a request in progress
+	// returns 409.
+	//
 	// POST /api/user/balance/withdraw
 	DeductPoints(ctx context.Context, req OptDeductPointsReq) (DeductPointsRes, error)
 }
@@ -15,6 +15,10 @@ var _ Handler = UnimplementedHandler{}
 
 // DeductPoints implements deductPoints operation.
 //
+// Supports the Idempotency-Key header: the first response is stored and replayed for retries with
+// the same key, a key reused with another request returns 422, a key of a request in progress
+// returns 409.
+//
 // POST /api/user/balance/withdraw
 func (UnimplementedHandler) DeductPoints(ctx context.Context, req OptDeductPointsReq) (r DeductPointsRes, _ error) {
 	return r, ht.ErrNotImplemented
@@ -82,6 +82,18 @@ func (s *GetWithdrawalsOKItem) encodeFields(e *jx.Encoder) {
 			s.Sum.Encode(e)
 		}
 	}
+	{
+		if s.Refunded.Set {
+			e.FieldStart("refunded")
+			s.Refunded.Encode(e)
+		}
+	}
+	{
+		if s.Status.Set {
+			e.FieldStart("status")
+			s.Status.Encode(e)
+		}
+	}
 	{
 		if s.ProcessedAt.Set {
 			e.FieldStart("processed_at")
@@ -90,10 +102,12 @@ func (s *GetWithdrawalsOKItem) encodeFields(e *jx.Encoder) {
 	}
 }
 
-var jsonFieldsNameOfGetWithdrawalsOKItem = [3]string{
+var jsonFieldsNameOfGetWithdrawalsOKItem = [5]string{
 	0: "order",
 	1: "sum",
-	2: "processed_at",
+	2: "refunded",
+	3: "status",
+	4: "processed_at",
 }
 
 // Decode decodes GetWithdrawalsOKItem from json.
@@ -124,6 +138,26 @@ func (s *GetWithdrawalsOKItem) Decode(d *jx.Decoder) error {
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"sum\"")
 			}
+		case "refunded":
+			if err := func() error {
+				s.Refunded.Reset()
+				if err := s.Refunded.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"refunded\"")
+			}
+		case "status":
+			if err := func() error {
+				s.Status.Reset()
+				if err := s.Status.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"status\"")
+			}
 		case "processed_at":
 			if err := func() error {
 				s.ProcessedAt.Reset()
@@ -158,6 +192,48 @@ func (s *GetWithdrawalsOKItem) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode encodes GetWithdrawalsOKItemStatus as json.
+func (s GetWithdrawalsOKItemStatus) Encode(e *jx.Encoder) {
+	e.Str(string(s))
+}
+
+// Decode decodes GetWithdrawalsOKItemStatus from json.
+func (s *GetWithdrawalsOKItemStatus) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetWithdrawalsOKItemStatus to nil")
+	}
+	v, err := d.StrBytes()
+	if err != nil {
+		return err
+	}
+	// Try to use constant string.
+	switch GetWithdrawalsOKItemStatus(v) {
+	case GetWithdrawalsOKItemStatusWITHDRAWN:
+		*s = GetWithdrawalsOKItemStatusWITHDRAWN
+	case GetWithdrawalsOKItemStatusPARTIALLYREFUNDED:
+		*s = GetWithdrawalsOKItemStatusPARTIALLYREFUNDED
+	case GetWithdrawalsOKItemStatusREFUNDED:
+		*s = GetWithdrawalsOKItemStatusREFUNDED
+	default:
+		*s = GetWithdrawalsOKItemStatus(v)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s GetWithdrawalsOKItemStatus) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetWithdrawalsOKItemStatus) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode encodes time.Time as json.
 func (o OptDateTime) Encode(e *jx.Encoder, format func(*jx.Encoder, time.Time)) {
 	if !o.Set {
@@ -228,6 +304,39 @@ func (s *OptFloat64) UnmarshalJSON(data []byte) error {
 	return s.Decode(d)
 }
 
+// Encode encodes GetWithdrawalsOKItemStatus as json.
+func (o OptGetWithdrawalsOKItemStatus) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.Str(string(o.Value))
+}
+
+// Decode decodes GetWithdrawalsOKItemStatus from json.
+func (o *OptGetWithdrawalsOKItemStatus) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptGetWithdrawalsOKItemStatus to nil")
+	}
+	o.Set = true
+	if err := o.Value.Decode(d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptGetWithdrawalsOKItemStatus) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptGetWithdrawalsOKItemStatus) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode encodes string as json.
 func (o OptString) Encode(e *jx.Encoder) {
 	if !o.Set {
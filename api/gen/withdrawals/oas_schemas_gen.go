@@ -4,6 +4,8 @@ package api
 
 import (
 	"time"
+
+	"github.com/go-faster/errors"
 )
 
 type BearerAuth struct {
@@ -35,9 +37,11 @@ type GetWithdrawalsOKApplicationJSON []GetWithdrawalsOKItem
 func (*GetWithdrawalsOKApplicationJSON) getWithdrawalsRes() {}
 
 type GetWithdrawalsOKItem struct {
-	Order       OptString   `json:"order"`
-	Sum         OptFloat64  `json:"sum"`
-	ProcessedAt OptDateTime `json:"processed_at"`
+	Order       OptString                     `json:"order"`
+	Sum         OptFloat64                    `json:"sum"`
+	Refunded    OptFloat64                    `json:"refunded"`
+	Status      OptGetWithdrawalsOKItemStatus `json:"status"`
+	ProcessedAt OptDateTime                   `json:"processed_at"`
 }
 
 // GetOrder returns the value of Order.
@@ -50,6 +54,16 @@ func (s *GetWithdrawalsOKItem) GetSum() OptFloat64 {
 	return s.Sum
 }
 
+// GetRefunded returns the value of Refunded.
+func (s *GetWithdrawalsOKItem) GetRefunded() OptFloat64 {
+	return s.Refunded
+}
+
+// GetStatus returns the value of Status.
+func (s *GetWithdrawalsOKItem) GetStatus() OptGetWithdrawalsOKItemStatus {
+	return s.Status
+}
+
 // GetProcessedAt returns the value of ProcessedAt.
 func (s *GetWithdrawalsOKItem) GetProcessedAt() OptDateTime {
 	return s.ProcessedAt
@@ -65,11 +79,69 @@ func (s *GetWithdrawalsOKItem) SetSum(val OptFloat64) {
 	s.Sum = val
 }
 
+// SetRefunded sets the value of Refunded.
+func (s *GetWithdrawalsOKItem) SetRefunded(val OptFloat64) {
+	s.Refunded = val
+}
+
+// SetStatus sets the value of Status.
+func (s *GetWithdrawalsOKItem) SetStatus(val OptGetWithdrawalsOKItemStatus) {
+	s.Status = val
+}
+
 // SetProcessedAt sets the value of ProcessedAt.
 func (s *GetWithdrawalsOKItem) SetProcessedAt(val OptDateTime) {
 	s.ProcessedAt = val
 }
 
+type GetWithdrawalsOKItemStatus string
+
+const (
+	GetWithdrawalsOKItemStatusWITHDRAWN         GetWithdrawalsOKItemStatus = "WITHDRAWN"
+	GetWithdrawalsOKItemStatusPARTIALLYREFUNDED GetWithdrawalsOKItemStatus = "PARTIALLY_REFUNDED"
+	GetWithdrawalsOKItemStatusREFUNDED          GetWithdrawalsOKItemStatus = "REFUNDED"
+)
+
+// AllValues returns all GetWithdrawalsOKItemStatus values.
+func (GetWithdrawalsOKItemStatus) AllValues() []GetWithdrawalsOKItemStatus {
+	return []GetWithdrawalsOKItemStatus{
+		GetWithdrawalsOKItemStatusWITHDRAWN,
+		GetWithdrawalsOKItemStatusPARTIALLYREFUNDED,
+		GetWithdrawalsOKItemStatusREFUNDED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s GetWithdrawalsOKItemStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case GetWithdrawalsOKItemStatusWITHDRAWN:
+		return []byte(s), nil
+	case GetWithdrawalsOKItemStatusPARTIALLYREFUNDED:
+		return []byte(s), nil
+	case GetWithdrawalsOKItemStatusREFUNDED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GetWithdrawalsOKItemStatus) UnmarshalText(data []byte) error {
+	switch GetWithdrawalsOKItemStatus(data) {
+	case GetWithdrawalsOKItemStatusWITHDRAWN:
+		*s = GetWithdrawalsOKItemStatusWITHDRAWN
+		return nil
+	case GetWithdrawalsOKItemStatusPARTIALLYREFUNDED:
+		*s = GetWithdrawalsOKItemStatusPARTIALLYREFUNDED
+		return nil
+	case GetWithdrawalsOKItemStatusREFUNDED:
+		*s = GetWithdrawalsOKItemStatusREFUNDED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // GetWithdrawalsUnauthorized is response for GetWithdrawals operation.
 type GetWithdrawalsUnauthorized struct{}
 
@@ -167,6 +239,52 @@ func (o OptFloat64) Or(d float64) float64 {
 	return d
 }
 
+// NewOptGetWithdrawalsOKItemStatus returns new OptGetWithdrawalsOKItemStatus with value set to v.
+func NewOptGetWithdrawalsOKItemStatus(v GetWithdrawalsOKItemStatus) OptGetWithdrawalsOKItemStatus {
+	return OptGetWithdrawalsOKItemStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptGetWithdrawalsOKItemStatus is optional GetWithdrawalsOKItemStatus.
+type OptGetWithdrawalsOKItemStatus struct {
+	Value GetWithdrawalsOKItemStatus
+	Set   bool
+}
+
+// IsSet returns true if OptGetWithdrawalsOKItemStatus was set.
+func (o OptGetWithdrawalsOKItemStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptGetWithdrawalsOKItemStatus) Reset() {
+	var v GetWithdrawalsOKItemStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptGetWithdrawalsOKItemStatus) SetTo(v GetWithdrawalsOKItemStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptGetWithdrawalsOKItemStatus) Get() (v GetWithdrawalsOKItemStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptGetWithdrawalsOKItemStatus) Or(d GetWithdrawalsOKItemStatus) GetWithdrawalsOKItemStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptString returns new OptString with value set to v.
 func NewOptString(v string) OptString {
 	return OptString{
@@ -6,3 +6,10 @@ package api
 //go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/balance --config balance-ogen.yaml openapi.yaml
 //go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/withdraw --config withdraw-ogen.yaml openapi.yaml
 //go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/withdrawals --config withdrawals-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/password --config password-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/admin --config admin-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/notifications --config notifications-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/promo --config promo-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/referrals --config referrals-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/transactions --config transactions-ogen.yaml openapi.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --loglevel error --clean --target gen/statements --config statements-ogen.yaml openapi.yaml
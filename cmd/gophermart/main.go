@@ -5,8 +5,12 @@ import (
 	"go.uber.org/zap"
 	"gophermat/internal/app"
 	"gophermat/internal/authentication"
+	"gophermat/internal/crypt"
 	"gophermat/internal/http"
 	"gophermat/internal/http/client"
+	"gophermat/internal/models"
+	"gophermat/internal/oidc"
+	"gophermat/internal/ordernumber"
 	"gophermat/internal/repository/postgres"
 	"gophermat/internal/settings"
 	"gophermat/internal/signals"
@@ -50,16 +54,59 @@ func main() {
 		cancel()
 	})
 
-	repo, err := postgres.NewStorage(ctx, logger, set.DatabaseURI)
+	repo, err := postgres.NewStorage(ctx, logger, set.DatabaseURI, set.Points.TTL)
 	if err != nil {
 		logger.Fatal("create storage", zap.Error(err))
 	}
 
-	auth := authentication.NewAuthenticator()
+	auth := authentication.NewAuthenticator(repo)
+
+	hasher, err := crypt.NewHasher(crypt.Config{
+		Algorithm:  set.Password.Hash,
+		BcryptCost: set.Password.BcryptCost,
+		Argon2: crypt.Argon2Params{
+			Time:    set.Password.Argon2Time,
+			Memory:  set.Password.Argon2Memory,
+			Threads: set.Password.Argon2Threads,
+		},
+	})
+	if err != nil {
+		logger.Fatal("create password hasher", zap.Error(err))
+	}
 
 	accrualClient := client.NewClient(logger, set.AccrualSystemAddress)
 
-	gm := app.NewGMart(logger, auth, repo, accrualClient)
+	orderNumbers, err := ordernumber.Parse(set.OrderNumber.Schemes)
+	if err != nil {
+		logger.Fatal("parse order number schemes", zap.Error(err))
+	}
+
+	tierPolicy, err := models.NewTierPolicy(set.Tiers.Rules, set.Tiers.Basis, set.Tiers.Window)
+	if err != nil {
+		logger.Fatal("parse tiers", zap.Error(err))
+	}
+
+	channels, err := models.NewChannelClients(set.Channel.Clients)
+	if err != nil {
+		logger.Fatal("parse channel clients", zap.Error(err))
+	}
+
+	webhookClient := client.NewWebhookClient(logger, set.Webhook.Timeout, set.Webhook.AllowPrivate)
+
+	gm := app.NewGMart(logger, &set, auth, hasher, repo, accrualClient, webhookClient, orderNumbers, tierPolicy,
+		channels)
+
+	gm.BootstrapAdmins(ctx, set.AdminLogins)
+
+	if set.OIDC.Issuer != "" {
+		gm.EnableOIDC(oidc.NewProvider(logger, oidc.Config{
+			Issuer:       set.OIDC.Issuer,
+			ClientID:     set.OIDC.ClientID,
+			ClientSecret: set.OIDC.ClientSecret,
+			RedirectURL:  set.OIDC.RedirectURL,
+			Scopes:       set.OIDC.Scopes,
+		}))
+	}
 
 	s, err := http.NewService(logger, &set, gm, auth)
 	if err != nil {
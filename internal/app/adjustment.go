@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// AdjustUserBalance вручную начисляет или списывает баллы пользователя. Код причины обязателен.
+// Изменение на сумму не меньше порога из настроек не применяется сразу, а ждёт подтверждения
+// другим сотрудником.
+func (gm *GMart) AdjustUserBalance(
+	ctx context.Context,
+	adj models.BalanceAdjustment,
+) (models.BalanceAdjustment, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermAdjustBalance)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	if adj.Amount == 0 || !adj.Reason.Valid() {
+		return models.BalanceAdjustment{}, models.ErrInvalidInput
+	}
+
+	if _, err := gm.storage.GetUserByID(ctx, adj.UserID); err != nil {
+		gm.log.Info("cannot get user", zap.Error(err))
+
+		if errors.Is(err, models.ErrNotFound) {
+			return models.BalanceAdjustment{}, models.ErrUserNotFound
+		}
+
+		return models.BalanceAdjustment{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	adj.OperatorID = tokenPayload.UserID
+	adj.ReversalOf = 0
+	adj.Status = models.AdjustmentApplied
+
+	if gm.needsApproval(adj.Amount) {
+		adj.Status = models.AdjustmentPending
+	}
+
+	adj, err = gm.storage.AddAdjustment(ctx, adj)
+	if err != nil {
+		if errors.Is(err, models.ErrInsufficientBalance) {
+			return models.BalanceAdjustment{}, err
+		}
+
+		gm.log.Error("cannot adjust balance", zap.Error(err))
+
+		return models.BalanceAdjustment{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("balance adjustment created",
+		zap.Int64("adjustment id", adj.ID),
+		zap.Int("user id", adj.UserID),
+		zap.Int("amount", adj.Amount),
+		zap.String("reason", string(adj.Reason)),
+		zap.String("status", string(adj.Status)),
+		zap.Int("operator id", adj.OperatorID))
+
+	return adj, nil
+}
+
+// GetUserAdjustments возвращает все ручные изменения баланса пользователя, включая ожидающие подтверждения.
+func (gm *GMart) GetUserAdjustments(ctx context.Context, userID int) ([]models.BalanceAdjustment, error) {
+	if err := gm.authorizeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	adjustments, err := gm.storage.GetAdjustments(ctx, userID)
+	if err != nil {
+		gm.log.Info("cannot get balance adjustments", zap.Error(err))
+
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// ApproveAdjustment подтверждает изменение баланса и применяет его.
+// Подтвердить изменение может только сотрудник, который его не создавал.
+func (gm *GMart) ApproveAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error) {
+	return gm.resolveAdjustment(ctx, id, true)
+}
+
+// RejectAdjustment отклоняет изменение баланса, ожидающее подтверждения.
+func (gm *GMart) RejectAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error) {
+	return gm.resolveAdjustment(ctx, id, false)
+}
+
+// ReverseAdjustment отменяет применённое изменение баланса и возвращает отменяющую запись.
+// Отмена изменения на сумму не меньше порога подтверждения перемещает столько же баллов,
+// поэтому она, как и само изменение, применяется только после подтверждения другим сотрудником.
+func (gm *GMart) ReverseAdjustment(ctx context.Context, id int64, note string) (models.BalanceAdjustment, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermAdjustBalance)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	orig, err := gm.storage.GetAdjustment(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.BalanceAdjustment{}, err
+		}
+
+		gm.log.Error("cannot get balance adjustment", zap.Error(err))
+
+		return models.BalanceAdjustment{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	status := models.AdjustmentApplied
+	if gm.needsApproval(orig.Amount) {
+		status = models.AdjustmentPending
+	}
+
+	reversal, err := gm.storage.ReverseAdjustment(ctx, id, tokenPayload.UserID, note, status)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) ||
+			errors.Is(err, models.ErrConflict) ||
+			errors.Is(err, models.ErrInsufficientBalance) {
+			return models.BalanceAdjustment{}, err
+		}
+
+		gm.log.Error("cannot reverse balance adjustment", zap.Error(err))
+
+		return models.BalanceAdjustment{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("balance adjustment reversal created",
+		zap.Int64("adjustment id", id),
+		zap.Int64("reversal id", reversal.ID),
+		zap.Int("user id", reversal.UserID),
+		zap.String("status", string(reversal.Status)),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	return reversal, nil
+}
+
+// GetAdjustments возвращает применённые и отменённые изменения баланса текущего пользователя.
+func (gm *GMart) GetAdjustments(ctx context.Context) ([]models.BalanceAdjustment, error) {
+	// получаем id пользователя
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, err
+	}
+
+	adjustments, err := gm.storage.GetAdjustments(ctx, tokenPayload.UserID,
+		models.AdjustmentApplied, models.AdjustmentReversed)
+	if err != nil {
+		gm.log.Info("cannot get balance adjustments", zap.Error(err))
+
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+func (gm *GMart) resolveAdjustment(ctx context.Context, id int64, approve bool) (models.BalanceAdjustment, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermAdjustBalance)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	adj, err := gm.storage.GetAdjustment(ctx, id)
+	if err != nil {
+		gm.log.Info("cannot get balance adjustment", zap.Error(err))
+
+		return models.BalanceAdjustment{}, err
+	}
+
+	// автор может отозвать своё изменение, но не подтвердить его
+	if approve && adj.OperatorID == tokenPayload.UserID {
+		return models.BalanceAdjustment{}, models.ErrForbidden
+	}
+
+	adj, err = gm.storage.ResolveAdjustment(ctx, id, tokenPayload.UserID, approve)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) ||
+			errors.Is(err, models.ErrConflict) ||
+			errors.Is(err, models.ErrInsufficientBalance) {
+			return models.BalanceAdjustment{}, err
+		}
+
+		gm.log.Error("cannot resolve balance adjustment", zap.Error(err))
+
+		return models.BalanceAdjustment{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("balance adjustment resolved",
+		zap.Int64("adjustment id", id),
+		zap.String("status", string(adj.Status)),
+		zap.Int("approver id", tokenPayload.UserID))
+
+	return adj, nil
+}
+
+// needsApproval сообщает, требует ли изменение на amount копеек подтверждения вторым сотрудником.
+func (gm *GMart) needsApproval(amount int) bool {
+	threshold := int(math.Round(gm.set.Adjustment.ApprovalThreshold * 100))
+	if threshold <= 0 {
+		return false
+	}
+
+	if amount < 0 {
+		amount = -amount
+	}
+
+	return amount >= threshold
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type adjustmentStorage struct {
+	storage
+
+	adjustments map[int64]models.BalanceAdjustment
+	reversals   []models.AdjustmentStatus
+}
+
+func (s *adjustmentStorage) GetUserByID(_ context.Context, userID int) (models.User, error) {
+	if userID != 1 {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return models.User{ID: userID}, nil
+}
+
+func (s *adjustmentStorage) AddAdjustment(_ context.Context, adj models.BalanceAdjustment) (models.BalanceAdjustment, error) {
+	adj.ID = int64(len(s.adjustments) + 1)
+	s.adjustments[adj.ID] = adj
+
+	return adj, nil
+}
+
+func (s *adjustmentStorage) GetAdjustment(_ context.Context, id int64) (models.BalanceAdjustment, error) {
+	adj, ok := s.adjustments[id]
+	if !ok {
+		return models.BalanceAdjustment{}, models.ErrNotFound
+	}
+
+	return adj, nil
+}
+
+func (s *adjustmentStorage) ResolveAdjustment(
+	_ context.Context,
+	id int64,
+	approverID int,
+	approve bool,
+) (models.BalanceAdjustment, error) {
+	adj := s.adjustments[id]
+	adj.ApproverID = approverID
+	adj.Status = models.AdjustmentRejected
+
+	if approve {
+		adj.Status = models.AdjustmentApplied
+	}
+
+	s.adjustments[id] = adj
+
+	return adj, nil
+}
+
+func (s *adjustmentStorage) ReverseAdjustment(
+	_ context.Context,
+	id int64,
+	operatorID int,
+	note string,
+	status models.AdjustmentStatus,
+) (models.BalanceAdjustment, error) {
+	s.reversals = append(s.reversals, status)
+
+	orig := s.adjustments[id]
+
+	return s.AddAdjustment(context.Background(), models.BalanceAdjustment{
+		UserID:     orig.UserID,
+		Amount:     -orig.Amount,
+		Reason:     models.AdjustmentReasonReversal,
+		Status:     status,
+		OperatorID: operatorID,
+		ReversalOf: id,
+	})
+}
+
+func newAdjustmentGMart() (*GMart, *adjustmentStorage) {
+	st := &adjustmentStorage{adjustments: make(map[int64]models.BalanceAdjustment)}
+
+	gm := newTestGMart(st, &settings.Settings{Adjustment: settings.AdjustmentSettings{ApprovalThreshold: 1000}})
+
+	return gm, st
+}
+
+func TestAdjustUserBalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       models.Role
+		adj        models.BalanceAdjustment
+		wantStatus models.AdjustmentStatus
+		wantErr    error
+	}{
+		{
+			name:    "support",
+			role:    models.RoleSupport,
+			adj:     models.BalanceAdjustment{UserID: 1, Amount: 100, Reason: models.AdjustmentReasonGoodwill},
+			wantErr: models.ErrForbidden,
+		},
+		{
+			name:    "zero amount",
+			role:    models.RoleAdmin,
+			adj:     models.BalanceAdjustment{UserID: 1, Reason: models.AdjustmentReasonGoodwill},
+			wantErr: models.ErrInvalidInput,
+		},
+		{
+			name:    "reversal reason",
+			role:    models.RoleAdmin,
+			adj:     models.BalanceAdjustment{UserID: 1, Amount: 100, Reason: models.AdjustmentReasonReversal},
+			wantErr: models.ErrInvalidInput,
+		},
+		{
+			name:    "unknown user",
+			role:    models.RoleAdmin,
+			adj:     models.BalanceAdjustment{UserID: 2, Amount: 100, Reason: models.AdjustmentReasonGoodwill},
+			wantErr: models.ErrUserNotFound,
+		},
+		{
+			name:       "below threshold",
+			role:       models.RoleAdmin,
+			adj:        models.BalanceAdjustment{UserID: 1, Amount: 99999, Reason: models.AdjustmentReasonGoodwill},
+			wantStatus: models.AdjustmentApplied,
+		},
+		{
+			name:       "threshold",
+			role:       models.RoleAdmin,
+			adj:        models.BalanceAdjustment{UserID: 1, Amount: 100000, Reason: models.AdjustmentReasonGoodwill},
+			wantStatus: models.AdjustmentPending,
+		},
+		{
+			name:       "large debit",
+			role:       models.RoleAdmin,
+			adj:        models.BalanceAdjustment{UserID: 1, Amount: -100000, Reason: models.AdjustmentReasonCorrection},
+			wantStatus: models.AdjustmentPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gm, _ := newAdjustmentGMart()
+
+			adj, err := gm.AdjustUserBalance(withPayload(context.Background(), 10, tt.role), tt.adj)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if adj.Status != tt.wantStatus || adj.OperatorID != 10 {
+				t.Errorf("adjustment = %+v", adj)
+			}
+		})
+	}
+}
+
+func TestApproveOwnAdjustment(t *testing.T) {
+	gm, st := newAdjustmentGMart()
+	author := withPayload(context.Background(), 10, models.RoleAdmin)
+
+	adj, err := gm.AdjustUserBalance(author, models.BalanceAdjustment{
+		UserID: 1,
+		Amount: 200000,
+		Reason: models.AdjustmentReasonGoodwill,
+	})
+	if err != nil {
+		t.Fatalf("AdjustUserBalance: %v", err)
+	}
+
+	if _, err := gm.ApproveAdjustment(author, adj.ID); !errors.Is(err, models.ErrForbidden) {
+		t.Fatalf("author approval: got %v, want ErrForbidden", err)
+	}
+
+	if _, err := gm.ApproveAdjustment(withPayload(context.Background(), 11, models.RoleAdmin), adj.ID); err != nil {
+		t.Fatalf("approval: %v", err)
+	}
+
+	if st.adjustments[adj.ID].Status != models.AdjustmentApplied {
+		t.Errorf("status = %s, want applied", st.adjustments[adj.ID].Status)
+	}
+}
+
+func TestReverseAdjustmentApproval(t *testing.T) {
+	gm, st := newAdjustmentGMart()
+	ctx := withPayload(context.Background(), 10, models.RoleAdmin)
+
+	small, _ := st.AddAdjustment(ctx, models.BalanceAdjustment{UserID: 1, Amount: 500, Status: models.AdjustmentApplied})
+	large, _ := st.AddAdjustment(ctx, models.BalanceAdjustment{UserID: 1, Amount: -150000, Status: models.AdjustmentApplied})
+
+	reversal, err := gm.ReverseAdjustment(ctx, small.ID, "")
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if reversal.Status != models.AdjustmentApplied {
+		t.Errorf("small reversal status = %s, want applied", reversal.Status)
+	}
+
+	reversal, err = gm.ReverseAdjustment(ctx, large.ID, "")
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if reversal.Status != models.AdjustmentPending {
+		t.Fatalf("large reversal status = %s, want pending", reversal.Status)
+	}
+
+	// отмену, как и изменение, подтверждает другой сотрудник
+	if _, err := gm.ApproveAdjustment(ctx, reversal.ID); !errors.Is(err, models.ErrForbidden) {
+		t.Fatalf("author approval of reversal: got %v, want ErrForbidden", err)
+	}
+
+	if _, err := gm.ReverseAdjustment(ctx, 100, ""); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown adjustment: got %v, want ErrNotFound", err)
+	}
+}
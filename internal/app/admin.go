@@ -0,0 +1,167 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	invalidStatusOrder   = "INVALID"
+	processedStatusOrder = "PROCESSED"
+)
+
+// finalOrderStatuses статусы, из которых оператор не может вернуть заказ в опрос или перевести в INVALID:
+// по обработанному заказу баллы уже начислены, отозванный заказ уже списан.
+var finalOrderStatuses = []string{processedStatusOrder, revokedStatusOrder}
+
+// SearchUsers ищет пользователей по части логина.
+func (gm *GMart) SearchUsers(ctx context.Context, login string, limit int) ([]models.User, error) {
+	if _, err := gm.authorize(ctx, models.PermViewUsers); err != nil {
+		return nil, err
+	}
+
+	users, err := gm.storage.SearchUsers(ctx, login, limit)
+	if err != nil {
+		gm.log.Error("cannot search users", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return users, nil
+}
+
+// GetUserOrders возвращает заказы любого пользователя.
+func (gm *GMart) GetUserOrders(ctx context.Context, userID int) ([]models.Order, error) {
+	if err := gm.authorizeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	orders, err := gm.storage.GetOrders(ctx, userID)
+	if err != nil {
+		gm.log.Info("cannot get orders", zap.Error(err))
+
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetUserBalance возвращает баланс любого пользователя.
+func (gm *GMart) GetUserBalance(ctx context.Context, userID int) (models.Balance, error) {
+	if err := gm.authorizeUser(ctx, userID); err != nil {
+		return models.Balance{}, err
+	}
+
+	balance, err := gm.storage.GetBalance(ctx, userID)
+	if err != nil {
+		gm.log.Info("cannot get balance", zap.Error(err))
+
+		return models.Balance{}, err
+	}
+
+	return balance, nil
+}
+
+// GetUserWithdrawals возвращает списания любого пользователя.
+func (gm *GMart) GetUserWithdrawals(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error) {
+	if err := gm.authorizeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := gm.storage.GetBalanceHistory(ctx, userID)
+	if err != nil {
+		gm.log.Info("cannot get balance history", zap.Error(err))
+
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// RepollOrder возвращает заказ в очередь опроса системы начислений и сразу запрашивает начисление.
+// Обработанный и отозванный заказ повторно не опрашивается, чтобы не начислить баллы дважды.
+func (gm *GMart) RepollOrder(ctx context.Context, orderNumber string) error {
+	tokenPayload, err := gm.authorize(ctx, models.PermRepollOrders)
+	if err != nil {
+		return err
+	}
+
+	order, err := gm.storage.GetOrder(ctx, orderNumber)
+	if err != nil {
+		gm.log.Info("cannot get order", zap.Error(err))
+
+		return err
+	}
+
+	if err := gm.storage.SetOrderStatus(ctx, orderNumber, newStatusOrder, finalOrderStatuses); err != nil {
+		if errors.Is(err, models.ErrConflict) || errors.Is(err, models.ErrNotFound) {
+			gm.log.Info("cannot set order status", zap.String("order number", orderNumber), zap.Error(err))
+
+			return err
+		}
+
+		gm.log.Error("cannot set order status", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("order repoll requested",
+		zap.String("order number", orderNumber),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	gm.pool.Submit(func() {
+		processOrder(gm.log, gm.storage, gm.client, gm.tiers, order)
+	})
+
+	return nil
+}
+
+// InvalidateOrder переводит заказ в статус INVALID, после чего он больше не опрашивается.
+func (gm *GMart) InvalidateOrder(ctx context.Context, orderNumber string) error {
+	tokenPayload, err := gm.authorize(ctx, models.PermInvalidateOrders)
+	if err != nil {
+		return err
+	}
+
+	if err := gm.storage.SetOrderStatus(ctx, orderNumber, invalidStatusOrder, finalOrderStatuses); err != nil {
+		if errors.Is(err, models.ErrConflict) || errors.Is(err, models.ErrNotFound) {
+			gm.log.Info("cannot set order status", zap.String("order number", orderNumber), zap.Error(err))
+
+			return err
+		}
+
+		gm.log.Error("cannot set order status", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("order invalidated",
+		zap.String("order number", orderNumber),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	return nil
+}
+
+// authorizeUser проверяет право на просмотр данных пользователя и что пользователь существует.
+func (gm *GMart) authorizeUser(ctx context.Context, userID int) error {
+	if _, err := gm.authorize(ctx, models.PermViewUsers); err != nil {
+		return err
+	}
+
+	if _, err := gm.storage.GetUserByID(ctx, userID); err != nil {
+		gm.log.Info("cannot get user", zap.Error(err))
+
+		if errors.Is(err, models.ErrNotFound) {
+			return models.ErrUserNotFound
+		}
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alitto/pond"
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+// orderStatusStorage меняет статус, только если заказ не в одном из статусов final, как и настоящее хранилище.
+type orderStatusStorage struct {
+	storage
+
+	orders map[string]models.Order
+}
+
+func (s *orderStatusStorage) GetOrder(_ context.Context, orderNumber string) (models.Order, error) {
+	o, ok := s.orders[orderNumber]
+	if !ok {
+		return models.Order{}, models.ErrNotFound
+	}
+
+	return o, nil
+}
+
+func (s *orderStatusStorage) SetOrderStatus(_ context.Context, orderNumber, status string, final []string) error {
+	o, ok := s.orders[orderNumber]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	for _, f := range final {
+		if o.Status == f {
+			return models.ErrConflict
+		}
+	}
+
+	o.Status = status
+	s.orders[orderNumber] = o
+
+	return nil
+}
+
+type notFoundAccrual struct {
+	numbers chan string
+}
+
+func (c notFoundAccrual) GetOrderAccrual(_ context.Context, orderNumber string) (models.OrderAccrual, error) {
+	c.numbers <- orderNumber
+
+	return models.OrderAccrual{}, models.ErrNotFound
+}
+
+func newOrderStatusGMart() (*GMart, *orderStatusStorage) {
+	st := &orderStatusStorage{orders: map[string]models.Order{
+		"1": {Number: "1", UserID: 1, Status: newStatusOrder},
+		"2": {Number: "2", UserID: 1, Status: invalidStatusOrder},
+		"3": {Number: "3", UserID: 1, Status: processedStatusOrder},
+		"4": {Number: "4", UserID: 1, Status: revokedStatusOrder},
+	}}
+
+	return newTestGMart(st, nil), st
+}
+
+func TestRepollOrder(t *testing.T) {
+	tests := []struct {
+		number  string
+		role    models.Role
+		wantErr error
+	}{
+		{number: "1", role: models.RoleUser, wantErr: models.ErrForbidden},
+		{number: "1", role: models.RoleSupport},
+		{number: "2", role: models.RoleSupport},
+		{number: "3", role: models.RoleSupport, wantErr: models.ErrConflict},
+		{number: "4", role: models.RoleAdmin, wantErr: models.ErrConflict},
+		{number: "5", role: models.RoleAdmin, wantErr: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.role, tt.number), func(t *testing.T) {
+			gm, st := newOrderStatusGMart()
+
+			accrual := notFoundAccrual{numbers: make(chan string, 1)}
+			gm.client = accrual
+			gm.pool = pond.New(1, 1)
+
+			err := gm.RepollOrder(withPayload(context.Background(), 1, tt.role), tt.number)
+
+			gm.pool.StopAndWait()
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+
+				if len(accrual.numbers) != 0 {
+					t.Error("accrual is requested for rejected repoll")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if status := st.orders[tt.number].Status; status != newStatusOrder {
+				t.Errorf("status = %s, want NEW", status)
+			}
+
+			if len(accrual.numbers) != 1 || <-accrual.numbers != tt.number {
+				t.Error("accrual is not requested")
+			}
+		})
+	}
+}
+
+func TestInvalidateOrder(t *testing.T) {
+	tests := []struct {
+		number  string
+		role    models.Role
+		wantErr error
+	}{
+		{number: "1", role: models.RoleSupport, wantErr: models.ErrForbidden},
+		{number: "1", role: models.RoleAdmin},
+		{number: "3", role: models.RoleAdmin, wantErr: models.ErrConflict},
+		{number: "4", role: models.RoleAdmin, wantErr: models.ErrConflict},
+		{number: "5", role: models.RoleAdmin, wantErr: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.role, tt.number), func(t *testing.T) {
+			gm, st := newOrderStatusGMart()
+
+			err := gm.InvalidateOrder(withPayload(context.Background(), 1, tt.role), tt.number)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && st.orders[tt.number].Status != invalidStatusOrder {
+				t.Errorf("status = %s, want INVALID", st.orders[tt.number].Status)
+			}
+		})
+	}
+}
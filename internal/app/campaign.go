@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// GetCampaigns возвращает все акции, включая выключенные.
+func (gm *GMart) GetCampaigns(ctx context.Context) ([]models.Campaign, error) {
+	if _, err := gm.authorize(ctx, models.PermManageCampaigns); err != nil {
+		return nil, err
+	}
+
+	campaigns, err := gm.storage.GetCampaigns(ctx, false)
+	if err != nil {
+		gm.log.Error("cannot get campaigns", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return campaigns, nil
+}
+
+// CreateCampaign создаёт акцию. Акция применяется к заказам, которые будут обработаны после её создания.
+func (gm *GMart) CreateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageCampaigns)
+	if err != nil {
+		return models.Campaign{}, err
+	}
+
+	c.Channel = normalizeChannel(c.Channel)
+	if !c.Valid() {
+		return models.Campaign{}, models.ErrInvalidInput
+	}
+
+	c.CreatedBy = tokenPayload.UserID
+
+	c, err = gm.storage.AddCampaign(ctx, c)
+	if err != nil {
+		gm.log.Error("cannot create campaign", zap.Error(err))
+
+		return models.Campaign{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("campaign created",
+		zap.Int64("campaign id", c.ID),
+		zap.String("name", c.Name),
+		zap.Int("operator id", c.CreatedBy))
+
+	return c, nil
+}
+
+// UpdateCampaign изменяет условия акции или выключает её.
+func (gm *GMart) UpdateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageCampaigns)
+	if err != nil {
+		return models.Campaign{}, err
+	}
+
+	c.Channel = normalizeChannel(c.Channel)
+	if !c.Valid() {
+		return models.Campaign{}, models.ErrInvalidInput
+	}
+
+	c, err = gm.storage.UpdateCampaign(ctx, c)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.Campaign{}, err
+		}
+
+		gm.log.Error("cannot update campaign", zap.Error(err))
+
+		return models.Campaign{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("campaign updated",
+		zap.Int64("campaign id", c.ID),
+		zap.Bool("active", c.Active),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	return c, nil
+}
+
+// DryRunCampaigns показывает, какие бонусы получил бы заказ, ничего не начисляя.
+// Если передан черновик акции, проверяется только он, иначе все включённые акции.
+// Для заданного пользователя учитывается его история заказов, без пользователя заказ считается первым.
+func (gm *GMart) DryRunCampaigns(
+	ctx context.Context,
+	order models.CampaignOrder,
+	draft *models.Campaign,
+) ([]models.CampaignBonus, error) {
+	if _, err := gm.authorize(ctx, models.PermManageCampaigns); err != nil {
+		return nil, err
+	}
+
+	if order.Accrual < 0 {
+		return nil, models.ErrInvalidInput
+	}
+
+	if order.UploadedAt.IsZero() {
+		order.UploadedAt = time.Now()
+	}
+
+	order.Channel = normalizeChannel(order.Channel)
+
+	if order.UserID != 0 {
+		if _, err := gm.storage.GetUserByID(ctx, order.UserID); err != nil {
+			if errors.Is(err, models.ErrNotFound) {
+				return nil, models.ErrUserNotFound
+			}
+
+			return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		processed, err := gm.storage.CountProcessedOrders(ctx, order.UserID)
+		if err != nil {
+			gm.log.Error("cannot count processed orders", zap.Error(err))
+
+			return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		order.ProcessedOrders = processed
+	}
+
+	var campaigns []models.Campaign
+
+	if draft != nil {
+		draft.Channel = normalizeChannel(draft.Channel)
+		if !draft.Valid() {
+			return nil, models.ErrInvalidInput
+		}
+
+		draft.Active = true
+		campaigns = []models.Campaign{*draft}
+	} else {
+		var err error
+
+		campaigns, err = gm.storage.GetCampaigns(ctx, true)
+		if err != nil {
+			gm.log.Error("cannot get campaigns", zap.Error(err))
+
+			return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+	}
+
+	return models.CampaignBonuses(campaigns, order), nil
+}
+
+// campaignAccrual возвращает включённые акции и заказ с начислением accrual, к которому они применяются.
+// Бонусы определяются хранилищем в транзакции начисления.
+func campaignAccrual(
+	ctx context.Context,
+	store storage,
+	order models.Order,
+	accrual int,
+) (models.CampaignAccrual, error) {
+	campaigns, err := store.GetCampaigns(ctx, true)
+	if err != nil {
+		return models.CampaignAccrual{}, err
+	}
+
+	return models.CampaignAccrual{
+		Campaigns: campaigns,
+		Order: models.CampaignOrder{
+			UserID:     order.UserID,
+			Number:     order.Number,
+			Channel:    order.Channel,
+			Accrual:    accrual,
+			UploadedAt: order.UploadedAt,
+		},
+	}, nil
+}
+
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimSpace(channel))
+}
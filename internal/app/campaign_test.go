@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+	"gophermat/internal/ordernumber"
+)
+
+type campaignStorage struct {
+	storage
+
+	campaigns []models.Campaign
+	processed int
+	accrued   []models.CampaignAccrual
+	amounts   []int
+	orders    []models.Order
+}
+
+func (s *campaignStorage) GetOrder(_ context.Context, _ string) (models.Order, error) {
+	return models.Order{}, models.ErrNotFound
+}
+
+func (s *campaignStorage) SaveOrder(_ context.Context, order models.Order) error {
+	s.orders = append(s.orders, order)
+
+	return nil
+}
+
+func (s *campaignStorage) GetCampaigns(_ context.Context, _ bool) ([]models.Campaign, error) {
+	return s.campaigns, nil
+}
+
+func (s *campaignStorage) GetUserByID(_ context.Context, userID int) (models.User, error) {
+	return models.User{ID: userID}, nil
+}
+
+func (s *campaignStorage) CountProcessedOrders(_ context.Context, _ int) (int, error) {
+	return s.processed, nil
+}
+
+func (s *campaignStorage) AccrueOrder(
+	_ context.Context,
+	_, _ string,
+	accrual int,
+	campaigns models.CampaignAccrual,
+) ([]models.CampaignBonus, error) {
+	s.amounts = append(s.amounts, accrual)
+	s.accrued = append(s.accrued, campaigns)
+
+	return models.CampaignBonuses(campaigns.Campaigns, campaigns.Order), nil
+}
+
+type fixedAccrual models.OrderAccrual
+
+func (c fixedAccrual) GetOrderAccrual(_ context.Context, orderNumber string) (models.OrderAccrual, error) {
+	a := models.OrderAccrual(c)
+	a.Order = orderNumber
+
+	return a, nil
+}
+
+func TestProcessOrderCampaigns(t *testing.T) {
+	uploaded := time.Now().Add(-time.Hour)
+	st := &campaignStorage{campaigns: []models.Campaign{
+		{ID: 1, Name: "double", Kind: models.CampaignPercent, Value: 100, Active: true},
+	}}
+	tiers := &tiers{log: zap.NewNop(), storage: st}
+	order := models.Order{UserID: 7, Number: "79927398713", Channel: "app", UploadedAt: uploaded}
+
+	processOrder(zap.NewNop(), st, fixedAccrual{Status: processedStatusOrder, Accrual: 5.5}, tiers, order)
+
+	if len(st.accrued) != 1 {
+		t.Fatalf("AccrueOrder calls = %d, want 1", len(st.accrued))
+	}
+
+	got := st.accrued[0]
+	if len(got.Campaigns) != 1 || got.Order.Accrual != 550 || got.Order.Channel != "app" ||
+		!got.Order.UploadedAt.Equal(uploaded) || st.amounts[0] != 550 {
+		t.Errorf("campaign accrual = %+v, amount = %d", got, st.amounts[0])
+	}
+
+	// необработанный заказ не получает бонусов
+	processOrder(zap.NewNop(), st, fixedAccrual{Status: "PROCESSING"}, tiers, order)
+
+	if len(st.accrued) != 2 || len(st.accrued[1].Campaigns) != 0 {
+		t.Errorf("campaigns for processing order = %+v", st.accrued[1])
+	}
+}
+
+func TestDryRunCampaigns(t *testing.T) {
+	st := &campaignStorage{
+		campaigns: []models.Campaign{{ID: 1, Name: "first", Kind: models.CampaignFixed, Value: 1, FirstOrder: true, Active: true}},
+		processed: 3,
+	}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 1, models.RoleAdmin)
+
+	bonuses, err := gm.DryRunCampaigns(ctx, models.CampaignOrder{Accrual: 1000}, nil)
+	if err != nil || len(bonuses) != 1 {
+		t.Fatalf("order without user: %+v, %v, want first order bonus", bonuses, err)
+	}
+
+	bonuses, err = gm.DryRunCampaigns(ctx, models.CampaignOrder{UserID: 7, Accrual: 1000}, nil)
+	if err != nil || len(bonuses) != 0 {
+		t.Fatalf("user with processed orders: %+v, %v, want no bonuses", bonuses, err)
+	}
+
+	draft := &models.Campaign{Name: "app", Kind: models.CampaignPercent, Value: 10, Channel: " APP "}
+
+	bonuses, err = gm.DryRunCampaigns(ctx, models.CampaignOrder{Accrual: 1000, Channel: "app"}, draft)
+	if err != nil || len(bonuses) != 1 || bonuses[0].Amount != 100 {
+		t.Fatalf("draft campaign: %+v, %v, want 100", bonuses, err)
+	}
+
+	if _, err := gm.DryRunCampaigns(withPayload(context.Background(), 1, models.RoleUser), models.CampaignOrder{}, nil); err == nil {
+		t.Error("user can dry run campaigns")
+	}
+}
+
+// TestLoadOrderChannel проверяет, что канал заказа определяется по ключу клиента, а не выбирается пользователем.
+func TestLoadOrderChannel(t *testing.T) {
+	st := &campaignStorage{}
+	gm := newTestGMart(st, nil)
+	gm.orders = ordernumber.Luhn{}
+	gm.channels = models.ChannelClients{{Channel: "app", Key: "0123456789abcdef"}}
+
+	ctx := withPayload(context.Background(), 1, models.RoleUser)
+
+	for _, key := range []string{"0123456789abcdef", "app", ""} {
+		if err := gm.LoadOrder(ctx, "79927398713", key); err != nil {
+			t.Fatalf("LoadOrder with key %q: %v", key, err)
+		}
+	}
+
+	want := []string{"app", "", ""}
+
+	if len(st.orders) != len(want) {
+		t.Fatalf("orders = %+v", st.orders)
+	}
+
+	for i, o := range st.orders {
+		if o.Channel != want[i] {
+			t.Errorf("order %d channel = %q, want %q", i, o.Channel, want[i])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const revokedStatusOrder = "REVOKED"
+
+// ClawbackOrder отзывает начисление по обработанному заказу и уведомляет пользователя.
+// Повторный вызов для того же заказа возвращает результат первого отзыва, баланс не изменяется.
+func (gm *GMart) ClawbackOrder(ctx context.Context, orderNumber, reason string) (models.OrderClawback, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermClawbackOrders)
+	if err != nil {
+		return models.OrderClawback{}, err
+	}
+
+	if orderNumber == "" || reason == "" {
+		return models.OrderClawback{}, models.ErrInvalidInput
+	}
+
+	if err := gm.checkOrderShop(ctx, tokenPayload, orderNumber); err != nil {
+		return models.OrderClawback{}, err
+	}
+
+	clawback, created, err := gm.storage.ClawbackOrder(ctx, models.OrderClawback{
+		Order:      orderNumber,
+		Reason:     reason,
+		OperatorID: tokenPayload.UserID,
+	}, models.Notification{
+		Kind:    models.NotificationAccrualRevoked,
+		Message: fmt.Sprintf("Points accrued for order %s were revoked: %s", orderNumber, reason),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) || errors.Is(err, models.ErrConflict) {
+			return models.OrderClawback{}, err
+		}
+
+		gm.log.Error("cannot clawback order", zap.Error(err))
+
+		return models.OrderClawback{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if created {
+		gm.log.Info("order accrual revoked",
+			zap.String("order number", orderNumber),
+			zap.Int("user id", clawback.UserID),
+			zap.Int("amount", clawback.Amount),
+			zap.Int("debt", clawback.Debt),
+			zap.String("reason", reason),
+			zap.Int("operator id", tokenPayload.UserID))
+	}
+
+	return clawback, nil
+}
+
+// GetNotifications возвращает уведомления текущего пользователя.
+func (gm *GMart) GetNotifications(ctx context.Context) ([]models.Notification, error) {
+	// получаем id пользователя
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, err
+	}
+
+	notifications, err := gm.storage.GetNotifications(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Info("cannot get notifications", zap.Error(err))
+
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+type clawbackStorage struct {
+	storage
+
+	clawbacks     map[string]models.OrderClawback
+	notifications []models.Notification
+	shop          string
+	err           error
+}
+
+func (s *clawbackStorage) GetUserShop(_ context.Context, _ int) (string, error) {
+	return s.shop, nil
+}
+
+func (s *clawbackStorage) ClawbackOrder(
+	_ context.Context,
+	clawback models.OrderClawback,
+	notification models.Notification,
+) (models.OrderClawback, bool, error) {
+	if s.err != nil {
+		return models.OrderClawback{}, false, s.err
+	}
+
+	if c, ok := s.clawbacks[clawback.Order]; ok {
+		return c, false, nil
+	}
+
+	clawback.ID = int64(len(s.clawbacks) + 1)
+	clawback.Amount = 500
+	s.clawbacks[clawback.Order] = clawback
+	s.notifications = append(s.notifications, notification)
+
+	return clawback, true, nil
+}
+
+func TestClawbackOrder(t *testing.T) {
+	st := &clawbackStorage{clawbacks: make(map[string]models.OrderClawback), shop: "7992"}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 7, models.RoleService)
+
+	c, err := gm.ClawbackOrder(ctx, "79927398713", "fraud")
+	if err != nil {
+		t.Fatalf("ClawbackOrder: %v", err)
+	}
+
+	if c.OperatorID != 7 || c.Reason != "fraud" {
+		t.Errorf("clawback = %+v", c)
+	}
+
+	if len(st.notifications) != 1 || st.notifications[0].Kind != models.NotificationAccrualRevoked ||
+		!strings.Contains(st.notifications[0].Message, "79927398713") {
+		t.Errorf("notifications = %+v", st.notifications)
+	}
+
+	again, err := gm.ClawbackOrder(ctx, "79927398713", "fraud")
+	if err != nil || again.ID != c.ID {
+		t.Errorf("repeated clawback = %+v, %v", again, err)
+	}
+
+	if len(st.notifications) != 1 {
+		t.Errorf("repeated clawback notified user again: %+v", st.notifications)
+	}
+}
+
+func TestClawbackOrderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       models.Role
+		order      string
+		reason     string
+		storageErr error
+		wantErr    error
+	}{
+		{name: "user", role: models.RoleUser, order: "1", reason: "r", wantErr: models.ErrForbidden},
+		{name: "support", role: models.RoleSupport, order: "1", reason: "r", wantErr: models.ErrForbidden},
+		{name: "no order", role: models.RoleService, reason: "r", wantErr: models.ErrInvalidInput},
+		{name: "no reason", role: models.RoleAdmin, order: "1", wantErr: models.ErrInvalidInput},
+		{name: "foreign shop", role: models.RoleService, order: "2377225624", reason: "r", wantErr: models.ErrForbidden},
+		{name: "admin any shop", role: models.RoleAdmin, order: "2377225624", reason: "r"},
+		{
+			name:       "unknown order",
+			role:       models.RoleService,
+			order:      "1",
+			reason:     "r",
+			storageErr: models.ErrNotFound,
+			wantErr:    models.ErrNotFound,
+		},
+		{
+			name:       "not processed",
+			role:       models.RoleService,
+			order:      "1",
+			reason:     "r",
+			storageErr: models.ErrConflict,
+			wantErr:    models.ErrConflict,
+		},
+		{
+			name:       "storage failure",
+			role:       models.RoleService,
+			order:      "1",
+			reason:     "r",
+			storageErr: errors.New("boom"),
+			wantErr:    models.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &clawbackStorage{clawbacks: make(map[string]models.OrderClawback), shop: "1", err: tt.storageErr}
+			gm := newTestGMart(st, nil)
+
+			_, err := gm.ClawbackOrder(withPayload(context.Background(), 7, tt.role), tt.order, tt.reason)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestClawbackUnboundShop проверяет, что учётная запись, не привязанная к магазину, не отзывает начисления.
+func TestClawbackUnboundShop(t *testing.T) {
+	st := &clawbackStorage{clawbacks: make(map[string]models.OrderClawback)}
+	gm := newTestGMart(st, nil)
+
+	if _, err := gm.ClawbackOrder(withPayload(context.Background(), 7, models.RoleService), "79927398713", "fraud"); !errors.Is(err, models.ErrForbidden) {
+		t.Fatalf("got %v, want ErrForbidden", err)
+	}
+
+	if len(st.clawbacks) != 0 {
+		t.Errorf("clawback reached storage: %+v", st.clawbacks)
+	}
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	eventsBatchSize       = 100
+	eventsListenRetry     = time.Second * 5
+	eventsCleanupDuration = time.Hour
+	eventsCleanupTimeout  = time.Second * 30
+)
+
+// eventBroker будит подписчиков экземпляра сервиса, когда для их пользователя сохранено событие.
+// Сами события подписчики читают из базы, поэтому пропущенный сигнал не теряет событий.
+type eventBroker struct {
+	mu   sync.Mutex
+	subs map[int]map[chan struct{}]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subs: make(map[int]map[chan struct{}]struct{})}
+}
+
+// subscribe возвращает канал, в который приходит сигнал о новых событиях пользователя, и функцию отписки.
+func (b *eventBroker) subscribe(userID int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[chan struct{}]struct{})
+	}
+
+	b.subs[userID][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subs[userID], ch)
+
+		if len(b.subs[userID]) == 0 {
+			delete(b.subs, userID)
+		}
+	}
+}
+
+// publish будит подписчиков пользователя. Подписчик, который ещё не обработал прошлый сигнал,
+// повторно не будится.
+func (b *eventBroker) publish(userID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[userID] {
+		wake(ch)
+	}
+}
+
+// publishAll будит всех подписчиков, например после переподключения к базе, когда сигналы могли быть пропущены.
+func (b *eventBroker) publishAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subs {
+		for ch := range subs {
+			wake(ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// SubscribeEvents подписывает текущего пользователя на сигналы о новых событиях.
+// Возвращает канал сигналов и функцию отписки, которую нужно вызвать по окончании потока.
+func (gm *GMart) SubscribeEvents(ctx context.Context) (<-chan struct{}, func(), error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, nil, err
+	}
+
+	ch, unsubscribe := gm.events.subscribe(tokenPayload.UserID)
+
+	return ch, unsubscribe, nil
+}
+
+// GetEvents возвращает очередную пачку событий текущего пользователя после события с id after.
+func (gm *GMart) GetEvents(ctx context.Context, after int64) ([]models.UserEvent, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, err
+	}
+
+	events, err := gm.storage.GetEvents(ctx, tokenPayload.UserID, after, eventsBatchSize)
+	if err != nil {
+		gm.log.Error("cannot get events", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return events, nil
+}
+
+// GetLastEventID возвращает id последнего события текущего пользователя. С него начинается поток
+// клиента, который подключается впервые и не должен получать старые события.
+func (gm *GMart) GetLastEventID(ctx context.Context) (int64, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return 0, err
+	}
+
+	id, err := gm.storage.GetLastEventID(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get last event id", zap.Error(err))
+
+		return 0, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return id, nil
+}
+
+// listenEvents получает из базы сигналы о событиях, сохранённых любым экземпляром сервиса,
+// и будит подписчиков. При потере соединения подписка восстанавливается.
+func (gm *GMart) listenEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-gm.doneCh
+		cancel()
+	}()
+
+	for {
+		// пока подписки не было, сигналы могли быть пропущены, поэтому после подписки будятся все подписчики
+		err := gm.storage.ListenEvents(ctx, gm.events.publishAll, gm.events.publish)
+		if ctx.Err() != nil {
+			return
+		}
+
+		gm.log.Warn("events listener stopped", zap.Error(err))
+
+		select {
+		case <-gm.doneCh:
+			return
+		case <-time.After(eventsListenRetry):
+		}
+	}
+}
+
+// cleanupEvents периодически удаляет события старше срока хранения.
+func (gm *GMart) cleanupEvents() {
+	if gm.set.Events.Retention <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(eventsCleanupDuration)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-gm.doneCh:
+			return
+		case <-tick.C:
+			ctx, cancel := context.WithTimeout(context.Background(), eventsCleanupTimeout)
+
+			deleted, err := gm.storage.DeleteEventsBefore(ctx, time.Now().Add(-gm.set.Events.Retention))
+			if err != nil {
+				gm.log.Warn("cannot delete old events", zap.Error(err))
+			} else if deleted > 0 {
+				gm.log.Debug("old events deleted", zap.Int64("count", deleted))
+			}
+
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+func woken(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestEventBroker(t *testing.T) {
+	b := newEventBroker()
+
+	alice, unsubscribeAlice := b.subscribe(1)
+	aliceTab, unsubscribeAliceTab := b.subscribe(1)
+	bob, unsubscribeBob := b.subscribe(2)
+
+	defer unsubscribeBob()
+
+	b.publish(1)
+
+	if !woken(alice) || !woken(aliceTab) || woken(bob) {
+		t.Error("publish must wake every subscriber of the user and only them")
+	}
+
+	// подписчик, не обработавший прошлый сигнал, получает один сигнал на несколько событий
+	b.publish(1)
+	b.publish(1)
+
+	if !woken(alice) || woken(alice) {
+		t.Error("pending signals must be coalesced")
+	}
+
+	unsubscribeAlice()
+	b.publish(1)
+
+	if woken(alice) || !woken(aliceTab) {
+		t.Error("unsubscribed channel was woken")
+	}
+
+	b.publishAll()
+
+	if !woken(aliceTab) || !woken(bob) {
+		t.Error("publishAll must wake every subscriber")
+	}
+
+	unsubscribeAliceTab()
+
+	b.mu.Lock()
+	_, ok := b.subs[1]
+	b.mu.Unlock()
+
+	if ok {
+		t.Error("user without subscribers is kept in the broker")
+	}
+}
+
+type eventStorage struct {
+	storage
+
+	userID int
+	after  int64
+	limit  int
+	err    error
+
+	// listen вызывается из ListenEvents и возвращает ошибку подписки
+	listen func(ctx context.Context, listening func(), fn func(userID int)) error
+}
+
+func (s *eventStorage) GetEvents(_ context.Context, userID int, after int64, limit int) ([]models.UserEvent, error) {
+	s.userID, s.after, s.limit = userID, after, limit
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []models.UserEvent{{ID: after + 1, UserID: userID, Kind: models.EventBalance}}, nil
+}
+
+func (s *eventStorage) GetLastEventID(_ context.Context, userID int) (int64, error) {
+	s.userID = userID
+
+	return 42, s.err
+}
+
+func (s *eventStorage) ListenEvents(ctx context.Context, listening func(), fn func(userID int)) error {
+	return s.listen(ctx, listening, fn)
+}
+
+func TestGetEvents(t *testing.T) {
+	st := &eventStorage{}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	events, err := gm.GetEvents(ctx, 10)
+	if err != nil || len(events) != 1 || events[0].ID != 11 {
+		t.Fatalf("GetEvents = %+v, %v", events, err)
+	}
+
+	if st.userID != 7 || st.after != 10 || st.limit != eventsBatchSize {
+		t.Errorf("storage got user %d after %d limit %d", st.userID, st.after, st.limit)
+	}
+
+	if id, err := gm.GetLastEventID(ctx); err != nil || id != 42 {
+		t.Errorf("GetLastEventID = %d, %v, want 42", id, err)
+	}
+
+	gm = newTestGMart(&eventStorage{err: errors.New("connection lost")}, nil)
+
+	if _, err := gm.GetEvents(ctx, 0); !errors.Is(err, models.ErrInternal) {
+		t.Errorf("GetEvents: err = %v, want ErrInternal", err)
+	}
+
+	if _, err := gm.GetLastEventID(ctx); !errors.Is(err, models.ErrInternal) {
+		t.Errorf("GetLastEventID: err = %v, want ErrInternal", err)
+	}
+
+	if _, _, err := gm.SubscribeEvents(context.Background()); err == nil {
+		t.Error("subscribed without token payload")
+	}
+}
+
+// TestListenEvents проверяет, что сигналы из базы будят подписчиков пользователя, после оформления
+// подписки будятся все подписчики, а остановка сервиса прекращает подписку.
+func TestListenEvents(t *testing.T) {
+	notify := make(chan int)
+	listening := make(chan struct{})
+
+	st := &eventStorage{listen: func(ctx context.Context, ready func(), fn func(userID int)) error {
+		ready()
+		close(listening)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case userID := <-notify:
+				fn(userID)
+			}
+		}
+	}}
+	gm := newTestGMart(st, nil)
+
+	alice, unsubscribeAlice, err := gm.SubscribeEvents(withPayload(context.Background(), 1, models.RoleUser))
+	if err != nil {
+		t.Fatalf("SubscribeEvents: %v", err)
+	}
+
+	defer unsubscribeAlice()
+
+	bob, unsubscribeBob, err := gm.SubscribeEvents(withPayload(context.Background(), 2, models.RoleUser))
+	if err != nil {
+		t.Fatalf("SubscribeEvents: %v", err)
+	}
+
+	defer unsubscribeBob()
+
+	done := make(chan struct{})
+
+	go func() {
+		gm.listenEvents()
+		close(done)
+	}()
+
+	select {
+	case <-listening:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not subscribe")
+	}
+
+	if !woken(alice) || !woken(bob) {
+		t.Error("subscribers must be woken once the listener is ready")
+	}
+
+	notify <- 2
+
+	select {
+	case <-bob:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriber was not woken by the notification")
+	}
+
+	if woken(alice) {
+		t.Error("notification woke another user")
+	}
+
+	close(gm.doneCh)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not stop")
+	}
+}
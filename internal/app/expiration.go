@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	expirationBatchSize = 100
+	expirationTimeout   = time.Second * 30
+)
+
+// fillExpiringPoints добавляет к балансу баллы, которые сгорят в ближайшее время.
+func (gm *GMart) fillExpiringPoints(ctx context.Context, userID int, balance *models.Balance) error {
+	if gm.set.Points.TTL <= 0 {
+		return nil
+	}
+
+	expiring, err := gm.storage.GetExpiringPoints(ctx, userID, time.Now().Add(gm.set.Points.ExpiringSoon))
+	if err != nil {
+		return err
+	}
+
+	balance.Expiring = expiring
+
+	for _, e := range expiring {
+		balance.ExpiringSoon += e.Sum
+	}
+
+	return nil
+}
+
+// expirePoints периодически сжигает партии баллов с истёкшим сроком.
+// Партии сжигаются пачками, пока не останется просроченных.
+func (gm *GMart) expirePoints() {
+	if gm.set.Points.ExpirationInterval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(gm.set.Points.ExpirationInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-gm.doneCh:
+			return
+		case <-tick.C:
+			ctx, cancel := context.WithTimeout(context.Background(), expirationTimeout)
+
+			for {
+				count, total, err := gm.storage.ExpirePoints(ctx, expirationBatchSize)
+				if err != nil {
+					gm.log.Warn("cannot expire points", zap.Error(err))
+
+					break
+				}
+
+				if count > 0 {
+					gm.log.Info("points expired", zap.Int("lots", count), zap.Int("sum", total))
+				}
+
+				if count < expirationBatchSize {
+					break
+				}
+			}
+
+			cancel()
+		}
+	}
+}
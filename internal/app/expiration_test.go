@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type expirationStorage struct {
+	storage
+
+	batches chan int
+	limits  chan int
+	before  time.Time
+}
+
+func (s *expirationStorage) ExpirePoints(_ context.Context, limit int) (int, int, error) {
+	select {
+	case s.limits <- limit:
+	default:
+	}
+
+	count, ok := <-s.batches
+	if !ok {
+		return 0, 0, errors.New("no more batches")
+	}
+
+	return count, count * 100, nil
+}
+
+func (s *expirationStorage) GetExpiringPoints(_ context.Context, _ int, before time.Time) ([]models.ExpiringPoints, error) {
+	s.before = before
+
+	return []models.ExpiringPoints{
+		{Sum: 300, ExpiresAt: time.Now().Add(time.Hour)},
+		{Sum: 200, ExpiresAt: time.Now().Add(48 * time.Hour)},
+	}, nil
+}
+
+// TestExpirePointsJob проверяет, что задача сжигает партии пачками, пока пачка заполнена целиком,
+// и продолжает работу по следующему тику.
+func TestExpirePointsJob(t *testing.T) {
+	st := &expirationStorage{batches: make(chan int, 10), limits: make(chan int, 10)}
+	gm := newTestGMart(st, &settings.Settings{Points: settings.PointsSettings{
+		TTL:                time.Hour,
+		ExpirationInterval: 10 * time.Millisecond,
+	}})
+
+	st.batches <- expirationBatchSize
+	st.batches <- expirationBatchSize
+	st.batches <- 3
+	st.batches <- 0
+
+	done := make(chan struct{})
+
+	go func() {
+		gm.expirePoints()
+		close(done)
+	}()
+
+	for i := 0; i < 4; i++ {
+		select {
+		case limit := <-st.limits:
+			if limit != expirationBatchSize {
+				t.Errorf("batch limit = %d, want %d", limit, expirationBatchSize)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expiration job made %d calls, want 4", i)
+		}
+	}
+
+	// следующие тики получают ошибку хранилища, задача должна продолжить работу до остановки
+	close(st.batches)
+	close(gm.doneCh)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expiration job did not stop")
+	}
+}
+
+func TestExpirePointsJobDisabled(t *testing.T) {
+	gm := newTestGMart(nil, &settings.Settings{Points: settings.PointsSettings{TTL: time.Hour}})
+
+	done := make(chan struct{})
+
+	go func() {
+		gm.expirePoints()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expiration job without interval did not return")
+	}
+}
+
+func TestFillExpiringPoints(t *testing.T) {
+	st := &expirationStorage{}
+	gm := newTestGMart(st, &settings.Settings{Points: settings.PointsSettings{
+		TTL:          time.Hour,
+		ExpiringSoon: 72 * time.Hour,
+	}})
+
+	balance := models.Balance{}
+
+	if err := gm.fillExpiringPoints(context.Background(), 1, &balance); err != nil {
+		t.Fatalf("fillExpiringPoints: %v", err)
+	}
+
+	if balance.ExpiringSoon != 500 || len(balance.Expiring) != 2 {
+		t.Errorf("balance = %+v, want 500 expiring in 2 days", balance)
+	}
+
+	if d := time.Until(st.before); d < 71*time.Hour || d > 72*time.Hour {
+		t.Errorf("expiring points requested before %v, want in 72h", st.before)
+	}
+
+	// без сгорания хранилище не запрашивается
+	gm = newTestGMart(nil, &settings.Settings{})
+	balance = models.Balance{}
+
+	if err := gm.fillExpiringPoints(context.Background(), 1, &balance); err != nil || balance.ExpiringSoon != 0 {
+		t.Errorf("disabled expiration: %+v, %v", balance, err)
+	}
+}
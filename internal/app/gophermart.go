@@ -7,9 +7,8 @@ import (
 	"github.com/go-faster/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"gophermat/internal/crypt"
-	"gophermat/internal/luhn"
-	"strconv"
+	"gophermat/internal/settings"
+	"math"
 	"time"
 
 	"gophermat/internal/models"
@@ -29,49 +28,192 @@ const (
 
 type storage interface {
 	RegisterUser(ctx context.Context, user models.User) (models.User, error)
+	RegisterReferredUser(ctx context.Context, user models.User, signup models.ReferralSignup) (models.User, error)
+	SetReferralCode(ctx context.Context, userID int, code string) (string, error)
+	GetReferrals(ctx context.Context, referrerID int) ([]models.Referral, error)
+	Transfer(ctx context.Context, t models.Transfer, limits models.TransferLimits) (models.Transfer, error)
+	ReverseTransfer(ctx context.Context, id int64, operatorID int, note string) (models.Transfer, error)
+	GetTransfers(ctx context.Context, userID int) ([]models.Transfer, error)
+	CreateHold(ctx context.Context, hold models.PointHold) (models.PointHold, error)
+	CaptureHold(ctx context.Context, userID int, id int64, sum int, limits models.WithdrawLimits) (models.PointHold, error)
+	ReleaseHold(ctx context.Context, userID int, id int64) (models.PointHold, error)
+	ExpireHolds(ctx context.Context) (int, error)
 	GetUser(ctx context.Context, user models.User) (models.User, error)
 	GetOrder(ctx context.Context, orderNumber string) (models.Order, error)
 	SaveOrder(ctx context.Context, order models.Order) error
 	GetOrders(ctx context.Context, userID int) ([]models.Order, error)
-	UpdateOrder(ctx context.Context, orderNumber, status string, accrual int) error
+	AccrueOrder(
+		ctx context.Context,
+		orderNumber, status string,
+		accrual int,
+		campaigns models.CampaignAccrual,
+	) ([]models.CampaignBonus, error)
 	GetBalance(ctx context.Context, userID int) (models.Balance, error)
-	UpdateBalance(ctx context.Context, balance models.Balance, userID int) error
-	AddBalanceHistory(ctx context.Context, orderNumber string, sum, userID int) error
+	Withdraw(ctx context.Context, userID int, withdraw models.BalanceWithdraw, limits models.WithdrawLimits) error
 	GetBalanceHistory(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error)
+	GetTransactions(ctx context.Context, userID int, page models.TransactionPage) ([]models.BalanceTransaction, error)
+	GetStatementBalance(ctx context.Context, userID int, before time.Time) (int, error)
+	StreamStatement(ctx context.Context, userID int, from, to time.Time, fn func(models.StatementEntry) error) error
 	GetNotProcessOrders() ([]models.Order, error)
+	GetLoginAttempts(ctx context.Context, key string) (models.LoginAttempts, error)
+	ReserveLoginAttempt(ctx context.Context, key string, failures int, window time.Duration) (models.LoginAttempts, error)
+	ReleaseLoginAttempt(ctx context.Context, key string) error
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	ResetLoginAttempts(ctx context.Context, key string) error
+	GetUserByID(ctx context.Context, userID int) (models.User, error)
+	UpdatePassword(ctx context.Context, userID int, password string) (int, error)
+	SavePasswordReset(ctx context.Context, reset models.PasswordReset) error
+	ResetPassword(ctx context.Context, tokenHash, password string) error
+	UpdatePasswordHash(ctx context.Context, userID int, password string) error
+	SaveOIDCState(ctx context.Context, state models.OIDCState) error
+	TakeOIDCState(ctx context.Context, state string) (models.OIDCState, error)
+	GetUserByIdentity(ctx context.Context, issuer, subject string) (models.User, error)
+	RegisterIdentityUser(ctx context.Context, user models.User, identity models.Identity) (models.User, error)
+	SetUserRole(ctx context.Context, change models.RoleChange) error
+	SearchUsers(ctx context.Context, login string, limit int) ([]models.User, error)
+	SetOrderStatus(ctx context.Context, orderNumber, status string, final []string) error
+	AddAdjustment(ctx context.Context, adj models.BalanceAdjustment) (models.BalanceAdjustment, error)
+	GetAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error)
+	GetAdjustments(
+		ctx context.Context,
+		userID int,
+		statuses ...models.AdjustmentStatus,
+	) ([]models.BalanceAdjustment, error)
+	ResolveAdjustment(ctx context.Context, id int64, approverID int, approve bool) (models.BalanceAdjustment, error)
+	ReverseAdjustment(
+		ctx context.Context,
+		id int64,
+		operatorID int,
+		note string,
+		status models.AdjustmentStatus,
+	) (models.BalanceAdjustment, error)
+	RefundWithdrawal(ctx context.Context, refund models.WithdrawalRefund) (models.BalanceWithdrawal, error)
+	ClawbackOrder(
+		ctx context.Context,
+		clawback models.OrderClawback,
+		notification models.Notification,
+	) (models.OrderClawback, bool, error)
+	GetNotifications(ctx context.Context, userID int) ([]models.Notification, error)
+	SaveIdempotencyKey(ctx context.Context, key models.IdempotencyKey) (models.IdempotencyKey, bool, error)
+	SaveIdempotentResponse(ctx context.Context, key models.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, userID int, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	GetExpiringPoints(ctx context.Context, userID int, before time.Time) ([]models.ExpiringPoints, error)
+	ExpirePoints(ctx context.Context, limit int) (int, int, error)
+	GetTierProgress(ctx context.Context, userID int, basis string, since time.Time) (int, error)
+	UpdateUserTier(ctx context.Context, change models.TierChange) (models.TierChange, bool, error)
+	AddCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	UpdateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	GetCampaigns(ctx context.Context, activeOnly bool) ([]models.Campaign, error)
+	CountProcessedOrders(ctx context.Context, userID int) (int, error)
+	AddPromoBatch(ctx context.Context, batch models.PromoBatch) (models.PromoBatch, error)
+	GetPromoBatch(ctx context.Context, id int64) (models.PromoBatch, error)
+	RedeemPromoCode(ctx context.Context, code string, userID int) (models.PromoRedemption, error)
+	GetEvents(ctx context.Context, userID int, after int64, limit int) ([]models.UserEvent, error)
+	GetLastEventID(ctx context.Context, userID int) (int64, error)
+	DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	ListenEvents(ctx context.Context, listening func(), fn func(userID int)) error
+	SetUserShop(ctx context.Context, userID int, shop string) error
+	GetUserShop(ctx context.Context, userID int) (string, error)
+	AddWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error)
+	GetWebhooks(ctx context.Context, createdBy int) ([]models.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (models.Webhook, error)
+	DisableWebhook(ctx context.Context, id int64) (models.Webhook, error)
+	GetWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]models.WebhookDelivery, error)
+	DispatchWebhookEvents(ctx context.Context, limit int) (int, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	SaveWebhookAttempt(
+		ctx context.Context,
+		attempt models.WebhookAttempt,
+		status models.WebhookDeliveryStatus,
+		next time.Time,
+	) error
+}
+
+type hasher interface {
+	HashPassword(password string) (string, error)
+	CheckPassword(password string, hash string) error
+	NeedsRehash(hash string) bool
 }
 
 type authorizer interface {
 	GenerateToken(payload models.TokenPayload) (string, error)
 }
 
+// orderNumberValidator проверяет номер заказа по схеме магазина, которому принадлежит заказ.
+type orderNumberValidator interface {
+	Validate(number string) error
+}
+
 type accrualClient interface {
 	GetOrderAccrual(ctx context.Context, orderNumber string) (models.OrderAccrual, error)
 }
 
+// webhookSender отправляет событие на адрес магазина и возвращает код ответа.
+type webhookSender interface {
+	SendWebhook(ctx context.Context, d models.WebhookDelivery, body []byte) (int, error)
+}
+
 type GMart struct {
-	log     *zap.Logger
-	auth    authorizer
-	storage storage
-	client  accrualClient
-	doneCh  chan struct{}
-	pool    *pond.WorkerPool
-	eg      errgroup.Group
+	log      *zap.Logger
+	set      *settings.Settings
+	auth     authorizer
+	hasher   hasher
+	storage  storage
+	client   accrualClient
+	doneCh   chan struct{}
+	pool     *pond.WorkerPool
+	eg       errgroup.Group
+	password models.PasswordPolicy
+	// dummyHash используется для сравнения пароля, если пользователь не найден,
+	// чтобы время ответа не выдавало существование логина.
+	dummyHash string
+	oidc      oidcProvider
+	orders    orderNumberValidator
+	tiers     *tiers
+	events    *eventBroker
+	webhooks  webhookSender
+	channels  models.ChannelClients
 }
 
-func NewGMart(log *zap.Logger, auth authorizer, storage storage, ac accrualClient) *GMart {
+func NewGMart(
+	log *zap.Logger,
+	set *settings.Settings,
+	auth authorizer,
+	hasher hasher,
+	storage storage,
+	ac accrualClient,
+	webhooks webhookSender,
+	orders orderNumberValidator,
+	tierPolicy models.TierPolicy,
+	channels models.ChannelClients) *GMart {
 	gm := &GMart{
-		log:     log,
-		auth:    auth,
-		storage: storage,
-		client:  ac,
-		doneCh:  make(chan struct{}),
-		pool:    pond.New(maxWorkers, maxCapacity),
-		eg:      errgroup.Group{},
+		log:      log,
+		set:      set,
+		auth:     auth,
+		hasher:   hasher,
+		storage:  storage,
+		client:   ac,
+		doneCh:   make(chan struct{}),
+		pool:     pond.New(maxWorkers, maxCapacity),
+		eg:       errgroup.Group{},
+		password: models.NewPasswordPolicy(set.Password.MinLen, set.Password.Banned),
+		orders:   orders,
+		tiers:    &tiers{log: log, storage: storage, policy: tierPolicy},
+		events:   newEventBroker(),
+		webhooks: webhooks,
+		channels: channels,
+	}
+
+	dummyHash, err := hasher.HashPassword(dummyPassword)
+	if err != nil {
+		log.Error("cannot hash dummy password", zap.Error(err))
 	}
 
+	gm.dummyHash = dummyHash
+
 	gm.eg.Go(func() error {
-		err := processingAccrualOrders(log, storage, ac, gm.doneCh, gm.pool)
+		err := processingAccrualOrders(log, storage, ac, gm.tiers, gm.doneCh, gm.pool)
 		if err != nil {
 			return fmt.Errorf("%w: %w", errProcessing, err)
 		}
@@ -79,6 +221,42 @@ func NewGMart(log *zap.Logger, auth authorizer, storage storage, ac accrualClien
 		return nil
 	})
 
+	gm.eg.Go(func() error {
+		gm.cleanupIdempotencyKeys()
+
+		return nil
+	})
+
+	gm.eg.Go(func() error {
+		gm.expirePoints()
+
+		return nil
+	})
+
+	gm.eg.Go(func() error {
+		gm.expireHolds()
+
+		return nil
+	})
+
+	gm.eg.Go(func() error {
+		gm.listenEvents()
+
+		return nil
+	})
+
+	gm.eg.Go(func() error {
+		gm.cleanupEvents()
+
+		return nil
+	})
+
+	gm.eg.Go(func() error {
+		gm.dispatchWebhooks()
+
+		return nil
+	})
+
 	return gm
 }
 
@@ -91,13 +269,20 @@ func (gm *GMart) Stop() {
 	}
 }
 
-func (gm *GMart) RegisterUser(ctx context.Context, user models.User) (string, error) {
-	if err := user.Validate(); err != nil {
+// RegisterUser регистрирует пользователя. Если задан реферальный код, пользователь сохраняется
+// приглашённым владельцем кода.
+func (gm *GMart) RegisterUser(ctx context.Context, user models.User, referralCode string) (string, error) {
+	if err := user.ValidateWithPolicy(gm.password); err != nil {
 		gm.log.Error("cannot input validate", zap.Error(err))
 
 		return "", fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
 	}
 
+	referralCode = normalizePromoCode(referralCode)
+	if len(referralCode) > maxPromoCodeLen {
+		return "", fmt.Errorf("%w: referral code is too long", models.ErrInvalidInput)
+	}
+
 	_, err := gm.storage.GetUser(ctx, user)
 	if err == nil {
 		gm.log.Info("this user is already registered")
@@ -111,7 +296,7 @@ func (gm *GMart) RegisterUser(ctx context.Context, user models.User) (string, er
 		}
 	}
 
-	passHash, err := crypt.HashPassword(user.Password)
+	passHash, err := gm.hasher.HashPassword(user.Password)
 	if err != nil {
 		gm.log.Error("cannot hash password", zap.Error(err))
 
@@ -120,14 +305,30 @@ func (gm *GMart) RegisterUser(ctx context.Context, user models.User) (string, er
 
 	user.Password = passHash
 
-	u, err := gm.storage.RegisterUser(ctx, user)
+	var u models.User
+
+	if referralCode == "" {
+		u, err = gm.storage.RegisterUser(ctx, user)
+	} else {
+		u, err = gm.storage.RegisterReferredUser(ctx, user, models.ReferralSignup{
+			Code:   referralCode,
+			Policy: gm.referralPolicy(),
+		})
+	}
+
 	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			gm.log.Info("unknown referral code", zap.String("code", referralCode))
+
+			return "", fmt.Errorf("%w: unknown referral code", models.ErrInvalidInput)
+		}
+
 		gm.log.Error("cannot user register", zap.Error(err))
 
 		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
 	}
 
-	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID})
+	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID, TokenVersion: u.TokenVersion, Role: u.Role})
 	if err != nil {
 		gm.log.Error("cannot generate token", zap.Error(err))
 
@@ -144,24 +345,47 @@ func (gm *GMart) LoginUser(ctx context.Context, user models.User) (string, error
 		return "", fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
 	}
 
+	limits := gm.loginLimits(ctx, user.Login)
+
+	reserved, err := gm.reserveLoginAttempts(ctx, limits)
+	if err != nil {
+		if errors.Is(err, models.ErrTooManyAttempts) {
+			return "", err
+		}
+
+		gm.log.Error("cannot check login attempts", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
 	u, err := gm.storage.GetUser(ctx, user)
 	if err != nil {
 		gm.log.Error("cannot user login", zap.Error(err))
 
 		if errors.Is(err, models.ErrNotFound) {
+			// сравниваем с заглушкой, чтобы время ответа не отличалось от неверного пароля
+			_ = gm.hasher.CheckPassword(user.Password, gm.dummyHash)
+			gm.addLoginFailure(ctx, limits, reserved)
+
 			return "", err
 		}
 
+		gm.releaseLoginAttempts(ctx, limits)
+
 		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
 	}
 
-	if err := crypt.CheckPassword(user.Password, u.Password); err != nil {
-		gm.log.Error("cannot user register", zap.Error(err))
+	if err := gm.hasher.CheckPassword(user.Password, u.Password); err != nil {
+		gm.log.Error("cannot user login", zap.Error(err))
+		gm.addLoginFailure(ctx, limits, reserved)
 
 		return "", models.ErrInvalidPassword
 	}
 
-	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID})
+	gm.resetLoginFailures(ctx, user.Login, limits)
+	gm.rehashPassword(ctx, u.ID, user.Password, u.Password)
+
+	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID, TokenVersion: u.TokenVersion, Role: u.Role})
 	if err != nil {
 		gm.log.Error("cannot generate token", zap.Error(err))
 
@@ -171,20 +395,16 @@ func (gm *GMart) LoginUser(ctx context.Context, user models.User) (string, error
 	return token, nil
 }
 
-func (gm *GMart) LoadOrder(ctx context.Context, orderNumber string) error {
-	// проверяем корректность номера заказа
-	on, err := strconv.Atoi(orderNumber)
-	if err != nil {
-		gm.log.Error("cannot order number check", zap.Error(err))
-
-		return fmt.Errorf("%w: %w", models.ErrInvalidOrderNumber, err)
+// LoadOrder загружает заказ пользователя. Канал загрузки, например app, который проверяется условиями акций,
+// определяется по ключу клиента clientKey, заказ с неизвестным ключом загружается без канала.
+func (gm *GMart) LoadOrder(ctx context.Context, orderNumber, clientKey string) error {
+	if err := gm.validateOrderNumber(orderNumber); err != nil {
+		return err
 	}
 
-	// проверяем номер заказа по алгоритму Луна
-	if ok := luhn.Valid(on); !ok {
-		gm.log.Error("order number is not correct on luhn", zap.String("order number", orderNumber))
-
-		return fmt.Errorf("%w: order number is not correct on luhn", models.ErrInvalidOrderNumber)
+	channel := gm.channels.Channel(clientKey)
+	if clientKey != "" && channel == "" {
+		gm.log.Info("unknown client key", zap.String("number", orderNumber))
 	}
 
 	// получаем id пользователя
@@ -212,6 +432,7 @@ func (gm *GMart) LoadOrder(ctx context.Context, orderNumber string) error {
 		Number:     orderNumber,
 		Status:     newStatusOrder,
 		UploadedAt: time.Now(),
+		Channel:    channel,
 	}
 
 	err = gm.storage.SaveOrder(ctx, o)
@@ -259,10 +480,31 @@ func (gm *GMart) GetBalance(ctx context.Context) (models.Balance, error) {
 		return models.Balance{}, err
 	}
 
+	if err := gm.fillExpiringPoints(ctx, tokenPayload.UserID, &balance); err != nil {
+		gm.log.Error("cannot get expiring points", zap.Error(err))
+
+		return models.Balance{}, err
+	}
+
+	if err := gm.fillTier(ctx, tokenPayload.UserID, &balance); err != nil {
+		gm.log.Error("cannot get user tier", zap.Error(err))
+
+		return models.Balance{}, err
+	}
+
 	return balance, nil
 }
 
 func (gm *GMart) DeductPoints(ctx context.Context, withdraw models.BalanceWithdraw) error {
+	// номер заказа проверяется так же, как при загрузке заказа
+	if err := gm.validateOrderNumber(withdraw.Order); err != nil {
+		return err
+	}
+
+	if withdraw.Sum <= 0 {
+		return fmt.Errorf("%w: withdrawal sum must be positive", models.ErrInvalidInput)
+	}
+
 	// получаем id пользователя
 	tokenPayload, err := payloadFromContext(ctx)
 	if err != nil {
@@ -271,41 +513,44 @@ func (gm *GMart) DeductPoints(ctx context.Context, withdraw models.BalanceWithdr
 		return err
 	}
 
-	// получаем баланс пользователя
-	balance, err := gm.storage.GetBalance(ctx, tokenPayload.UserID)
+	// баланс, история списаний и ограничения по заказу проверяются и изменяются в одной транзакции
+	err = gm.storage.Withdraw(ctx, tokenPayload.UserID, withdraw, gm.withdrawLimits())
 	if err != nil {
-		gm.log.Error("cannot get balance", zap.Error(err))
+		if errors.Is(err, models.ErrInsufficientBalance) ||
+			errors.Is(err, models.ErrOrderWithdrawn) ||
+			errors.Is(err, models.ErrOrderWithdrawalCap) {
+			gm.log.Info("cannot withdraw", zap.String("order number", withdraw.Order), zap.Error(err))
 
-		return err
-	}
+			return err
+		}
 
-	// проверяем, что текущий баланс позволяет списать запрошенную сумму
-	if balance.Current < withdraw.Sum {
-		gm.log.Error("the user's balance is less than the requested amount")
+		gm.log.Error("cannot withdraw", zap.Error(err))
 
-		return models.ErrInsufficientBalance
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
 	}
 
-	// меняем баланс
-	err = gm.storage.UpdateBalance(ctx, models.Balance{
-		Current:  balance.Current - withdraw.Sum,
-		Withdraw: balance.Withdraw + withdraw.Sum,
-	}, tokenPayload.UserID)
-	if err != nil {
-		gm.log.Error("cannot update balance", zap.Error(err))
-
-		return err
+	// списание могло поднять уровень пользователя, если уровень зависит от потраченных баллов
+	if gm.tiers.policy.Enabled() && gm.tiers.policy.Basis == models.TierBasisSpent {
+		if _, err := gm.tiers.evaluate(ctx, tokenPayload.UserID); err != nil {
+			gm.log.Warn("cannot update user tier", zap.Error(err))
+		}
 	}
 
-	// записываем изменение баланса в историю
-	err = gm.storage.AddBalanceHistory(ctx, withdraw.Order, withdraw.Sum, tokenPayload.UserID)
-	if err != nil {
-		gm.log.Error("cannot add balance history", zap.Error(err))
+	return nil
+}
 
-		return err
+// withdrawLimits возвращает ограничения на списания за один заказ из настроек.
+func (gm *GMart) withdrawLimits() models.WithdrawLimits {
+	limits := models.WithdrawLimits{
+		MaxPerOrder: gm.set.Withdraw.MaxPerOrder,
+		OrderCap:    int(math.Round(gm.set.Withdraw.OrderCap * 100)),
 	}
 
-	return nil
+	if limits.MaxPerOrder < 1 {
+		limits.MaxPerOrder = 1
+	}
+
+	return limits
 }
 
 func (gm *GMart) GetWithdrawals(ctx context.Context) ([]models.BalanceWithdrawal, error) { // получаем id пользователя
@@ -326,6 +571,40 @@ func (gm *GMart) GetWithdrawals(ctx context.Context) ([]models.BalanceWithdrawal
 	return history, nil
 }
 
+// validateOrderNumber проверяет номер заказа по схеме магазина. Ошибка содержит причину отказа.
+func (gm *GMart) validateOrderNumber(orderNumber string) error {
+	if err := gm.orders.Validate(orderNumber); err != nil {
+		gm.log.Info("order number is not correct", zap.String("order number", orderNumber), zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// rehashPassword заменяет хэш пароля, полученный устаревшим алгоритмом или с другими параметрами.
+// Ошибка не прерывает вход пользователя, хэш будет обновлён при следующем входе.
+func (gm *GMart) rehashPassword(ctx context.Context, userID int, password, hash string) {
+	if !gm.hasher.NeedsRehash(hash) {
+		return
+	}
+
+	passHash, err := gm.hasher.HashPassword(password)
+	if err != nil {
+		gm.log.Error("cannot rehash password", zap.Error(err))
+
+		return
+	}
+
+	if err := gm.storage.UpdatePasswordHash(ctx, userID, passHash); err != nil {
+		gm.log.Error("cannot update password hash", zap.Error(err))
+
+		return
+	}
+
+	gm.log.Info("password hash upgraded", zap.Int("user id", userID))
+}
+
 func payloadFromContext(ctx context.Context) (models.TokenPayload, error) {
 	value := ctx.Value(models.CtxTokenPayload{})
 	if value == nil {
@@ -344,6 +623,7 @@ func processingAccrualOrders(
 	log *zap.Logger,
 	store storage,
 	client accrualClient,
+	tiers *tiers,
 	doneCh chan struct{},
 	pool *pond.WorkerPool) error {
 	tick := time.NewTicker(tickerDuration)
@@ -366,7 +646,7 @@ func processingAccrualOrders(
 				for _, o := range orders {
 					o := o
 					pool.Submit(func() {
-						processOrder(log, store, client, o)
+						processOrder(log, store, client, tiers, o)
 					})
 				}
 
@@ -376,7 +656,7 @@ func processingAccrualOrders(
 	}
 }
 
-func processOrder(log *zap.Logger, store storage, client accrualClient, order models.Order) {
+func processOrder(log *zap.Logger, store storage, client accrualClient, tiers *tiers, order models.Order) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second+5)
 	defer cancel()
 
@@ -393,7 +673,30 @@ func processOrder(log *zap.Logger, store storage, client accrualClient, order mo
 		return
 	}
 
-	err = store.UpdateOrder(ctx, order.Number, accrual.Status, int(accrual.Accrual*100))
+	amount := int(accrual.Accrual * 100)
+
+	var campaigns models.CampaignAccrual
+
+	if accrual.Status == processedStatusOrder {
+		// бонусы по акциям считаются от начисления системы расчёта
+		campaigns, err = campaignAccrual(ctx, store, order, amount)
+		if err != nil {
+			log.Error("cannot evaluate campaigns", zap.Error(err))
+
+			return
+		}
+
+		// начисление увеличивается по уровню, который был у пользователя до этого заказа
+		amount, err = tiers.applyMultiplier(ctx, order.UserID, amount)
+		if err != nil {
+			log.Error("cannot apply tier multiplier", zap.Error(err))
+
+			return
+		}
+	}
+
+	// начисление, бонусы и зачисление на баланс выполняются в одной транзакции
+	bonuses, err := store.AccrueOrder(ctx, order.Number, accrual.Status, amount, campaigns)
 	if err != nil {
 		log.Error("cannot update order accrual", zap.Error(err))
 
@@ -403,20 +706,14 @@ func processOrder(log *zap.Logger, store storage, client accrualClient, order mo
 	log.Info("order successful updated",
 		zap.String("order number", order.Number),
 		zap.String("status", accrual.Status),
-		zap.Float32("accrual", accrual.Accrual))
-
-	balance, err := store.GetBalance(ctx, order.UserID)
-	if err != nil {
-		log.Debug("cannot get balance", zap.Error(err))
-	}
-
-	err = store.UpdateBalance(ctx, models.Balance{
-		Current:  balance.Current + int(accrual.Accrual*100),
-		Withdraw: balance.Withdraw,
-	}, order.UserID)
-	if err != nil {
-		log.Error("cannot update balance", zap.Error(err))
-
-		return
+		zap.Float32("accrual", accrual.Accrual),
+		zap.Int("credited", amount),
+		zap.Int("campaign bonuses", len(bonuses)))
+
+	// начисление могло поднять уровень пользователя
+	if accrual.Status == processedStatusOrder && amount > 0 && tiers.policy.Enabled() {
+		if _, err := tiers.evaluate(ctx, order.UserID); err != nil {
+			log.Warn("cannot update user tier", zap.Error(err))
+		}
 	}
 }
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+// testHasher хранит пароль открытым текстом с префиксом, чтобы тесты не тратили время на хэширование.
+type testHasher struct{}
+
+func (testHasher) HashPassword(password string) (string, error) {
+	return "plain:" + password, nil
+}
+
+func (testHasher) CheckPassword(password string, hash string) error {
+	if hash != "plain:"+password {
+		return errors.New("password does not match hash")
+	}
+
+	return nil
+}
+
+func (testHasher) NeedsRehash(hash string) bool {
+	return !strings.HasPrefix(hash, "plain:")
+}
+
+type testAuthorizer struct{}
+
+func (testAuthorizer) GenerateToken(payload models.TokenPayload) (string, error) {
+	return "token", nil
+}
+
+// newTestGMart создаёт сервис без фоновых задач. Хранилище реализует только методы, которые нужны тесту:
+// вызов остальных методов встроенного интерфейса storage завершится паникой.
+func newTestGMart(st storage, set *settings.Settings) *GMart {
+	if set == nil {
+		set = &settings.Settings{}
+	}
+
+	return &GMart{
+		log:      zap.NewNop(),
+		set:      set,
+		auth:     testAuthorizer{},
+		hasher:   testHasher{},
+		storage:  st,
+		doneCh:   make(chan struct{}),
+		password: models.NewPasswordPolicy(set.Password.MinLen, set.Password.Banned),
+		events:   newEventBroker(),
+		tiers:    &tiers{log: zap.NewNop(), storage: st},
+	}
+}
+
+// withPayload возвращает контекст аутентифицированного запроса.
+func withPayload(ctx context.Context, userID int, role models.Role) context.Context {
+	return context.WithValue(ctx, models.CtxTokenPayload{}, models.TokenPayload{UserID: userID, Role: role})
+}
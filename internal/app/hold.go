@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// CreateHold удерживает баллы текущего пользователя в оплату заказа. Удержанные баллы недоступны
+// для других списаний, пока удержание не будет списано, снято или не истечёт.
+func (gm *GMart) CreateHold(ctx context.Context, withdraw models.BalanceWithdraw) (models.PointHold, error) {
+	// номер заказа проверяется так же, как при списании
+	if err := gm.validateOrderNumber(withdraw.Order); err != nil {
+		return models.PointHold{}, err
+	}
+
+	if withdraw.Sum <= 0 {
+		return models.PointHold{}, fmt.Errorf("%w: hold sum must be positive", models.ErrInvalidInput)
+	}
+
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.PointHold{}, err
+	}
+
+	hold, err := gm.storage.CreateHold(ctx, models.PointHold{
+		UserID:    tokenPayload.UserID,
+		Order:     withdraw.Order,
+		Amount:    withdraw.Sum,
+		ExpiresAt: time.Now().Add(gm.set.Hold.TTL),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrInsufficientBalance) {
+			return models.PointHold{}, err
+		}
+
+		gm.log.Error("cannot create hold", zap.Error(err))
+
+		return models.PointHold{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("points held",
+		zap.Int64("hold id", hold.ID),
+		zap.Int("user id", hold.UserID),
+		zap.String("order number", hold.Order),
+		zap.Int("amount", hold.Amount))
+
+	return hold, nil
+}
+
+// CaptureHold списывает удержанные баллы в оплату заказа. Нулевая сумма списывает всё удержание,
+// при частичном списании остаток удержания снимается.
+func (gm *GMart) CaptureHold(ctx context.Context, id int64, sum int) (models.PointHold, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.PointHold{}, err
+	}
+
+	hold, err := gm.storage.CaptureHold(ctx, tokenPayload.UserID, id, sum, gm.withdrawLimits())
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound),
+			errors.Is(err, models.ErrHoldNotActive),
+			errors.Is(err, models.ErrInvalidInput),
+			errors.Is(err, models.ErrInsufficientBalance),
+			errors.Is(err, models.ErrOrderWithdrawn),
+			errors.Is(err, models.ErrOrderWithdrawalCap):
+			gm.log.Info("cannot capture hold", zap.Int64("hold id", id), zap.Error(err))
+
+			return models.PointHold{}, err
+		default:
+			gm.log.Error("cannot capture hold", zap.Error(err))
+
+			return models.PointHold{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+	}
+
+	gm.log.Info("hold captured",
+		zap.Int64("hold id", hold.ID),
+		zap.Int("user id", hold.UserID),
+		zap.Int("captured", hold.Captured))
+
+	return hold, nil
+}
+
+// ReleaseHold снимает удержание без списания баллов.
+func (gm *GMart) ReleaseHold(ctx context.Context, id int64) (models.PointHold, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.PointHold{}, err
+	}
+
+	hold, err := gm.storage.ReleaseHold(ctx, tokenPayload.UserID, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) || errors.Is(err, models.ErrHoldNotActive) {
+			return models.PointHold{}, err
+		}
+
+		gm.log.Error("cannot release hold", zap.Error(err))
+
+		return models.PointHold{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("hold released", zap.Int64("hold id", hold.ID), zap.Int("user id", hold.UserID))
+
+	return hold, nil
+}
+
+// expireHolds периодически снимает удержания с истёкшим сроком.
+func (gm *GMart) expireHolds() {
+	if gm.set.Hold.ExpirationInterval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(gm.set.Hold.ExpirationInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-gm.doneCh:
+			return
+		case <-tick.C:
+			ctx, cancel := context.WithTimeout(context.Background(), expirationTimeout)
+
+			count, err := gm.storage.ExpireHolds(ctx)
+			if err != nil {
+				gm.log.Warn("cannot expire holds", zap.Error(err))
+			} else if count > 0 {
+				gm.log.Info("holds expired", zap.Int("holds", count))
+			}
+
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/ordernumber"
+	"gophermat/internal/settings"
+)
+
+type holdStorage struct {
+	storage
+
+	holds   []models.PointHold
+	limits  []models.WithdrawLimits
+	err     error
+	expired chan struct{}
+}
+
+func (s *holdStorage) CreateHold(_ context.Context, hold models.PointHold) (models.PointHold, error) {
+	if s.err != nil {
+		return models.PointHold{}, s.err
+	}
+
+	hold.ID = int64(len(s.holds) + 1)
+	hold.Status = models.HoldActive
+	s.holds = append(s.holds, hold)
+
+	return hold, nil
+}
+
+func (s *holdStorage) CaptureHold(
+	_ context.Context,
+	userID int,
+	id int64,
+	sum int,
+	limits models.WithdrawLimits,
+) (models.PointHold, error) {
+	s.limits = append(s.limits, limits)
+
+	if s.err != nil {
+		return models.PointHold{}, s.err
+	}
+
+	return models.PointHold{ID: id, UserID: userID, Captured: sum, Status: models.HoldCaptured}, nil
+}
+
+func (s *holdStorage) ReleaseHold(_ context.Context, userID int, id int64) (models.PointHold, error) {
+	if s.err != nil {
+		return models.PointHold{}, s.err
+	}
+
+	return models.PointHold{ID: id, UserID: userID, Status: models.HoldReleased}, nil
+}
+
+func (s *holdStorage) ExpireHolds(_ context.Context) (int, error) {
+	select {
+	case s.expired <- struct{}{}:
+	default:
+	}
+
+	return 1, s.err
+}
+
+func TestCreateHold(t *testing.T) {
+	st := &holdStorage{}
+	gm := newTestGMart(st, &settings.Settings{Hold: settings.HoldSettings{TTL: 15 * time.Minute}})
+	gm.orders = ordernumber.Luhn{}
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	start := time.Now()
+
+	hold, err := gm.CreateHold(ctx, models.BalanceWithdraw{Order: "2377225624", Sum: 500})
+	if err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+
+	if hold.UserID != 7 || hold.Order != "2377225624" || hold.Amount != 500 ||
+		hold.ExpiresAt.Before(start.Add(15*time.Minute)) || hold.ExpiresAt.After(time.Now().Add(15*time.Minute)) {
+		t.Errorf("hold = %+v, want 500 of user 7 for 15 minutes", hold)
+	}
+
+	tests := []struct {
+		name     string
+		withdraw models.BalanceWithdraw
+		wantErr  error
+	}{
+		{name: "luhn mismatch", withdraw: models.BalanceWithdraw{Order: "2377225625", Sum: 500}, wantErr: models.ErrInvalidOrderNumber},
+		{name: "empty order", withdraw: models.BalanceWithdraw{Sum: 500}, wantErr: models.ErrInvalidOrderNumber},
+		{name: "zero sum", withdraw: models.BalanceWithdraw{Order: "2377225624"}, wantErr: models.ErrInvalidInput},
+		{name: "negative sum", withdraw: models.BalanceWithdraw{Order: "2377225624", Sum: -1}, wantErr: models.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gm.CreateHold(ctx, tt.withdraw); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if len(st.holds) != 1 {
+		t.Errorf("holds = %d, want only the valid one", len(st.holds))
+	}
+}
+
+func TestCreateHoldErrors(t *testing.T) {
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	tests := []struct {
+		storageErr error
+		wantErr    error
+	}{
+		{storageErr: models.ErrInsufficientBalance, wantErr: models.ErrInsufficientBalance},
+		{storageErr: errors.New("connection lost"), wantErr: models.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		gm := newTestGMart(&holdStorage{err: tt.storageErr}, nil)
+		gm.orders = ordernumber.Luhn{}
+
+		if _, err := gm.CreateHold(ctx, models.BalanceWithdraw{Order: "2377225624", Sum: 500}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("storage error %v: err = %v, want %v", tt.storageErr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	st := &holdStorage{}
+	gm := newTestGMart(st, &settings.Settings{Withdraw: settings.WithdrawSettings{MaxPerOrder: 3}})
+
+	hold, err := gm.CaptureHold(ctx, 1, 300)
+	if err != nil {
+		t.Fatalf("CaptureHold: %v", err)
+	}
+
+	if hold.UserID != 7 || hold.Captured != 300 || st.limits[0].MaxPerOrder != 3 {
+		t.Errorf("hold = %+v, limits = %+v, want 300 captured by user 7 within withdraw limits", hold, st.limits[0])
+	}
+
+	for _, want := range []error{
+		models.ErrNotFound,
+		models.ErrHoldNotActive,
+		models.ErrInvalidInput,
+		models.ErrInsufficientBalance,
+		models.ErrOrderWithdrawn,
+		models.ErrOrderWithdrawalCap,
+	} {
+		gm := newTestGMart(&holdStorage{err: want}, nil)
+
+		if _, err := gm.CaptureHold(ctx, 1, 0); !errors.Is(err, want) || errors.Is(err, models.ErrInternal) {
+			t.Errorf("err = %v, want %v", err, want)
+		}
+	}
+
+	gm = newTestGMart(&holdStorage{err: errors.New("connection lost")}, nil)
+
+	if _, err := gm.CaptureHold(ctx, 1, 0); !errors.Is(err, models.ErrInternal) {
+		t.Errorf("storage failure: err = %v, want ErrInternal", err)
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	hold, err := newTestGMart(&holdStorage{}, nil).ReleaseHold(ctx, 1)
+	if err != nil || hold.UserID != 7 || hold.Status != models.HoldReleased {
+		t.Errorf("ReleaseHold = %+v, %v", hold, err)
+	}
+
+	for _, tt := range []struct {
+		storageErr error
+		wantErr    error
+	}{
+		{storageErr: models.ErrNotFound, wantErr: models.ErrNotFound},
+		{storageErr: models.ErrHoldNotActive, wantErr: models.ErrHoldNotActive},
+		{storageErr: errors.New("connection lost"), wantErr: models.ErrInternal},
+	} {
+		gm := newTestGMart(&holdStorage{err: tt.storageErr}, nil)
+
+		if _, err := gm.ReleaseHold(ctx, 1); !errors.Is(err, tt.wantErr) {
+			t.Errorf("storage error %v: err = %v, want %v", tt.storageErr, err, tt.wantErr)
+		}
+	}
+
+	if _, err := newTestGMart(&holdStorage{}, nil).ReleaseHold(context.Background(), 1); err == nil {
+		t.Error("released without token payload")
+	}
+}
+
+// TestExpireHoldsJob проверяет, что задача снимает просроченные удержания по каждому тику,
+// продолжает работу после ошибки хранилища и останавливается вместе с сервисом.
+func TestExpireHoldsJob(t *testing.T) {
+	st := &holdStorage{expired: make(chan struct{}), err: errors.New("connection lost")}
+	gm := newTestGMart(st, &settings.Settings{Hold: settings.HoldSettings{ExpirationInterval: 10 * time.Millisecond}})
+
+	done := make(chan struct{})
+
+	go func() {
+		gm.expireHolds()
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-st.expired:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expiration job made %d calls, want 3", i)
+		}
+	}
+
+	close(gm.doneCh)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expiration job did not stop")
+	}
+}
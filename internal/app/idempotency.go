@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyCleanupDuration = time.Hour
+	idempotencyCleanupTimeout  = time.Second * 10
+)
+
+// BeginIdempotentRequest занимает ключ Idempotency-Key текущего пользователя для запроса с хэшем requestHash.
+// Если ключ новый, возвращается запись с нулевым StatusCode и запрос нужно выполнить.
+// Если запрос с этим ключом уже выполнен, возвращается сохранённый ответ.
+// Ключ, использованный для другого запроса, даёт models.ErrIdempotencyKeyReused,
+// а ключ запроса, который ещё выполняется, models.ErrConflict.
+func (gm *GMart) BeginIdempotentRequest(ctx context.Context, key, requestHash string) (models.IdempotencyKey, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.IdempotencyKey{}, err
+	}
+
+	saved, created, err := gm.storage.SaveIdempotencyKey(ctx, models.IdempotencyKey{
+		UserID:      tokenPayload.UserID,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(gm.set.Idempotency.KeyTTL),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return models.IdempotencyKey{}, err
+		}
+
+		gm.log.Error("cannot save idempotency key", zap.Error(err))
+
+		return models.IdempotencyKey{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if created {
+		return saved, nil
+	}
+
+	if saved.RequestHash != requestHash {
+		return models.IdempotencyKey{}, models.ErrIdempotencyKeyReused
+	}
+
+	if saved.StatusCode == 0 {
+		return models.IdempotencyKey{}, models.ErrConflict
+	}
+
+	gm.log.Debug("replay idempotent response",
+		zap.Int("user id", saved.UserID),
+		zap.String("key", saved.Key),
+		zap.Int("status code", saved.StatusCode))
+
+	return saved, nil
+}
+
+// CompleteIdempotentRequest сохраняет ответ на запрос, чтобы вернуть его на повторные запросы с тем же ключом.
+func (gm *GMart) CompleteIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error {
+	if err := gm.storage.SaveIdempotentResponse(ctx, key); err != nil {
+		gm.log.Error("cannot save idempotent response", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// CancelIdempotentRequest освобождает ключ, например если запрос завершился ошибкой сервера
+// и клиент должен иметь возможность повторить его.
+func (gm *GMart) CancelIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error {
+	if err := gm.storage.DeleteIdempotencyKey(ctx, key.UserID, key.Key); err != nil {
+		gm.log.Error("cannot delete idempotency key", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// cleanupIdempotencyKeys периодически удаляет ключи с истёкшим сроком.
+func (gm *GMart) cleanupIdempotencyKeys() {
+	tick := time.NewTicker(idempotencyCleanupDuration)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-gm.doneCh:
+			return
+		case <-tick.C:
+			ctx, cancel := context.WithTimeout(context.Background(), idempotencyCleanupTimeout)
+
+			deleted, err := gm.storage.DeleteExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				gm.log.Warn("cannot delete expired idempotency keys", zap.Error(err))
+			} else if deleted > 0 {
+				gm.log.Debug("expired idempotency keys deleted", zap.Int64("count", deleted))
+			}
+
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type idempotencyStorage struct {
+	storage
+
+	keys map[string]models.IdempotencyKey
+}
+
+func (s *idempotencyStorage) SaveIdempotencyKey(
+	_ context.Context,
+	key models.IdempotencyKey,
+) (models.IdempotencyKey, bool, error) {
+	if saved, ok := s.keys[key.Key]; ok && saved.ExpiresAt.After(time.Now()) {
+		return saved, false, nil
+	}
+
+	s.keys[key.Key] = key
+
+	return key, true, nil
+}
+
+func (s *idempotencyStorage) SaveIdempotentResponse(_ context.Context, key models.IdempotencyKey) error {
+	s.keys[key.Key] = key
+
+	return nil
+}
+
+func TestBeginIdempotentRequest(t *testing.T) {
+	st := &idempotencyStorage{keys: make(map[string]models.IdempotencyKey)}
+	gm := newTestGMart(st, &settings.Settings{Idempotency: settings.IdempotencySettings{KeyTTL: time.Hour}})
+	ctx := withPayload(context.Background(), 1, models.RoleUser)
+
+	key, err := gm.BeginIdempotentRequest(ctx, "k1", "hash")
+	if err != nil {
+		t.Fatalf("BeginIdempotentRequest: %v", err)
+	}
+
+	if key.StatusCode != 0 || key.UserID != 1 || time.Until(key.ExpiresAt) < 59*time.Minute {
+		t.Fatalf("new key = %+v", key)
+	}
+
+	if _, err := gm.BeginIdempotentRequest(ctx, "k1", "hash"); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("request in progress: got %v, want ErrConflict", err)
+	}
+
+	key.StatusCode = 200
+	key.Response = []byte("{}")
+
+	if err := gm.CompleteIdempotentRequest(ctx, key); err != nil {
+		t.Fatalf("CompleteIdempotentRequest: %v", err)
+	}
+
+	saved, err := gm.BeginIdempotentRequest(ctx, "k1", "hash")
+	if err != nil || saved.StatusCode != 200 || string(saved.Response) != "{}" {
+		t.Fatalf("replay: %+v, %v", saved, err)
+	}
+
+	if _, err := gm.BeginIdempotentRequest(ctx, "k1", "other"); !errors.Is(err, models.ErrIdempotencyKeyReused) {
+		t.Fatalf("reused key: got %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// ключ с истёкшим сроком занимается заново
+	expired := st.keys["k1"]
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	st.keys["k1"] = expired
+
+	key, err = gm.BeginIdempotentRequest(ctx, "k1", "other")
+	if err != nil || key.StatusCode != 0 {
+		t.Fatalf("expired key: %+v, %v", key, err)
+	}
+
+	if _, err := gm.BeginIdempotentRequest(context.Background(), "k2", "hash"); err == nil {
+		t.Error("request without token payload is accepted")
+	}
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	loginKeyPrefix = "login:"
+	ipKeyPrefix    = "ip:"
+
+	// maxDelayShift ограничивает рост прогрессивной задержки, чтобы не переполнить time.Duration.
+	maxDelayShift = 16
+
+	// dummyPassword пароль, хэш которого сравнивается с введённым, если пользователь не найден.
+	dummyPassword = "gophermart-dummy-password"
+)
+
+// loginLimit описывает счётчик попыток входа и его предел.
+type loginLimit struct {
+	key         string
+	maxAttempts int
+}
+
+func (gm *GMart) loginLimits(ctx context.Context, login string) []loginLimit {
+	limits := []loginLimit{{
+		key:         loginKeyPrefix + login,
+		maxAttempts: gm.set.Login.MaxAttempts,
+	}}
+
+	if ip, ok := ctx.Value(models.CtxClientIP{}).(string); ok && ip != "" {
+		limits = append(limits, loginLimit{
+			key:         ipKeyPrefix + ip,
+			maxAttempts: gm.set.Login.IPMaxAttempts,
+		})
+	}
+
+	return limits
+}
+
+// reserveLoginAttempts проверяет, что для логина и ip адреса вход не заблокирован и выдержана задержка,
+// и засчитывает попытку как неудачную ещё до проверки пароля, чтобы параллельные попытки не обходили
+// задержку и блокировку. Возвращает счётчики с учётом этой попытки в порядке limits.
+func (gm *GMart) reserveLoginAttempts(ctx context.Context, limits []loginLimit) ([]models.LoginAttempts, error) {
+	reserved := make([]models.LoginAttempts, 0, len(limits))
+
+	for _, l := range limits {
+		attempts, err := gm.reserveLoginAttempt(ctx, l)
+		if err != nil {
+			gm.releaseLoginAttempts(ctx, limits[:len(reserved)])
+
+			return nil, err
+		}
+
+		reserved = append(reserved, attempts)
+	}
+
+	return reserved, nil
+}
+
+func (gm *GMart) reserveLoginAttempt(ctx context.Context, l loginLimit) (models.LoginAttempts, error) {
+	now := time.Now()
+
+	attempts, err := gm.storage.GetLoginAttempts(ctx, l.key)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return models.LoginAttempts{}, err
+	}
+
+	if attempts.LockedUntil != nil && now.Before(*attempts.LockedUntil) {
+		gm.log.Info("login is locked", zap.String("key", l.key), zap.Time("until", *attempts.LockedUntil))
+
+		return models.LoginAttempts{}, models.ErrTooManyAttempts
+	}
+
+	if failures := gm.recentLoginFailures(attempts, now); failures > 0 {
+		// блокировку выставляет попытка, которая ещё не завершилась, но предел уже исчерпан
+		if l.maxAttempts > 0 && failures >= l.maxAttempts {
+			gm.log.Info("login attempts are exhausted", zap.String("key", l.key), zap.Int("failures", failures))
+
+			return models.LoginAttempts{}, models.ErrTooManyAttempts
+		}
+
+		if now.Before(attempts.LastFailureAt.Add(gm.loginDelay(failures))) {
+			gm.log.Info("login attempt is too early", zap.String("key", l.key), zap.Int("failures", failures))
+
+			return models.LoginAttempts{}, models.ErrTooManyAttempts
+		}
+	}
+
+	reserved, err := gm.storage.ReserveLoginAttempt(ctx, l.key, attempts.Failures, gm.set.Login.Window)
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			gm.log.Info("concurrent login attempt", zap.String("key", l.key))
+
+			return models.LoginAttempts{}, models.ErrTooManyAttempts
+		}
+
+		return models.LoginAttempts{}, err
+	}
+
+	return reserved, nil
+}
+
+// recentLoginFailures возвращает число неудачных попыток, которые ещё учитываются.
+// После окна или окончания блокировки счётчик начинается заново.
+func (gm *GMart) recentLoginFailures(attempts models.LoginAttempts, now time.Time) int {
+	if attempts.LastFailureAt == nil || now.Sub(*attempts.LastFailureAt) > gm.set.Login.Window {
+		return 0
+	}
+
+	if attempts.LockedUntil != nil && !now.Before(*attempts.LockedUntil) {
+		return 0
+	}
+
+	return attempts.Failures
+}
+
+// loginDelay возвращает задержку перед следующей попыткой. Задержка удваивается с каждой неудачей.
+func (gm *GMart) loginDelay(failures int) time.Duration {
+	if failures <= gm.set.Login.DelayAfter {
+		return 0
+	}
+
+	shift := failures - gm.set.Login.DelayAfter - 1
+	if shift > maxDelayShift {
+		shift = maxDelayShift
+	}
+
+	delay := gm.set.Login.Delay << shift
+	if delay > gm.set.Login.Lockout {
+		return gm.set.Login.Lockout
+	}
+
+	return delay
+}
+
+// addLoginFailure блокирует вход, если засчитанная заранее попытка оказалась неудачной и предел превышен.
+func (gm *GMart) addLoginFailure(ctx context.Context, limits []loginLimit, reserved []models.LoginAttempts) {
+	for i, l := range limits {
+		if l.maxAttempts <= 0 || reserved[i].Failures < l.maxAttempts {
+			continue
+		}
+
+		gm.log.Warn("lock login", zap.String("key", l.key), zap.Int("failures", reserved[i].Failures))
+
+		if err := gm.storage.LockLogin(ctx, l.key, time.Now().Add(gm.set.Login.Lockout)); err != nil {
+			gm.log.Error("cannot lock login", zap.String("key", l.key), zap.Error(err))
+		}
+	}
+}
+
+// releaseLoginAttempts возвращает засчитанные заранее попытки, которые не были неудачными.
+func (gm *GMart) releaseLoginAttempts(ctx context.Context, limits []loginLimit) {
+	for _, l := range limits {
+		if err := gm.storage.ReleaseLoginAttempt(ctx, l.key); err != nil {
+			gm.log.Error("cannot release login attempt", zap.String("key", l.key), zap.Error(err))
+		}
+	}
+}
+
+// resetLoginFailures сбрасывает счётчик для логина после успешного входа.
+// Счётчик ip адреса не сбрасывается, чтобы вход в свой аккаунт не снимал ограничение перебора чужих,
+// из него только возвращается засчитанная заранее попытка.
+func (gm *GMart) resetLoginFailures(ctx context.Context, login string, limits []loginLimit) {
+	for _, l := range limits {
+		if l.key != loginKeyPrefix+login {
+			gm.releaseLoginAttempts(ctx, []loginLimit{l})
+
+			continue
+		}
+
+		if err := gm.storage.ResetLoginAttempts(ctx, l.key); err != nil {
+			gm.log.Error("cannot reset login attempts", zap.String("login", login), zap.Error(err))
+		}
+	}
+}
+
+// UnlockUser снимает блокировку входа для логина. Доступно поддержке и администраторам,
+// снять блокировку учётной записи с повышенными правами может только администратор.
+func (gm *GMart) UnlockUser(ctx context.Context, login string) error {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageUsers)
+	if err != nil {
+		return err
+	}
+
+	if login == "" {
+		return models.ErrInvalidInput
+	}
+
+	// счётчик ведётся и для несуществующих логинов, его снятие ничего не открывает
+	u, err := gm.storage.GetUser(ctx, models.User{Login: login})
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if err == nil {
+		if err := gm.authorizeManagedUser(tokenPayload, u); err != nil {
+			return err
+		}
+	}
+
+	if err := gm.storage.ResetLoginAttempts(ctx, loginKeyPrefix+login); err != nil {
+		gm.log.Error("cannot unlock user", zap.String("login", login), zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("user unlocked", zap.String("login", login))
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type loginStorage struct {
+	storage
+
+	users    map[string]models.User
+	attempts map[string]models.LoginAttempts
+
+	// concurrent выполняется после чтения счётчика, как параллельная попытка входа
+	concurrent func()
+}
+
+func newLoginStorage(users ...models.User) *loginStorage {
+	st := &loginStorage{
+		users:    make(map[string]models.User),
+		attempts: make(map[string]models.LoginAttempts),
+	}
+
+	for i, u := range users {
+		u.ID = i + 1
+		u.Password = "plain:" + u.Password
+		st.users[u.Login] = u
+	}
+
+	return st
+}
+
+func (s *loginStorage) GetUser(_ context.Context, user models.User) (models.User, error) {
+	u, ok := s.users[user.Login]
+	if !ok {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (s *loginStorage) GetLoginAttempts(_ context.Context, key string) (models.LoginAttempts, error) {
+	a, ok := s.attempts[key]
+
+	if fn := s.concurrent; fn != nil {
+		s.concurrent = nil
+		fn()
+	}
+
+	if !ok {
+		return models.LoginAttempts{}, models.ErrNotFound
+	}
+
+	return a, nil
+}
+
+func (s *loginStorage) ReserveLoginAttempt(
+	_ context.Context, key string, failures int, window time.Duration,
+) (models.LoginAttempts, error) {
+	now := time.Now()
+
+	a, ok := s.attempts[key]
+	if ok && (a.Failures != failures || a.LockedUntil != nil && now.Before(*a.LockedUntil)) {
+		return models.LoginAttempts{}, models.ErrConflict
+	}
+
+	if a.LastFailureAt != nil && now.Sub(*a.LastFailureAt) > window || a.LockedUntil != nil {
+		a.Failures = 0
+		a.LockedUntil = nil
+	}
+
+	a.Key = key
+	a.Failures++
+	a.LastFailureAt = &now
+	s.attempts[key] = a
+
+	return a, nil
+}
+
+func (s *loginStorage) ReleaseLoginAttempt(_ context.Context, key string) error {
+	if a, ok := s.attempts[key]; ok && a.Failures > 0 {
+		a.Failures--
+		s.attempts[key] = a
+	}
+
+	return nil
+}
+
+func (s *loginStorage) LockLogin(_ context.Context, key string, until time.Time) error {
+	a := s.attempts[key]
+	a.LockedUntil = &until
+	s.attempts[key] = a
+
+	return nil
+}
+
+func (s *loginStorage) ResetLoginAttempts(_ context.Context, key string) error {
+	delete(s.attempts, key)
+
+	return nil
+}
+
+func loginSettings(maxAttempts, ipMaxAttempts int) *settings.Settings {
+	return &settings.Settings{Login: settings.LoginSettings{
+		MaxAttempts:   maxAttempts,
+		IPMaxAttempts: ipMaxAttempts,
+		DelayAfter:    100,
+		Delay:         time.Second,
+		Window:        time.Minute,
+		Lockout:       time.Minute,
+	}}
+}
+
+func withClientIP(ip string) context.Context {
+	return context.WithValue(context.Background(), models.CtxClientIP{}, ip)
+}
+
+func TestLoginDelay(t *testing.T) {
+	gm := newTestGMart(nil, &settings.Settings{Login: settings.LoginSettings{
+		DelayAfter: 2,
+		Delay:      time.Second,
+		Lockout:    10 * time.Second,
+	}})
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 2, want: 0},
+		{failures: 3, want: time.Second},
+		{failures: 4, want: 2 * time.Second},
+		{failures: 5, want: 4 * time.Second},
+		{failures: 7, want: 10 * time.Second},
+		{failures: 1000, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := gm.loginDelay(tt.failures); got != tt.want {
+			t.Errorf("loginDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	gm := newTestGMart(st, loginSettings(3, 0))
+	ctx := withClientIP("203.0.113.5")
+
+	for i := 0; i < 3; i++ {
+		_, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"})
+		if !errors.Is(err, models.ErrInvalidPassword) {
+			t.Fatalf("attempt %d: got %v, want ErrInvalidPassword", i+1, err)
+		}
+	}
+
+	_, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "secret"})
+	if !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("locked login: got %v, want ErrTooManyAttempts", err)
+	}
+}
+
+func TestLoginDelayAfterFailures(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	set := loginSettings(0, 0)
+	set.Login.DelayAfter = 1
+
+	gm := newTestGMart(st, set)
+	ctx := context.Background()
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"}); !errors.Is(err, models.ErrInvalidPassword) {
+		t.Fatalf("first attempt: got %v", err)
+	}
+
+	// после первой неудачи задержки нет
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"}); !errors.Is(err, models.ErrInvalidPassword) {
+		t.Fatalf("second attempt: got %v", err)
+	}
+
+	// после второй неудачи следующая попытка возможна только через Delay
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "secret"}); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("early attempt: got %v, want ErrTooManyAttempts", err)
+	}
+}
+
+func TestLoginIPLimit(t *testing.T) {
+	st := newLoginStorage(
+		models.User{Login: "alice", Password: "secret"},
+		models.User{Login: "bob", Password: "secret"},
+	)
+	gm := newTestGMart(st, loginSettings(0, 2))
+	attacker := withClientIP("203.0.113.5")
+
+	for _, login := range []string{"alice", "bob"} {
+		if _, err := gm.LoginUser(attacker, models.User{Login: login, Password: "wrong"}); !errors.Is(err, models.ErrInvalidPassword) {
+			t.Fatalf("%s: got %v, want ErrInvalidPassword", login, err)
+		}
+	}
+
+	if _, err := gm.LoginUser(attacker, models.User{Login: "alice", Password: "secret"}); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("locked ip: got %v, want ErrTooManyAttempts", err)
+	}
+
+	if _, err := gm.LoginUser(withClientIP("198.51.100.7"), models.User{Login: "alice", Password: "secret"}); err != nil {
+		t.Fatalf("other ip: got %v", err)
+	}
+}
+
+func TestLoginSuccessResetsLoginOnly(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	gm := newTestGMart(st, loginSettings(5, 20))
+	ctx := withClientIP("203.0.113.5")
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"}); !errors.Is(err, models.ErrInvalidPassword) {
+		t.Fatalf("wrong password: got %v", err)
+	}
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "secret"}); err != nil {
+		t.Fatalf("login: got %v", err)
+	}
+
+	if _, ok := st.attempts[loginKeyPrefix+"alice"]; ok {
+		t.Error("login counter is not reset after successful login")
+	}
+
+	if a := st.attempts[ipKeyPrefix+"203.0.113.5"]; a.Failures != 1 {
+		t.Errorf("ip failures = %d, want 1", a.Failures)
+	}
+}
+
+func TestLoginUnknownUserCountsFailure(t *testing.T) {
+	st := newLoginStorage()
+	gm := newTestGMart(st, loginSettings(5, 20))
+
+	if _, err := gm.LoginUser(context.Background(), models.User{Login: "ghost", Password: "secret"}); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+
+	if a := st.attempts[loginKeyPrefix+"ghost"]; a.Failures != 1 {
+		t.Errorf("failures = %d, want 1", a.Failures)
+	}
+}
+
+// TestLoginConcurrentAttempts проверяет, что параллельные попытки не проверяют пароль по одному и тому же счётчику.
+func TestLoginConcurrentAttempts(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	gm := newTestGMart(st, loginSettings(1, 0))
+	ctx := context.Background()
+
+	var concurrentErr error
+
+	st.concurrent = func() {
+		_, concurrentErr = gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"})
+	}
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "secret"}); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Errorf("attempt with a stale counter: got %v, want ErrTooManyAttempts", err)
+	}
+
+	if !errors.Is(concurrentErr, models.ErrInvalidPassword) {
+		t.Errorf("concurrent attempt: got %v, want ErrInvalidPassword", concurrentErr)
+	}
+
+	if a := st.attempts[loginKeyPrefix+"alice"]; a.Failures != 1 || a.LockedUntil == nil {
+		t.Errorf("attempts = %+v, want one failure and a lock", a)
+	}
+}
+
+// TestLoginExhaustedBeforeLock проверяет, что исчерпанный предел блокирует вход,
+// даже если неудачная попытка ещё не успела выставить блокировку.
+func TestLoginExhaustedBeforeLock(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	gm := newTestGMart(st, loginSettings(3, 0))
+
+	now := time.Now()
+	st.attempts[loginKeyPrefix+"alice"] = models.LoginAttempts{Key: loginKeyPrefix + "alice", Failures: 3, LastFailureAt: &now}
+
+	if _, err := gm.LoginUser(context.Background(), models.User{Login: "alice", Password: "secret"}); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("got %v, want ErrTooManyAttempts", err)
+	}
+}
+
+// TestLoginLockoutExpired проверяет, что после окончания блокировки счётчик начинается заново
+// и первая неудача не блокирует вход снова.
+func TestLoginLockoutExpired(t *testing.T) {
+	st := newLoginStorage(models.User{Login: "alice", Password: "secret"})
+	gm := newTestGMart(st, loginSettings(3, 0))
+	ctx := context.Background()
+
+	now := time.Now()
+	expired := now.Add(-time.Second)
+	st.attempts[loginKeyPrefix+"alice"] = models.LoginAttempts{
+		Key: loginKeyPrefix + "alice", Failures: 3, LastFailureAt: &now, LockedUntil: &expired,
+	}
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "wrong"}); !errors.Is(err, models.ErrInvalidPassword) {
+		t.Fatalf("wrong password: got %v, want ErrInvalidPassword", err)
+	}
+
+	if a := st.attempts[loginKeyPrefix+"alice"]; a.Failures != 1 || a.LockedUntil != nil {
+		t.Errorf("attempts = %+v, want a new window with one failure", a)
+	}
+
+	if _, err := gm.LoginUser(ctx, models.User{Login: "alice", Password: "secret"}); err != nil {
+		t.Fatalf("login: got %v", err)
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	st := newLoginStorage(
+		models.User{Login: "alice", Password: "secret", Role: models.RoleUser},
+		models.User{Login: "root", Password: "secret", Role: models.RoleAdmin},
+	)
+	gm := newTestGMart(st, loginSettings(1, 0))
+
+	until := time.Now().Add(time.Hour)
+
+	for _, login := range []string{"alice", "root", "ghost"} {
+		st.attempts[loginKeyPrefix+login] = models.LoginAttempts{Key: loginKeyPrefix + login, Failures: 1, LockedUntil: &until}
+	}
+
+	support := withPayload(context.Background(), 3, models.RoleSupport)
+
+	if err := gm.UnlockUser(withPayload(context.Background(), 1, models.RoleUser), "alice"); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("user unlocks: got %v, want ErrForbidden", err)
+	}
+
+	for _, login := range []string{"alice", "ghost"} {
+		if err := gm.UnlockUser(support, login); err != nil {
+			t.Errorf("support unlocks %s: %v", login, err)
+		}
+
+		if _, ok := st.attempts[loginKeyPrefix+login]; ok {
+			t.Errorf("%s is still locked", login)
+		}
+	}
+
+	// разблокировав администратора, поддержка могла бы подбирать его пароль
+	if err := gm.UnlockUser(support, "root"); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("support unlocks admin: got %v, want ErrForbidden", err)
+	}
+
+	if _, ok := st.attempts[loginKeyPrefix+"root"]; !ok {
+		t.Error("admin is unlocked by support")
+	}
+
+	if err := gm.UnlockUser(withPayload(context.Background(), 2, models.RoleAdmin), "root"); err != nil {
+		t.Errorf("admin unlocks admin: %v", err)
+	}
+}
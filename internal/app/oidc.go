@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/crypt"
+	"gophermat/internal/models"
+	"gophermat/internal/oidc"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+var errOIDCDisabled = errors.New("oidc login is not configured")
+
+type oidcProvider interface {
+	Issuer() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier string) (oidc.Claims, error)
+}
+
+// EnableOIDC включает вход через внешнего OpenID Connect провайдера.
+func (gm *GMart) EnableOIDC(provider oidcProvider) {
+	gm.oidc = provider
+}
+
+// OIDCAuthURL начинает вход через провайдера: сохраняет state, nonce и PKCE verifier
+// и возвращает адрес страницы входа провайдера.
+func (gm *GMart) OIDCAuthURL(ctx context.Context) (string, error) {
+	if gm.oidc == nil {
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, errOIDCDisabled)
+	}
+
+	st := models.OIDCState{
+		ExpiresAt: time.Now().Add(gm.set.OIDC.StateTTL),
+	}
+
+	for _, v := range []*string{&st.State, &st.Nonce, &st.CodeVerifier} {
+		token, err := crypt.RandomToken()
+		if err != nil {
+			gm.log.Error("cannot generate oidc state", zap.Error(err))
+
+			return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		*v = token
+	}
+
+	if err := gm.storage.SaveOIDCState(ctx, st); err != nil {
+		gm.log.Error("cannot save oidc state", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	u, err := gm.oidc.AuthCodeURL(ctx, st.State, st.Nonce, st.CodeVerifier)
+	if err != nil {
+		gm.log.Error("cannot get oidc auth url", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return u, nil
+}
+
+// OIDCCallback завершает вход через провайдера: проверяет state и id токен, находит или создаёт
+// связанного пользователя и выдаёт токен gophermart.
+func (gm *GMart) OIDCCallback(ctx context.Context, code, state string) (string, error) {
+	if gm.oidc == nil {
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, errOIDCDisabled)
+	}
+
+	if code == "" || state == "" {
+		return "", models.ErrInvalidInput
+	}
+
+	st, err := gm.storage.TakeOIDCState(ctx, state)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			gm.log.Info("oidc state is unknown or expired")
+
+			return "", models.ErrInvalidToken
+		}
+
+		gm.log.Error("cannot get oidc state", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	claims, err := gm.oidc.Exchange(ctx, code, st.CodeVerifier)
+	if err != nil {
+		gm.log.Info("cannot exchange oidc code", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInvalidToken, err)
+	}
+
+	if claims.Nonce != st.Nonce {
+		gm.log.Info("oidc nonce mismatch", zap.String("subject", claims.Subject))
+
+		return "", models.ErrInvalidToken
+	}
+
+	u, err := gm.storage.GetUserByIdentity(ctx, gm.oidc.Issuer(), claims.Subject)
+	if err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			gm.log.Error("cannot get user by identity", zap.Error(err))
+
+			return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		u, err = gm.registerIdentityUser(ctx, claims)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID, TokenVersion: u.TokenVersion, Role: u.Role})
+	if err != nil {
+		gm.log.Error("cannot generate token", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return token, nil
+}
+
+// registerIdentityUser создаёт пользователя для учётной записи провайдера.
+// Пароль пользователю не задаётся: хранится хэш случайной строки, войти по паролю можно только после сброса.
+func (gm *GMart) registerIdentityUser(ctx context.Context, claims oidc.Claims) (models.User, error) {
+	login := claims.Subject
+	if claims.PreferredUsername != "" {
+		login = claims.PreferredUsername
+	}
+
+	if claims.Email != "" && claims.EmailVerified {
+		login = claims.Email
+	}
+
+	random, err := crypt.RandomToken()
+	if err != nil {
+		gm.log.Error("cannot generate password", zap.Error(err))
+
+		return models.User{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	passHash, err := gm.hasher.HashPassword(random)
+	if err != nil {
+		gm.log.Error("cannot hash password", zap.Error(err))
+
+		return models.User{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	u, err := gm.storage.RegisterIdentityUser(ctx, models.User{
+		Login:    login,
+		Password: passHash,
+	}, models.Identity{
+		Issuer:  gm.oidc.Issuer(),
+		Subject: claims.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			// не связываем автоматически с существующим локальным пользователем, чтобы провайдер
+			// не мог получить доступ к чужому аккаунту
+			gm.log.Info("login of oidc user is already registered", zap.String("login", login))
+
+			return models.User{}, err
+		}
+
+		gm.log.Error("cannot register oidc user", zap.Error(err))
+
+		return models.User{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("oidc user registered", zap.Int("user id", u.ID), zap.String("subject", claims.Subject))
+
+	return u, nil
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/golang-jwt/jwt/v5"
+
+	"gophermat/internal/models"
+	"gophermat/internal/oidc"
+)
+
+type oidcStorage struct {
+	storage
+
+	states     map[string]models.OIDCState
+	identities map[string]models.User
+	logins     map[string]bool
+}
+
+func (s *oidcStorage) SaveOIDCState(_ context.Context, state models.OIDCState) error {
+	s.states[state.State] = state
+
+	return nil
+}
+
+func (s *oidcStorage) TakeOIDCState(_ context.Context, state string) (models.OIDCState, error) {
+	st, ok := s.states[state]
+	if !ok {
+		return models.OIDCState{}, models.ErrNotFound
+	}
+
+	delete(s.states, state)
+
+	return st, nil
+}
+
+func (s *oidcStorage) GetUserByIdentity(_ context.Context, issuer, subject string) (models.User, error) {
+	u, ok := s.identities[issuer+" "+subject]
+	if !ok {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (s *oidcStorage) RegisterIdentityUser(_ context.Context, user models.User, identity models.Identity) (models.User, error) {
+	if s.logins[user.Login] {
+		return models.User{}, models.ErrConflict
+	}
+
+	user.ID = len(s.identities) + 1
+	s.logins[user.Login] = true
+	s.identities[identity.Issuer+" "+identity.Subject] = user
+
+	return user, nil
+}
+
+// testProvider возвращает заданные claims для любого кода и запоминает переданный PKCE verifier.
+type testProvider struct {
+	claims   oidc.Claims
+	err      error
+	verifier string
+}
+
+func (p *testProvider) Issuer() string {
+	return "https://idp.example.com"
+}
+
+func (p *testProvider) AuthCodeURL(_ context.Context, state, nonce, codeVerifier string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + state + "&nonce=" + nonce, nil
+}
+
+func (p *testProvider) Exchange(_ context.Context, code, codeVerifier string) (oidc.Claims, error) {
+	p.verifier = codeVerifier
+
+	return p.claims, p.err
+}
+
+func newOIDCGMart(claims oidc.Claims) (*GMart, *oidcStorage, *testProvider) {
+	st := &oidcStorage{
+		states:     make(map[string]models.OIDCState),
+		identities: make(map[string]models.User),
+		logins:     map[string]bool{"taken@example.com": true},
+	}
+
+	p := &testProvider{claims: claims}
+
+	gm := newTestGMart(st, nil)
+	gm.EnableOIDC(p)
+
+	return gm, st, p
+}
+
+// startOIDCLogin начинает вход и возвращает сохранённое состояние.
+func startOIDCLogin(t *testing.T, gm *GMart, st *oidcStorage) models.OIDCState {
+	t.Helper()
+
+	if _, err := gm.OIDCAuthURL(context.Background()); err != nil {
+		t.Fatalf("OIDCAuthURL: %v", err)
+	}
+
+	if len(st.states) != 1 {
+		t.Fatalf("states = %d, want 1", len(st.states))
+	}
+
+	for _, s := range st.states {
+		return s
+	}
+
+	return models.OIDCState{}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	claims := oidc.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-1"},
+		Email:            "alice@example.com",
+		EmailVerified:    true,
+	}
+
+	gm, st, p := newOIDCGMart(claims)
+
+	state := startOIDCLogin(t, gm, st)
+	p.claims.Nonce = state.Nonce
+
+	if _, err := gm.OIDCCallback(context.Background(), "code", state.State); err != nil {
+		t.Fatalf("OIDCCallback: %v", err)
+	}
+
+	if p.verifier != state.CodeVerifier {
+		t.Error("code verifier of the state is not passed to the provider")
+	}
+
+	if u, ok := st.identities["https://idp.example.com subject-1"]; !ok || u.Login != "alice@example.com" {
+		t.Errorf("identity user = %+v", u)
+	}
+
+	// state одноразовый
+	if _, err := gm.OIDCCallback(context.Background(), "code", state.State); !errors.Is(err, models.ErrInvalidToken) {
+		t.Fatalf("reused state: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestOIDCCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  oidc.Claims
+		nonce   func(state models.OIDCState) string
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "nonce mismatch",
+			claims:  oidc.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-1"}},
+			nonce:   func(models.OIDCState) string { return "other" },
+			wantErr: models.ErrInvalidToken,
+		},
+		{
+			name:    "empty nonce",
+			claims:  oidc.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-1"}},
+			nonce:   func(models.OIDCState) string { return "" },
+			wantErr: models.ErrInvalidToken,
+		},
+		{
+			name:    "invalid id token",
+			claims:  oidc.Claims{},
+			nonce:   func(s models.OIDCState) string { return s.Nonce },
+			err:     oidc.ErrIDToken,
+			wantErr: models.ErrInvalidToken,
+		},
+		{
+			name: "login of local user",
+			claims: oidc.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-2"},
+				Email:            "taken@example.com",
+				EmailVerified:    true,
+			},
+			nonce:   func(s models.OIDCState) string { return s.Nonce },
+			wantErr: models.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gm, st, p := newOIDCGMart(tt.claims)
+
+			state := startOIDCLogin(t, gm, st)
+			p.claims.Nonce = tt.nonce(state)
+			p.err = tt.err
+
+			if _, err := gm.OIDCCallback(context.Background(), "code", state.State); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if len(st.identities) != 0 {
+				t.Error("user is registered after rejected login")
+			}
+		})
+	}
+
+	gm, _, _ := newOIDCGMart(oidc.Claims{})
+
+	if _, err := gm.OIDCCallback(context.Background(), "code", "unknown"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Fatalf("unknown state: got %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/crypt"
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// ChangePassword меняет пароль текущего пользователя и отзывает все выданные ему токены.
+// Возвращает новый токен, так как текущий после смены пароля становится недействительным.
+func (gm *GMart) ChangePassword(ctx context.Context, oldPassword, newPassword string) (string, error) {
+	// получаем id пользователя
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return "", err
+	}
+
+	u, err := gm.storage.GetUserByID(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if err := gm.hasher.CheckPassword(oldPassword, u.Password); err != nil {
+		gm.log.Info("cannot change password: old password is not correct", zap.Int("user id", u.ID))
+
+		return "", models.ErrInvalidPassword
+	}
+
+	nu := models.User{Login: u.Login, Password: newPassword}
+	if err := nu.ValidateWithPolicy(gm.password); err != nil {
+		gm.log.Info("cannot input validate", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
+	}
+
+	passHash, err := gm.hasher.HashPassword(newPassword)
+	if err != nil {
+		gm.log.Error("cannot hash password", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	version, err := gm.storage.UpdatePassword(ctx, u.ID, passHash)
+	if err != nil {
+		gm.log.Error("cannot update password", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("password changed", zap.Int("user id", u.ID))
+
+	token, err := gm.auth.GenerateToken(models.TokenPayload{UserID: u.ID, TokenVersion: version, Role: u.Role})
+	if err != nil {
+		gm.log.Error("cannot generate token", zap.Error(err))
+
+		return "", fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return token, nil
+}
+
+// IssuePasswordReset выдаёт одноразовый токен для сброса пароля пользователя. Доступно поддержке и администраторам,
+// сбросить пароль учётной записи с повышенными правами может только администратор.
+func (gm *GMart) IssuePasswordReset(ctx context.Context, login string) (models.PasswordReset, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageUsers)
+	if err != nil {
+		return models.PasswordReset{}, err
+	}
+
+	if login == "" {
+		return models.PasswordReset{}, models.ErrInvalidInput
+	}
+
+	u, err := gm.storage.GetUser(ctx, models.User{Login: login})
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			gm.log.Info("cannot issue password reset: user not found", zap.String("login", login))
+
+			return models.PasswordReset{}, err
+		}
+
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return models.PasswordReset{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if err := gm.authorizeManagedUser(tokenPayload, u); err != nil {
+		return models.PasswordReset{}, err
+	}
+
+	token, err := crypt.RandomToken()
+	if err != nil {
+		gm.log.Error("cannot generate reset token", zap.Error(err))
+
+		return models.PasswordReset{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	reset := models.PasswordReset{
+		UserID:    u.ID,
+		Token:     token,
+		TokenHash: crypt.HashToken(token),
+		ExpiresAt: time.Now().Add(gm.set.Password.ResetTTL),
+	}
+
+	if err := gm.storage.SavePasswordReset(ctx, reset); err != nil {
+		gm.log.Error("cannot save password reset", zap.Error(err))
+
+		return models.PasswordReset{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("password reset issued", zap.Int("user id", u.ID), zap.Time("expires at", reset.ExpiresAt))
+
+	return reset, nil
+}
+
+// ResetPassword устанавливает новый пароль по одноразовому токену и отзывает все выданные пользователю токены.
+func (gm *GMart) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return models.ErrInvalidToken
+	}
+
+	if err := gm.password.Validate(newPassword); err != nil {
+		gm.log.Info("cannot input validate", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
+	}
+
+	passHash, err := gm.hasher.HashPassword(newPassword)
+	if err != nil {
+		gm.log.Error("cannot hash password", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if err := gm.storage.ResetPassword(ctx, crypt.HashToken(token), passHash); err != nil {
+		if errors.Is(err, models.ErrInvalidToken) {
+			gm.log.Info("password reset token is invalid or expired")
+
+			return err
+		}
+
+		gm.log.Error("cannot reset password", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return nil
+}
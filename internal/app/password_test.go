@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"gophermat/internal/crypt"
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type passwordStorage struct {
+	storage
+
+	user   models.User
+	resets map[string]models.PasswordReset
+}
+
+func (s *passwordStorage) GetUserByID(_ context.Context, userID int) (models.User, error) {
+	if userID != s.user.ID {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return s.user, nil
+}
+
+func (s *passwordStorage) GetUser(_ context.Context, user models.User) (models.User, error) {
+	if user.Login != s.user.Login {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return s.user, nil
+}
+
+func (s *passwordStorage) UpdatePassword(_ context.Context, userID int, password string) (int, error) {
+	s.user.Password = password
+	s.user.TokenVersion++
+
+	return s.user.TokenVersion, nil
+}
+
+func (s *passwordStorage) SavePasswordReset(_ context.Context, reset models.PasswordReset) error {
+	s.resets[reset.TokenHash] = reset
+
+	return nil
+}
+
+func (s *passwordStorage) ResetPassword(_ context.Context, tokenHash, password string) error {
+	reset, ok := s.resets[tokenHash]
+	if !ok || time.Now().After(reset.ExpiresAt) {
+		return models.ErrInvalidToken
+	}
+
+	delete(s.resets, tokenHash)
+
+	s.user.Password = password
+	s.user.TokenVersion++
+
+	return nil
+}
+
+func newPasswordGMart() (*GMart, *passwordStorage) {
+	st := &passwordStorage{
+		user:   models.User{ID: 1, Login: "alice", Password: "plain:old-secret", Role: models.RoleUser},
+		resets: make(map[string]models.PasswordReset),
+	}
+
+	gm := newTestGMart(st, &settings.Settings{Password: settings.PasswordSettings{
+		MinLen:   8,
+		Banned:   []string{"password123"},
+		ResetTTL: time.Hour,
+	}})
+
+	return gm, st
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := withPayload(context.Background(), 1, models.RoleUser)
+
+	tests := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		wantErr     error
+	}{
+		{name: "wrong old password", oldPassword: "wrong", newPassword: "new-secret", wantErr: models.ErrInvalidPassword},
+		{name: "too short", oldPassword: "old-secret", newPassword: "short", wantErr: models.ErrInvalidInput},
+		{name: "banned", oldPassword: "old-secret", newPassword: "Password123", wantErr: models.ErrInvalidInput},
+		{name: "changed", oldPassword: "old-secret", newPassword: "new-secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gm, st := newPasswordGMart()
+
+			_, err := gm.ChangePassword(ctx, tt.oldPassword, tt.newPassword)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+
+				if st.user.TokenVersion != 0 {
+					t.Error("tokens are revoked after failed change")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if st.user.Password != "plain:"+tt.newPassword {
+				t.Errorf("password hash = %q", st.user.Password)
+			}
+
+			if st.user.TokenVersion != 1 {
+				t.Errorf("token version = %d, want 1", st.user.TokenVersion)
+			}
+		})
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	gm, st := newPasswordGMart()
+
+	if _, err := gm.IssuePasswordReset(withPayload(context.Background(), 2, models.RoleUser), "alice"); !errors.Is(err, models.ErrForbidden) {
+		t.Fatalf("user issued reset: got %v, want ErrForbidden", err)
+	}
+
+	support := withPayload(context.Background(), 2, models.RoleSupport)
+
+	if _, err := gm.IssuePasswordReset(support, "bob"); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown login: got %v, want ErrNotFound", err)
+	}
+
+	reset, err := gm.IssuePasswordReset(support, "alice")
+	if err != nil {
+		t.Fatalf("issue reset: %v", err)
+	}
+
+	if _, ok := st.resets[crypt.HashToken(reset.Token)]; !ok {
+		t.Fatal("only the token hash must be stored")
+	}
+
+	if err := gm.ResetPassword(context.Background(), reset.Token, "short"); !errors.Is(err, models.ErrInvalidInput) {
+		t.Fatalf("weak password: got %v, want ErrInvalidInput", err)
+	}
+
+	if err := gm.ResetPassword(context.Background(), reset.Token, "new-secret"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	if st.user.Password != "plain:new-secret" || st.user.TokenVersion != 1 {
+		t.Errorf("user after reset = %+v", st.user)
+	}
+
+	if err := gm.ResetPassword(context.Background(), reset.Token, "other-secret"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Fatalf("reused token: got %v, want ErrInvalidToken", err)
+	}
+
+	if err := gm.ResetPassword(context.Background(), "", "other-secret"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Fatalf("empty token: got %v, want ErrInvalidToken", err)
+	}
+}
+
+// TestPasswordResetUnknownLogin проверяет, что опечатка в логине не логируется как внутренняя ошибка.
+func TestPasswordResetUnknownLogin(t *testing.T) {
+	gm, _ := newPasswordGMart()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	gm.log = zap.New(core)
+
+	support := withPayload(context.Background(), 2, models.RoleSupport)
+
+	if _, err := gm.IssuePasswordReset(support, "bob"); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown login: got %v, want ErrNotFound", err)
+	}
+
+	if errs := logs.FilterLevelExact(zapcore.ErrorLevel).All(); len(errs) != 0 {
+		t.Errorf("unknown login logged at error level: %v", errs)
+	}
+}
+
+// TestPasswordResetPrivilegedUser проверяет, что поддержка не может сбросить пароль администратора или магазина.
+func TestPasswordResetPrivilegedUser(t *testing.T) {
+	for _, role := range []models.Role{models.RoleAdmin, models.RoleSupport, models.RoleService} {
+		gm, st := newPasswordGMart()
+		st.user.Role = role
+
+		support := withPayload(context.Background(), 2, models.RoleSupport)
+
+		if _, err := gm.IssuePasswordReset(support, "alice"); !errors.Is(err, models.ErrForbidden) {
+			t.Errorf("support resets %s: got %v, want ErrForbidden", role, err)
+		}
+
+		if len(st.resets) != 0 {
+			t.Errorf("support resets %s: token is saved", role)
+		}
+
+		admin := withPayload(context.Background(), 3, models.RoleAdmin)
+
+		if _, err := gm.IssuePasswordReset(admin, "alice"); err != nil {
+			t.Errorf("admin resets %s: %v", role, err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// promoAlphabet символы случайных кодов без похожих друг на друга 0, O, 1 и I.
+	promoAlphabet    = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	promoCodeLen     = 10
+	maxPromoCodeLen  = 32
+	maxPromoCount    = 10000
+	promoGenAttempts = 3
+)
+
+// RedeemPromoCode начисляет текущему пользователю баллы по коду. Регистр кода не учитывается.
+func (gm *GMart) RedeemPromoCode(ctx context.Context, code string) (models.PromoRedemption, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.PromoRedemption{}, err
+	}
+
+	code = normalizePromoCode(code)
+	if code == "" || len(code) > maxPromoCodeLen {
+		return models.PromoRedemption{}, models.ErrInvalidInput
+	}
+
+	r, err := gm.storage.RedeemPromoCode(ctx, code, tokenPayload.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound),
+			errors.Is(err, models.ErrPromoExpired),
+			errors.Is(err, models.ErrPromoExhausted),
+			errors.Is(err, models.ErrPromoAlreadyRedeemed):
+			gm.log.Info("promo code is not redeemed", zap.Int("user id", tokenPayload.UserID), zap.Error(err))
+
+			return models.PromoRedemption{}, err
+		default:
+			gm.log.Error("cannot redeem promo code", zap.Error(err))
+
+			return models.PromoRedemption{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+	}
+
+	gm.log.Info("promo code redeemed",
+		zap.String("code", r.Code),
+		zap.Int("user id", r.UserID),
+		zap.Int("amount", r.Amount))
+
+	return r, nil
+}
+
+// GeneratePromoCodes выпускает коды с одинаковыми условиями. Случайные коды уникальны,
+// при совпадении с уже существующим кодом выпуск повторяется.
+func (gm *GMart) GeneratePromoCodes(ctx context.Context, req models.PromoGenerate) (models.PromoBatch, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManagePromoCodes)
+	if err != nil {
+		return models.PromoBatch{}, err
+	}
+
+	req.Code = normalizePromoCode(req.Code)
+	req.Prefix = normalizePromoCode(req.Prefix)
+
+	if req.Code != "" {
+		req.Count = 1
+	}
+
+	if strings.TrimSpace(req.Name) == "" || req.Count <= 0 || req.Count > maxPromoCount || req.Points <= 0 ||
+		req.MaxRedemptions < 0 || req.PerUserLimit < 0 ||
+		len(req.Code) > maxPromoCodeLen || len(req.Prefix)+promoCodeLen > maxPromoCodeLen {
+		return models.PromoBatch{}, models.ErrInvalidInput
+	}
+
+	for attempt := 1; ; attempt++ {
+		batch := models.PromoBatch{
+			Name:      req.Name,
+			CreatedBy: tokenPayload.UserID,
+			Codes:     make([]models.PromoCode, 0, req.Count),
+		}
+
+		codes, err := promoCodes(req)
+		if err != nil {
+			return models.PromoBatch{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		for _, code := range codes {
+			batch.Codes = append(batch.Codes, models.PromoCode{
+				Code:           code,
+				Points:         req.Points,
+				MaxRedemptions: req.MaxRedemptions,
+				PerUserLimit:   req.PerUserLimit,
+				ExpiresAt:      req.ExpiresAt,
+			})
+		}
+
+		batch, err = gm.storage.AddPromoBatch(ctx, batch)
+		if err == nil {
+			gm.log.Info("promo codes generated",
+				zap.Int64("batch id", batch.ID),
+				zap.Int("count", len(batch.Codes)),
+				zap.Int("operator id", tokenPayload.UserID))
+
+			return batch, nil
+		}
+
+		if !errors.Is(err, models.ErrConflict) {
+			gm.log.Error("cannot save promo codes", zap.Error(err))
+
+			return models.PromoBatch{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+		}
+
+		if req.Code != "" || attempt == promoGenAttempts {
+			return models.PromoBatch{}, err
+		}
+	}
+}
+
+// ExportPromoBatch возвращает выпуск со всеми кодами и количеством их использований.
+func (gm *GMart) ExportPromoBatch(ctx context.Context, id int64) (models.PromoBatch, error) {
+	if _, err := gm.authorize(ctx, models.PermManagePromoCodes); err != nil {
+		return models.PromoBatch{}, err
+	}
+
+	batch, err := gm.storage.GetPromoBatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.PromoBatch{}, err
+		}
+
+		gm.log.Error("cannot get promo batch", zap.Error(err))
+
+		return models.PromoBatch{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return batch, nil
+}
+
+// promoCodes возвращает заданный код или случайные коды без повторов.
+func promoCodes(req models.PromoGenerate) ([]string, error) {
+	if req.Code != "" {
+		return []string{req.Code}, nil
+	}
+
+	codes := make([]string, 0, req.Count)
+	seen := make(map[string]struct{}, req.Count)
+
+	for len(codes) < req.Count {
+		random, err := randomCode(promoCodeLen)
+		if err != nil {
+			return nil, err
+		}
+
+		code := req.Prefix + random
+		if _, ok := seen[code]; ok {
+			continue
+		}
+
+		seen[code] = struct{}{}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// randomCode возвращает случайный код из n символов алфавита promoAlphabet.
+func randomCode(n int) (string, error) {
+	buf := make([]byte, n)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("cannot generate code: %w", err)
+	}
+
+	// в алфавите 32 символа, поэтому младшие 5 бит байта выбирают символ равновероятно
+	for i := range buf {
+		buf[i] = promoAlphabet[buf[i]&31]
+	}
+
+	return string(buf), nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+type promoStorage struct {
+	storage
+
+	conflicts int
+	batches   []models.PromoBatch
+	redeemed  []string
+	redeemErr error
+}
+
+func (s *promoStorage) AddPromoBatch(_ context.Context, batch models.PromoBatch) (models.PromoBatch, error) {
+	s.batches = append(s.batches, batch)
+
+	if s.conflicts > 0 {
+		s.conflicts--
+
+		return models.PromoBatch{}, models.ErrConflict
+	}
+
+	batch.ID = int64(len(s.batches))
+
+	return batch, nil
+}
+
+func (s *promoStorage) RedeemPromoCode(_ context.Context, code string, userID int) (models.PromoRedemption, error) {
+	s.redeemed = append(s.redeemed, code)
+
+	if s.redeemErr != nil {
+		return models.PromoRedemption{}, s.redeemErr
+	}
+
+	return models.PromoRedemption{Code: code, UserID: userID, Amount: 100}, nil
+}
+
+func TestRedeemPromoCode(t *testing.T) {
+	st := &promoStorage{}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	r, err := gm.RedeemPromoCode(ctx, "  welcome10 ")
+	if err != nil {
+		t.Fatalf("RedeemPromoCode: %v", err)
+	}
+
+	if r.Code != "WELCOME10" || r.UserID != 7 || st.redeemed[0] != "WELCOME10" {
+		t.Errorf("redemption = %+v, storage got %v, want normalized code of user 7", r, st.redeemed)
+	}
+
+	// пустой и слишком длинный код не доходят до хранилища
+	for _, code := range []string{"", "   ", strings.Repeat("A", maxPromoCodeLen+1)} {
+		if _, err := gm.RedeemPromoCode(ctx, code); !errors.Is(err, models.ErrInvalidInput) {
+			t.Errorf("code %q: err = %v, want ErrInvalidInput", code, err)
+		}
+	}
+
+	if len(st.redeemed) != 1 {
+		t.Errorf("storage calls = %d, want 1", len(st.redeemed))
+	}
+
+	if _, err := gm.RedeemPromoCode(context.Background(), "WELCOME10"); err == nil {
+		t.Error("redeemed without token payload")
+	}
+}
+
+func TestRedeemPromoCodeErrors(t *testing.T) {
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	for _, want := range []error{
+		models.ErrNotFound,
+		models.ErrPromoExpired,
+		models.ErrPromoExhausted,
+		models.ErrPromoAlreadyRedeemed,
+	} {
+		gm := newTestGMart(&promoStorage{redeemErr: want}, nil)
+
+		if _, err := gm.RedeemPromoCode(ctx, "CODE"); !errors.Is(err, want) || errors.Is(err, models.ErrInternal) {
+			t.Errorf("err = %v, want %v", err, want)
+		}
+	}
+
+	gm := newTestGMart(&promoStorage{redeemErr: errors.New("connection lost")}, nil)
+
+	if _, err := gm.RedeemPromoCode(ctx, "CODE"); !errors.Is(err, models.ErrInternal) {
+		t.Errorf("storage failure: err = %v, want ErrInternal", err)
+	}
+}
+
+func TestGeneratePromoCodes(t *testing.T) {
+	st := &promoStorage{}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 1, models.RoleAdmin)
+
+	batch, err := gm.GeneratePromoCodes(ctx, models.PromoGenerate{
+		Name:         "black friday",
+		Count:        50,
+		Prefix:       "bf-",
+		Points:       500,
+		PerUserLimit: 1,
+	})
+	if err != nil {
+		t.Fatalf("GeneratePromoCodes: %v", err)
+	}
+
+	if batch.CreatedBy != 1 || len(batch.Codes) != 50 {
+		t.Fatalf("batch = %+v, want 50 codes created by 1", batch)
+	}
+
+	seen := make(map[string]struct{}, len(batch.Codes))
+
+	for _, c := range batch.Codes {
+		random, ok := strings.CutPrefix(c.Code, "BF-")
+		if !ok || len(random) != promoCodeLen || strings.Trim(random, promoAlphabet) != "" {
+			t.Errorf("code %q, want BF- and %d characters of the alphabet", c.Code, promoCodeLen)
+		}
+
+		if _, ok := seen[c.Code]; ok {
+			t.Errorf("duplicate code %q", c.Code)
+		}
+
+		seen[c.Code] = struct{}{}
+
+		if c.Points != 500 || c.PerUserLimit != 1 {
+			t.Errorf("code %+v, want 500 points once per user", c)
+		}
+	}
+
+	// заданный код выпускается один, независимо от количества
+	batch, err = gm.GeneratePromoCodes(ctx, models.PromoGenerate{Name: "launch", Count: 10, Code: " launch ", Points: 100})
+	if err != nil {
+		t.Fatalf("GeneratePromoCodes: %v", err)
+	}
+
+	if len(batch.Codes) != 1 || batch.Codes[0].Code != "LAUNCH" {
+		t.Errorf("codes = %+v, want only LAUNCH", batch.Codes)
+	}
+}
+
+func TestGeneratePromoCodesValidation(t *testing.T) {
+	valid := models.PromoGenerate{Name: "batch", Count: 1, Points: 100}
+
+	tests := map[string]func(r *models.PromoGenerate){
+		"empty name":          func(r *models.PromoGenerate) { r.Name = " " },
+		"zero count":          func(r *models.PromoGenerate) { r.Count = 0 },
+		"too many codes":      func(r *models.PromoGenerate) { r.Count = maxPromoCount + 1 },
+		"zero points":         func(r *models.PromoGenerate) { r.Points = 0 },
+		"negative max":        func(r *models.PromoGenerate) { r.MaxRedemptions = -1 },
+		"negative per user":   func(r *models.PromoGenerate) { r.PerUserLimit = -1 },
+		"long code":           func(r *models.PromoGenerate) { r.Code = strings.Repeat("A", maxPromoCodeLen+1) },
+		"long prefix":         func(r *models.PromoGenerate) { r.Prefix = strings.Repeat("A", maxPromoCodeLen-promoCodeLen+1) },
+		"code without points": func(r *models.PromoGenerate) { r.Code, r.Points = "CODE", 0 },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := &promoStorage{}
+			gm := newTestGMart(st, nil)
+
+			req := valid
+			modify(&req)
+
+			_, err := gm.GeneratePromoCodes(withPayload(context.Background(), 1, models.RoleAdmin), req)
+			if !errors.Is(err, models.ErrInvalidInput) || len(st.batches) != 0 {
+				t.Errorf("err = %v, saved %d batches, want ErrInvalidInput and nothing saved", err, len(st.batches))
+			}
+		})
+	}
+
+	for _, role := range []models.Role{models.RoleUser, models.RoleSupport, models.RoleService} {
+		st := &promoStorage{}
+		gm := newTestGMart(st, nil)
+
+		_, err := gm.GeneratePromoCodes(withPayload(context.Background(), 1, role), valid)
+		if !errors.Is(err, models.ErrForbidden) || len(st.batches) != 0 {
+			t.Errorf("role %s: err = %v, want ErrForbidden", role, err)
+		}
+	}
+}
+
+func TestGeneratePromoCodesConflict(t *testing.T) {
+	ctx := withPayload(context.Background(), 1, models.RoleAdmin)
+	req := models.PromoGenerate{Name: "batch", Count: 5, Points: 100}
+
+	// совпадение случайного кода с существующим лечится повторным выпуском новых кодов
+	st := &promoStorage{conflicts: promoGenAttempts - 1}
+	gm := newTestGMart(st, nil)
+
+	batch, err := gm.GeneratePromoCodes(ctx, req)
+	if err != nil || len(st.batches) != promoGenAttempts {
+		t.Fatalf("err = %v, attempts = %d, want success on attempt %d", err, len(st.batches), promoGenAttempts)
+	}
+
+	if batch.Codes[0].Code == st.batches[0].Codes[0].Code {
+		t.Error("retry reused codes of the failed attempt")
+	}
+
+	st = &promoStorage{conflicts: promoGenAttempts}
+	gm = newTestGMart(st, nil)
+
+	if _, err := gm.GeneratePromoCodes(ctx, req); !errors.Is(err, models.ErrConflict) ||
+		len(st.batches) != promoGenAttempts {
+		t.Errorf("err = %v, attempts = %d, want ErrConflict after %d attempts", err, len(st.batches), promoGenAttempts)
+	}
+
+	// заданный код, который уже занят, повторять бессмысленно
+	st = &promoStorage{conflicts: 1}
+	gm = newTestGMart(st, nil)
+
+	req.Code = "TAKEN"
+
+	if _, err := gm.GeneratePromoCodes(ctx, req); !errors.Is(err, models.ErrConflict) || len(st.batches) != 1 {
+		t.Errorf("err = %v, attempts = %d, want ErrConflict after one attempt", err, len(st.batches))
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	referralCodeLen      = 8
+	referralCodeAttempts = 3
+)
+
+// GetReferrals возвращает реферальный код текущего пользователя и его приглашения.
+// Код создаётся при первом запросе.
+func (gm *GMart) GetReferrals(ctx context.Context) (models.ReferralSummary, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.ReferralSummary{}, err
+	}
+
+	code, err := gm.referralCode(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get referral code", zap.Error(err))
+
+		return models.ReferralSummary{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	referrals, err := gm.storage.GetReferrals(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get referrals", zap.Error(err))
+
+		return models.ReferralSummary{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return models.ReferralSummary{Code: code, Referrals: referrals}, nil
+}
+
+// referralCode возвращает реферальный код пользователя, создавая его при необходимости.
+func (gm *GMart) referralCode(ctx context.Context, userID int) (string, error) {
+	for attempt := 1; ; attempt++ {
+		code, err := randomCode(referralCodeLen)
+		if err != nil {
+			return "", err
+		}
+
+		code, err = gm.storage.SetReferralCode(ctx, userID, code)
+		if err == nil {
+			return code, nil
+		}
+
+		if !errors.Is(err, models.ErrConflict) || attempt == referralCodeAttempts {
+			return "", err
+		}
+	}
+}
+
+// referralPolicy возвращает условия реферальной программы из настроек.
+func (gm *GMart) referralPolicy() models.ReferralPolicy {
+	return models.ReferralPolicy{
+		ReferrerBonus: int(math.Round(gm.set.Referral.ReferrerBonus * 100)),
+		RefereeBonus:  int(math.Round(gm.set.Referral.RefereeBonus * 100)),
+		MaxPerUser:    gm.set.Referral.MaxPerUser,
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type referralStorage struct {
+	storage
+
+	// conflicts сколько следующих кодов уже заняты другими пользователями
+	conflicts int
+	codes     []string
+	signups   []models.ReferralSignup
+	referrals []models.Referral
+}
+
+func (s *referralStorage) SetReferralCode(_ context.Context, _ int, code string) (string, error) {
+	s.codes = append(s.codes, code)
+
+	if s.conflicts > 0 {
+		s.conflicts--
+
+		return "", models.ErrConflict
+	}
+
+	return code, nil
+}
+
+func (s *referralStorage) GetReferrals(_ context.Context, _ int) ([]models.Referral, error) {
+	return s.referrals, nil
+}
+
+func (s *referralStorage) GetUser(_ context.Context, _ models.User) (models.User, error) {
+	return models.User{}, models.ErrNotFound
+}
+
+func (s *referralStorage) RegisterReferredUser(
+	_ context.Context,
+	user models.User,
+	signup models.ReferralSignup,
+) (models.User, error) {
+	if signup.Code != "ALICE" {
+		return models.User{}, models.ErrNotFound
+	}
+
+	s.signups = append(s.signups, signup)
+
+	return models.User{ID: 2, Login: user.Login, Role: models.RoleUser}, nil
+}
+
+func TestGetReferrals(t *testing.T) {
+	st := &referralStorage{
+		conflicts: 2,
+		referrals: []models.Referral{{ID: 1, RefereeLogin: "bob", Status: models.ReferralPending}},
+	}
+	gm := newTestGMart(st, nil)
+
+	summary, err := gm.GetReferrals(withPayload(context.Background(), 1, models.RoleUser))
+	if err != nil {
+		t.Fatalf("GetReferrals: %v", err)
+	}
+
+	// занятый код заменяется новым случайным
+	if len(st.codes) != 3 || summary.Code != st.codes[2] || len(summary.Code) != referralCodeLen {
+		t.Errorf("code = %q, tried %v", summary.Code, st.codes)
+	}
+
+	if len(summary.Referrals) != 1 || summary.Referrals[0].RefereeLogin != "bob" {
+		t.Errorf("referrals = %+v", summary.Referrals)
+	}
+}
+
+func TestGetReferralsCodeConflicts(t *testing.T) {
+	st := &referralStorage{conflicts: referralCodeAttempts}
+	gm := newTestGMart(st, nil)
+
+	if _, err := gm.GetReferrals(withPayload(context.Background(), 1, models.RoleUser)); !errors.Is(err, models.ErrInternal) {
+		t.Fatalf("got %v, want ErrInternal", err)
+	}
+
+	if len(st.codes) != referralCodeAttempts {
+		t.Errorf("attempts = %d, want %d", len(st.codes), referralCodeAttempts)
+	}
+}
+
+func TestRegisterReferredUser(t *testing.T) {
+	st := &referralStorage{}
+	gm := newTestGMart(st, &settings.Settings{Referral: settings.ReferralSettings{
+		ReferrerBonus: 100,
+		RefereeBonus:  50.5,
+		MaxPerUser:    20,
+	}})
+
+	if _, err := gm.RegisterUser(context.Background(), models.User{Login: "bob", Password: "secret-password"}, " alice "); err != nil {
+		t.Fatalf("RegisterUser: %v", err)
+	}
+
+	// код нормализуется, бонусы фиксируются в копейках
+	want := models.ReferralSignup{
+		Code:   "ALICE",
+		Policy: models.ReferralPolicy{ReferrerBonus: 10000, RefereeBonus: 5050, MaxPerUser: 20},
+	}
+
+	if len(st.signups) != 1 || st.signups[0] != want {
+		t.Errorf("signups = %+v, want %+v", st.signups, want)
+	}
+
+	_, err := gm.RegisterUser(context.Background(), models.User{Login: "carol", Password: "secret-password"}, "UNKNOWN")
+	if !errors.Is(err, models.ErrInvalidInput) {
+		t.Errorf("unknown code: got %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// RefundWithdrawal возвращает на баланс пользователя баллы, списанные за заказ, полностью или частично.
+// Вызывается сотрудником или учётной записью магазина при отмене заказа.
+func (gm *GMart) RefundWithdrawal(
+	ctx context.Context,
+	refund models.WithdrawalRefund,
+) (models.BalanceWithdrawal, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermRefundWithdrawals)
+	if err != nil {
+		return models.BalanceWithdrawal{}, err
+	}
+
+	if refund.Order == "" || refund.Sum < 0 || refund.Reason == "" {
+		return models.BalanceWithdrawal{}, models.ErrInvalidInput
+	}
+
+	if err := gm.checkOrderShop(ctx, tokenPayload, refund.Order); err != nil {
+		return models.BalanceWithdrawal{}, err
+	}
+
+	refund.OperatorID = tokenPayload.UserID
+
+	withdrawal, err := gm.storage.RefundWithdrawal(ctx, refund)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) || errors.Is(err, models.ErrConflict) {
+			return models.BalanceWithdrawal{}, err
+		}
+
+		gm.log.Error("cannot refund withdrawal", zap.Error(err))
+
+		return models.BalanceWithdrawal{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("withdrawal refunded",
+		zap.String("order number", refund.Order),
+		zap.Int("sum", refund.Sum),
+		zap.Int("refunded", withdrawal.Refunded),
+		zap.String("reason", refund.Reason),
+		zap.Int("operator id", refund.OperatorID))
+
+	return withdrawal, nil
+}
+
+// checkOrderShop проверяет, что учётная запись магазина работает только с заказами своего магазина,
+// номера которых начинаются с её префикса. Сотрудников проверка не ограничивает.
+func (gm *GMart) checkOrderShop(ctx context.Context, tokenPayload models.TokenPayload, order string) error {
+	if tokenPayload.Role != models.RoleService {
+		return nil
+	}
+
+	shop, err := gm.storage.GetUserShop(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get user shop", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if shop == "" || !strings.HasPrefix(order, shop) {
+		gm.log.Warn("order of a foreign shop",
+			zap.Int("user id", tokenPayload.UserID),
+			zap.String("order number", order),
+			zap.String("own shop", shop))
+
+		return models.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+type refundStorage struct {
+	storage
+
+	refunds []models.WithdrawalRefund
+	shop    string
+	err     error
+}
+
+func (s *refundStorage) GetUserShop(_ context.Context, _ int) (string, error) {
+	return s.shop, nil
+}
+
+func (s *refundStorage) RefundWithdrawal(
+	_ context.Context,
+	refund models.WithdrawalRefund,
+) (models.BalanceWithdrawal, error) {
+	if s.err != nil {
+		return models.BalanceWithdrawal{}, s.err
+	}
+
+	s.refunds = append(s.refunds, refund)
+
+	return models.BalanceWithdrawal{Order: refund.Order, Sum: 1000, Refunded: refund.Sum}, nil
+}
+
+func TestRefundWithdrawal(t *testing.T) {
+	valid := models.WithdrawalRefund{Order: "2377225624", Sum: 500, Reason: "order cancelled"}
+
+	tests := []struct {
+		name       string
+		role       models.Role
+		refund     models.WithdrawalRefund
+		storageErr error
+		wantErr    error
+	}{
+		{name: "service", role: models.RoleService, refund: valid},
+		{name: "admin", role: models.RoleAdmin, refund: valid},
+		{
+			name:   "full refund",
+			role:   models.RoleService,
+			refund: models.WithdrawalRefund{Order: "2377225624", Reason: "order cancelled"},
+		},
+		{
+			name:    "foreign shop",
+			role:    models.RoleService,
+			refund:  models.WithdrawalRefund{Order: "79927398713", Sum: 500, Reason: "order cancelled"},
+			wantErr: models.ErrForbidden,
+		},
+		{
+			name:   "admin any shop",
+			role:   models.RoleAdmin,
+			refund: models.WithdrawalRefund{Order: "79927398713", Sum: 500, Reason: "order cancelled"},
+		},
+		{name: "user", role: models.RoleUser, refund: valid, wantErr: models.ErrForbidden},
+		{name: "support", role: models.RoleSupport, refund: valid, wantErr: models.ErrForbidden},
+		{
+			name:    "no order",
+			role:    models.RoleService,
+			refund:  models.WithdrawalRefund{Sum: 500, Reason: "order cancelled"},
+			wantErr: models.ErrInvalidInput,
+		},
+		{
+			name:    "negative sum",
+			role:    models.RoleService,
+			refund:  models.WithdrawalRefund{Order: "2377225624", Sum: -1, Reason: "order cancelled"},
+			wantErr: models.ErrInvalidInput,
+		},
+		{
+			name:    "no reason",
+			role:    models.RoleService,
+			refund:  models.WithdrawalRefund{Order: "2377225624", Sum: 500},
+			wantErr: models.ErrInvalidInput,
+		},
+		{
+			name:       "unknown order",
+			role:       models.RoleService,
+			refund:     valid,
+			storageErr: models.ErrNotFound,
+			wantErr:    models.ErrNotFound,
+		},
+		{
+			name:       "over refund",
+			role:       models.RoleService,
+			refund:     valid,
+			storageErr: models.ErrConflict,
+			wantErr:    models.ErrConflict,
+		},
+		{
+			name:       "storage failure",
+			role:       models.RoleService,
+			refund:     valid,
+			storageErr: errors.New("boom"),
+			wantErr:    models.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &refundStorage{shop: "2377", err: tt.storageErr}
+			gm := newTestGMart(st, nil)
+
+			w, err := gm.RefundWithdrawal(withPayload(context.Background(), 7, tt.role), tt.refund)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(st.refunds) != 0 {
+					t.Errorf("refund reached storage: %+v", st.refunds)
+				}
+
+				return
+			}
+
+			if len(st.refunds) != 1 || st.refunds[0].OperatorID != 7 {
+				t.Errorf("stored refunds = %+v, want one with operator 7", st.refunds)
+			}
+
+			if w.Order != tt.refund.Order {
+				t.Errorf("withdrawal = %+v", w)
+			}
+		})
+	}
+}
+
+// TestRefundUnboundShop проверяет, что учётная запись, не привязанная к магазину, не возвращает баллы.
+func TestRefundUnboundShop(t *testing.T) {
+	st := &refundStorage{}
+	gm := newTestGMart(st, nil)
+
+	refund := models.WithdrawalRefund{Order: "2377225624", Sum: 500, Reason: "order cancelled"}
+
+	if _, err := gm.RefundWithdrawal(withPayload(context.Background(), 7, models.RoleService), refund); !errors.Is(err, models.ErrForbidden) {
+		t.Fatalf("got %v, want ErrForbidden", err)
+	}
+
+	if len(st.refunds) != 0 {
+		t.Errorf("refund reached storage: %+v", st.refunds)
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// authorize проверяет, что у текущего пользователя есть право на действие.
+func (gm *GMart) authorize(ctx context.Context, perm models.Permission) (models.TokenPayload, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.TokenPayload{}, err
+	}
+
+	if !tokenPayload.Role.Can(perm) {
+		gm.log.Warn("permission denied",
+			zap.Int("user id", tokenPayload.UserID),
+			zap.String("role", string(tokenPayload.Role)),
+			zap.Int("permission", int(perm)))
+
+		return models.TokenPayload{}, models.ErrForbidden
+	}
+
+	return tokenPayload, nil
+}
+
+// authorizeManagedUser проверяет, что текущий пользователь может управлять учётной записью u.
+// Поддержка управляет только обычными пользователями: сбросив пароль администратора или магазина,
+// она могла бы войти под ним и получить его права.
+func (gm *GMart) authorizeManagedUser(tokenPayload models.TokenPayload, u models.User) error {
+	if u.Role == models.RoleUser || tokenPayload.Role.Can(models.PermManageRoles) {
+		return nil
+	}
+
+	gm.log.Warn("cannot manage privileged user",
+		zap.Int("user id", tokenPayload.UserID),
+		zap.Int("target id", u.ID),
+		zap.String("target role", string(u.Role)))
+
+	return models.ErrForbidden
+}
+
+// SetUserRole меняет роль пользователя. Изменение записывается в аудит, выданные пользователю токены отзываются.
+func (gm *GMart) SetUserRole(ctx context.Context, login string, role models.Role) error {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageRoles)
+	if err != nil {
+		return err
+	}
+
+	if login == "" || !role.Valid() {
+		return models.ErrInvalidInput
+	}
+
+	return gm.setUserRole(ctx, login, role, tokenPayload.UserID)
+}
+
+// BootstrapAdmins назначает роль администратора пользователям из настроек, чтобы можно было
+// назначить первого администратора без доступа к базе данных.
+func (gm *GMart) BootstrapAdmins(ctx context.Context, logins []string) {
+	for _, login := range logins {
+		if login == "" {
+			continue
+		}
+
+		if err := gm.setUserRole(ctx, login, models.RoleAdmin, 0); err != nil {
+			gm.log.Warn("cannot bootstrap admin", zap.String("login", login), zap.Error(err))
+		}
+	}
+}
+
+func (gm *GMart) setUserRole(ctx context.Context, login string, role models.Role, changedBy int) error {
+	u, err := gm.storage.GetUser(ctx, models.User{Login: login})
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	err = gm.storage.SetUserRole(ctx, models.RoleChange{
+		UserID:    u.ID,
+		OldRole:   u.Role,
+		NewRole:   role,
+		ChangedBy: changedBy,
+	})
+	if err != nil {
+		gm.log.Error("cannot set user role", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if u.Role != role {
+		gm.log.Info("user role changed",
+			zap.Int("user id", u.ID),
+			zap.String("old role", string(u.Role)),
+			zap.String("new role", string(role)),
+			zap.Int("changed by", changedBy))
+	}
+
+	return nil
+}
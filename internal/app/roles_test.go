@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+type roleStorage struct {
+	storage
+
+	users   map[string]models.User
+	changes []models.RoleChange
+}
+
+func (s *roleStorage) GetUser(_ context.Context, user models.User) (models.User, error) {
+	u, ok := s.users[user.Login]
+	if !ok {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (s *roleStorage) SetUserRole(_ context.Context, change models.RoleChange) error {
+	s.changes = append(s.changes, change)
+
+	return nil
+}
+
+func TestSetUserRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    models.Role
+		login   string
+		newRole models.Role
+		wantErr error
+	}{
+		{name: "user", role: models.RoleUser, login: "bob", newRole: models.RoleAdmin, wantErr: models.ErrForbidden},
+		{name: "support", role: models.RoleSupport, login: "bob", newRole: models.RoleAdmin, wantErr: models.ErrForbidden},
+		{name: "service", role: models.RoleService, login: "bob", newRole: models.RoleAdmin, wantErr: models.ErrForbidden},
+		{name: "unknown role", role: models.RoleAdmin, login: "bob", newRole: "root", wantErr: models.ErrInvalidInput},
+		{name: "empty login", role: models.RoleAdmin, login: "", newRole: models.RoleSupport, wantErr: models.ErrInvalidInput},
+		{name: "unknown user", role: models.RoleAdmin, login: "ghost", newRole: models.RoleSupport, wantErr: models.ErrNotFound},
+		{name: "admin", role: models.RoleAdmin, login: "bob", newRole: models.RoleSupport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &roleStorage{users: map[string]models.User{"bob": {ID: 2, Login: "bob", Role: models.RoleUser}}}
+			gm := newTestGMart(st, nil)
+
+			err := gm.SetUserRole(withPayload(context.Background(), 1, tt.role), tt.login, tt.newRole)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+
+				if len(st.changes) != 0 {
+					t.Error("role is changed")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := models.RoleChange{UserID: 2, OldRole: models.RoleUser, NewRole: tt.newRole, ChangedBy: 1}
+			if len(st.changes) != 1 || st.changes[0] != want {
+				t.Errorf("audit = %+v, want %+v", st.changes, want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithoutToken(t *testing.T) {
+	gm := newTestGMart(nil, nil)
+
+	if _, err := gm.authorize(context.Background(), models.PermViewUsers); err == nil {
+		t.Fatal("request without token payload must not be authorized")
+	}
+}
+
+func TestBootstrapAdmins(t *testing.T) {
+	st := &roleStorage{users: map[string]models.User{"root": {ID: 5, Login: "root", Role: models.RoleUser}}}
+	gm := newTestGMart(st, nil)
+
+	gm.BootstrapAdmins(context.Background(), []string{"", "root", "ghost"})
+
+	want := models.RoleChange{UserID: 5, OldRole: models.RoleUser, NewRole: models.RoleAdmin}
+	if len(st.changes) != 1 || st.changes[0] != want {
+		t.Errorf("audit = %+v, want %+v", st.changes, want)
+	}
+}
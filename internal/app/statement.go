@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// statementMonthLayout формат месяца выписки.
+const statementMonthLayout = "2006-01"
+
+// OpenStatement готовит выписку текущего пользователя за месяц в формате YYYY-MM: период и баланс на его начало.
+// Операции выписки передаются отдельно через StreamStatement, чтобы большие выписки не загружались в память.
+func (gm *GMart) OpenStatement(ctx context.Context, month string) (models.Statement, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.Statement{}, err
+	}
+
+	from, err := time.Parse(statementMonthLayout, month)
+	if err != nil {
+		return models.Statement{}, fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
+	}
+
+	user, err := gm.storage.GetUserByID(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return models.Statement{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	opening, err := gm.storage.GetStatementBalance(ctx, user.ID, from)
+	if err != nil {
+		gm.log.Error("cannot get statement balance", zap.Error(err))
+
+		return models.Statement{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return models.Statement{
+		UserID:  user.ID,
+		Login:   user.Login,
+		From:    from,
+		To:      from.AddDate(0, 1, 0),
+		Opening: opening,
+	}, nil
+}
+
+// StreamStatement передаёт в fn операции выписки в хронологическом порядке с балансом после каждой операции.
+func (gm *GMart) StreamStatement(ctx context.Context, st models.Statement, fn func(models.StatementEntry) error) error {
+	err := gm.storage.StreamStatement(ctx, st.UserID, st.From, st.To, fn)
+	if err != nil {
+		gm.log.Error("cannot stream statement", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
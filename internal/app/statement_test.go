@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+type statementStorage struct {
+	storage
+
+	before  time.Time
+	entries []models.StatementEntry
+}
+
+func (s *statementStorage) GetUserByID(_ context.Context, userID int) (models.User, error) {
+	return models.User{ID: userID, Login: "alice"}, nil
+}
+
+func (s *statementStorage) GetStatementBalance(_ context.Context, _ int, before time.Time) (int, error) {
+	s.before = before
+
+	return 700, nil
+}
+
+func (s *statementStorage) StreamStatement(
+	_ context.Context,
+	_ int,
+	_, _ time.Time,
+	fn func(models.StatementEntry) error,
+) error {
+	for _, e := range s.entries {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestOpenStatement(t *testing.T) {
+	st := &statementStorage{}
+	gm := newTestGMart(st, nil)
+	ctx := withPayload(context.Background(), 7, models.RoleUser)
+
+	statement, err := gm.OpenStatement(ctx, "2023-12")
+	if err != nil {
+		t.Fatalf("OpenStatement: %v", err)
+	}
+
+	from := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	if statement.UserID != 7 || statement.Login != "alice" || statement.Opening != 700 ||
+		!statement.From.Equal(from) || !statement.To.Equal(from.AddDate(0, 1, 0)) || !st.before.Equal(from) {
+		t.Errorf("statement = %+v, opening balance at %v", statement, st.before)
+	}
+
+	for _, month := range []string{"", "2023-13", "2023/12", "december"} {
+		if _, err := gm.OpenStatement(ctx, month); !errors.Is(err, models.ErrInvalidInput) {
+			t.Errorf("month %q: err = %v, want ErrInvalidInput", month, err)
+		}
+	}
+}
+
+// TestStreamStatement проверяет, что операции выписки передаются с балансом из журнала движений.
+func TestStreamStatement(t *testing.T) {
+	st := &statementStorage{entries: []models.StatementEntry{
+		{Kind: models.LotSourceRefund, Amount: 100, Balance: 800},
+		{Kind: models.LotSourceTransfer, Amount: -200, Balance: 600},
+	}}
+	gm := newTestGMart(st, nil)
+
+	got := make([]models.StatementEntry, 0)
+
+	err := gm.StreamStatement(context.Background(), models.Statement{UserID: 7, Opening: 700},
+		func(e models.StatementEntry) error {
+			got = append(got, e)
+
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamStatement: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != st.entries[0] || got[1] != st.entries[1] {
+		t.Errorf("entries = %+v, want %+v", got, st.entries)
+	}
+
+	stop := errors.New("client disconnected")
+
+	err = gm.StreamStatement(context.Background(), models.Statement{UserID: 7}, func(models.StatementEntry) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("err = %v, want the callback error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// tiers определяет уровни пользователей программы лояльности.
+type tiers struct {
+	log     *zap.Logger
+	storage storage
+	policy  models.TierPolicy
+}
+
+// status определяет уровень пользователя по баллам за скользящий период без сохранения.
+func (t *tiers) status(ctx context.Context, userID int) (models.TierStatus, error) {
+	progress, err := t.storage.GetTierProgress(ctx, userID, t.policy.Basis, time.Now().Add(-t.policy.Window))
+	if err != nil {
+		return models.TierStatus{}, err
+	}
+
+	return t.policy.Status(progress), nil
+}
+
+// evaluate определяет уровень пользователя и сохраняет его, если он изменился.
+// Вызывается только после операций, которые меняют баллы за период: начисления и списания.
+func (t *tiers) evaluate(ctx context.Context, userID int) (models.TierStatus, error) {
+	status, err := t.status(ctx, userID)
+	if err != nil {
+		return models.TierStatus{}, err
+	}
+
+	change, changed, err := t.storage.UpdateUserTier(ctx, models.TierChange{
+		UserID:   userID,
+		NewTier:  status.Tier.Name,
+		Progress: status.Progress,
+	})
+	if err != nil {
+		return models.TierStatus{}, err
+	}
+
+	if changed {
+		t.log.Info("user tier changed",
+			zap.Int("user id", userID),
+			zap.String("old tier", change.OldTier),
+			zap.String("new tier", change.NewTier))
+	}
+
+	return status, nil
+}
+
+// applyMultiplier увеличивает начисление за заказ по текущему уровню пользователя.
+func (t *tiers) applyMultiplier(ctx context.Context, userID, accrual int) (int, error) {
+	if !t.policy.Enabled() || accrual <= 0 {
+		return accrual, nil
+	}
+
+	status, err := t.evaluate(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(math.Round(float64(accrual) * status.Tier.Multiplier)), nil
+}
+
+// fillTier добавляет к балансу уровень пользователя и продвижение к следующему уровню.
+// Уровень вычисляется без сохранения: запрос баланса не меняет данные.
+func (gm *GMart) fillTier(ctx context.Context, userID int, balance *models.Balance) error {
+	if !gm.tiers.policy.Enabled() {
+		return nil
+	}
+
+	status, err := gm.tiers.status(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	balance.Tier = &status
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+	"gophermat/internal/ordernumber"
+)
+
+type tierStorage struct {
+	withdrawStorage
+
+	progress int
+	tier     string
+	changes  []models.TierChange
+}
+
+func (s *tierStorage) GetBalance(_ context.Context, _ int) (models.Balance, error) {
+	return models.Balance{Current: 100}, nil
+}
+
+func (s *tierStorage) GetTierProgress(_ context.Context, _ int, _ string, _ time.Time) (int, error) {
+	return s.progress, nil
+}
+
+func (s *tierStorage) UpdateUserTier(_ context.Context, change models.TierChange) (models.TierChange, bool, error) {
+	if change.NewTier == s.tier {
+		return change, false, nil
+	}
+
+	change.OldTier = s.tier
+	s.tier = change.NewTier
+	s.changes = append(s.changes, change)
+
+	return change, true, nil
+}
+
+func newTierGMart(t *testing.T, st *tierStorage, basis string) *GMart {
+	t.Helper()
+
+	policy, err := models.NewTierPolicy([]string{"bronze:0:1", "silver:50:1.5"}, basis, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTierPolicy: %v", err)
+	}
+
+	gm := newTestGMart(st, nil)
+	gm.orders = ordernumber.Luhn{}
+	gm.tiers = &tiers{log: zap.NewNop(), storage: st, policy: policy}
+
+	return gm
+}
+
+// TestGetBalanceTierReadOnly проверяет, что запрос баланса показывает текущий уровень, но не сохраняет его.
+func TestGetBalanceTierReadOnly(t *testing.T) {
+	st := &tierStorage{progress: 6000, tier: "bronze"}
+	gm := newTierGMart(t, st, models.TierBasisAccrued)
+
+	balance, err := gm.GetBalance(withPayload(context.Background(), 1, models.RoleUser))
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+
+	if balance.Tier == nil || balance.Tier.Tier.Name != "silver" || balance.Tier.Progress != 6000 {
+		t.Errorf("balance tier = %+v, want silver with progress 6000", balance.Tier)
+	}
+
+	if len(st.changes) != 0 || st.tier != "bronze" {
+		t.Errorf("GetBalance changed tier: %+v", st.changes)
+	}
+}
+
+func TestApplyMultiplierPersistsTier(t *testing.T) {
+	st := &tierStorage{progress: 6000, tier: "bronze"}
+	gm := newTierGMart(t, st, models.TierBasisAccrued)
+
+	amount, err := gm.tiers.applyMultiplier(context.Background(), 1, 1000)
+	if err != nil {
+		t.Fatalf("applyMultiplier: %v", err)
+	}
+
+	if amount != 1500 {
+		t.Errorf("amount = %d, want 1500", amount)
+	}
+
+	if len(st.changes) != 1 || st.changes[0].OldTier != "bronze" || st.changes[0].NewTier != "silver" {
+		t.Errorf("tier changes = %+v, want bronze to silver", st.changes)
+	}
+
+	// уровень не изменился, повторно не сохраняется
+	if _, err := gm.tiers.applyMultiplier(context.Background(), 1, 1000); err != nil || len(st.changes) != 1 {
+		t.Errorf("tier changes = %+v, %v", st.changes, err)
+	}
+}
+
+func TestDeductPointsUpdatesSpentTier(t *testing.T) {
+	withdraw := models.BalanceWithdraw{Order: "2377225624", Sum: 751}
+
+	st := &tierStorage{progress: 6000, tier: "bronze"}
+	gm := newTierGMart(t, st, models.TierBasisSpent)
+
+	if err := gm.DeductPoints(withPayload(context.Background(), 1, models.RoleUser), withdraw); err != nil {
+		t.Fatalf("DeductPoints: %v", err)
+	}
+
+	if st.tier != "silver" {
+		t.Errorf("tier after withdrawal = %s, want silver", st.tier)
+	}
+
+	// уровень по начисленным баллам от списания не зависит
+	st = &tierStorage{progress: 6000, tier: "bronze"}
+	gm = newTierGMart(t, st, models.TierBasisAccrued)
+
+	if err := gm.DeductPoints(withPayload(context.Background(), 1, models.RoleUser), withdraw); err != nil {
+		t.Fatalf("DeductPoints: %v", err)
+	}
+
+	if len(st.changes) != 0 {
+		t.Errorf("withdrawal changed accrued tier: %+v", st.changes)
+	}
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// GetTransactions возвращает страницу журнала движений баланса пользователя в хронологическом порядке.
+func (gm *GMart) GetTransactions(ctx context.Context, page models.TransactionPage) ([]models.BalanceTransaction, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, err
+	}
+
+	transactions, err := gm.storage.GetTransactions(ctx, tokenPayload.UserID, page)
+	if err != nil {
+		gm.log.Error("cannot get balance transactions", zap.Error(err))
+
+		return nil, err
+	}
+
+	return transactions, nil
+}
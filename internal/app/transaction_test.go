@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+)
+
+type transactionStorage struct {
+	storage
+
+	userID int
+	page   models.TransactionPage
+	err    error
+}
+
+func (s *transactionStorage) GetTransactions(
+	_ context.Context,
+	userID int,
+	page models.TransactionPage,
+) ([]models.BalanceTransaction, error) {
+	s.userID, s.page = userID, page
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []models.BalanceTransaction{{ID: page.After + 1, UserID: userID, Kind: models.LotSourceAccrual, Amount: 100}}, nil
+}
+
+func TestGetTransactions(t *testing.T) {
+	st := &transactionStorage{}
+	gm := newTestGMart(st, nil)
+
+	page := models.TransactionPage{After: 10, Limit: 20}
+
+	transactions, err := gm.GetTransactions(withPayload(context.Background(), 7, models.RoleUser), page)
+	if err != nil {
+		t.Fatalf("GetTransactions: %v", err)
+	}
+
+	if st.userID != 7 || st.page != page || len(transactions) != 1 || transactions[0].ID != 11 {
+		t.Errorf("storage got user %d, page %+v, returned %+v", st.userID, st.page, transactions)
+	}
+
+	// пустой журнал отдаётся обработчику как есть, чтобы он ответил 204
+	gm = newTestGMart(&transactionStorage{err: models.ErrNotFound}, nil)
+
+	if _, err := gm.GetTransactions(withPayload(context.Background(), 7, models.RoleUser), page); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("empty journal: err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := gm.GetTransactions(context.Background(), page); err == nil {
+		t.Error("got transactions without token payload")
+	}
+}
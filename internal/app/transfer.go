@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+// TransferPoints переводит баллы текущего пользователя пользователю с логином recipient.
+// Сумма в копейках должна быть не меньше минимальной и не превышать дневных ограничений из настроек.
+// Неизвестный получатель отклоняется так же, как перевод самому себе, чтобы перевод не выдавал,
+// существует ли логин.
+func (gm *GMart) TransferPoints(ctx context.Context, recipient string, amount int) (models.Transfer, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return models.Transfer{}, err
+	}
+
+	limits := models.TransferLimits{
+		MinAmount:   int(math.Round(gm.set.Transfer.MinAmount * 100)),
+		DailyAmount: int(math.Round(gm.set.Transfer.DailyAmount * 100)),
+		DailyCount:  gm.set.Transfer.DailyCount,
+	}
+
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" || amount <= 0 || amount < limits.MinAmount {
+		return models.Transfer{}, models.ErrInvalidInput
+	}
+
+	sender, err := gm.storage.GetUserByID(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return models.Transfer{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	t, err := gm.storage.Transfer(ctx, models.Transfer{
+		SenderID:       sender.ID,
+		SenderLogin:    sender.Login,
+		RecipientLogin: recipient,
+		Amount:         amount,
+	}, limits)
+	if err != nil {
+		if errors.Is(err, models.ErrInsufficientBalance) ||
+			errors.Is(err, models.ErrTransferLimit) ||
+			errors.Is(err, models.ErrInvalidInput) {
+			gm.log.Info("points are not transferred", zap.Int("user id", sender.ID), zap.Error(err))
+
+			return models.Transfer{}, err
+		}
+
+		gm.log.Error("cannot transfer points", zap.Error(err))
+
+		return models.Transfer{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("points transferred",
+		zap.Int64("transfer id", t.ID),
+		zap.Int("sender id", t.SenderID),
+		zap.Int("recipient id", t.RecipientID),
+		zap.Int("amount", t.Amount))
+
+	return t, nil
+}
+
+// GetTransfers возвращает отправленные и полученные текущим пользователем переводы.
+func (gm *GMart) GetTransfers(ctx context.Context) ([]models.TransferEntry, error) {
+	tokenPayload, err := payloadFromContext(ctx)
+	if err != nil {
+		gm.log.Error("cannot get payload", zap.Error(err))
+
+		return nil, err
+	}
+
+	transfers, err := gm.storage.GetTransfers(ctx, tokenPayload.UserID)
+	if err != nil {
+		gm.log.Error("cannot get transfers", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	entries := make([]models.TransferEntry, 0, len(transfers))
+	for _, t := range transfers {
+		entries = append(entries, t.EntryFor(tokenPayload.UserID))
+	}
+
+	return entries, nil
+}
+
+// GetUserTransfers возвращает переводы пользователя для сотрудника.
+func (gm *GMart) GetUserTransfers(ctx context.Context, userID int) ([]models.Transfer, error) {
+	if err := gm.authorizeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	transfers, err := gm.storage.GetTransfers(ctx, userID)
+	if err != nil {
+		gm.log.Error("cannot get transfers", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return transfers, nil
+}
+
+// ReverseTransfer отменяет перевод и возвращает баллы отправителю.
+func (gm *GMart) ReverseTransfer(ctx context.Context, id int64, note string) (models.Transfer, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermReverseTransfers)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	t, err := gm.storage.ReverseTransfer(ctx, id, tokenPayload.UserID, note)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) ||
+			errors.Is(err, models.ErrConflict) ||
+			errors.Is(err, models.ErrInsufficientBalance) {
+			return models.Transfer{}, err
+		}
+
+		gm.log.Error("cannot reverse transfer", zap.Error(err))
+
+		return models.Transfer{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("transfer reversed",
+		zap.Int64("transfer id", t.ID),
+		zap.Int("sender id", t.SenderID),
+		zap.Int("recipient id", t.RecipientID),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	return t, nil
+}
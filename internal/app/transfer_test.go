@@ -0,0 +1,263 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+// transferStorage переводит баллы между пользователями так же, как хранилище: получатель проверяется
+// после ограничений и баланса.
+type transferStorage struct {
+	storage
+
+	users     []models.User
+	balances  map[int]int
+	transfers []models.Transfer
+	limits    models.TransferLimits
+	err       error
+}
+
+func newTransferStorage() *transferStorage {
+	return &transferStorage{
+		users: []models.User{
+			{ID: 1, Login: "alice"},
+			{ID: 2, Login: "bob"},
+			{ID: 3, Login: "carol"},
+		},
+		balances: map[int]int{1: 10000},
+	}
+}
+
+func (s *transferStorage) GetUserByID(_ context.Context, userID int) (models.User, error) {
+	for _, u := range s.users {
+		if u.ID == userID {
+			return u, nil
+		}
+	}
+
+	return models.User{}, models.ErrNotFound
+}
+
+func (s *transferStorage) Transfer(
+	_ context.Context,
+	t models.Transfer,
+	limits models.TransferLimits,
+) (models.Transfer, error) {
+	s.limits = limits
+
+	if s.err != nil {
+		return models.Transfer{}, s.err
+	}
+
+	count, total := 0, 0
+
+	for _, tr := range s.transfers {
+		if tr.SenderID == t.SenderID && tr.Status == models.TransferCompleted {
+			count++
+			total += tr.Amount
+		}
+	}
+
+	if (limits.DailyCount > 0 && count >= limits.DailyCount) ||
+		(limits.DailyAmount > 0 && total+t.Amount > limits.DailyAmount) {
+		return models.Transfer{}, models.ErrTransferLimit
+	}
+
+	if s.balances[t.SenderID] < t.Amount {
+		return models.Transfer{}, models.ErrInsufficientBalance
+	}
+
+	for _, u := range s.users {
+		if u.Login == t.RecipientLogin {
+			t.RecipientID = u.ID
+		}
+	}
+
+	if t.RecipientID == 0 || t.RecipientID == t.SenderID {
+		return models.Transfer{}, models.ErrInvalidInput
+	}
+
+	t.ID = int64(len(s.transfers) + 1)
+	t.Status = models.TransferCompleted
+	s.transfers = append(s.transfers, t)
+	s.balances[t.SenderID] -= t.Amount
+	s.balances[t.RecipientID] += t.Amount
+
+	return t, nil
+}
+
+func (s *transferStorage) ReverseTransfer(_ context.Context, id int64, operatorID int, note string) (models.Transfer, error) {
+	if s.err != nil {
+		return models.Transfer{}, s.err
+	}
+
+	if id < 1 || int(id) > len(s.transfers) {
+		return models.Transfer{}, models.ErrNotFound
+	}
+
+	t := &s.transfers[id-1]
+	if t.Status != models.TransferCompleted {
+		return models.Transfer{}, models.ErrConflict
+	}
+
+	t.Status = models.TransferReversed
+	t.ReversedBy = operatorID
+	t.ReversalNote = note
+	s.balances[t.RecipientID] -= t.Amount
+	s.balances[t.SenderID] += t.Amount
+
+	return *t, nil
+}
+
+func transferSettings() *settings.Settings {
+	return &settings.Settings{Transfer: settings.TransferSettings{MinAmount: 1, DailyAmount: 30, DailyCount: 2}}
+}
+
+func TestTransferPoints(t *testing.T) {
+	st := newTransferStorage()
+	gm := newTestGMart(st, transferSettings())
+	alice := withPayload(context.Background(), 1, models.RoleUser)
+
+	tr, err := gm.TransferPoints(alice, " bob ", 1000)
+	if err != nil {
+		t.Fatalf("TransferPoints: %v", err)
+	}
+
+	if tr.SenderID != 1 || tr.SenderLogin != "alice" || tr.RecipientID != 2 || tr.RecipientLogin != "bob" ||
+		tr.Amount != 1000 {
+		t.Errorf("transfer = %+v", tr)
+	}
+
+	if st.balances[1] != 9000 || st.balances[2] != 1000 {
+		t.Errorf("balances = %v", st.balances)
+	}
+
+	// ограничения из настроек передаются хранилищу в копейках
+	if st.limits != (models.TransferLimits{MinAmount: 100, DailyAmount: 3000, DailyCount: 2}) {
+		t.Errorf("limits = %+v", st.limits)
+	}
+}
+
+func TestTransferPointsRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipient  string
+		amount     int
+		storageErr error
+		wantErr    error
+	}{
+		{name: "below minimum", recipient: "bob", amount: 99, wantErr: models.ErrInvalidInput},
+		{name: "zero amount", recipient: "bob", amount: 0, wantErr: models.ErrInvalidInput},
+		{name: "negative amount", recipient: "bob", amount: -100, wantErr: models.ErrInvalidInput},
+		{name: "no recipient", recipient: " ", amount: 100, wantErr: models.ErrInvalidInput},
+		{name: "yourself", recipient: "alice", amount: 100, wantErr: models.ErrInvalidInput},
+		{name: "unknown recipient", recipient: "ghost", amount: 100, wantErr: models.ErrInvalidInput},
+		{name: "daily amount", recipient: "bob", amount: 3100, wantErr: models.ErrTransferLimit},
+		{name: "insufficient balance", recipient: "bob", amount: 3000, wantErr: models.ErrInsufficientBalance},
+		{name: "storage failure", recipient: "bob", amount: 100, storageErr: errors.New("boom"), wantErr: models.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newTransferStorage()
+			st.balances[1] = 2000
+			st.err = tt.storageErr
+
+			gm := newTestGMart(st, transferSettings())
+
+			_, err := gm.TransferPoints(withPayload(context.Background(), 1, models.RoleUser), tt.recipient, tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if len(st.transfers) != 0 {
+				t.Errorf("transfers = %+v, want none", st.transfers)
+			}
+		})
+	}
+}
+
+// TestTransferPointsUnknownRecipient проверяет, что неизвестный логин неотличим от существующего,
+// пока перевод не может пройти по ограничениям или балансу.
+func TestTransferPointsUnknownRecipient(t *testing.T) {
+	st := newTransferStorage()
+	gm := newTestGMart(st, transferSettings())
+	carol := withPayload(context.Background(), 3, models.RoleUser)
+
+	for _, recipient := range []string{"bob", "ghost"} {
+		if _, err := gm.TransferPoints(carol, recipient, 100); !errors.Is(err, models.ErrInsufficientBalance) {
+			t.Errorf("transfer to %s without points: got %v, want ErrInsufficientBalance", recipient, err)
+		}
+	}
+}
+
+func TestTransferPointsDailyCount(t *testing.T) {
+	st := newTransferStorage()
+	gm := newTestGMart(st, transferSettings())
+	alice := withPayload(context.Background(), 1, models.RoleUser)
+
+	for i := 0; i < 2; i++ {
+		if _, err := gm.TransferPoints(alice, "bob", 100); err != nil {
+			t.Fatalf("transfer %d: %v", i+1, err)
+		}
+	}
+
+	if _, err := gm.TransferPoints(alice, "carol", 100); !errors.Is(err, models.ErrTransferLimit) {
+		t.Fatalf("third transfer: got %v, want ErrTransferLimit", err)
+	}
+}
+
+func TestReverseTransfer(t *testing.T) {
+	st := newTransferStorage()
+	gm := newTestGMart(st, transferSettings())
+
+	if _, err := gm.TransferPoints(withPayload(context.Background(), 1, models.RoleUser), "bob", 1000); err != nil {
+		t.Fatalf("TransferPoints: %v", err)
+	}
+
+	for _, role := range []models.Role{models.RoleUser, models.RoleSupport, models.RoleService} {
+		if _, err := gm.ReverseTransfer(withPayload(context.Background(), 2, role), 1, "mistake"); !errors.Is(err, models.ErrForbidden) {
+			t.Errorf("%s reverses transfer: got %v, want ErrForbidden", role, err)
+		}
+	}
+
+	admin := withPayload(context.Background(), 9, models.RoleAdmin)
+
+	tr, err := gm.ReverseTransfer(admin, 1, "mistake")
+	if err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+
+	if tr.Status != models.TransferReversed || tr.ReversedBy != 9 || tr.ReversalNote != "mistake" {
+		t.Errorf("reversed transfer = %+v", tr)
+	}
+
+	if st.balances[1] != 10000 || st.balances[2] != 0 {
+		t.Errorf("balances after reversal = %v", st.balances)
+	}
+
+	if _, err := gm.ReverseTransfer(admin, 1, "again"); !errors.Is(err, models.ErrConflict) {
+		t.Errorf("repeated reversal: got %v, want ErrConflict", err)
+	}
+
+	if _, err := gm.ReverseTransfer(admin, 5, "mistake"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown transfer: got %v, want ErrNotFound", err)
+	}
+
+	st.err = models.ErrInsufficientBalance
+
+	if _, err := gm.ReverseTransfer(admin, 1, "spent"); !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("spent points: got %v, want ErrInsufficientBalance", err)
+	}
+
+	st.err = errors.New("boom")
+
+	if _, err := gm.ReverseTransfer(admin, 1, "mistake"); !errors.Is(err, models.ErrInternal) {
+		t.Errorf("storage failure: got %v, want ErrInternal", err)
+	}
+}
@@ -0,0 +1,407 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gophermat/internal/crypt"
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookDispatchBatch   = 100
+	webhookDeliveryBatch   = 20
+	webhookDeliveriesLimit = 100
+	webhookStorageTimeout  = time.Second * 10
+	// webhookMaxError длина ошибки попытки, которая сохраняется в истории доставки.
+	webhookMaxError = 500
+)
+
+// webhookPayload тело запроса с событием. Id события одинаковый во всех попытках доставки,
+// по нему магазин отбрасывает повторы. Сумма в баллах.
+type webhookPayload struct {
+	ID        int64   `json:"id"`
+	Type      string  `json:"type"`
+	Order     string  `json:"order"`
+	Amount    float64 `json:"amount"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// GetWebhooks возвращает адреса магазинов. Учётная запись магазина видит только свои адреса.
+func (gm *GMart) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy := tokenPayload.UserID
+	if tokenPayload.Role.Can(models.PermManageAllWebhooks) {
+		createdBy = 0
+	}
+
+	webhooks, err := gm.storage.GetWebhooks(ctx, createdBy)
+	if err != nil {
+		gm.log.Error("cannot get webhooks", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return webhooks, nil
+}
+
+// CreateWebhook регистрирует адрес магазина и создаёт ключ подписи событий. Ключ возвращается
+// только при регистрации. Учётная запись магазина регистрирует адреса только для заказов своего магазина.
+func (gm *GMart) CreateWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageWebhooks)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	w.Shop = strings.TrimSpace(w.Shop)
+	w.Events = uniqueStrings(w.Events)
+
+	if !w.Valid() {
+		return models.Webhook{}, models.ErrInvalidInput
+	}
+
+	if !tokenPayload.Role.Can(models.PermManageAllWebhooks) {
+		if err := gm.checkWebhookShop(ctx, tokenPayload.UserID, w.Shop); err != nil {
+			return models.Webhook{}, err
+		}
+	}
+
+	w.Secret, err = crypt.RandomToken()
+	if err != nil {
+		gm.log.Error("cannot generate webhook secret", zap.Error(err))
+
+		return models.Webhook{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	w.CreatedBy = tokenPayload.UserID
+
+	w, err = gm.storage.AddWebhook(ctx, w)
+	if err != nil {
+		gm.log.Error("cannot create webhook", zap.Error(err))
+
+		return models.Webhook{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("webhook created",
+		zap.Int64("webhook id", w.ID),
+		zap.String("shop", w.Shop),
+		zap.Int("operator id", w.CreatedBy))
+
+	return w, nil
+}
+
+// checkWebhookShop проверяет, что префикс адреса совпадает с магазином, к которому привязана учётная запись.
+// Пустой или чужой префикс отправлял бы магазину события чужих заказов.
+func (gm *GMart) checkWebhookShop(ctx context.Context, userID int, shop string) error {
+	if shop == "" {
+		return models.ErrInvalidInput
+	}
+
+	own, err := gm.storage.GetUserShop(ctx, userID)
+	if err != nil {
+		gm.log.Error("cannot get user shop", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if own == "" || shop != own {
+		gm.log.Warn("webhook for a foreign shop",
+			zap.Int("user id", userID),
+			zap.String("shop", shop),
+			zap.String("own shop", own))
+
+		return models.ErrForbidden
+	}
+
+	return nil
+}
+
+// SetUserShop привязывает учётную запись магазина к префиксу номеров заказов магазина, пустой префикс
+// снимает привязку. Адреса, зарегистрированные для прежнего магазина, перестают получать события.
+func (gm *GMart) SetUserShop(ctx context.Context, login, shop string) error {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageRoles)
+	if err != nil {
+		return err
+	}
+
+	shop = strings.TrimSpace(shop)
+
+	if login == "" {
+		return models.ErrInvalidInput
+	}
+
+	u, err := gm.storage.GetUser(ctx, models.User{Login: login})
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+
+		gm.log.Error("cannot get user", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if u.Role != models.RoleService {
+		return models.ErrInvalidInput
+	}
+
+	if err := gm.storage.SetUserShop(ctx, u.ID, shop); err != nil {
+		if errors.Is(err, models.ErrNotFound) || errors.Is(err, models.ErrConflict) {
+			return err
+		}
+
+		gm.log.Error("cannot set user shop", zap.Error(err))
+
+		return fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("user shop changed",
+		zap.Int("user id", u.ID),
+		zap.String("shop", shop),
+		zap.Int("changed by", tokenPayload.UserID))
+
+	return nil
+}
+
+// DisableWebhook выключает адрес магазина, недоставленные события на него больше не отправляются.
+func (gm *GMart) DisableWebhook(ctx context.Context, id int64) (models.Webhook, error) {
+	tokenPayload, err := gm.ownWebhook(ctx, id)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	w, err := gm.storage.DisableWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.Webhook{}, err
+		}
+
+		gm.log.Error("cannot disable webhook", zap.Error(err))
+
+		return models.Webhook{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	gm.log.Info("webhook disabled",
+		zap.Int64("webhook id", w.ID),
+		zap.Int("operator id", tokenPayload.UserID))
+
+	return w, nil
+}
+
+// GetWebhookDeliveries возвращает последние доставки на адрес магазина с историей попыток.
+func (gm *GMart) GetWebhookDeliveries(ctx context.Context, id int64) ([]models.WebhookDelivery, error) {
+	if _, err := gm.ownWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := gm.storage.GetWebhookDeliveries(ctx, id, webhookDeliveriesLimit)
+	if err != nil {
+		gm.log.Error("cannot get webhook deliveries", zap.Error(err))
+
+		return nil, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	return deliveries, nil
+}
+
+// ownWebhook проверяет право на управление адресом. Чужой адрес для учётной записи магазина не существует.
+func (gm *GMart) ownWebhook(ctx context.Context, id int64) (models.TokenPayload, error) {
+	tokenPayload, err := gm.authorize(ctx, models.PermManageWebhooks)
+	if err != nil {
+		return models.TokenPayload{}, err
+	}
+
+	w, err := gm.storage.GetWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.TokenPayload{}, err
+		}
+
+		gm.log.Error("cannot get webhook", zap.Error(err))
+
+		return models.TokenPayload{}, fmt.Errorf("%w: %w", models.ErrInternal, err)
+	}
+
+	if !tokenPayload.Role.Can(models.PermManageAllWebhooks) && w.CreatedBy != tokenPayload.UserID {
+		return models.TokenPayload{}, models.ErrNotFound
+	}
+
+	return tokenPayload, nil
+}
+
+// dispatchWebhooks периодически создаёт доставки новых событий outbox и отправляет доставки,
+// время попытки которых наступило.
+func (gm *GMart) dispatchWebhooks() {
+	if gm.set.Webhook.DispatchInterval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(gm.set.Webhook.DispatchInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-gm.doneCh:
+			return
+		case <-tick.C:
+			gm.dispatchWebhookEvents()
+			gm.deliverWebhooks()
+		}
+	}
+}
+
+func (gm *GMart) dispatchWebhookEvents() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookStorageTimeout)
+		count, err := gm.storage.DispatchWebhookEvents(ctx, webhookDispatchBatch)
+
+		cancel()
+
+		if err != nil {
+			gm.log.Warn("cannot dispatch webhook events", zap.Error(err))
+
+			return
+		}
+
+		if count < webhookDispatchBatch {
+			return
+		}
+	}
+}
+
+// deliverWebhooks отправляет пачку доставок параллельно. Выбранные доставки откладываются на время,
+// большее времени запроса, поэтому за время отправки их не выберет другой экземпляр сервиса.
+func (gm *GMart) deliverWebhooks() {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookStorageTimeout)
+
+	deliveries, err := gm.storage.ClaimWebhookDeliveries(ctx, webhookDeliveryBatch,
+		gm.set.Webhook.Timeout+webhookStorageTimeout)
+
+	cancel()
+
+	if err != nil {
+		gm.log.Warn("cannot claim webhook deliveries", zap.Error(err))
+
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for _, d := range deliveries {
+		wg.Add(1)
+
+		go func(d models.WebhookDelivery) {
+			defer wg.Done()
+
+			gm.deliverWebhook(d)
+		}(d)
+	}
+
+	wg.Wait()
+}
+
+// deliverWebhook выполняет попытку доставки и записывает её результат. После неудачной попытки
+// следующая назначается с экспоненциальной задержкой, после последней доставка считается неудачной.
+func (gm *GMart) deliverWebhook(d models.WebhookDelivery) {
+	body, err := json.Marshal(webhookPayload{
+		ID:        d.Event.ID,
+		Type:      d.Event.Kind,
+		Order:     d.Event.Order,
+		Amount:    float64(d.Event.Amount) / 100,
+		CreatedAt: d.Event.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		gm.log.Error("cannot encode webhook event", zap.Error(err))
+
+		return
+	}
+
+	attempt := models.WebhookAttempt{
+		DeliveryID: d.ID,
+		Attempt:    d.Attempts,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gm.set.Webhook.Timeout)
+	start := time.Now()
+
+	attempt.StatusCode, err = gm.webhooks.SendWebhook(ctx, d, body)
+	attempt.Duration = time.Since(start)
+
+	cancel()
+
+	status := models.WebhookDelivered
+	next := time.Now()
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case attempt.StatusCode < 200 || attempt.StatusCode > 299:
+		attempt.Error = fmt.Sprintf("unexpected status code %d", attempt.StatusCode)
+	}
+
+	if attempt.Error != "" {
+		if len(attempt.Error) > webhookMaxError {
+			attempt.Error = attempt.Error[:webhookMaxError]
+		}
+
+		status = models.WebhookPending
+		next = next.Add(gm.webhookRetryDelay(d.Attempts))
+
+		if d.Attempts >= gm.set.Webhook.MaxAttempts {
+			status = models.WebhookFailed
+		}
+
+		gm.log.Info("webhook delivery failed",
+			zap.Int64("delivery id", d.ID),
+			zap.Int("attempt", d.Attempts),
+			zap.String("error", attempt.Error))
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), webhookStorageTimeout)
+	defer cancel()
+
+	if err := gm.storage.SaveWebhookAttempt(ctx, attempt, status, next); err != nil {
+		gm.log.Warn("cannot save webhook attempt", zap.Int64("delivery id", d.ID), zap.Error(err))
+	}
+}
+
+// webhookRetryDelay возвращает задержку после попытки attempt: RetryDelay, удваиваемую с каждой попыткой,
+// но не больше MaxRetryDelay.
+func (gm *GMart) webhookRetryDelay(attempt int) time.Duration {
+	delay := gm.set.Webhook.RetryDelay
+
+	for i := 1; i < attempt && delay < gm.set.Webhook.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > gm.set.Webhook.MaxRetryDelay {
+		delay = gm.set.Webhook.MaxRetryDelay
+	}
+
+	return delay
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	res := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
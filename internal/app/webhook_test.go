@@ -0,0 +1,485 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"gophermat/internal/http/client"
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type webhookStorage struct {
+	storage
+
+	mu         sync.Mutex
+	users      map[string]models.User
+	shops      map[int]string
+	webhooks   []models.Webhook
+	createdBy  int
+	deliveries []models.WebhookDelivery
+	attempts   []savedAttempt
+	shopErr    error
+}
+
+// savedAttempt записанная попытка доставки с новым состоянием доставки.
+type savedAttempt struct {
+	attempt models.WebhookAttempt
+	status  models.WebhookDeliveryStatus
+	next    time.Time
+}
+
+func (s *webhookStorage) GetUser(_ context.Context, user models.User) (models.User, error) {
+	u, ok := s.users[user.Login]
+	if !ok {
+		return models.User{}, models.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (s *webhookStorage) SetUserShop(_ context.Context, userID int, shop string) error {
+	if s.shopErr != nil {
+		return s.shopErr
+	}
+
+	s.shops[userID] = shop
+
+	return nil
+}
+
+func (s *webhookStorage) GetUserShop(_ context.Context, userID int) (string, error) {
+	return s.shops[userID], nil
+}
+
+func (s *webhookStorage) AddWebhook(_ context.Context, w models.Webhook) (models.Webhook, error) {
+	w.ID = int64(len(s.webhooks) + 1)
+	w.Active = true
+	s.webhooks = append(s.webhooks, w)
+
+	return w, nil
+}
+
+func (s *webhookStorage) GetWebhooks(_ context.Context, createdBy int) ([]models.Webhook, error) {
+	s.createdBy = createdBy
+
+	return s.webhooks, nil
+}
+
+func (s *webhookStorage) GetWebhook(_ context.Context, id int64) (models.Webhook, error) {
+	if id < 1 || int(id) > len(s.webhooks) {
+		return models.Webhook{}, models.ErrNotFound
+	}
+
+	return s.webhooks[id-1], nil
+}
+
+func (s *webhookStorage) DisableWebhook(_ context.Context, id int64) (models.Webhook, error) {
+	s.webhooks[id-1].Active = false
+
+	return s.webhooks[id-1], nil
+}
+
+func (s *webhookStorage) ClaimWebhookDeliveries(_ context.Context, _ int, _ time.Duration) ([]models.WebhookDelivery, error) {
+	deliveries := s.deliveries
+	s.deliveries = nil
+
+	return deliveries, nil
+}
+
+func (s *webhookStorage) SaveWebhookAttempt(
+	_ context.Context,
+	attempt models.WebhookAttempt,
+	status models.WebhookDeliveryStatus,
+	next time.Time,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts = append(s.attempts, savedAttempt{attempt: attempt, status: status, next: next})
+
+	return nil
+}
+
+// newWebhookStorage возвращает хранилище с учётными записями магазинов shop (id 2, привязана к 123),
+// unbound (id 3, без магазина) и пользователем bob (id 4).
+func newWebhookStorage() *webhookStorage {
+	return &webhookStorage{
+		users: map[string]models.User{
+			"shop":    {ID: 2, Login: "shop", Role: models.RoleService},
+			"unbound": {ID: 3, Login: "unbound", Role: models.RoleService},
+			"bob":     {ID: 4, Login: "bob", Role: models.RoleUser},
+		},
+		shops: map[int]string{2: "123"},
+	}
+}
+
+func TestCreateWebhookShop(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   int
+		role     models.Role
+		shop     string
+		wantShop string
+		wantErr  error
+	}{
+		{name: "own shop", userID: 2, role: models.RoleService, shop: " 123 ", wantShop: "123"},
+		{name: "empty shop", userID: 2, role: models.RoleService, wantErr: models.ErrInvalidInput},
+		{name: "foreign shop", userID: 2, role: models.RoleService, shop: "456", wantErr: models.ErrForbidden},
+		// более короткий префикс захватывал бы заказы других магазинов
+		{name: "shorter prefix", userID: 2, role: models.RoleService, shop: "12", wantErr: models.ErrForbidden},
+		{name: "unbound account", userID: 3, role: models.RoleService, shop: "123", wantErr: models.ErrForbidden},
+		{name: "admin any shop", userID: 1, role: models.RoleAdmin, shop: "456", wantShop: "456"},
+		{name: "admin all orders", userID: 1, role: models.RoleAdmin, wantShop: ""},
+		{name: "user", userID: 4, role: models.RoleUser, shop: "123", wantErr: models.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newWebhookStorage()
+			gm := newTestGMart(st, nil)
+
+			w, err := gm.CreateWebhook(withPayload(context.Background(), tt.userID, tt.role), models.Webhook{
+				Shop:   tt.shop,
+				URL:    "https://shop.example/hook",
+				Events: []string{models.WebhookAccrual, models.WebhookAccrual},
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || len(st.webhooks) != 0 {
+					t.Errorf("err = %v, webhooks = %+v, want %v", err, st.webhooks, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateWebhook: %v", err)
+			}
+
+			if w.Shop != tt.wantShop || w.CreatedBy != tt.userID || w.Secret == "" || len(w.Events) != 1 {
+				t.Errorf("webhook = %+v, want shop %q created by %d with a secret", w, tt.wantShop, tt.userID)
+			}
+		})
+	}
+}
+
+func TestCreateWebhookInvalid(t *testing.T) {
+	ctx := withPayload(context.Background(), 1, models.RoleAdmin)
+
+	for _, w := range []models.Webhook{
+		{URL: "ftp://shop.example", Events: []string{models.WebhookAccrual}},
+		{URL: "https://", Events: []string{models.WebhookAccrual}},
+		{URL: "https://shop.example"},
+		{URL: "https://shop.example", Events: []string{"refund"}},
+		// адреса во внутренней сети
+		{URL: "http://127.0.0.1:8080/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "http://localhost/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "http://169.254.169.254/latest/meta-data", Events: []string{models.WebhookAccrual}},
+		{URL: "https://10.0.0.5/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "https://192.168.1.1/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "https://172.16.0.1/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "https://[::1]/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "https://[::ffff:127.0.0.1]/hook", Events: []string{models.WebhookAccrual}},
+		{URL: "https://0.0.0.0/hook", Events: []string{models.WebhookAccrual}},
+	} {
+		if _, err := newTestGMart(newWebhookStorage(), nil).CreateWebhook(ctx, w); !errors.Is(err, models.ErrInvalidInput) {
+			t.Errorf("webhook %+v: err = %v, want ErrInvalidInput", w, err)
+		}
+	}
+}
+
+// TestWebhookOwnership проверяет, что учётная запись магазина видит и меняет только свои адреса.
+func TestWebhookOwnership(t *testing.T) {
+	st := newWebhookStorage()
+	st.webhooks = []models.Webhook{{ID: 1, Shop: "123", CreatedBy: 2}, {ID: 2, Shop: "456", CreatedBy: 5}}
+	gm := newTestGMart(st, nil)
+
+	shop := withPayload(context.Background(), 2, models.RoleService)
+	admin := withPayload(context.Background(), 1, models.RoleAdmin)
+
+	if _, err := gm.GetWebhooks(shop); err != nil || st.createdBy != 2 {
+		t.Errorf("service GetWebhooks: createdBy = %d, %v, want own webhooks", st.createdBy, err)
+	}
+
+	if _, err := gm.GetWebhooks(admin); err != nil || st.createdBy != 0 {
+		t.Errorf("admin GetWebhooks: createdBy = %d, %v, want all webhooks", st.createdBy, err)
+	}
+
+	if _, err := gm.DisableWebhook(shop, 2); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("disable foreign webhook: err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := gm.GetWebhookDeliveries(shop, 2); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("deliveries of foreign webhook: err = %v, want ErrNotFound", err)
+	}
+
+	if w, err := gm.DisableWebhook(shop, 1); err != nil || w.Active {
+		t.Errorf("disable own webhook = %+v, %v", w, err)
+	}
+
+	if w, err := gm.DisableWebhook(admin, 2); err != nil || w.Active {
+		t.Errorf("admin disable = %+v, %v", w, err)
+	}
+
+	if _, err := gm.GetWebhooks(withPayload(context.Background(), 4, models.RoleUser)); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("user GetWebhooks: err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestSetUserShop(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       models.Role
+		login      string
+		shop       string
+		storageErr error
+		wantErr    error
+	}{
+		{name: "admin", role: models.RoleAdmin, login: "unbound", shop: " 456 "},
+		{name: "unbind", role: models.RoleAdmin, login: "shop", shop: ""},
+		{name: "service", role: models.RoleService, login: "unbound", shop: "456", wantErr: models.ErrForbidden},
+		{name: "support", role: models.RoleSupport, login: "unbound", shop: "456", wantErr: models.ErrForbidden},
+		{name: "not a service account", role: models.RoleAdmin, login: "bob", shop: "456", wantErr: models.ErrInvalidInput},
+		{name: "empty login", role: models.RoleAdmin, shop: "456", wantErr: models.ErrInvalidInput},
+		{name: "unknown user", role: models.RoleAdmin, login: "ghost", shop: "456", wantErr: models.ErrNotFound},
+		{name: "overlapping shop", role: models.RoleAdmin, login: "unbound", shop: "1234",
+			storageErr: models.ErrConflict, wantErr: models.ErrConflict},
+		{name: "storage failure", role: models.RoleAdmin, login: "unbound", shop: "456",
+			storageErr: errors.New("connection lost"), wantErr: models.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newWebhookStorage()
+			st.shopErr = tt.storageErr
+			gm := newTestGMart(st, nil)
+
+			err := gm.SetUserShop(withPayload(context.Background(), 1, tt.role), tt.login, tt.shop)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetUserShop: %v", err)
+			}
+
+			id := st.users[tt.login].ID
+			if got, want := st.shops[id], map[string]string{"unbound": "456", "shop": ""}[tt.login]; got != want {
+				t.Errorf("shop = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// testReceiver магазин, который проверяет подпись событий и отвечает заданными кодами.
+type testReceiver struct {
+	t      *testing.T
+	secret string
+
+	mu       sync.Mutex
+	codes    []int
+	payloads []webhookPayload
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("cannot read webhook: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(r.secret))
+	mac.Write([]byte(req.Header.Get(client.WebhookTimestampHeader) + "."))
+	mac.Write(body)
+
+	if req.Header.Get(client.WebhookSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	var p webhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		r.t.Errorf("cannot decode webhook %q: %v", body, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.payloads = append(r.payloads, p)
+
+	code := http.StatusOK
+	if len(r.codes) > 0 {
+		code, r.codes = r.codes[0], r.codes[1:]
+	}
+
+	w.WriteHeader(code)
+}
+
+func newWebhookGMart(st *webhookStorage) *GMart {
+	gm := newTestGMart(st, &settings.Settings{Webhook: settings.WebhookSettings{
+		Timeout:       time.Second,
+		MaxAttempts:   3,
+		RetryDelay:    time.Minute,
+		MaxRetryDelay: time.Hour,
+	}})
+	gm.webhooks = client.NewWebhookClient(zap.NewNop(), time.Second, true)
+
+	return gm
+}
+
+func testDelivery(url, secret string, attempts int) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:       1,
+		URL:      url,
+		Secret:   secret,
+		Attempts: attempts,
+		Event: models.WebhookEvent{ID: 10, Kind: models.WebhookAccrual, Order: "12345678903", Amount: 1050,
+			CreatedAt: time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	receiver := &testReceiver{t: t, secret: "secret"}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	st := newWebhookStorage()
+	gm := newWebhookGMart(st)
+
+	gm.deliverWebhook(testDelivery(srv.URL, "secret", 1))
+
+	want := webhookPayload{ID: 10, Type: models.WebhookAccrual, Order: "12345678903", Amount: 10.5,
+		CreatedAt: "2023-12-01T12:00:00Z"}
+
+	if len(receiver.payloads) != 1 || receiver.payloads[0] != want {
+		t.Errorf("payloads = %+v, want %+v", receiver.payloads, want)
+	}
+
+	if len(st.attempts) != 1 {
+		t.Fatalf("attempts = %+v, want 1", st.attempts)
+	}
+
+	a := st.attempts[0]
+	if a.status != models.WebhookDelivered || a.attempt.DeliveryID != 1 || a.attempt.Attempt != 1 ||
+		a.attempt.StatusCode != http.StatusOK || a.attempt.Error != "" {
+		t.Errorf("attempt = %+v", a)
+	}
+
+	// магазин отвергает подпись чужим ключом, доставка повторяется
+	gm.deliverWebhook(testDelivery(srv.URL, "other", 1))
+
+	if a := st.attempts[1]; a.status != models.WebhookPending || a.attempt.StatusCode != http.StatusUnauthorized {
+		t.Errorf("attempt with a wrong signature = %+v", a)
+	}
+}
+
+// TestDeliverWebhookRetry проверяет повтор с экспоненциальной задержкой и отказ после последней попытки.
+func TestDeliverWebhookRetry(t *testing.T) {
+	receiver := &testReceiver{t: t, secret: "secret",
+		codes: []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway}}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	st := newWebhookStorage()
+	gm := newWebhookGMart(st)
+
+	tests := []struct {
+		attempt int
+		code    int
+		status  models.WebhookDeliveryStatus
+		delay   time.Duration
+	}{
+		{attempt: 1, code: http.StatusInternalServerError, status: models.WebhookPending, delay: time.Minute},
+		{attempt: 2, code: http.StatusServiceUnavailable, status: models.WebhookPending, delay: 2 * time.Minute},
+		{attempt: 3, code: http.StatusBadGateway, status: models.WebhookFailed, delay: 4 * time.Minute},
+	}
+
+	for i, tt := range tests {
+		start := time.Now()
+
+		gm.deliverWebhook(testDelivery(srv.URL, "secret", tt.attempt))
+
+		a := st.attempts[i]
+		if a.status != tt.status || a.attempt.Attempt != tt.attempt || a.attempt.StatusCode != tt.code ||
+			a.attempt.Error == "" {
+			t.Errorf("attempt %d = %+v, want %s with code %d", tt.attempt, a, tt.status, tt.code)
+		}
+
+		if a.next.Before(start.Add(tt.delay)) || a.next.After(time.Now().Add(tt.delay)) {
+			t.Errorf("attempt %d: next attempt in %v, want %v", tt.attempt, a.next.Sub(start), tt.delay)
+		}
+	}
+
+	// закрытый адрес: ответа нет, код 0 и ошибка соединения
+	srv.Close()
+
+	gm.deliverWebhook(testDelivery(srv.URL, "secret", 1))
+
+	if a := st.attempts[len(st.attempts)-1]; a.status != models.WebhookPending || a.attempt.StatusCode != 0 ||
+		a.attempt.Error == "" {
+		t.Errorf("attempt to a closed receiver = %+v", a)
+	}
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	receiver := &testReceiver{t: t, secret: "secret"}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	st := newWebhookStorage()
+	gm := newWebhookGMart(st)
+
+	for i := 1; i <= 3; i++ {
+		d := testDelivery(srv.URL, "secret", 1)
+		d.ID, d.Event.ID = int64(i), int64(i)
+		st.deliveries = append(st.deliveries, d)
+	}
+
+	gm.deliverWebhooks()
+
+	if len(receiver.payloads) != 3 || len(st.attempts) != 3 {
+		t.Fatalf("payloads = %d, attempts = %d, want 3", len(receiver.payloads), len(st.attempts))
+	}
+
+	for _, a := range st.attempts {
+		if a.status != models.WebhookDelivered {
+			t.Errorf("attempt = %+v, want delivered", a)
+		}
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	gm := newTestGMart(nil, &settings.Settings{Webhook: settings.WebhookSettings{
+		RetryDelay:    30 * time.Second,
+		MaxRetryDelay: 5 * time.Minute,
+	}})
+
+	for attempt, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		4:  4 * time.Minute,
+		5:  5 * time.Minute,
+		20: 5 * time.Minute,
+	} {
+		if got := gm.webhookRetryDelay(attempt); got != want {
+			t.Errorf("webhookRetryDelay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"gophermat/internal/models"
+	"gophermat/internal/ordernumber"
+	"gophermat/internal/settings"
+)
+
+type withdrawStorage struct {
+	storage
+
+	limits    []models.WithdrawLimits
+	withdrawn []models.BalanceWithdraw
+	err       error
+}
+
+func (s *withdrawStorage) Withdraw(
+	_ context.Context,
+	_ int,
+	withdraw models.BalanceWithdraw,
+	limits models.WithdrawLimits,
+) error {
+	s.limits = append(s.limits, limits)
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.withdrawn = append(s.withdrawn, withdraw)
+
+	return nil
+}
+
+func TestDeductPoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		withdraw   models.BalanceWithdraw
+		storageErr error
+		wantErr    error
+	}{
+		{name: "valid", withdraw: models.BalanceWithdraw{Order: "2377225624", Sum: 751}},
+		{
+			name:     "luhn mismatch",
+			withdraw: models.BalanceWithdraw{Order: "2377225625", Sum: 751},
+			wantErr:  models.ErrInvalidOrderNumber,
+		},
+		{
+			name:     "not a number",
+			withdraw: models.BalanceWithdraw{Order: "23772256a4", Sum: 751},
+			wantErr:  models.ErrInvalidOrderNumber,
+		},
+		{name: "empty order", withdraw: models.BalanceWithdraw{Sum: 751}, wantErr: models.ErrInvalidOrderNumber},
+		{name: "zero sum", withdraw: models.BalanceWithdraw{Order: "2377225624"}, wantErr: models.ErrInvalidInput},
+		{
+			name:     "negative sum",
+			withdraw: models.BalanceWithdraw{Order: "2377225624", Sum: -1},
+			wantErr:  models.ErrInvalidInput,
+		},
+		{
+			name:       "insufficient funds",
+			withdraw:   models.BalanceWithdraw{Order: "2377225624", Sum: 751},
+			storageErr: models.ErrInsufficientBalance,
+			wantErr:    models.ErrInsufficientBalance,
+		},
+		{
+			name:       "duplicate order",
+			withdraw:   models.BalanceWithdraw{Order: "2377225624", Sum: 751},
+			storageErr: models.ErrOrderWithdrawn,
+			wantErr:    models.ErrOrderWithdrawn,
+		},
+		{
+			name:       "order cap",
+			withdraw:   models.BalanceWithdraw{Order: "2377225624", Sum: 751},
+			storageErr: models.ErrOrderWithdrawalCap,
+			wantErr:    models.ErrOrderWithdrawalCap,
+		},
+		{
+			name:       "storage failure",
+			withdraw:   models.BalanceWithdraw{Order: "2377225624", Sum: 751},
+			storageErr: errors.New("boom"),
+			wantErr:    models.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &withdrawStorage{err: tt.storageErr}
+			gm := newTestGMart(st, nil)
+			gm.orders = ordernumber.Luhn{}
+
+			err := gm.DeductPoints(withPayload(context.Background(), 1, models.RoleUser), tt.withdraw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			// неверный запрос не доходит до хранилища
+			if tt.storageErr == nil && tt.wantErr != nil && len(st.limits) != 0 {
+				t.Errorf("invalid withdrawal reached storage")
+			}
+		})
+	}
+}
+
+func TestWithdrawLimits(t *testing.T) {
+	tests := []struct {
+		set  settings.WithdrawSettings
+		want models.WithdrawLimits
+	}{
+		{set: settings.WithdrawSettings{}, want: models.WithdrawLimits{MaxPerOrder: 1}},
+		{set: settings.WithdrawSettings{MaxPerOrder: 1}, want: models.WithdrawLimits{MaxPerOrder: 1}},
+		{
+			set:  settings.WithdrawSettings{MaxPerOrder: 3, OrderCap: 500.5},
+			want: models.WithdrawLimits{MaxPerOrder: 3, OrderCap: 50050},
+		},
+	}
+
+	for _, tt := range tests {
+		gm := newTestGMart(nil, &settings.Settings{Withdraw: tt.set})
+
+		if got := gm.withdrawLimits(); got != tt.want {
+			t.Errorf("withdrawLimits() with %+v = %+v, want %+v", tt.set, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package authentication
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -21,6 +22,7 @@ var (
 	ErrParseToken           = errors.New("parse token error")
 	ErrSignedToken          = errors.New("signed token")
 	ErrUnknownSigningMethod = errors.New("unexpected signing method")
+	ErrTokenIsRevoked       = errors.New("the token is revoked")
 )
 
 // Claims contains internal claims and user payload.
@@ -29,11 +31,18 @@ type Claims struct {
 	models.TokenPayload
 }
 
+type storage interface {
+	GetTokenVersion(ctx context.Context, userID int) (int, error)
+}
+
 type Authenticator struct {
+	storage storage
 }
 
-func NewAuthenticator() *Authenticator {
-	return &Authenticator{}
+func NewAuthenticator(storage storage) *Authenticator {
+	return &Authenticator{
+		storage: storage,
+	}
 }
 
 func (a *Authenticator) GenerateToken(payload models.TokenPayload) (string, error) {
@@ -54,7 +63,8 @@ func (a *Authenticator) GenerateToken(payload models.TokenPayload) (string, erro
 	return ss, nil
 }
 
-func (a *Authenticator) ParseToken(tokenString string) (models.TokenPayload, error) {
+// ParseToken проверяет подпись и срок действия токена, а также что токен не был отозван сменой пароля.
+func (a *Authenticator) ParseToken(ctx context.Context, tokenString string) (models.TokenPayload, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("%w: %v", ErrUnknownSigningMethod, token.Header["alg"])
@@ -62,14 +72,30 @@ func (a *Authenticator) ParseToken(tokenString string) (models.TokenPayload, err
 
 		return []byte(Secret), nil
 	}, jwt.WithLeeway(LeewayDuration*time.Second))
+	if err != nil {
+		// у неразобранного токена нет claims
+		return models.TokenPayload{}, fmt.Errorf("%w: %w", ErrParseToken, err)
+	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
 		if time.Now().After(claims.ExpiresAt.Time) {
 			return models.TokenPayload{}, ErrTokenIsExpired
 		}
 
-		return claims.TokenPayload, nil
+		version, err := a.storage.GetTokenVersion(ctx, claims.UserID)
+		if err != nil {
+			return models.TokenPayload{}, fmt.Errorf("%w: %w", ErrParseToken, err)
+		}
+
+		if version != claims.TokenVersion {
+			return models.TokenPayload{}, ErrTokenIsRevoked
+		}
+
+		payload := claims.TokenPayload
+		payload.Expiry = claims.ExpiresAt.Time
+
+		return payload, nil
 	}
 
-	return models.TokenPayload{}, fmt.Errorf("%w: %w", ErrParseToken, err)
+	return models.TokenPayload{}, ErrParseToken
 }
@@ -0,0 +1,98 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"gophermat/internal/models"
+)
+
+type versionStorage map[int]int
+
+func (s versionStorage) GetTokenVersion(_ context.Context, userID int) (int, error) {
+	version, ok := s[userID]
+	if !ok {
+		return 0, models.ErrNotFound
+	}
+
+	return version, nil
+}
+
+func TestParseToken(t *testing.T) {
+	a := NewAuthenticator(versionStorage{7: 2})
+	ctx := context.Background()
+
+	start := time.Now()
+
+	token, err := a.GenerateToken(models.TokenPayload{UserID: 7, TokenVersion: 2, Role: models.RoleService})
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	payload, err := a.ParseToken(ctx, token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+
+	// срок действия нужен соединениям, которые закрываются вместе с истечением токена
+	if payload.UserID != 7 || payload.Role != models.RoleService ||
+		payload.Expiry.Before(start.Add(ExpiresAt).Truncate(time.Second)) ||
+		payload.Expiry.After(time.Now().Add(ExpiresAt)) {
+		t.Errorf("payload = %+v, want user 7 expiring in %v", payload, ExpiresAt)
+	}
+}
+
+func TestParseTokenInvalid(t *testing.T) {
+	a := NewAuthenticator(versionStorage{7: 2})
+	ctx := context.Background()
+
+	sign := func(claims Claims, method jwt.SigningMethod, key any) string {
+		t.Helper()
+
+		s, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("cannot sign token: %v", err)
+		}
+
+		return s
+	}
+
+	valid := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		TokenPayload:     models.TokenPayload{UserID: 7, TokenVersion: 2},
+	}
+
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	revoked := valid
+	revoked.TokenVersion = 1
+
+	unknown := valid
+	unknown.UserID = 8
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "garbage", token: "token", wantErr: ErrParseToken},
+		{name: "other secret", token: sign(valid, jwt.SigningMethodHS256, []byte("other")), wantErr: ErrParseToken},
+		{name: "unsigned", token: sign(valid, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType), wantErr: ErrParseToken},
+		{name: "expired", token: sign(expired, jwt.SigningMethodHS256, []byte(Secret)), wantErr: ErrParseToken},
+		{name: "revoked", token: sign(revoked, jwt.SigningMethodHS256, []byte(Secret)), wantErr: ErrTokenIsRevoked},
+		{name: "unknown user", token: sign(unknown, jwt.SigningMethodHS256, []byte(Secret)), wantErr: ErrParseToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := a.ParseToken(ctx, tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
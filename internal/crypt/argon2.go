@@ -0,0 +1,144 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2Prefix = "$" + AlgorithmArgon2id + "$"
+
+	defaultArgon2Time    = 3
+	defaultArgon2Memory  = 64 * 1024
+	defaultArgon2Threads = 2
+	defaultArgon2KeyLen  = 32
+	defaultArgon2SaltLen = 16
+)
+
+// Argon2Params describes tunable parameters of argon2id.
+type Argon2Params struct {
+	// Time number of passes over the memory.
+	Time uint32
+	// Memory size of the memory in KiB.
+	Memory uint32
+	// Threads number of threads.
+	Threads uint8
+	// KeyLen length of the generated key in bytes.
+	KeyLen uint32
+	// SaltLen length of the random salt in bytes.
+	SaltLen uint32
+}
+
+type argon2idAlgorithm struct {
+	params Argon2Params
+}
+
+func newArgon2id(params Argon2Params) *argon2idAlgorithm {
+	if params.Time == 0 {
+		params.Time = defaultArgon2Time
+	}
+
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Memory
+	}
+
+	if params.Threads == 0 {
+		params.Threads = defaultArgon2Threads
+	}
+
+	if params.KeyLen == 0 {
+		params.KeyLen = defaultArgon2KeyLen
+	}
+
+	if params.SaltLen == 0 {
+		params.SaltLen = defaultArgon2SaltLen
+	}
+
+	return &argon2idAlgorithm{params: params}
+}
+
+// hash returns hash in PHC string format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>.
+func (a *argon2idAlgorithm) hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("argon2id: generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Time, a.params.Memory, a.params.Threads, a.params.KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix,
+		argon2.Version,
+		a.params.Memory,
+		a.params.Time,
+		a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (a *argon2idAlgorithm) check(password, hash string) error {
+	params, salt, key, err := decodeArgon2(hash)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatch
+	}
+
+	return nil
+}
+
+func (a *argon2idAlgorithm) match(hash string) bool {
+	return strings.HasPrefix(hash, argon2Prefix)
+}
+
+func (a *argon2idAlgorithm) outdated(hash string) bool {
+	params, salt, _, err := decodeArgon2(hash)
+	if err != nil {
+		return true
+	}
+
+	params.SaltLen = uint32(len(salt))
+
+	return params != a.params
+}
+
+func decodeArgon2(hash string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return Argon2Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: unsupported argon2 version", ErrInvalidHash)
+	}
+
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+
+	params.KeyLen = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,58 @@
+package crypt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptAlgorithm struct {
+	cost int
+}
+
+func newBcrypt(cost int) *bcryptAlgorithm {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &bcryptAlgorithm{cost: cost}
+}
+
+func (b *bcryptAlgorithm) hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", fmt.Errorf("bcrypt: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func (b *bcryptAlgorithm) check(password, hash string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatch
+		}
+
+		return fmt.Errorf("bcrypt: %w", err)
+	}
+
+	return nil
+}
+
+func (b *bcryptAlgorithm) match(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+func (b *bcryptAlgorithm) outdated(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+
+	return cost != b.cost
+}
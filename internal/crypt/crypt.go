@@ -1,27 +1,109 @@
 package crypt
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
 
-	"golang.org/x/crypto/bcrypt"
+var (
+	ErrUnknownAlgorithm = errors.New("unknown hash algorithm")
+	ErrInvalidHash      = errors.New("invalid hash format")
+	ErrMismatch         = errors.New("password does not match hash")
 )
 
+// Config describes the algorithm and its parameters used for new password hashes.
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// algorithm is implemented by every supported hash algorithm.
+type algorithm interface {
+	// hash generates hash string in PHC-like format which records the algorithm and its parameters.
+	hash(password string) (string, error)
+	// check compares password with hash.
+	check(password, hash string) error
+	// match reports whether the hash is produced by this algorithm.
+	match(hash string) bool
+	// outdated reports whether the hash parameters differ from the configured ones.
+	outdated(hash string) bool
+}
+
+// Hasher generates hashes with the configured algorithm and verifies hashes of any supported algorithm,
+// so the algorithm can be changed without invalidating stored passwords.
+type Hasher struct {
+	primary    algorithm
+	algorithms []algorithm
+}
+
+// NewHasher creates password hasher based on provided config.
+func NewHasher(cfg Config) (*Hasher, error) {
+	bc := newBcrypt(cfg.BcryptCost)
+	ar := newArgon2id(cfg.Argon2)
+
+	h := &Hasher{
+		algorithms: []algorithm{bc, ar},
+	}
+
+	switch strings.ToLower(cfg.Algorithm) {
+	case AlgorithmBcrypt:
+		h.primary = bc
+	case AlgorithmArgon2id, "":
+		h.primary = ar
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	return h, nil
+}
+
 // HashPassword generates hash string based on provided password string.
-func HashPassword(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+func (h *Hasher) HashPassword(password string) (string, error) {
+	hash, err := h.primary.hash(password)
 	if err != nil {
 		return "", fmt.Errorf("generate hash failed: %w", err)
 	}
 
-	return string(hash), nil
+	return hash, nil
 }
 
-// CheckPassword decrypt hash and compares with provided password string.
-func CheckPassword(password string, hash string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+// CheckPassword compares provided password string with hash of any supported algorithm.
+func (h *Hasher) CheckPassword(password string, hash string) error {
+	a, err := h.algorithm(hash)
 	if err != nil {
+		return err
+	}
+
+	if err := a.check(password, hash); err != nil {
 		return fmt.Errorf("compare string with hash failed: %w", err)
 	}
 
 	return nil
 }
+
+// NeedsRehash reports whether the hash was produced by another algorithm or with other parameters
+// than the configured ones and should be replaced after successful password check.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if !h.primary.match(hash) {
+		return true
+	}
+
+	return h.primary.outdated(hash)
+}
+
+func (h *Hasher) algorithm(hash string) (algorithm, error) {
+	for _, a := range h.algorithms {
+		if a.match(hash) {
+			return a, nil
+		}
+	}
+
+	return nil, ErrUnknownAlgorithm
+}
@@ -0,0 +1,218 @@
+package crypt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testArgon2 cheap parameters to keep tests fast.
+var testArgon2 = Argon2Params{Time: 1, Memory: 1024, Threads: 1}
+
+func TestHasher(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h, err := NewHasher(Config{Algorithm: algorithm, BcryptCost: 4, Argon2: testArgon2})
+			if err != nil {
+				t.Fatalf("NewHasher: %v", err)
+			}
+
+			hash, err := h.HashPassword("secret")
+			if err != nil {
+				t.Fatalf("HashPassword: %v", err)
+			}
+
+			if err := h.CheckPassword("secret", hash); err != nil {
+				t.Errorf("CheckPassword: %v", err)
+			}
+
+			if err := h.CheckPassword("other", hash); !errors.Is(err, ErrMismatch) {
+				t.Errorf("CheckPassword with wrong password: got %v, want ErrMismatch", err)
+			}
+
+			if h.NeedsRehash(hash) {
+				t.Error("fresh hash needs rehash")
+			}
+
+			other, err := h.HashPassword("secret")
+			if err != nil {
+				t.Fatalf("HashPassword: %v", err)
+			}
+
+			if other == hash {
+				t.Error("hashes of the same password must differ by salt")
+			}
+		})
+	}
+}
+
+func TestHasherMigration(t *testing.T) {
+	bc, err := NewHasher(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 4})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	ar, err := NewHasher(Config{Algorithm: AlgorithmArgon2id, Argon2: testArgon2})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	old, err := bc.HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	// hashes of the previous algorithm are accepted but must be upgraded
+	if err := ar.CheckPassword("secret", old); err != nil {
+		t.Errorf("CheckPassword of bcrypt hash: %v", err)
+	}
+
+	if !ar.NeedsRehash(old) {
+		t.Error("bcrypt hash must be rehashed when argon2id is configured")
+	}
+
+	stronger, err := NewHasher(Config{Algorithm: AlgorithmArgon2id, Argon2: Argon2Params{Time: 2, Memory: 1024, Threads: 1}})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	hash, err := ar.HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	if !stronger.NeedsRehash(hash) {
+		t.Error("hash with outdated parameters must be rehashed")
+	}
+
+	cheaper, err := NewHasher(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 5})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	if !cheaper.NeedsRehash(old) {
+		t.Error("bcrypt hash with other cost must be rehashed")
+	}
+}
+
+func TestHasherErrors(t *testing.T) {
+	if _, err := NewHasher(Config{Algorithm: "md5"}); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("NewHasher: got %v, want ErrUnknownAlgorithm", err)
+	}
+
+	h, err := NewHasher(Config{Argon2: testArgon2})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		hash string
+		want error
+	}{
+		{name: "unknown", hash: "$md5$abc", want: ErrUnknownAlgorithm},
+		{name: "truncated argon2", hash: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA", want: ErrInvalidHash},
+		{name: "argon2 version", hash: "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5", want: ErrInvalidHash},
+		{name: "argon2 salt", hash: "$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5", want: ErrInvalidHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := h.CheckPassword("secret", tt.hash); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+
+			if !h.NeedsRehash(tt.hash) {
+				t.Error("invalid hash must be rehashed")
+			}
+		})
+	}
+}
+
+func TestArgon2Format(t *testing.T) {
+	h, err := NewHasher(Config{Argon2: testArgon2})
+	if err != nil {
+		t.Fatalf("NewHasher: %v", err)
+	}
+
+	hash, err := h.HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected hash format %q", hash)
+	}
+}
+
+func TestToken(t *testing.T) {
+	a, err := RandomToken()
+	if err != nil {
+		t.Fatalf("RandomToken: %v", err)
+	}
+
+	b, err := RandomToken()
+	if err != nil {
+		t.Fatalf("RandomToken: %v", err)
+	}
+
+	if a == b || len(a) < tokenLen {
+		t.Errorf("weak tokens %q, %q", a, b)
+	}
+
+	if HashToken(a) != HashToken(a) || HashToken(a) == HashToken(b) || HashToken(a) == a {
+		t.Error("unexpected token hash")
+	}
+}
+
+// Benchmarks help to choose hashing parameters: checking one password should stay within
+// the acceptable login latency, usually tens of milliseconds.
+//
+//	go test -run=^$ -bench=. -benchmem ./internal/crypt
+
+func BenchmarkBcrypt(b *testing.B) {
+	for _, cost := range []int{8, 10, 12} {
+		b.Run(fmt.Sprintf("cost=%d", cost), func(b *testing.B) {
+			benchmarkHasher(b, Config{Algorithm: AlgorithmBcrypt, BcryptCost: cost})
+		})
+	}
+}
+
+func BenchmarkArgon2id(b *testing.B) {
+	params := []Argon2Params{
+		{Time: 1, Memory: 64 * 1024, Threads: 4},
+		{Time: 2, Memory: 19 * 1024, Threads: 1},
+		{Time: 3, Memory: 64 * 1024, Threads: 2},
+		{Time: 3, Memory: 128 * 1024, Threads: 2},
+	}
+
+	for _, p := range params {
+		b.Run(fmt.Sprintf("t=%d,m=%d,p=%d", p.Time, p.Memory, p.Threads), func(b *testing.B) {
+			benchmarkHasher(b, Config{Algorithm: AlgorithmArgon2id, Argon2: p})
+		})
+	}
+}
+
+// benchmarkHasher measures password check which is performed on every login.
+func benchmarkHasher(b *testing.B, cfg Config) {
+	b.Helper()
+
+	h, err := NewHasher(cfg)
+	if err != nil {
+		b.Fatalf("NewHasher: %v", err)
+	}
+
+	hash, err := h.HashPassword("correct horse battery staple")
+	if err != nil {
+		b.Fatalf("HashPassword: %v", err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := h.CheckPassword("correct horse battery staple", hash); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
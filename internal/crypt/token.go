@@ -0,0 +1,29 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// tokenLen defines the number of random bytes in generated tokens.
+const tokenLen = 32
+
+// RandomToken generates url-safe random string.
+func RandomToken() (string, error) {
+	b := make([]byte, tokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token failed: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns hex encoded sha256 hash of token. It is used to store tokens without their values.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+// Заголовки запроса с событием. Получатель проверяет подпись: HMAC-SHA256 ключом адреса
+// от строки "<timestamp>.<тело запроса>", в заголовке подписи в виде sha256=<hex>.
+const (
+	WebhookEventHeader     = "X-Gophermart-Event"
+	WebhookDeliveryHeader  = "X-Gophermart-Delivery"
+	WebhookTimestampHeader = "X-Gophermart-Timestamp"
+	WebhookSignatureHeader = "X-Gophermart-Signature"
+
+	// maxWebhookResponse часть ответа получателя, которая читается для повторного использования соединения.
+	maxWebhookResponse = 64 << 10
+)
+
+// ErrPrivateAddress адрес получателя во внутренней сети.
+var ErrPrivateAddress = errors.New("private address")
+
+type WebhookClient struct {
+	dc  *http.Client
+	log *zap.Logger
+}
+
+// NewWebhookClient создаёт клиент доставки событий. Адрес получателя проверяется при каждом подключении
+// после разрешения имени, поэтому имя, которое позже разрешилось во внутренний адрес, не поможет
+// обратиться к внутренним сервисам. allowPrivate снимает эту проверку для локального окружения.
+func NewWebhookClient(log *zap.Logger, timeout time.Duration, allowPrivate bool) *WebhookClient {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !models.PublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+			}
+
+			return nil
+		}
+	}
+
+	return &WebhookClient{
+		dc: &http.Client{
+			Timeout: timeout,
+			// прокси не используется: подключение к нему обошло бы проверку адреса получателя
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// перенаправление на другой адрес не считается доставкой
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		log: log,
+	}
+}
+
+// SendWebhook отправляет подписанное событие на адрес доставки и возвращает код ответа.
+func (c *WebhookClient) SendWebhook(ctx context.Context, d models.WebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("cannot prepare request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, d.Event.Kind)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(d.Secret, timestamp, body))
+
+	resp, err := c.dc.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("cannot do request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponse)); err != nil {
+		c.log.Debug("cannot read webhook response", zap.Error(err))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhook возвращает подпись тела запроса с событием в hex.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
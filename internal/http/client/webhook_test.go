@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+// verify проверяет подпись запроса так, как это делает магазин.
+func verify(r *http.Request, secret string, body []byte) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(r.Header.Get(WebhookTimestampHeader) + "."))
+	mac.Write(body)
+
+	return hmac.Equal([]byte(r.Header.Get(WebhookSignatureHeader)), []byte("sha256="+hex.EncodeToString(mac.Sum(nil))))
+}
+
+func TestSendWebhook(t *testing.T) {
+	body := []byte(`{"id":1,"type":"accrual","order":"79927398713","amount":10.5,"created_at":"2023-12-01T12:00:00Z"}`)
+
+	var (
+		got      []byte
+		verified bool
+		header   http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = io.ReadAll(r.Body)
+		verified = verify(r, "secret", got)
+		header = r.Header.Clone()
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	d := models.WebhookDelivery{ID: 5, URL: srv.URL, Secret: "secret", Event: models.WebhookEvent{Kind: models.WebhookAccrual}}
+
+	start := time.Now().Unix()
+
+	code, err := NewWebhookClient(zap.NewNop(), time.Second, true).SendWebhook(context.Background(), d, body)
+	if err != nil || code != http.StatusAccepted {
+		t.Fatalf("SendWebhook = %d, %v, want 202", code, err)
+	}
+
+	if string(got) != string(body) || !verified {
+		t.Errorf("receiver got %q, signature verified %v", got, verified)
+	}
+
+	if header.Get(WebhookEventHeader) != models.WebhookAccrual || header.Get(WebhookDeliveryHeader) != "5" ||
+		header.Get("Content-Type") != "application/json" {
+		t.Errorf("headers = %v", header)
+	}
+
+	// подпись другим ключом магазин отвергает
+	if r := (&http.Request{Header: header}); verify(r, "other", body) {
+		t.Error("signature is valid with another secret")
+	}
+
+	ts, err := strconv.ParseInt(header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil || ts < start || ts > time.Now().Unix() {
+		t.Errorf("timestamp = %q, want the time of the request", header.Get(WebhookTimestampHeader))
+	}
+}
+
+func TestSendWebhookFailures(t *testing.T) {
+	// перенаправление не считается доставкой, его код возвращается как есть
+	redirect := httptest.NewServer(http.RedirectHandler("/elsewhere", http.StatusFound))
+	defer redirect.Close()
+
+	c := NewWebhookClient(zap.NewNop(), 100*time.Millisecond, true)
+
+	code, err := c.SendWebhook(context.Background(), models.WebhookDelivery{URL: redirect.URL}, nil)
+	if err != nil || code != http.StatusFound {
+		t.Errorf("redirect = %d, %v, want 302", code, err)
+	}
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+
+	if code, err := c.SendWebhook(context.Background(), models.WebhookDelivery{URL: slow.URL}, nil); err == nil || code != 0 {
+		t.Errorf("slow receiver = %d, %v, want timeout", code, err)
+	}
+
+	if code, err := c.SendWebhook(context.Background(), models.WebhookDelivery{URL: "://bad"}, nil); err == nil || code != 0 {
+		t.Errorf("invalid url = %d, %v, want error", code, err)
+	}
+}
+
+// TestSendWebhookPrivateAddress проверяет, что адрес получателя проверяется при подключении, в том числе
+// когда имя разрешается во внутренний адрес.
+func TestSendWebhookPrivateAddress(t *testing.T) {
+	called := false
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	c := NewWebhookClient(zap.NewNop(), time.Second, false)
+
+	for _, url := range []string{srv.URL, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)} {
+		code, err := c.SendWebhook(context.Background(), models.WebhookDelivery{URL: url}, nil)
+		if !errors.Is(err, ErrPrivateAddress) || code != 0 {
+			t.Errorf("%s = %d, %v, want ErrPrivateAddress", url, code, err)
+		}
+	}
+
+	if called {
+		t.Error("receiver in a private network was called")
+	}
+}
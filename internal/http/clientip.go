@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"gophermat/internal/models"
+)
+
+// parseProxies разбирает список адресов и подсетей доверенных прокси.
+func parseProxies(values []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0, len(values))
+
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", v)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+		}
+
+		proxies = append(proxies, n)
+	}
+
+	return proxies, nil
+}
+
+// clientIP сохраняет ip адрес клиента в контексте запроса. Адресом клиента считается адрес сокета.
+// Заголовок X-Forwarded-For учитывается, только если соединение пришло от доверенного прокси:
+// адресом клиента становится последний адрес в цепочке, который не принадлежит доверенным прокси.
+// Адреса левее него клиент может подставить сам, поэтому они не используются.
+func clientIP(proxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+
+			if trusted(proxies, ip) {
+				ip = forwardedFor(proxies, r.Header.Values("X-Forwarded-For"), ip)
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), models.CtxClientIP{}, ip)))
+		})
+	}
+}
+
+// forwardedFor возвращает адрес клиента из цепочки X-Forwarded-For, просматривая её справа налево.
+func forwardedFor(proxies []*net.IPNet, headers []string, peer string) string {
+	chain := make([]string, 0)
+
+	for _, h := range headers {
+		for _, addr := range strings.Split(h, ",") {
+			chain = append(chain, strings.TrimSpace(addr))
+		}
+	}
+
+	ip := peer
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if net.ParseIP(chain[i]) == nil {
+			break
+		}
+
+		ip = chain[i]
+
+		if !trusted(proxies, ip) {
+			break
+		}
+	}
+
+	return ip
+}
+
+func trusted(proxies []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
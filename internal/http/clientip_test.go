@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := parseProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatalf("parseProxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "direct connection",
+			remoteAddr: "203.0.113.5:40000",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "forwarded header from untrusted peer is ignored",
+			remoteAddr: "203.0.113.5:40000",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "spoofed addresses left of the client are ignored",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  []string{"1.1.1.1, 2.2.2.2, 198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "192.168.1.1:40000",
+			forwarded:  []string{"198.51.100.7, 10.0.0.2", "10.0.0.3"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.1.2.3:40000",
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "malformed address stops the chain",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  []string{"198.51.100.7, garbage"},
+			want:       "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			h := clientIP(proxies)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got, _ = r.Context().Value(models.CtxClientIP{}).(string)
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/api/user/login", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			r.Header.Set("X-Real-IP", "1.2.3.4")
+
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.want {
+				t.Errorf("client ip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProxies(t *testing.T) {
+	if _, err := parseProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("expected error for invalid subnet")
+	}
+
+	if _, err := parseProxies([]string{"proxy.local"}); err == nil {
+		t.Error("expected error for host name")
+	}
+
+	proxies, err := parseProxies([]string{" ", "::1", "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("parseProxies: %v", err)
+	}
+
+	if len(proxies) != 2 || !trusted(proxies, "::1") || !trusted(proxies, "127.0.0.1") || trusted(proxies, "127.0.0.2") {
+		t.Errorf("unexpected proxies %v", proxies)
+	}
+}
@@ -0,0 +1,990 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math"
+	"strconv"
+	"time"
+
+	api "gophermat/api/gen/admin"
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	APIAdminPath = "/api/admin"
+
+	defaultSearchLimit = 20
+)
+
+type gmart interface {
+	UnlockUser(ctx context.Context, login string) error
+	IssuePasswordReset(ctx context.Context, login string) (models.PasswordReset, error)
+	SetUserRole(ctx context.Context, login string, role models.Role) error
+	SetUserShop(ctx context.Context, login, shop string) error
+	SearchUsers(ctx context.Context, login string, limit int) ([]models.User, error)
+	GetUserOrders(ctx context.Context, userID int) ([]models.Order, error)
+	GetUserBalance(ctx context.Context, userID int) (models.Balance, error)
+	GetUserWithdrawals(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error)
+	AdjustUserBalance(ctx context.Context, adj models.BalanceAdjustment) (models.BalanceAdjustment, error)
+	GetUserAdjustments(ctx context.Context, userID int) ([]models.BalanceAdjustment, error)
+	ApproveAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error)
+	RejectAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error)
+	ReverseAdjustment(ctx context.Context, id int64, note string) (models.BalanceAdjustment, error)
+	RefundWithdrawal(ctx context.Context, refund models.WithdrawalRefund) (models.BalanceWithdrawal, error)
+	ClawbackOrder(ctx context.Context, orderNumber, reason string) (models.OrderClawback, error)
+	RepollOrder(ctx context.Context, orderNumber string) error
+	InvalidateOrder(ctx context.Context, orderNumber string) error
+	GetCampaigns(ctx context.Context) ([]models.Campaign, error)
+	CreateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	UpdateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	GeneratePromoCodes(ctx context.Context, req models.PromoGenerate) (models.PromoBatch, error)
+	ExportPromoBatch(ctx context.Context, id int64) (models.PromoBatch, error)
+	GetUserTransfers(ctx context.Context, userID int) ([]models.Transfer, error)
+	ReverseTransfer(ctx context.Context, id int64, note string) (models.Transfer, error)
+	DryRunCampaigns(ctx context.Context, order models.CampaignOrder, draft *models.Campaign) ([]models.CampaignBonus, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error)
+	DisableWebhook(ctx context.Context, id int64) (models.Webhook, error)
+	GetWebhookDeliveries(ctx context.Context, id int64) ([]models.WebhookDelivery, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) SearchUsers(ctx context.Context, params api.SearchUsersParams) (api.SearchUsersRes, error) {
+	users, err := h.gmart.SearchUsers(ctx, params.Login, params.Limit.Or(defaultSearchLimit))
+	if err != nil {
+		if errors.Is(err, models.ErrForbidden) {
+			return &api.SearchUsersForbidden{}, nil
+		}
+
+		return &api.SearchUsersInternalServerError{}, err
+	}
+
+	result := make(api.SearchUsersOKApplicationJSON, 0, len(users))
+	for _, u := range users {
+		result = append(result, api.User{
+			ID:    u.ID,
+			Login: u.Login,
+			Role:  api.Role(u.Role),
+		})
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) GetUserOrders(ctx context.Context, params api.GetUserOrdersParams) (api.GetUserOrdersRes, error) {
+	orders, err := h.gmart.GetUserOrders(ctx, params.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetUserOrdersForbidden{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.GetUserOrdersNotFound{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.GetUserOrdersNoContent{}, nil
+		default:
+			return &api.GetUserOrdersInternalServerError{}, err
+		}
+	}
+
+	result := make(api.GetUserOrdersOKApplicationJSON, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, api.GetUserOrdersOKItem{
+			Number:     api.NewOptString(o.Number),
+			Status:     api.NewOptString(o.Status),
+			Accrual:    api.NewOptFloat64(float64(o.Accrual) / 100),
+			UploadedAt: api.NewOptDateTime(o.UploadedAt),
+		})
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) GetUserBalance(ctx context.Context, params api.GetUserBalanceParams) (api.GetUserBalanceRes, error) {
+	balance, err := h.gmart.GetUserBalance(ctx, params.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetUserBalanceForbidden{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.GetUserBalanceNotFound{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.GetUserBalanceNoContent{}, nil
+		default:
+			return &api.GetUserBalanceInternalServerError{}, err
+		}
+	}
+
+	return &api.Balance{
+		Current:   float64(balance.Current) / 100,
+		Withdrawn: float64(balance.Withdraw) / 100,
+	}, nil
+}
+
+func (h *Handler) GetUserWithdrawals(
+	ctx context.Context,
+	params api.GetUserWithdrawalsParams,
+) (api.GetUserWithdrawalsRes, error) {
+	drawals, err := h.gmart.GetUserWithdrawals(ctx, params.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetUserWithdrawalsForbidden{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.GetUserWithdrawalsNotFound{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.GetUserWithdrawalsNoContent{}, nil
+		default:
+			return &api.GetUserWithdrawalsInternalServerError{}, err
+		}
+	}
+
+	result := make(api.GetUserWithdrawalsOKApplicationJSON, 0, len(drawals))
+	for _, d := range drawals {
+		result = append(result, *withdrawalResponse(d))
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) RefundWithdrawal(
+	ctx context.Context,
+	req api.OptRefundWithdrawalReq,
+	params api.RefundWithdrawalParams,
+) (api.RefundWithdrawalRes, error) {
+	if !req.Set {
+		return &api.RefundWithdrawalBadRequest{}, nil
+	}
+
+	withdrawal, err := h.gmart.RefundWithdrawal(ctx, models.WithdrawalRefund{
+		Order:  params.Order,
+		Sum:    int(math.Round(req.Value.Sum.Or(0) * 100)),
+		Reason: req.Value.Reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.RefundWithdrawalForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.RefundWithdrawalBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.RefundWithdrawalNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.RefundWithdrawalConflict{}, nil
+		default:
+			return &api.RefundWithdrawalInternalServerError{}, err
+		}
+	}
+
+	return withdrawalResponse(withdrawal), nil
+}
+
+func (h *Handler) GetUserAdjustments(
+	ctx context.Context,
+	params api.GetUserAdjustmentsParams,
+) (api.GetUserAdjustmentsRes, error) {
+	adjustments, err := h.gmart.GetUserAdjustments(ctx, params.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetUserAdjustmentsForbidden{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.GetUserAdjustmentsNotFound{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.GetUserAdjustmentsNoContent{}, nil
+		default:
+			return &api.GetUserAdjustmentsInternalServerError{}, err
+		}
+	}
+
+	result := make(api.GetUserAdjustmentsOKApplicationJSON, 0, len(adjustments))
+	for _, adj := range adjustments {
+		result = append(result, *adjustmentResponse(adj))
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) AdjustUserBalance(
+	ctx context.Context,
+	req api.OptAdjustUserBalanceReq,
+	params api.AdjustUserBalanceParams,
+) (api.AdjustUserBalanceRes, error) {
+	if !req.Set {
+		return &api.AdjustUserBalanceBadRequest{}, nil
+	}
+
+	adj, err := h.gmart.AdjustUserBalance(ctx, models.BalanceAdjustment{
+		UserID: params.UserId,
+		Amount: int(math.Round(req.Value.Amount * 100)),
+		Reason: models.AdjustmentReason(req.Value.Reason),
+		Note:   req.Value.Note.Or(""),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.AdjustUserBalanceForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.AdjustUserBalanceBadRequest{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.AdjustUserBalanceNotFound{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.AdjustUserBalancePaymentRequired{}, nil
+		default:
+			return &api.AdjustUserBalanceInternalServerError{}, err
+		}
+	}
+
+	return adjustmentResponse(adj), nil
+}
+
+func (h *Handler) ApproveAdjustment(
+	ctx context.Context,
+	params api.ApproveAdjustmentParams,
+) (api.ApproveAdjustmentRes, error) {
+	adj, err := h.gmart.ApproveAdjustment(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.ApproveAdjustmentForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ApproveAdjustmentNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.ApproveAdjustmentConflict{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.ApproveAdjustmentPaymentRequired{}, nil
+		default:
+			return &api.ApproveAdjustmentInternalServerError{}, err
+		}
+	}
+
+	return adjustmentResponse(adj), nil
+}
+
+func (h *Handler) RejectAdjustment(
+	ctx context.Context,
+	params api.RejectAdjustmentParams,
+) (api.RejectAdjustmentRes, error) {
+	adj, err := h.gmart.RejectAdjustment(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.RejectAdjustmentForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.RejectAdjustmentNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.RejectAdjustmentConflict{}, nil
+		default:
+			return &api.RejectAdjustmentInternalServerError{}, err
+		}
+	}
+
+	return adjustmentResponse(adj), nil
+}
+
+func (h *Handler) ReverseAdjustment(
+	ctx context.Context,
+	req api.OptReverseAdjustmentReq,
+	params api.ReverseAdjustmentParams,
+) (api.ReverseAdjustmentRes, error) {
+	reversal, err := h.gmart.ReverseAdjustment(ctx, params.ID, req.Value.Note.Or(""))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.ReverseAdjustmentForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ReverseAdjustmentNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.ReverseAdjustmentConflict{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.ReverseAdjustmentPaymentRequired{}, nil
+		default:
+			return &api.ReverseAdjustmentInternalServerError{}, err
+		}
+	}
+
+	return adjustmentResponse(reversal), nil
+}
+
+func (h *Handler) GetUserTransfers(
+	ctx context.Context,
+	params api.GetUserTransfersParams,
+) (api.GetUserTransfersRes, error) {
+	transfers, err := h.gmart.GetUserTransfers(ctx, params.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetUserTransfersForbidden{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.GetUserTransfersNotFound{}, nil
+		default:
+			return &api.GetUserTransfersInternalServerError{}, err
+		}
+	}
+
+	if len(transfers) == 0 {
+		return &api.GetUserTransfersNoContent{}, nil
+	}
+
+	result := make(api.GetUserTransfersOKApplicationJSON, 0, len(transfers))
+	for _, t := range transfers {
+		result = append(result, *transferResponse(t))
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) ReverseTransfer(
+	ctx context.Context,
+	req api.OptReverseTransferReq,
+	params api.ReverseTransferParams,
+) (api.ReverseTransferRes, error) {
+	t, err := h.gmart.ReverseTransfer(ctx, params.ID, req.Value.Note.Or(""))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.ReverseTransferForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ReverseTransferNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.ReverseTransferConflict{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.ReverseTransferPaymentRequired{}, nil
+		default:
+			return &api.ReverseTransferInternalServerError{}, err
+		}
+	}
+
+	return transferResponse(t), nil
+}
+
+func (h *Handler) ClawbackOrder(
+	ctx context.Context,
+	req api.OptClawbackOrderReq,
+	params api.ClawbackOrderParams,
+) (api.ClawbackOrderRes, error) {
+	if !req.Set {
+		return &api.ClawbackOrderBadRequest{}, nil
+	}
+
+	clawback, err := h.gmart.ClawbackOrder(ctx, params.Number, req.Value.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.ClawbackOrderForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.ClawbackOrderBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ClawbackOrderNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.ClawbackOrderConflict{}, nil
+		default:
+			return &api.ClawbackOrderInternalServerError{}, err
+		}
+	}
+
+	return &api.Clawback{
+		Order:     clawback.Order,
+		UserID:    clawback.UserID,
+		Amount:    float64(clawback.Amount) / 100,
+		Debt:      float64(clawback.Debt) / 100,
+		Reason:    clawback.Reason,
+		CreatedAt: clawback.CreatedAt,
+	}, nil
+}
+
+func (h *Handler) RepollOrder(ctx context.Context, params api.RepollOrderParams) (api.RepollOrderRes, error) {
+	err := h.gmart.RepollOrder(ctx, params.Number)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.RepollOrderForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.RepollOrderNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.RepollOrderConflict{}, nil
+		default:
+			return &api.RepollOrderInternalServerError{}, err
+		}
+	}
+
+	return &api.RepollOrderAccepted{}, nil
+}
+
+func (h *Handler) InvalidateOrder(ctx context.Context, params api.InvalidateOrderParams) (api.InvalidateOrderRes, error) {
+	err := h.gmart.InvalidateOrder(ctx, params.Number)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.InvalidateOrderForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.InvalidateOrderNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.InvalidateOrderConflict{}, nil
+		default:
+			return &api.InvalidateOrderInternalServerError{}, err
+		}
+	}
+
+	return &api.InvalidateOrderOK{}, nil
+}
+
+func (h *Handler) UnlockUser(ctx context.Context, req api.OptLogin) (api.UnlockUserRes, error) {
+	if !req.Set {
+		return &api.UnlockUserBadRequest{}, nil
+	}
+
+	err := h.gmart.UnlockUser(ctx, req.Value.Login)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.UnlockUserForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.UnlockUserBadRequest{}, nil
+		default:
+			return &api.UnlockUserInternalServerError{}, err
+		}
+	}
+
+	return &api.UnlockUserOK{}, nil
+}
+
+func (h *Handler) IssuePasswordReset(ctx context.Context, req api.OptLogin) (api.IssuePasswordResetRes, error) {
+	if !req.Set {
+		return &api.IssuePasswordResetBadRequest{}, nil
+	}
+
+	reset, err := h.gmart.IssuePasswordReset(ctx, req.Value.Login)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.IssuePasswordResetForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.IssuePasswordResetBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.IssuePasswordResetNotFound{}, nil
+		default:
+			return &api.IssuePasswordResetInternalServerError{}, err
+		}
+	}
+
+	return &api.IssuePasswordResetOK{
+		Token:     reset.Token,
+		ExpiresAt: reset.ExpiresAt,
+	}, nil
+}
+
+func (h *Handler) SetUserRole(ctx context.Context, req api.OptSetUserRoleReq) (api.SetUserRoleRes, error) {
+	if !req.Set {
+		return &api.SetUserRoleBadRequest{}, nil
+	}
+
+	err := h.gmart.SetUserRole(ctx, req.Value.Login, models.Role(req.Value.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.SetUserRoleForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.SetUserRoleBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.SetUserRoleNotFound{}, nil
+		default:
+			return &api.SetUserRoleInternalServerError{}, err
+		}
+	}
+
+	return &api.SetUserRoleOK{}, nil
+}
+
+func (h *Handler) SetUserShop(ctx context.Context, req api.OptSetUserShopReq) (api.SetUserShopRes, error) {
+	if !req.Set {
+		return &api.SetUserShopBadRequest{}, nil
+	}
+
+	err := h.gmart.SetUserShop(ctx, req.Value.Login, req.Value.Shop)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.SetUserShopForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.SetUserShopBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.SetUserShopNotFound{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.SetUserShopConflict{}, nil
+		default:
+			return &api.SetUserShopInternalServerError{}, err
+		}
+	}
+
+	return &api.SetUserShopOK{}, nil
+}
+
+func (h *Handler) GetCampaigns(ctx context.Context) (api.GetCampaignsRes, error) {
+	campaigns, err := h.gmart.GetCampaigns(ctx)
+	if err != nil {
+		if errors.Is(err, models.ErrForbidden) {
+			return &api.GetCampaignsForbidden{}, nil
+		}
+
+		return &api.GetCampaignsInternalServerError{}, err
+	}
+
+	result := make(api.GetCampaignsOKApplicationJSON, 0, len(campaigns))
+	for _, c := range campaigns {
+		result = append(result, *campaignResponse(c))
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) CreateCampaign(ctx context.Context, req *api.CampaignInput) (api.CreateCampaignRes, error) {
+	c, err := h.gmart.CreateCampaign(ctx, campaignRequest(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.CreateCampaignForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.CreateCampaignBadRequest{}, nil
+		default:
+			return &api.CreateCampaignInternalServerError{}, err
+		}
+	}
+
+	return campaignResponse(c), nil
+}
+
+func (h *Handler) UpdateCampaign(
+	ctx context.Context,
+	req *api.CampaignInput,
+	params api.UpdateCampaignParams,
+) (api.UpdateCampaignRes, error) {
+	c := campaignRequest(req)
+	c.ID = params.ID
+
+	c, err := h.gmart.UpdateCampaign(ctx, c)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.UpdateCampaignForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.UpdateCampaignBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.UpdateCampaignNotFound{}, nil
+		default:
+			return &api.UpdateCampaignInternalServerError{}, err
+		}
+	}
+
+	return campaignResponse(c), nil
+}
+
+func (h *Handler) DryRunCampaigns(ctx context.Context, req *api.DryRunCampaignsReq) (api.DryRunCampaignsRes, error) {
+	order := models.CampaignOrder{
+		UserID:     req.UserID.Or(0),
+		Channel:    req.Channel.Or(""),
+		Accrual:    int(math.Round(req.Accrual * 100)),
+		UploadedAt: req.UploadedAt.Or(time.Time{}),
+	}
+
+	var draft *models.Campaign
+
+	if c, ok := req.Campaign.Get(); ok {
+		d := campaignRequest(&c)
+		draft = &d
+	}
+
+	bonuses, err := h.gmart.DryRunCampaigns(ctx, order, draft)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.DryRunCampaignsForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.DryRunCampaignsBadRequest{}, nil
+		case errors.Is(err, models.ErrUserNotFound):
+			return &api.DryRunCampaignsNotFound{}, nil
+		default:
+			return &api.DryRunCampaignsInternalServerError{}, err
+		}
+	}
+
+	result := make(api.DryRunCampaignsOKApplicationJSON, 0, len(bonuses))
+	for _, b := range bonuses {
+		result = append(result, api.CampaignBonus{
+			CampaignID: b.CampaignID,
+			Name:       b.Name,
+			Amount:     float64(b.Amount) / 100,
+		})
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) GeneratePromoCodes(ctx context.Context, req *api.GeneratePromoCodesReq) (api.GeneratePromoCodesRes, error) {
+	gen := models.PromoGenerate{
+		Name:           req.Name,
+		Count:          req.Count.Or(0),
+		Prefix:         req.Prefix.Or(""),
+		Code:           req.Code.Or(""),
+		Points:         int(math.Round(req.Points * 100)),
+		MaxRedemptions: req.MaxRedemptions.Or(1),
+		PerUserLimit:   req.PerUserLimit.Or(1),
+	}
+
+	if v, ok := req.ExpiresAt.Get(); ok {
+		gen.ExpiresAt = &v
+	}
+
+	batch, err := h.gmart.GeneratePromoCodes(ctx, gen)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GeneratePromoCodesForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.GeneratePromoCodesBadRequest{}, nil
+		case errors.Is(err, models.ErrConflict):
+			return &api.GeneratePromoCodesConflict{}, nil
+		default:
+			return &api.GeneratePromoCodesInternalServerError{}, err
+		}
+	}
+
+	codes := make([]api.PromoCode, 0, len(batch.Codes))
+	for _, c := range batch.Codes {
+		code := api.PromoCode{
+			Code:           c.Code,
+			Points:         float64(c.Points) / 100,
+			MaxRedemptions: c.MaxRedemptions,
+			PerUserLimit:   c.PerUserLimit,
+			Redemptions:    c.Redemptions,
+		}
+
+		if c.ExpiresAt != nil {
+			code.ExpiresAt = api.NewOptDateTime(*c.ExpiresAt)
+		}
+
+		codes = append(codes, code)
+	}
+
+	return &api.PromoBatch{
+		ID:        batch.ID,
+		Name:      batch.Name,
+		CreatedAt: batch.CreatedAt,
+		Codes:     codes,
+	}, nil
+}
+
+func (h *Handler) ExportPromoCodes(ctx context.Context, params api.ExportPromoCodesParams) (api.ExportPromoCodesRes, error) {
+	batch, err := h.gmart.ExportPromoBatch(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.ExportPromoCodesForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ExportPromoCodesNotFound{}, nil
+		default:
+			return &api.ExportPromoCodesInternalServerError{}, err
+		}
+	}
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{"code", "points", "max_redemptions", "per_user_limit", "redemptions", "expires_at"})
+
+	for _, c := range batch.Codes {
+		expiresAt := ""
+		if c.ExpiresAt != nil {
+			expiresAt = c.ExpiresAt.Format(time.RFC3339)
+		}
+
+		_ = w.Write([]string{
+			c.Code,
+			strconv.FormatFloat(float64(c.Points)/100, 'f', 2, 64),
+			strconv.Itoa(c.MaxRedemptions),
+			strconv.Itoa(c.PerUserLimit),
+			strconv.Itoa(c.Redemptions),
+			expiresAt,
+		})
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return &api.ExportPromoCodesInternalServerError{}, err
+	}
+
+	return &api.ExportPromoCodesOK{Data: &buf}, nil
+}
+
+func (h *Handler) GetWebhooks(ctx context.Context) (api.GetWebhooksRes, error) {
+	webhooks, err := h.gmart.GetWebhooks(ctx)
+	if err != nil {
+		if errors.Is(err, models.ErrForbidden) {
+			return &api.GetWebhooksForbidden{}, nil
+		}
+
+		return &api.GetWebhooksInternalServerError{}, err
+	}
+
+	result := make(api.GetWebhooksOKApplicationJSON, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, *webhookResponse(w))
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) CreateWebhook(ctx context.Context, req *api.WebhookInput) (api.CreateWebhookRes, error) {
+	w := models.Webhook{
+		URL:    req.URL,
+		Shop:   req.Shop.Or(""),
+		Events: make([]string, 0, len(req.Events)),
+	}
+
+	for _, e := range req.Events {
+		w.Events = append(w.Events, string(e))
+	}
+
+	w, err := h.gmart.CreateWebhook(ctx, w)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.CreateWebhookForbidden{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.CreateWebhookBadRequest{}, nil
+		default:
+			return &api.CreateWebhookInternalServerError{}, err
+		}
+	}
+
+	// ключ подписи возвращается только при регистрации
+	res := webhookResponse(w)
+	res.Secret = api.NewOptString(w.Secret)
+
+	return res, nil
+}
+
+func (h *Handler) DisableWebhook(ctx context.Context, params api.DisableWebhookParams) (api.DisableWebhookRes, error) {
+	w, err := h.gmart.DisableWebhook(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.DisableWebhookForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.DisableWebhookNotFound{}, nil
+		default:
+			return &api.DisableWebhookInternalServerError{}, err
+		}
+	}
+
+	return webhookResponse(w), nil
+}
+
+func (h *Handler) GetWebhookDeliveries(
+	ctx context.Context,
+	params api.GetWebhookDeliveriesParams,
+) (api.GetWebhookDeliveriesRes, error) {
+	deliveries, err := h.gmart.GetWebhookDeliveries(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			return &api.GetWebhookDeliveriesForbidden{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.GetWebhookDeliveriesNotFound{}, nil
+		default:
+			return &api.GetWebhookDeliveriesInternalServerError{}, err
+		}
+	}
+
+	result := make(api.GetWebhookDeliveriesOKApplicationJSON, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, webhookDeliveryResponse(d))
+	}
+
+	return &result, nil
+}
+
+func adjustmentResponse(adj models.BalanceAdjustment) *api.Adjustment {
+	res := &api.Adjustment{
+		ID:        adj.ID,
+		UserID:    adj.UserID,
+		Amount:    float64(adj.Amount) / 100,
+		Reason:    api.AdjustmentReason(adj.Reason),
+		Note:      adj.Note,
+		Status:    api.AdjustmentStatus(adj.Status),
+		CreatedAt: adj.CreatedAt,
+	}
+
+	if adj.OperatorID != 0 {
+		res.OperatorID = api.NewOptInt(adj.OperatorID)
+	}
+
+	if adj.ApproverID != 0 {
+		res.ApproverID = api.NewOptInt(adj.ApproverID)
+	}
+
+	if adj.ReversalOf != 0 {
+		res.ReversalOf = api.NewOptInt64(adj.ReversalOf)
+	}
+
+	if adj.ResolvedAt != nil {
+		res.ResolvedAt = api.NewOptDateTime(*adj.ResolvedAt)
+	}
+
+	return res
+}
+
+func withdrawalResponse(w models.BalanceWithdrawal) *api.Withdrawal {
+	return &api.Withdrawal{
+		Order:       w.Order,
+		Sum:         float64(w.Sum) / 100,
+		Refunded:    float64(w.Refunded) / 100,
+		Status:      api.WithdrawalStatus(w.Status()),
+		ProcessedAt: w.ProcessedAt,
+	}
+}
+
+func transferResponse(t models.Transfer) *api.Transfer {
+	res := &api.Transfer{
+		ID:             t.ID,
+		SenderID:       t.SenderID,
+		SenderLogin:    t.SenderLogin,
+		RecipientID:    t.RecipientID,
+		RecipientLogin: t.RecipientLogin,
+		Amount:         float64(t.Amount) / 100,
+		Status:         api.TransferStatus(t.Status),
+		CreatedAt:      t.CreatedAt,
+	}
+
+	if t.ReversalNote != "" {
+		res.ReversalNote = api.NewOptString(t.ReversalNote)
+	}
+
+	if t.ReversedBy != 0 {
+		res.ReversedBy = api.NewOptInt(t.ReversedBy)
+	}
+
+	if t.ReversedAt != nil {
+		res.ReversedAt = api.NewOptDateTime(*t.ReversedAt)
+	}
+
+	return res
+}
+
+func campaignRequest(req *api.CampaignInput) models.Campaign {
+	c := models.Campaign{
+		Name:       req.Name,
+		Kind:       models.CampaignKind(req.Kind),
+		Value:      req.Value,
+		FirstOrder: req.FirstOrder.Or(false),
+		MinAccrual: int(math.Round(req.MinAccrual.Or(0) * 100)),
+		Channel:    req.Channel.Or(""),
+		Active:     req.Active.Or(true),
+	}
+
+	if v, ok := req.StartsAt.Get(); ok {
+		c.StartsAt = &v
+	}
+
+	if v, ok := req.EndsAt.Get(); ok {
+		c.EndsAt = &v
+	}
+
+	return c
+}
+
+func campaignResponse(c models.Campaign) *api.Campaign {
+	res := &api.Campaign{
+		ID:         c.ID,
+		Name:       c.Name,
+		Kind:       api.CampaignKind(c.Kind),
+		Value:      c.Value,
+		FirstOrder: c.FirstOrder,
+		MinAccrual: float64(c.MinAccrual) / 100,
+		Channel:    c.Channel,
+		Active:     c.Active,
+		CreatedAt:  c.CreatedAt,
+	}
+
+	if c.StartsAt != nil {
+		res.StartsAt = api.NewOptDateTime(*c.StartsAt)
+	}
+
+	if c.EndsAt != nil {
+		res.EndsAt = api.NewOptDateTime(*c.EndsAt)
+	}
+
+	if c.CreatedBy != 0 {
+		res.CreatedBy = api.NewOptInt(c.CreatedBy)
+	}
+
+	return res
+}
+
+func webhookResponse(w models.Webhook) *api.Webhook {
+	res := &api.Webhook{
+		ID:        w.ID,
+		URL:       w.URL,
+		Shop:      w.Shop,
+		Events:    make([]api.WebhookEvent, 0, len(w.Events)),
+		Active:    w.Active,
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt,
+	}
+
+	for _, e := range w.Events {
+		res.Events = append(res.Events, api.WebhookEvent(e))
+	}
+
+	return res
+}
+
+func webhookDeliveryResponse(d models.WebhookDelivery) api.WebhookDelivery {
+	res := api.WebhookDelivery{
+		ID:            d.ID,
+		EventID:       d.Event.ID,
+		Event:         api.WebhookEvent(d.Event.Kind),
+		Order:         d.Event.Order,
+		Amount:        float64(d.Event.Amount) / 100,
+		Status:        api.WebhookDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		History:       make([]api.WebhookAttempt, 0, len(d.History)),
+	}
+
+	if d.DeliveredAt != nil {
+		res.DeliveredAt = api.NewOptDateTime(*d.DeliveredAt)
+	}
+
+	for _, a := range d.History {
+		res.History = append(res.History, api.WebhookAttempt{
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMs: a.Duration.Milliseconds(),
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+
+	return res
+}
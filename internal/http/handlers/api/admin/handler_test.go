@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	api "gophermat/api/gen/admin"
+	"gophermat/internal/models"
+)
+
+type testGMart struct {
+	gmart
+
+	err     error
+	login   string
+	shop    string
+	webhook models.Webhook
+}
+
+func (g *testGMart) SetUserShop(_ context.Context, login, shop string) error {
+	g.login, g.shop = login, shop
+
+	return g.err
+}
+
+func (g *testGMart) CreateWebhook(_ context.Context, w models.Webhook) (models.Webhook, error) {
+	g.webhook = w
+
+	if g.err != nil {
+		return models.Webhook{}, g.err
+	}
+
+	w.ID = 1
+	w.Secret = "secret"
+	w.CreatedBy = 2
+
+	return w, nil
+}
+
+func TestSetUserShop(t *testing.T) {
+	tests := []struct {
+		err  error
+		want api.SetUserShopRes
+	}{
+		{err: nil, want: &api.SetUserShopOK{}},
+		{err: models.ErrForbidden, want: &api.SetUserShopForbidden{}},
+		{err: models.ErrInvalidInput, want: &api.SetUserShopBadRequest{}},
+		{err: models.ErrNotFound, want: &api.SetUserShopNotFound{}},
+		{err: models.ErrConflict, want: &api.SetUserShopConflict{}},
+		{err: models.ErrInternal, want: &api.SetUserShopInternalServerError{}},
+	}
+
+	req := api.NewOptSetUserShopReq(api.SetUserShopReq{Login: "shop", Shop: "123"})
+
+	for _, tt := range tests {
+		g := &testGMart{err: tt.err}
+
+		res, err := NewHandler(zap.NewNop(), g).SetUserShop(context.Background(), req)
+		if (err != nil) != errors.Is(tt.err, models.ErrInternal) {
+			t.Errorf("error %v: handler err = %v", tt.err, err)
+		}
+
+		if fmt.Sprintf("%T", res) != fmt.Sprintf("%T", tt.want) {
+			t.Errorf("error %v: response %T, want %T", tt.err, res, tt.want)
+		}
+
+		if g.login != "shop" || g.shop != "123" {
+			t.Errorf("gmart got login %q, shop %q", g.login, g.shop)
+		}
+	}
+
+	res, err := NewHandler(zap.NewNop(), &testGMart{}).SetUserShop(context.Background(), api.OptSetUserShopReq{})
+	if _, ok := res.(*api.SetUserShopBadRequest); !ok || err != nil {
+		t.Errorf("empty request = %T, %v, want bad request", res, err)
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	req := &api.WebhookInput{
+		URL:    "https://shop.example/hook",
+		Shop:   api.NewOptString("123"),
+		Events: []api.WebhookEvent{api.WebhookEventAccrual},
+	}
+
+	g := &testGMart{}
+
+	res, err := NewHandler(zap.NewNop(), g).CreateWebhook(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+
+	w, ok := res.(*api.Webhook)
+	if !ok || w.Shop != "123" || w.Secret.Or("") != "secret" {
+		t.Errorf("response = %+v, want the webhook with its secret", res)
+	}
+
+	if g.webhook.Shop != "123" || g.webhook.URL != req.URL || len(g.webhook.Events) != 1 ||
+		g.webhook.Events[0] != models.WebhookAccrual {
+		t.Errorf("gmart got %+v", g.webhook)
+	}
+
+	// пустой префикс учётной записи магазина отклоняется как неверный запрос, чужой как запрещённый
+	for _, tt := range []struct {
+		err  error
+		want api.CreateWebhookRes
+	}{
+		{err: models.ErrInvalidInput, want: &api.CreateWebhookBadRequest{}},
+		{err: models.ErrForbidden, want: &api.CreateWebhookForbidden{}},
+		{err: models.ErrInternal, want: &api.CreateWebhookInternalServerError{}},
+	} {
+		res, _ := NewHandler(zap.NewNop(), &testGMart{err: tt.err}).CreateWebhook(context.Background(), req)
+		if fmt.Sprintf("%T", res) != fmt.Sprintf("%T", tt.want) {
+			t.Errorf("error %v: response %T, want %T", tt.err, res, tt.want)
+		}
+	}
+}
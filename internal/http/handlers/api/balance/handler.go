@@ -6,6 +6,7 @@ import (
 	"go.uber.org/zap"
 	api "gophermat/api/gen/balance"
 	"gophermat/internal/models"
+	"math"
 )
 
 const (
@@ -15,6 +16,12 @@ const (
 type gmart interface {
 	GetBalance(ctx context.Context) (models.Balance, error)
 	DeductPoints(ctx context.Context, withdraw models.BalanceWithdraw) error
+	GetAdjustments(ctx context.Context) ([]models.BalanceAdjustment, error)
+	TransferPoints(ctx context.Context, recipient string, amount int) (models.Transfer, error)
+	GetTransfers(ctx context.Context) ([]models.TransferEntry, error)
+	CreateHold(ctx context.Context, withdraw models.BalanceWithdraw) (models.PointHold, error)
+	CaptureHold(ctx context.Context, id int64, sum int) (models.PointHold, error)
+	ReleaseHold(ctx context.Context, id int64) (models.PointHold, error)
 }
 
 type Handler struct {
@@ -33,7 +40,7 @@ func NewHandler(log *zap.Logger, gmart gmart) *Handler {
 func (h *Handler) DeductPoints(ctx context.Context, req api.OptDeductPointsReq) (api.DeductPointsRes, error) {
 	err := h.gmart.DeductPoints(ctx, models.BalanceWithdraw{
 		Order: req.Value.GetOrder(),
-		Sum:   int(req.Value.GetSum() * 100),
+		Sum:   int(math.Round(req.Value.GetSum() * 100)),
 	})
 
 	if err != nil {
@@ -45,6 +52,14 @@ func (h *Handler) DeductPoints(ctx context.Context, req api.OptDeductPointsReq)
 			return &api.DeductPointsUnprocessableEntity{}, nil
 		}
 
+		if errors.Is(err, models.ErrOrderWithdrawn) || errors.Is(err, models.ErrOrderWithdrawalCap) {
+			return &api.DeductPointsConflict{}, nil
+		}
+
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.DeductPointsBadRequest{}, nil
+		}
+
 		return &api.DeductPointsInternalServerError{}, err
 	}
 
@@ -61,8 +76,204 @@ func (h *Handler) GetBalance(ctx context.Context) (api.GetBalanceRes, error) {
 		return &api.GetBalanceInternalServerError{}, err
 	}
 
-	return &api.GetBalanceOK{
-		Current:   api.NewOptFloat64(float64(balance.Current) / 100),
-		Withdrawn: api.NewOptFloat64(float64(balance.Withdraw) / 100),
-	}, nil
+	expiring := make([]api.GetBalanceOKExpiringItem, 0, len(balance.Expiring))
+	for _, e := range balance.Expiring {
+		expiring = append(expiring, api.GetBalanceOKExpiringItem{
+			Sum:       api.NewOptFloat64(float64(e.Sum) / 100),
+			ExpiresAt: api.NewOptDateTime(e.ExpiresAt),
+		})
+	}
+
+	result := &api.GetBalanceOK{
+		Current:      api.NewOptFloat64(float64(balance.Current) / 100),
+		Withdrawn:    api.NewOptFloat64(float64(balance.Withdraw) / 100),
+		Available:    api.NewOptFloat64(float64(balance.Available()) / 100),
+		Held:         api.NewOptFloat64(float64(balance.Held) / 100),
+		ExpiringSoon: api.NewOptFloat64(float64(balance.ExpiringSoon) / 100),
+		Expiring:     expiring,
+	}
+
+	if balance.Tier != nil {
+		tier := api.GetBalanceOKTier{
+			Name:       api.NewOptString(balance.Tier.Tier.Name),
+			Multiplier: api.NewOptFloat64(balance.Tier.Tier.Multiplier),
+			Progress:   api.NewOptFloat64(float64(balance.Tier.Progress) / 100),
+		}
+
+		if balance.Tier.Next != nil {
+			tier.NextTier = api.NewOptString(balance.Tier.Next.Name)
+			tier.NextThreshold = api.NewOptFloat64(float64(balance.Tier.Next.Threshold) / 100)
+			tier.ToNext = api.NewOptFloat64(float64(balance.Tier.ToNext()) / 100)
+		}
+
+		result.Tier = api.NewOptGetBalanceOKTier(tier)
+	}
+
+	return result, nil
+}
+
+func (h *Handler) GetAdjustments(ctx context.Context) (api.GetAdjustmentsRes, error) {
+	adjustments, err := h.gmart.GetAdjustments(ctx)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return &api.GetAdjustmentsNoContent{}, nil
+		}
+
+		return &api.GetAdjustmentsInternalServerError{}, err
+	}
+
+	result := make(api.GetAdjustmentsOKApplicationJSON, 0, len(adjustments))
+	for _, adj := range adjustments {
+		item := api.GetAdjustmentsOKItem{
+			ID:        api.NewOptInt64(adj.ID),
+			Amount:    api.NewOptFloat64(float64(adj.Amount) / 100),
+			Reason:    api.NewOptString(string(adj.Reason)),
+			Status:    api.NewOptString(string(adj.Status)),
+			CreatedAt: api.NewOptDateTime(adj.CreatedAt),
+		}
+
+		if adj.ReversalOf != 0 {
+			item.ReversalOf = api.NewOptInt64(adj.ReversalOf)
+		}
+
+		result = append(result, item)
+	}
+
+	return &result, nil
+}
+
+func (h *Handler) TransferPoints(ctx context.Context, req *api.TransferPointsReq) (api.TransferPointsRes, error) {
+	t, err := h.gmart.TransferPoints(ctx, req.Recipient, int(math.Round(req.Amount*100)))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.TransferPointsBadRequest{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.TransferPointsPaymentRequired{}, nil
+		case errors.Is(err, models.ErrTransferLimit):
+			return &api.TransferPointsConflict{}, nil
+		default:
+			return &api.TransferPointsInternalServerError{}, err
+		}
+	}
+
+	res := transferResponse(t.EntryFor(t.SenderID))
+
+	return &res, nil
+}
+
+func (h *Handler) GetTransfers(ctx context.Context) (api.GetTransfersRes, error) {
+	transfers, err := h.gmart.GetTransfers(ctx)
+	if err != nil {
+		return &api.GetTransfersInternalServerError{}, err
+	}
+
+	if len(transfers) == 0 {
+		return &api.GetTransfersNoContent{}, nil
+	}
+
+	result := make(api.GetTransfersOKApplicationJSON, 0, len(transfers))
+	for _, t := range transfers {
+		result = append(result, transferResponse(t))
+	}
+
+	return &result, nil
+}
+
+func transferResponse(t models.TransferEntry) api.Transfer {
+	res := api.Transfer{
+		ID:           t.ID,
+		Direction:    api.TransferDirection(t.Direction),
+		Counterparty: t.Counterparty,
+		Amount:       float64(t.Amount) / 100,
+		Status:       api.TransferStatus(t.Status),
+		CreatedAt:    t.CreatedAt,
+	}
+
+	if t.ReversedAt != nil {
+		res.ReversedAt = api.NewOptDateTime(*t.ReversedAt)
+	}
+
+	return res
+}
+
+func (h *Handler) CreateHold(ctx context.Context, req *api.CreateHoldReq) (api.CreateHoldRes, error) {
+	hold, err := h.gmart.CreateHold(ctx, models.BalanceWithdraw{
+		Order: req.Order,
+		Sum:   int(math.Round(req.Sum * 100)),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidOrderNumber):
+			return &api.CreateHoldUnprocessableEntity{}, nil
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.CreateHoldBadRequest{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.CreateHoldPaymentRequired{}, nil
+		default:
+			return &api.CreateHoldInternalServerError{}, err
+		}
+	}
+
+	return holdResponse(hold), nil
+}
+
+func (h *Handler) CaptureHold(
+	ctx context.Context,
+	req api.OptCaptureHoldReq,
+	params api.CaptureHoldParams,
+) (api.CaptureHoldRes, error) {
+	hold, err := h.gmart.CaptureHold(ctx, params.ID, int(math.Round(req.Value.Sum.Or(0)*100)))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.CaptureHoldBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.CaptureHoldNotFound{}, nil
+		case errors.Is(err, models.ErrInsufficientBalance):
+			return &api.CaptureHoldPaymentRequired{}, nil
+		case errors.Is(err, models.ErrHoldNotActive),
+			errors.Is(err, models.ErrOrderWithdrawn),
+			errors.Is(err, models.ErrOrderWithdrawalCap):
+			return &api.CaptureHoldConflict{}, nil
+		default:
+			return &api.CaptureHoldInternalServerError{}, err
+		}
+	}
+
+	return holdResponse(hold), nil
+}
+
+func (h *Handler) ReleaseHold(ctx context.Context, params api.ReleaseHoldParams) (api.ReleaseHoldRes, error) {
+	hold, err := h.gmart.ReleaseHold(ctx, params.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return &api.ReleaseHoldNotFound{}, nil
+		case errors.Is(err, models.ErrHoldNotActive):
+			return &api.ReleaseHoldConflict{}, nil
+		default:
+			return &api.ReleaseHoldInternalServerError{}, err
+		}
+	}
+
+	return holdResponse(hold), nil
+}
+
+func holdResponse(hold models.PointHold) *api.Hold {
+	res := &api.Hold{
+		ID:        hold.ID,
+		Order:     hold.Order,
+		Amount:    float64(hold.Amount) / 100,
+		Captured:  float64(hold.Captured) / 100,
+		Status:    api.HoldStatus(hold.Status),
+		CreatedAt: hold.CreatedAt,
+		ExpiresAt: hold.ExpiresAt,
+	}
+
+	if hold.ResolvedAt != nil {
+		res.ResolvedAt = api.NewOptDateTime(*hold.ResolvedAt)
+	}
+
+	return res
 }
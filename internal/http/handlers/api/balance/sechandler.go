@@ -8,7 +8,7 @@ import (
 )
 
 type authorizer interface {
-	ParseToken(string) (models.TokenPayload, error)
+	ParseToken(context.Context, string) (models.TokenPayload, error)
 }
 
 type SecHandler struct {
@@ -24,7 +24,7 @@ func (s SecHandler) HandleBearerAuth(
 	_ string,
 	t api.BearerAuth,
 ) (context.Context, error) {
-	tokenPayload, err := s.auth.ParseToken(t.Token)
+	tokenPayload, err := s.auth.ParseToken(ctx, t.Token)
 	if err != nil {
 		return ctx, fmt.Errorf("handled authorization: %w", err)
 	}
@@ -0,0 +1,216 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+const (
+	APIEventsPath = "/events"
+
+	// eventsRetry время в миллисекундах, через которое клиент переподключается после обрыва потока.
+	eventsRetry = 3000
+)
+
+type gmart interface {
+	SubscribeEvents(ctx context.Context) (<-chan struct{}, func(), error)
+	GetEvents(ctx context.Context, after int64) ([]models.UserEvent, error)
+	GetLastEventID(ctx context.Context) (int64, error)
+}
+
+type authorizer interface {
+	ParseToken(context.Context, string) (models.TokenPayload, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart     gmart
+	auth      authorizer
+	keepAlive time.Duration
+}
+
+func NewHandler(log *zap.Logger, gmart gmart, auth authorizer, keepAlive time.Duration) *Handler {
+	return &Handler{
+		log:       log,
+		gmart:     gmart,
+		auth:      auth,
+		keepAlive: keepAlive,
+	}
+}
+
+// EventData данные события для клиента. Суммы в баллах.
+type EventData struct {
+	Order       string   `json:"order,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Transaction string   `json:"type,omitempty"`
+	Accrual     *float64 `json:"accrual,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Sum         *float64 `json:"sum,omitempty"`
+	Balance     *float64 `json:"balance,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// Events отдаёт поток событий пользователя в формате Server-Sent Events. Клиент, переподключившийся
+// с заголовком Last-Event-ID, сначала получает пропущенные события.
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		http.Error(w, "user is not authentication", http.StatusUnauthorized)
+
+		return
+	}
+
+	tokenPayload, err := h.auth.ParseToken(r.Context(), token)
+	if err != nil {
+		h.log.Info(fmt.Sprintf("Failed to subscribe events: %s", err.Error()))
+
+		http.Error(w, "user is not authentication", http.StatusUnauthorized)
+
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), models.CtxTokenPayload{}, tokenPayload)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	var after int64
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after < 0 {
+			http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	// подписка оформляется до чтения событий, чтобы не пропустить сохранённые между чтением и подпиской
+	wakeCh, unsubscribe, err := h.gmart.SubscribeEvents(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	defer unsubscribe()
+
+	if after == 0 {
+		after, err = h.gmart.GetLastEventID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", eventsRetry)
+	flusher.Flush()
+
+	var keepAliveCh <-chan time.Time
+
+	if h.keepAlive > 0 {
+		keepAlive := time.NewTicker(h.keepAlive)
+		defer keepAlive.Stop()
+
+		keepAliveCh = keepAlive.C
+	}
+
+	for {
+		after, err = h.writeEvents(ctx, w, after)
+		if err != nil {
+			h.log.Info(fmt.Sprintf("Events stream closed: %s", err.Error()))
+
+			return
+		}
+
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wakeCh:
+		case <-keepAliveCh:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvents пишет в поток все события после after и возвращает id последнего записанного.
+func (h *Handler) writeEvents(ctx context.Context, w http.ResponseWriter, after int64) (int64, error) {
+	for {
+		events, err := h.gmart.GetEvents(ctx, after)
+		if err != nil {
+			return after, err
+		}
+
+		if len(events) == 0 {
+			return after, nil
+		}
+
+		for _, e := range events {
+			data, err := json.Marshal(NewEventData(e))
+			if err != nil {
+				return after, err
+			}
+
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Kind, data); err != nil {
+				return after, err
+			}
+
+			after = e.ID
+		}
+	}
+}
+
+// NewEventData возвращает данные события для клиента.
+func NewEventData(e models.UserEvent) EventData {
+	res := EventData{
+		Order:     e.Order,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+
+	points := func(v int) *float64 {
+		p := float64(v) / 100
+
+		return &p
+	}
+
+	switch e.Kind {
+	case models.EventOrderStatus:
+		res.Status = e.Status
+		res.Accrual = points(e.Amount)
+	case models.EventBalance:
+		res.Transaction = e.Transaction
+		res.Amount = points(e.Amount)
+		res.Balance = points(e.Balance)
+	case models.EventWithdrawal:
+		res.Sum = points(e.Amount)
+		res.Balance = points(e.Balance)
+	}
+
+	return res
+}
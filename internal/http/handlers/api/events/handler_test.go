@@ -0,0 +1,261 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+type testGMart struct {
+	mu     sync.Mutex
+	events []models.UserEvent
+	wakeCh chan struct{}
+}
+
+func (g *testGMart) SubscribeEvents(_ context.Context) (<-chan struct{}, func(), error) {
+	return g.wakeCh, func() {}, nil
+}
+
+func (g *testGMart) GetEvents(_ context.Context, after int64) ([]models.UserEvent, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	events := make([]models.UserEvent, 0)
+
+	for _, e := range g.events {
+		if e.ID > after {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func (g *testGMart) GetLastEventID(_ context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.events) == 0 {
+		return 0, nil
+	}
+
+	return g.events[len(g.events)-1].ID, nil
+}
+
+// add сохраняет событие и будит поток, как это делает сервис после фиксации транзакции.
+func (g *testGMart) add(e models.UserEvent) {
+	g.mu.Lock()
+	g.events = append(g.events, e)
+	g.mu.Unlock()
+
+	g.wakeCh <- struct{}{}
+}
+
+type testAuthorizer struct{}
+
+func (testAuthorizer) ParseToken(_ context.Context, token string) (models.TokenPayload, error) {
+	if token != "token" {
+		return models.TokenPayload{}, errors.New("invalid token")
+	}
+
+	return models.TokenPayload{UserID: 7}, nil
+}
+
+func balanceEvent(id int64, amount, balance int) models.UserEvent {
+	return models.UserEvent{
+		ID:          id,
+		UserID:      7,
+		Kind:        models.EventBalance,
+		Transaction: models.LotSourceAccrual,
+		Amount:      amount,
+		Balance:     balance,
+		CreatedAt:   time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func newTestServer(t *testing.T, g *testGMart, keepAlive time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(NewHandler(zap.NewNop(), g, testAuthorizer{}, keepAlive).Events))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// openStream открывает поток событий и возвращает его читатель. Поток закрывается по окончании теста.
+func openStream(t *testing.T, url, lastEventID string) *bufio.Reader {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer token")
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("cannot open stream: %v", err)
+	}
+
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	return bufio.NewReader(resp.Body)
+}
+
+// readMessage читает из потока одно сообщение до пустой строки.
+func readMessage(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var msg strings.Builder
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("cannot read stream: %v", err)
+		}
+
+		if line == "\n" {
+			return msg.String()
+		}
+
+		msg.WriteString(line)
+	}
+}
+
+func TestEventsUnauthorized(t *testing.T) {
+	srv := newTestServer(t, &testGMart{wakeCh: make(chan struct{}, 1)}, 0)
+
+	tests := []struct {
+		name        string
+		auth        string
+		lastEventID string
+		want        int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "invalid token", auth: "Bearer other", want: http.StatusUnauthorized},
+		{name: "not a bearer token", auth: "token", want: http.StatusUnauthorized},
+		{name: "invalid last event id", auth: "Bearer token", lastEventID: "abc", want: http.StatusBadRequest},
+		{name: "negative last event id", auth: "Bearer token", lastEventID: "-1", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+// TestEventsResume проверяет, что переподключившийся клиент сначала получает пропущенные события,
+// а затем новые по мере их сохранения.
+func TestEventsResume(t *testing.T) {
+	g := &testGMart{
+		events: []models.UserEvent{balanceEvent(1, 10000, 10000), balanceEvent(2, 5050, 15050)},
+		wakeCh: make(chan struct{}, 1),
+	}
+	r := openStream(t, newTestServer(t, g, 0).URL, "1")
+
+	if msg := readMessage(t, r); msg != "retry: 3000\n" {
+		t.Errorf("first message = %q, want retry interval", msg)
+	}
+
+	want := "id: 2\nevent: balance\n" +
+		`data: {"type":"accrual","amount":50.5,"balance":150.5,"created_at":"2023-12-01T12:00:00Z"}` + "\n"
+
+	if msg := readMessage(t, r); msg != want {
+		t.Errorf("missed event = %q, want %q", msg, want)
+	}
+
+	g.add(models.UserEvent{ID: 3, UserID: 7, Kind: models.EventOrderStatus, Order: "79927398713",
+		Status: "PROCESSED", Amount: 500, CreatedAt: time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC)})
+
+	want = "id: 3\nevent: order_status\n" +
+		`data: {"order":"79927398713","status":"PROCESSED","accrual":5,"created_at":"2023-12-01T12:00:00Z"}` + "\n"
+
+	if msg := readMessage(t, r); msg != want {
+		t.Errorf("new event = %q, want %q", msg, want)
+	}
+}
+
+// TestEventsFirstConnect проверяет, что клиент без Last-Event-ID не получает старые события.
+func TestEventsFirstConnect(t *testing.T) {
+	g := &testGMart{
+		events: []models.UserEvent{balanceEvent(1, 10000, 10000), balanceEvent(2, 5000, 15000)},
+		wakeCh: make(chan struct{}, 1),
+	}
+	r := openStream(t, newTestServer(t, g, 0).URL, "")
+
+	readMessage(t, r)
+
+	g.add(balanceEvent(3, -5000, 10000))
+
+	if msg := readMessage(t, r); !strings.HasPrefix(msg, "id: 3\n") {
+		t.Errorf("first event = %q, want only the new event 3", msg)
+	}
+}
+
+func TestEventsKeepAlive(t *testing.T) {
+	g := &testGMart{wakeCh: make(chan struct{}, 1)}
+	r := openStream(t, newTestServer(t, g, 10*time.Millisecond).URL, "")
+
+	readMessage(t, r)
+
+	if msg := readMessage(t, r); msg != ": keepalive\n" {
+		t.Errorf("message = %q, want keepalive comment", msg)
+	}
+}
+
+func TestNewEventData(t *testing.T) {
+	created := time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC)
+
+	d := NewEventData(models.UserEvent{Kind: models.EventWithdrawal, Order: "2377225624", Amount: 30000,
+		Balance: 70000, CreatedAt: created})
+
+	if d.Order != "2377225624" || d.Sum == nil || *d.Sum != 300 || d.Balance == nil || *d.Balance != 700 ||
+		d.Amount != nil || d.Accrual != nil || d.CreatedAt != "2023-12-01T12:00:00Z" {
+		t.Errorf("withdrawal event data = %+v", d)
+	}
+}
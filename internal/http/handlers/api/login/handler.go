@@ -65,14 +65,16 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.log.Info(fmt.Sprintf("Failed to user login: %s", err.Error()))
 
-		if errors.Is(err, models.ErrNotFound) {
-			http.Error(w, "user not found", http.StatusUnauthorized)
+		// не различаем отсутствие пользователя и неверный пароль, чтобы нельзя было перебрать логины
+		if errors.Is(err, models.ErrNotFound) ||
+			errors.Is(err, models.ErrInvalidPassword) {
+			http.Error(w, "invalid login or password", http.StatusUnauthorized)
 
 			return
 		}
 
-		if errors.Is(err, models.ErrInvalidPassword) {
-			http.Error(w, "invalid password", http.StatusUnauthorized)
+		if errors.Is(err, models.ErrTooManyAttempts) {
+			http.Error(w, "too many login attempts, try again later", http.StatusTooManyRequests)
 
 			return
 		}
@@ -95,7 +97,8 @@ func (h *Handler) LoginUser(ctx context.Context, req api.OptLoginUserReq) (api.L
 
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) ||
-			errors.Is(err, models.ErrInvalidPassword) {
+			errors.Is(err, models.ErrInvalidPassword) ||
+			errors.Is(err, models.ErrTooManyAttempts) {
 			return &api.LoginUserUnauthorized{}, nil
 		}
 
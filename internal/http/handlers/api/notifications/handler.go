@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+
+	api "gophermat/api/gen/notifications"
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	APINotificationsPath = "/notifications"
+)
+
+type gmart interface {
+	GetNotifications(ctx context.Context) ([]models.Notification, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) GetNotifications(ctx context.Context) (api.GetNotificationsRes, error) {
+	notifications, err := h.gmart.GetNotifications(ctx)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return &api.GetNotificationsNoContent{}, nil
+		}
+
+		return &api.GetNotificationsInternalServerError{}, err
+	}
+
+	result := make(api.GetNotificationsOKApplicationJSON, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, api.GetNotificationsOKItem{
+			ID:        api.NewOptInt64(n.ID),
+			Kind:      api.NewOptString(n.Kind),
+			Message:   api.NewOptString(n.Message),
+			CreatedAt: api.NewOptDateTime(n.CreatedAt),
+		})
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	api "gophermat/api/gen/notifications"
+	"gophermat/internal/models"
+)
+
+type authorizer interface {
+	ParseToken(context.Context, string) (models.TokenPayload, error)
+}
+
+type SecHandler struct {
+	auth authorizer
+}
+
+func NewSecHandler(auth authorizer) *SecHandler {
+	return &SecHandler{auth: auth}
+}
+
+func (s SecHandler) HandleBearerAuth(
+	ctx context.Context,
+	_ string,
+	t api.BearerAuth,
+) (context.Context, error) {
+	tokenPayload, err := s.auth.ParseToken(ctx, t.Token)
+	if err != nil {
+		return ctx, fmt.Errorf("handled authorization: %w", err)
+	}
+
+	return context.WithValue(ctx, models.CtxTokenPayload{}, tokenPayload), nil
+}
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	APIOIDCLoginPath    = "/oidc/login"
+	APIOIDCCallbackPath = "/oidc/callback"
+)
+
+type gmart interface {
+	OIDCAuthURL(ctx context.Context) (string, error)
+	OIDCCallback(ctx context.Context, code, state string) (string, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+// Login перенаправляет пользователя на страницу входа провайдера.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	u, err := h.gmart.OIDCAuthURL(r.Context())
+	if err != nil {
+		h.log.Info(fmt.Sprintf("Failed to start oidc login: %s", err.Error()))
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+// Callback принимает пользователя, вернувшегося от провайдера, и выдаёт токен так же, как вход по паролю.
+func (h *Handler) Callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if e := q.Get("error"); e != "" {
+		h.log.Info(fmt.Sprintf("Failed to oidc login: provider error: %s", e))
+
+		http.Error(w, "login is rejected by identity provider", http.StatusUnauthorized)
+
+		return
+	}
+
+	token, err := h.gmart.OIDCCallback(r.Context(), q.Get("code"), q.Get("state"))
+	if err != nil {
+		h.log.Info(fmt.Sprintf("Failed to oidc login: %s", err.Error()))
+
+		if errors.Is(err, models.ErrInvalidInput) {
+			http.Error(w, "code and state are required", http.StatusBadRequest)
+
+			return
+		}
+
+		if errors.Is(err, models.ErrInvalidToken) {
+			http.Error(w, "invalid or expired login", http.StatusUnauthorized)
+
+			return
+		}
+
+		if errors.Is(err, models.ErrConflict) {
+			http.Error(w, "a user with this login is already registered", http.StatusConflict)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	w.WriteHeader(http.StatusOK)
+}
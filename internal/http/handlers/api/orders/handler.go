@@ -14,7 +14,7 @@ const (
 )
 
 type gmart interface {
-	LoadOrder(ctx context.Context, orderNumber string) error
+	LoadOrder(ctx context.Context, orderNumber, clientKey string) error
 	GetOrders(ctx context.Context) ([]models.Order, error)
 }
 
@@ -57,18 +57,26 @@ func (h *Handler) GetOrders(ctx context.Context) (api.GetOrdersRes, error) {
 	return &result, nil
 }
 
-func (h *Handler) LoadOrder(ctx context.Context, req api.LoadOrderReq) (api.LoadOrderRes, error) {
+func (h *Handler) LoadOrder(
+	ctx context.Context,
+	req api.LoadOrderReq,
+	params api.LoadOrderParams,
+) (api.LoadOrderRes, error) {
 	order, err := io.ReadAll(req.Data)
 	if err != nil {
 		return &api.LoadOrderInternalServerError{}, err
 	}
 
-	err = h.gmart.LoadOrder(ctx, string(order))
+	err = h.gmart.LoadOrder(ctx, string(order), params.XClientKey.Or(""))
 	if err != nil {
 		if errors.Is(err, models.ErrInvalidOrderNumber) {
 			return &api.LoadOrderUnprocessableEntity{}, nil
 		}
 
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.LoadOrderBadRequest{}, nil
+		}
+
 		if errors.Is(err, models.ErrOrderUploaded) {
 			return &api.LoadOrderOK{}, nil
 		}
@@ -0,0 +1,79 @@
+package password
+
+import (
+	"context"
+
+	api "gophermat/api/gen/password"
+	"gophermat/internal/models"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	APIPasswordPath = "/password"
+)
+
+type gmart interface {
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) (string, error)
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) ChangePassword(ctx context.Context, req api.OptChangePasswordReq) (api.ChangePasswordRes, error) {
+	if !req.Set {
+		return &api.ChangePasswordBadRequest{}, nil
+	}
+
+	token, err := h.gmart.ChangePassword(ctx, req.Value.OldPassword, req.Value.NewPassword)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPassword) {
+			return &api.ChangePasswordUnauthorized{}, nil
+		}
+
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.ChangePasswordUnprocessableEntity{}, nil
+		}
+
+		return &api.ChangePasswordInternalServerError{}, err
+	}
+
+	return &api.ChangePasswordOK{
+		Data: api.NewOptChangePasswordOKData(api.ChangePasswordOKData{
+			Token: api.NewOptString(token),
+		}),
+	}, nil
+}
+
+func (h *Handler) ResetPassword(ctx context.Context, req api.OptResetPasswordReq) (api.ResetPasswordRes, error) {
+	if !req.Set {
+		return &api.ResetPasswordBadRequest{}, nil
+	}
+
+	err := h.gmart.ResetPassword(ctx, req.Value.Token, req.Value.NewPassword)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidToken) {
+			return &api.ResetPasswordUnauthorized{}, nil
+		}
+
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.ResetPasswordUnprocessableEntity{}, nil
+		}
+
+		return &api.ResetPasswordInternalServerError{}, err
+	}
+
+	return &api.ResetPasswordOK{}, nil
+}
@@ -0,0 +1,56 @@
+package promo
+
+import (
+	"context"
+	"errors"
+
+	api "gophermat/api/gen/promo"
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	APIPromoPath = "/promo"
+)
+
+type gmart interface {
+	RedeemPromoCode(ctx context.Context, code string) (models.PromoRedemption, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) RedeemPromoCode(ctx context.Context, req *api.RedeemPromoCodeReq) (api.RedeemPromoCodeRes, error) {
+	r, err := h.gmart.RedeemPromoCode(ctx, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidInput):
+			return &api.RedeemPromoCodeBadRequest{}, nil
+		case errors.Is(err, models.ErrNotFound):
+			return &api.RedeemPromoCodeNotFound{}, nil
+		case errors.Is(err, models.ErrPromoExhausted), errors.Is(err, models.ErrPromoAlreadyRedeemed):
+			return &api.RedeemPromoCodeConflict{}, nil
+		case errors.Is(err, models.ErrPromoExpired):
+			return &api.RedeemPromoCodeGone{}, nil
+		default:
+			return &api.RedeemPromoCodeInternalServerError{}, err
+		}
+	}
+
+	return &api.RedeemPromoCodeOK{
+		Code:       r.Code,
+		Amount:     float64(r.Amount) / 100,
+		RedeemedAt: r.CreatedAt,
+	}, nil
+}
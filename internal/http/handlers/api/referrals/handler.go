@@ -0,0 +1,59 @@
+package referrals
+
+import (
+	"context"
+
+	api "gophermat/api/gen/referrals"
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	APIReferralsPath = "/referrals"
+)
+
+type gmart interface {
+	GetReferrals(ctx context.Context) (models.ReferralSummary, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) GetReferrals(ctx context.Context) (api.GetReferralsRes, error) {
+	summary, err := h.gmart.GetReferrals(ctx)
+	if err != nil {
+		return &api.GetReferralsInternalServerError{}, err
+	}
+
+	items := make([]api.GetReferralsOKReferralsItem, 0, len(summary.Referrals))
+	for _, r := range summary.Referrals {
+		item := api.GetReferralsOKReferralsItem{
+			Login:     r.RefereeLogin,
+			Status:    api.GetReferralsOKReferralsItemStatus(r.Status),
+			Bonus:     float64(r.ReferrerBonus) / 100,
+			CreatedAt: r.CreatedAt,
+		}
+
+		if r.RewardedAt != nil {
+			item.RewardedAt = api.NewOptDateTime(*r.RewardedAt)
+		}
+
+		items = append(items, item)
+	}
+
+	return &api.GetReferralsOK{
+		Code:      summary.Code,
+		Referrals: items,
+	}, nil
+}
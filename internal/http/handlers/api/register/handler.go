@@ -18,12 +18,13 @@ const (
 )
 
 type gmart interface {
-	RegisterUser(ctx context.Context, user models.User) (string, error)
+	RegisterUser(ctx context.Context, user models.User, referralCode string) (string, error)
 }
 
 type register struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	ReferralCode string `json:"referral_code"`
 }
 
 type Handler struct {
@@ -61,7 +62,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	token, err := h.gmart.RegisterUser(r.Context(), models.User{
 		Login:    reg.Login,
 		Password: reg.Password,
-	})
+	}, reg.ReferralCode)
 
 	if err != nil {
 		h.log.Info(fmt.Sprintf("Failed to user register: %s", err.Error()))
@@ -72,6 +73,12 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, models.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 
 		return
@@ -86,13 +93,17 @@ func (h *Handler) RegisterUser(ctx context.Context, req api.OptRegisterUserReq)
 	token, err := h.gmart.RegisterUser(ctx, models.User{
 		Login:    req.Value.Login,
 		Password: req.Value.Password,
-	})
+	}, req.Value.ReferralCode.Or(""))
 
 	if err != nil {
 		if errors.Is(err, models.ErrConflict) {
 			return &api.RegisterUserConflict{}, nil
 		}
 
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.RegisterUserBadRequest{}, nil
+		}
+
 		return &api.RegisterUserInternalServerError{}, nil
 	}
 
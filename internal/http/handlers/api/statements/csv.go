@@ -0,0 +1,57 @@
+package statements
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+// csvWriter пишет выписку в CSV: строки начального баланса, операций, итогов и конечного баланса.
+type csvWriter struct {
+	w *csv.Writer
+
+	totals statementTotals
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Begin(st models.Statement) error {
+	c.totals.begin(st)
+
+	_ = c.w.Write([]string{"date", "type", "order", "amount", "balance"})
+	_ = c.w.Write([]string{st.From.Format(time.RFC3339), "opening_balance", "", "", formatPoints(st.Opening)})
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) Entry(e models.StatementEntry) error {
+	c.totals.add(e)
+
+	// csv.Writer буферизует строки и сам сбрасывает буфер при заполнении
+	_ = c.w.Write([]string{
+		e.Time.Format(time.RFC3339),
+		e.Kind,
+		e.Order,
+		formatPoints(e.Amount),
+		formatPoints(e.Balance),
+	})
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) End() error {
+	t := c.totals
+	to := t.st.To.Format(time.RFC3339)
+
+	_ = c.w.Write([]string{to, "total_credited", "", formatPoints(t.credited), ""})
+	_ = c.w.Write([]string{to, "total_debited", "", formatPoints(-t.debited), ""})
+	_ = c.w.Write([]string{to, "closing_balance", "", "", formatPoints(t.closing)})
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
@@ -0,0 +1,166 @@
+package statements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	api "gophermat/api/gen/statements"
+	"gophermat/internal/models"
+)
+
+const (
+	APIStatementsPath = "/statements"
+)
+
+type gmart interface {
+	OpenStatement(ctx context.Context, month string) (models.Statement, error)
+	StreamStatement(ctx context.Context, st models.Statement, fn func(models.StatementEntry) error) error
+}
+
+// statementWriter формирует файл выписки по мере получения операций.
+type statementWriter interface {
+	Begin(st models.Statement) error
+	Entry(e models.StatementEntry) error
+	End() error
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+// GetStatement отдаёт выписку по мере её формирования: файл пишется в канал, из которого читается ответ,
+// поэтому операции выписки не накапливаются в памяти.
+func (h *Handler) GetStatement(ctx context.Context, params api.GetStatementParams) (api.GetStatementRes, error) {
+	st, err := h.gmart.OpenStatement(ctx, params.Month)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidInput) {
+			return &api.GetStatementBadRequest{}, nil
+		}
+
+		return &api.GetStatementInternalServerError{}, err
+	}
+
+	format := params.Format.Or(api.GetStatementFormatCsv)
+
+	var sw statementWriter
+
+	pr, pw := io.Pipe()
+
+	if format == api.GetStatementFormatPdf {
+		sw = newPDFWriter(pw)
+	} else {
+		sw = newCSVWriter(pw)
+	}
+
+	go func() {
+		err := h.writeStatement(ctx, st, sw)
+		if err != nil {
+			h.log.Error("cannot write statement", zap.Error(err))
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	// если клиент отключился и ответ больше не читается, запись выписки прерывается
+	go func() {
+		<-ctx.Done()
+		pr.CloseWithError(ctx.Err())
+	}()
+
+	disposition := api.NewOptString(fmt.Sprintf("attachment; filename=%s",
+		strconv.Quote("statement-"+st.From.Format("2006-01")+"."+string(format))))
+
+	if format == api.GetStatementFormatPdf {
+		return &api.GetStatementOKApplicationPdfHeaders{
+			ContentDisposition: disposition,
+			Response:           api.GetStatementOKApplicationPdf{Data: pr},
+		}, nil
+	}
+
+	return &api.GetStatementOKTextCsvHeaders{
+		ContentDisposition: disposition,
+		Response:           api.GetStatementOKTextCsv{Data: pr},
+	}, nil
+}
+
+func (h *Handler) writeStatement(ctx context.Context, st models.Statement, sw statementWriter) error {
+	if err := sw.Begin(st); err != nil {
+		return err
+	}
+
+	if err := h.gmart.StreamStatement(ctx, st, sw.Entry); err != nil {
+		return err
+	}
+
+	return sw.End()
+}
+
+// statementTotals итоги выписки, которые подсчитываются по мере записи операций.
+type statementTotals struct {
+	st       models.Statement
+	credited int
+	debited  int
+	closing  int
+}
+
+func (t *statementTotals) begin(st models.Statement) {
+	t.st = st
+	t.closing = st.Opening
+}
+
+func (t *statementTotals) add(e models.StatementEntry) {
+	if e.Amount > 0 {
+		t.credited += e.Amount
+	} else {
+		t.debited -= e.Amount
+	}
+
+	t.closing = e.Balance
+}
+
+// formatPoints форматирует сумму в копейках как баллы с двумя знаками после точки.
+func formatPoints(v int) string {
+	return strconv.FormatFloat(float64(v)/100, 'f', 2, 64)
+}
+
+// entryTitle возвращает название операции выписки.
+func entryTitle(kind string) string {
+	switch kind {
+	case models.LotSourceAccrual:
+		return "Accrual"
+	case models.LotSourceCampaign:
+		return "Campaign bonus"
+	case models.LotSourcePromo:
+		return "Promo code"
+	case models.LotSourceReferral:
+		return "Referral bonus"
+	case models.LotSourceTransfer:
+		return "Transfer"
+	case models.LotSourceAdjustment:
+		return "Adjustment"
+	case models.LotSourceRefund:
+		return "Refund"
+	case models.TransactionWithdrawal:
+		return "Withdrawal"
+	case models.TransactionClawback:
+		return "Clawback"
+	case models.TransactionExpiration:
+		return "Expiration"
+	default:
+		return kind
+	}
+}
@@ -0,0 +1,123 @@
+package statements
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	api "gophermat/api/gen/statements"
+	"gophermat/internal/models"
+)
+
+type testGMart struct {
+	st      models.Statement
+	entries []models.StatementEntry
+}
+
+func (g testGMart) OpenStatement(_ context.Context, _ string) (models.Statement, error) {
+	return g.st, nil
+}
+
+func (g testGMart) StreamStatement(_ context.Context, _ models.Statement, fn func(models.StatementEntry) error) error {
+	for _, e := range g.entries {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTestGMart() testGMart {
+	from := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	return testGMart{
+		st: models.Statement{UserID: 7, Login: "alice", From: from, To: from.AddDate(0, 1, 0), Opening: 70000},
+		entries: []models.StatementEntry{
+			{Kind: models.LotSourceRefund, Order: "2377225624", Amount: 10000, Balance: 80000, Time: from.Add(48 * time.Hour)},
+			{Kind: models.LotSourceTransfer, Amount: -20000, Balance: 60000, Time: from.Add(72 * time.Hour)},
+			{Kind: models.TransactionExpiration, Amount: -5050, Balance: 54950, Time: from.Add(96 * time.Hour)},
+		},
+	}
+}
+
+func readStatement(t *testing.T, res api.GetStatementRes) []byte {
+	t.Helper()
+
+	var r io.Reader
+
+	switch res := res.(type) {
+	case *api.GetStatementOKTextCsvHeaders:
+		r = res.Response.Data
+	case *api.GetStatementOKApplicationPdfHeaders:
+		r = res.Response.Data
+	default:
+		t.Fatalf("response = %T", res)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("cannot read statement: %v", err)
+	}
+
+	return data
+}
+
+func TestGetStatementCSV(t *testing.T) {
+	h := NewHandler(zap.NewNop(), newTestGMart())
+
+	res, err := h.GetStatement(context.Background(), api.GetStatementParams{Month: "2023-02"})
+	if err != nil {
+		t.Fatalf("GetStatement: %v", err)
+	}
+
+	if d := res.(*api.GetStatementOKTextCsvHeaders).ContentDisposition.Or(""); !strings.Contains(d, "statement-2023-02.csv") {
+		t.Errorf("content disposition = %q", d)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(readStatement(t, res))).ReadAll()
+	if err != nil {
+		t.Fatalf("cannot parse csv: %v", err)
+	}
+
+	want := [][]string{
+		{"date", "type", "order", "amount", "balance"},
+		{"2023-02-01T00:00:00Z", "opening_balance", "", "", "700.00"},
+		{"2023-02-03T00:00:00Z", "refund", "2377225624", "100.00", "800.00"},
+		{"2023-02-04T00:00:00Z", "transfer", "", "-200.00", "600.00"},
+		{"2023-02-05T00:00:00Z", "expiration", "", "-50.50", "549.50"},
+		{"2023-03-01T00:00:00Z", "total_credited", "", "100.00", ""},
+		{"2023-03-01T00:00:00Z", "total_debited", "", "-250.50", ""},
+		{"2023-03-01T00:00:00Z", "closing_balance", "", "", "549.50"},
+	}
+
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("statement = %q, want %q", records, want)
+	}
+}
+
+func TestGetStatementPDF(t *testing.T) {
+	h := NewHandler(zap.NewNop(), newTestGMart())
+
+	res, err := h.GetStatement(context.Background(), api.GetStatementParams{
+		Month:  "2023-02",
+		Format: api.NewOptGetStatementFormat(api.GetStatementFormatPdf),
+	})
+	if err != nil {
+		t.Fatalf("GetStatement: %v", err)
+	}
+
+	data := readStatement(t, res)
+
+	if !bytes.HasPrefix(data, []byte("%PDF-")) || !bytes.Contains(data, []byte("Expiration")) ||
+		!bytes.Contains(data, []byte("Closing balance")) {
+		t.Errorf("pdf statement is malformed:\n%s", data)
+	}
+}
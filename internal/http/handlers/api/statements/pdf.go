@@ -0,0 +1,285 @@
+package statements
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"gophermat/internal/models"
+)
+
+// Разметка страницы A4 в пунктах.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfLineHeight = 14
+	pdfFontSize   = 10
+	pdfTitleSize  = 14
+)
+
+// Номера объектов, которые известны заранее. Страницы и их содержимое получают следующие номера.
+const (
+	pdfCatalogObject = 1
+	pdfPagesObject   = 2
+	pdfRegularObject = 3
+	pdfBoldObject    = 4
+)
+
+// Колонки таблицы операций: левая граница для текста и правая для сумм.
+const (
+	pdfDateX    = pdfMargin
+	pdfTypeX    = 160
+	pdfOrderX   = 250
+	pdfAmountX  = 450
+	pdfBalanceX = pdfPageWidth - pdfMargin
+)
+
+// pdfWriter пишет выписку в PDF стандартными шрифтами Helvetica, которые не нужно встраивать.
+// Каждая страница записывается сразу после заполнения, в памяти хранятся только текущая страница
+// и смещения объектов для таблицы xref.
+type pdfWriter struct {
+	w *countingWriter
+
+	offsets []int64
+	pages   []int
+	page    bytes.Buffer
+	y       int
+
+	totals statementTotals
+}
+
+func newPDFWriter(w io.Writer) *pdfWriter {
+	return &pdfWriter{
+		w:       &countingWriter{w: w},
+		offsets: make([]int64, pdfBoldObject),
+	}
+}
+
+func (p *pdfWriter) Begin(st models.Statement) error {
+	p.totals.begin(st)
+
+	// двоичный комментарий во второй строке сообщает программам, что файл не текстовый
+	if _, err := io.WriteString(p.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return err
+	}
+
+	objects := []struct {
+		num  int
+		body string
+	}{
+		{pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject)},
+		{pdfRegularObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"},
+		{pdfBoldObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"},
+	}
+
+	for _, o := range objects {
+		if err := p.object(o.num, o.body); err != nil {
+			return err
+		}
+	}
+
+	p.y = pdfPageHeight - pdfMargin
+
+	last := st.To.AddDate(0, 0, -1)
+
+	p.text("F2", pdfTitleSize, pdfMargin, "Account statement")
+	p.y -= pdfLineHeight / 2
+	p.line("F1", "Account: "+st.Login)
+	p.line("F1", fmt.Sprintf("Period: %s - %s", st.From.Format("2006-01-02"), last.Format("2006-01-02")))
+	p.line("F1", "Opening balance: "+formatPoints(st.Opening))
+	p.y -= pdfLineHeight
+
+	p.tableHeader()
+
+	return nil
+}
+
+func (p *pdfWriter) Entry(e models.StatementEntry) error {
+	p.totals.add(e)
+
+	if p.y < pdfMargin+pdfLineHeight {
+		if err := p.flushPage(); err != nil {
+			return err
+		}
+
+		p.tableHeader()
+	}
+
+	p.row("F1", e.Time.Format("2006-01-02 15:04"), entryTitle(e.Kind), e.Order,
+		formatPoints(e.Amount), formatPoints(e.Balance))
+
+	return nil
+}
+
+func (p *pdfWriter) End() error {
+	t := p.totals
+
+	// итоги не разрываются между страницами
+	if p.y < pdfMargin+4*pdfLineHeight {
+		if err := p.flushPage(); err != nil {
+			return err
+		}
+	}
+
+	p.y -= pdfLineHeight
+	p.line("F2", "Credited: "+formatPoints(t.credited))
+	p.line("F2", "Debited: "+formatPoints(t.debited))
+	p.line("F2", "Closing balance: "+formatPoints(t.closing))
+
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, 0, len(p.pages))
+	for _, n := range p.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", n))
+	}
+
+	err := p.object(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(p.pages)))
+	if err != nil {
+		return err
+	}
+
+	xref := p.w.n
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+
+	for _, off := range p.offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(p.offsets)+1, pdfCatalogObject, xref)
+
+	_, err = io.WriteString(p.w, b.String())
+
+	return err
+}
+
+// tableHeader добавляет на страницу заголовок таблицы операций.
+func (p *pdfWriter) tableHeader() {
+	p.row("F2", "Date", "Type", "Order", "Amount", "Balance")
+}
+
+// row добавляет строку таблицы операций, суммы выравниваются по правому краю колонки.
+func (p *pdfWriter) row(font, date, kind, order, amount, balance string) {
+	p.y -= pdfLineHeight
+
+	p.textAt(font, pdfFontSize, pdfDateX, p.y, date)
+	p.textAt(font, pdfFontSize, pdfTypeX, p.y, kind)
+	p.textAt(font, pdfFontSize, pdfOrderX, p.y, order)
+	p.textAt(font, pdfFontSize, pdfAmountX-textWidth(amount, pdfFontSize), p.y, amount)
+	p.textAt(font, pdfFontSize, pdfBalanceX-textWidth(balance, pdfFontSize), p.y, balance)
+}
+
+// line добавляет строку текста обычного размера.
+func (p *pdfWriter) line(font, s string) {
+	p.text(font, pdfFontSize, pdfMargin, s)
+}
+
+func (p *pdfWriter) text(font string, size, x int, s string) {
+	p.y -= pdfLineHeight
+	p.textAt(font, size, x, p.y, s)
+}
+
+func (p *pdfWriter) textAt(font string, size, x, y int, s string) {
+	fmt.Fprintf(&p.page, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// flushPage записывает содержимое текущей страницы и саму страницу и начинает новую.
+func (p *pdfWriter) flushPage() error {
+	content := p.newObject()
+
+	p.offsets[content-1] = p.w.n
+
+	_, err := fmt.Fprintf(p.w, "%d 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+		content, p.page.Len(), p.page.Bytes())
+	if err != nil {
+		return err
+	}
+
+	page := p.newObject()
+
+	err = p.object(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+		"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfRegularObject, pdfBoldObject, content))
+	if err != nil {
+		return err
+	}
+
+	p.pages = append(p.pages, page)
+	p.page.Reset()
+	p.y = pdfPageHeight - pdfMargin
+
+	return nil
+}
+
+func (p *pdfWriter) newObject() int {
+	p.offsets = append(p.offsets, 0)
+
+	return len(p.offsets)
+}
+
+func (p *pdfWriter) object(num int, body string) error {
+	p.offsets[num-1] = p.w.n
+
+	_, err := fmt.Fprintf(p.w, "%d 0 obj\n%s\nendobj\n", num, body)
+
+	return err
+}
+
+// pdfString экранирует строку для PDF. Символы вне ASCII заменяются на '?', так как шрифт не встраивается.
+func pdfString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// textWidth возвращает ширину числа шрифтом Helvetica в пунктах. Ширины символов заданы
+// в тысячных долях размера шрифта по метрикам шрифта.
+func textWidth(s string, size int) int {
+	width := 0
+
+	for _, r := range s {
+		switch r {
+		case '.', ',':
+			width += 278
+		case '-':
+			width += 333
+		default:
+			width += 556
+		}
+	}
+
+	return width * size / 1000
+}
+
+// countingWriter считает записанные байты, по ним вычисляются смещения объектов.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+
+	return n, err
+}
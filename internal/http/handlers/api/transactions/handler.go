@@ -0,0 +1,65 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	api "gophermat/api/gen/transactions"
+	"gophermat/internal/models"
+)
+
+const (
+	APITransactionsPath = "/transactions"
+)
+
+type gmart interface {
+	GetTransactions(ctx context.Context, page models.TransactionPage) ([]models.BalanceTransaction, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+}
+
+func NewHandler(log *zap.Logger, gmart gmart) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+	}
+}
+
+func (h *Handler) GetTransactions(ctx context.Context, params api.GetTransactionsParams) (api.GetTransactionsRes, error) {
+	transactions, err := h.gmart.GetTransactions(ctx, models.TransactionPage{
+		After: params.After.Or(0),
+		Limit: params.Limit.Or(50),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return &api.GetTransactionsNoContent{}, nil
+		}
+
+		return &api.GetTransactionsInternalServerError{}, err
+	}
+
+	result := make(api.GetTransactionsOKApplicationJSON, 0, len(transactions))
+	for _, t := range transactions {
+		r := api.GetTransactionsOKItem{
+			ID:        t.ID,
+			Type:      api.GetTransactionsOKItemType(t.Kind),
+			Amount:    float64(t.Amount) / 100,
+			Balance:   float64(t.Balance) / 100,
+			CreatedAt: t.CreatedAt,
+		}
+
+		if t.Order != "" {
+			r.Order = api.NewOptString(t.Order)
+		}
+
+		result = append(result, r)
+	}
+
+	return &result, nil
+}
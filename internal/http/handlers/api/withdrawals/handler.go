@@ -45,6 +45,8 @@ func (h *Handler) GetWithdrawals(ctx context.Context) (api.GetWithdrawalsRes, er
 		r := api.GetWithdrawalsOKItem{
 			Order:       api.NewOptString(d.Order),
 			Sum:         api.NewOptFloat64(float64(d.Sum) / 100),
+			Refunded:    api.NewOptFloat64(float64(d.Refunded) / 100),
+			Status:      api.NewOptGetWithdrawalsOKItemStatus(api.GetWithdrawalsOKItemStatus(d.Status())),
 			ProcessedAt: api.NewOptDateTime(d.ProcessedAt),
 		}
 
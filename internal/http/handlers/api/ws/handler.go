@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/http/websocket"
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+const APIWebSocketPath = "/ws"
+
+// TokenProtocol подпротокол, за которым клиент передаёт токен в Sec-WebSocket-Protocol.
+const TokenProtocol = "access_token"
+
+type gmart interface {
+	SubscribeEvents(ctx context.Context) (<-chan struct{}, func(), error)
+	GetEvents(ctx context.Context, after int64) ([]models.UserEvent, error)
+	GetLastEventID(ctx context.Context) (int64, error)
+}
+
+type authorizer interface {
+	ParseToken(context.Context, string) (models.TokenPayload, error)
+}
+
+type Handler struct {
+	log *zap.Logger
+
+	gmart gmart
+	auth  authorizer
+	set   settings.WebSocketSettings
+}
+
+func NewHandler(log *zap.Logger, gmart gmart, auth authorizer, set settings.WebSocketSettings) *Handler {
+	return &Handler{
+		log:   log,
+		gmart: gmart,
+		auth:  auth,
+		set:   set,
+	}
+}
+
+// WebSocket переводит соединение в протокол WebSocket и отправляет клиенту изменения заказов
+// и баланса по темам, на которые он подписался. Браузер не может передать заголовок Authorization
+// при открытии WebSocket, поэтому токен можно передать в Sec-WebSocket-Protocol как подпротоколы
+// "access_token, <токен>". В ответе сервер выбирает только access_token, а в строку запроса токен
+// не передаётся, чтобы он не попадал в журналы прокси.
+func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	var protocol string
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = protocolToken(websocket.Subprotocols(r))
+		protocol = TokenProtocol
+	}
+
+	if token == "" {
+		http.Error(w, "user is not authentication", http.StatusUnauthorized)
+
+		return
+	}
+
+	tokenPayload, err := h.auth.ParseToken(r.Context(), token)
+	if err != nil {
+		h.log.Info(fmt.Sprintf("Failed to open websocket: %s", err.Error()))
+
+		http.Error(w, "user is not authentication", http.StatusUnauthorized)
+
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), models.CtxTokenPayload{}, tokenPayload)
+
+	var after int64
+
+	if lastEventID := r.URL.Query().Get("last_event_id"); lastEventID != "" {
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after < 0 {
+			http.Error(w, "invalid last_event_id", http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	// подписка оформляется до чтения событий, чтобы не пропустить сохранённые между чтением и подпиской
+	wakeCh, unsubscribe, err := h.gmart.SubscribeEvents(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	defer unsubscribe()
+
+	if after == 0 {
+		after, err = h.gmart.GetLastEventID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	conn, err := websocket.Upgrade(w, r, protocol)
+	if err != nil {
+		h.log.Info(fmt.Sprintf("Failed to open websocket: %s", err.Error()))
+
+		return
+	}
+
+	s := newSession(h.log, h.gmart, conn, h.set, after)
+
+	code, reason := s.run(ctx, wakeCh, time.Until(tokenPayload.Expiry))
+
+	h.log.Debug("websocket closed", zap.Int("user_id", tokenPayload.UserID), zap.Int("code", code),
+		zap.String("reason", reason))
+}
+
+// protocolToken возвращает токен, предложенный подпротоколом сразу после access_token.
+func protocolToken(protocols []string) string {
+	for i := 0; i+1 < len(protocols); i++ {
+		if protocols[i] == TokenProtocol {
+			return protocols[i+1]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,387 @@
+package ws
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+type testGMart struct {
+	mu     sync.Mutex
+	events []models.UserEvent
+	wakeCh chan struct{}
+}
+
+func (g *testGMart) SubscribeEvents(_ context.Context) (<-chan struct{}, func(), error) {
+	return g.wakeCh, func() {}, nil
+}
+
+func (g *testGMart) GetEvents(_ context.Context, after int64) ([]models.UserEvent, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	events := make([]models.UserEvent, 0)
+
+	for _, e := range g.events {
+		if e.ID > after {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func (g *testGMart) GetLastEventID(_ context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.events) == 0 {
+		return 0, nil
+	}
+
+	return g.events[len(g.events)-1].ID, nil
+}
+
+// add сохраняет событие и будит сессию, как это делает сервис после фиксации транзакции.
+func (g *testGMart) add(e models.UserEvent) {
+	g.mu.Lock()
+	g.events = append(g.events, e)
+	g.mu.Unlock()
+
+	g.wakeCh <- struct{}{}
+}
+
+// testAuthorizer принимает токен "token" на час и "expiring" на 50 мс.
+type testAuthorizer struct{}
+
+func (testAuthorizer) ParseToken(_ context.Context, token string) (models.TokenPayload, error) {
+	switch token {
+	case "token":
+		return models.TokenPayload{UserID: 7, Expiry: time.Now().Add(time.Hour)}, nil
+	case "expiring":
+		return models.TokenPayload{UserID: 7, Expiry: time.Now().Add(50 * time.Millisecond)}, nil
+	default:
+		return models.TokenPayload{}, errors.New("invalid token")
+	}
+}
+
+func testEvent(id int64, kind string) models.UserEvent {
+	return models.UserEvent{ID: id, UserID: 7, Kind: kind, Order: "79927398713", Status: "PROCESSED",
+		Transaction: models.LotSourceAccrual, Amount: 500, Balance: 500 * int(id),
+		CreatedAt: time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+var testSettings = settings.WebSocketSettings{PingInterval: time.Hour, PongTimeout: time.Hour, SendBuffer: 16}
+
+func newTestServer(t *testing.T, g *testGMart, set settings.WebSocketSettings) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(NewHandler(zap.NewNop(), g, testAuthorizer{}, set).WebSocket))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// testClient клиент WebSocket, который отправляет текстовые сообщения и читает кадры сервера.
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dial открывает соединение с токеном в подпротоколе, как это делает браузер.
+func dial(t *testing.T, srv *httptest.Server, query string, protocols string) (*testClient, *http.Response) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Protocol", protocols)
+
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+
+	return &testClient{t: t, conn: conn, br: br}, resp
+}
+
+// connect открывает соединение и проверяет, что сервер выбрал подпротокол токена.
+func connect(t *testing.T, srv *httptest.Server, query, token string) *testClient {
+	t.Helper()
+
+	c, resp := dial(t, srv, query, TokenProtocol+", "+token)
+
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Protocol") != TokenProtocol {
+		t.Fatalf("handshake = %d, protocol %q", resp.StatusCode, resp.Header.Get("Sec-WebSocket-Protocol"))
+	}
+
+	return c
+}
+
+// send отправляет замаскированный кадр.
+func (c *testClient) send(op byte, payload string) {
+	c.t.Helper()
+
+	mask := [4]byte{1, 2, 3, 4}
+	frame := append([]byte{0x80 | op, 0x80 | byte(len(payload))}, mask[:]...)
+
+	for i := 0; i < len(payload); i++ {
+		frame = append(frame, payload[i]^mask[i%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		c.t.Fatalf("write frame: %v", err)
+	}
+}
+
+// receive читает кадр сервера, io.EOF означает, что сервер разорвал соединение.
+func (c *testClient) receive() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	length := int(header[1] & 0x7f)
+
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+
+	return header[0] & 0x0f, payload, nil
+}
+
+// message читает сообщение сервера.
+func (c *testClient) message() message {
+	c.t.Helper()
+
+	op, payload, err := c.receive()
+	if err != nil || op != 0x1 {
+		c.t.Fatalf("frame = %x %q, %v, want text message", op, payload, err)
+	}
+
+	var m message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		c.t.Fatalf("cannot decode %q: %v", payload, err)
+	}
+
+	return m
+}
+
+// expectClose проверяет, что сервер закрыл соединение с кодом code.
+func (c *testClient) expectClose(code int) string {
+	c.t.Helper()
+
+	op, payload, err := c.receive()
+	if err != nil || op != 0x8 || len(payload) < 2 || int(binary.BigEndian.Uint16(payload)) != code {
+		c.t.Fatalf("frame = %x %q, %v, want close %d", op, payload, err, code)
+	}
+
+	return string(payload[2:])
+}
+
+func TestWebSocketUnauthorized(t *testing.T) {
+	srv := newTestServer(t, &testGMart{wakeCh: make(chan struct{}, 1)}, testSettings)
+
+	tests := []struct {
+		name      string
+		query     string
+		protocols string
+		want      int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "invalid token", protocols: "access_token, other", want: http.StatusUnauthorized},
+		{name: "no token after protocol", protocols: "access_token", want: http.StatusUnauthorized},
+		{name: "token without protocol", protocols: "token", want: http.StatusUnauthorized},
+		// токен в строке запроса попадает в журналы прокси и не принимается
+		{name: "token in query", query: "?access_token=token", want: http.StatusUnauthorized},
+		{name: "invalid last event id", query: "?last_event_id=abc", protocols: "access_token, token",
+			want: http.StatusBadRequest},
+		{name: "negative last event id", query: "?last_event_id=-1", protocols: "access_token, token",
+			want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp := dial(t, srv, tt.query, tt.protocols)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+// TestWebSocketAuthorizationHeader проверяет, что клиенты, которые могут передать заголовок Authorization,
+// подключаются без подпротокола.
+func TestWebSocketAuthorizationHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Authorization", "Bearer token")
+
+	// без заголовков рукопожатия запрос доходит до Upgrade, то есть токен принят
+	w := httptest.NewRecorder()
+	NewHandler(zap.NewNop(), &testGMart{}, testAuthorizer{}, testSettings).WebSocket(w, req)
+
+	if w.Code != http.StatusUpgradeRequired {
+		t.Errorf("status = %d, want 426", w.Code)
+	}
+}
+
+// TestWebSocketSession проверяет подписку, получение пропущенных и новых событий по темам и ошибки команд.
+func TestWebSocketSession(t *testing.T) {
+	g := &testGMart{
+		events: []models.UserEvent{testEvent(1, models.EventBalance), testEvent(2, models.EventBalance)},
+		wakeCh: make(chan struct{}, 1),
+	}
+	c := connect(t, newTestServer(t, g, testSettings), "?last_event_id=1", "token")
+
+	c.send(0x1, `{"action":"subscribe","topics":["balance"]}`)
+
+	if m := c.message(); m.Type != messageSubscription || len(m.Topics) != 1 || m.Topics[0] != TopicBalance {
+		t.Errorf("subscription = %+v", m)
+	}
+
+	// после подписки приходит событие, пропущенное после last_event_id
+	m := c.message()
+	if m.Type != messageEvent || m.ID != 2 || m.Topic != TopicBalance || m.Event != models.EventBalance ||
+		m.Data == nil || m.Data.Balance == nil || *m.Data.Balance != 10 {
+		t.Errorf("missed event = %+v", m)
+	}
+
+	// события тем без подписки пропускаются
+	g.add(testEvent(3, models.EventOrderStatus))
+	g.add(testEvent(4, models.EventWithdrawal))
+
+	if m := c.message(); m.ID != 4 || m.Topic != TopicBalance || m.Event != models.EventWithdrawal {
+		t.Errorf("new event = %+v, want withdrawal 4", m)
+	}
+
+	c.send(0x1, `{"action":"subscribe","topics":["orders","balance"]}`)
+
+	if m := c.message(); m.Type != messageSubscription || len(m.Topics) != 2 {
+		t.Errorf("subscription = %+v", m)
+	}
+
+	g.add(testEvent(5, models.EventOrderStatus))
+
+	if m := c.message(); m.ID != 5 || m.Topic != TopicOrders || m.Data == nil || m.Data.Status != "PROCESSED" {
+		t.Errorf("order event = %+v", m)
+	}
+
+	c.send(0x1, `{"action":"unsubscribe","topics":["orders"]}`)
+
+	if m := c.message(); m.Type != messageSubscription || len(m.Topics) != 1 || m.Topics[0] != TopicBalance {
+		t.Errorf("subscription after unsubscribe = %+v", m)
+	}
+
+	for command, want := range map[string]string{
+		`{"action":"subscribe","topics":["news"]}`: "unknown topic: news",
+		`{"action":"publish"}`:                     "unknown action: publish",
+		`not json`:                                 "invalid message format",
+	} {
+		c.send(0x1, command)
+
+		if m := c.message(); m.Type != messageError || m.Error != want {
+			t.Errorf("reply to %s = %+v, want error %q", command, m, want)
+		}
+	}
+
+	c.send(0x8, "\x03\xe8")
+	c.expectClose(1000)
+}
+
+// TestWebSocketFirstConnect проверяет, что клиент без last_event_id получает только новые события.
+func TestWebSocketFirstConnect(t *testing.T) {
+	g := &testGMart{events: []models.UserEvent{testEvent(1, models.EventBalance)}, wakeCh: make(chan struct{}, 1)}
+	c := connect(t, newTestServer(t, g, testSettings), "", "token")
+
+	c.send(0x1, `{"action":"subscribe","topics":["balance"]}`)
+	c.message()
+
+	g.add(testEvent(2, models.EventBalance))
+
+	if m := c.message(); m.ID != 2 {
+		t.Errorf("first event = %+v, want only the new event 2", m)
+	}
+}
+
+func TestWebSocketTokenExpired(t *testing.T) {
+	c := connect(t, newTestServer(t, &testGMart{wakeCh: make(chan struct{}, 1)}, testSettings), "", "expiring")
+
+	if reason := c.expectClose(1008); reason != "token expired" {
+		t.Errorf("close reason = %q, want token expired", reason)
+	}
+}
+
+// TestWebSocketPongTimeout проверяет, что клиент, который не отвечает на ping, отключается.
+func TestWebSocketPongTimeout(t *testing.T) {
+	set := settings.WebSocketSettings{PingInterval: 20 * time.Millisecond, PongTimeout: 20 * time.Millisecond, SendBuffer: 16}
+	c := connect(t, newTestServer(t, &testGMart{wakeCh: make(chan struct{}, 1)}, set), "", "token")
+
+	pings := 0
+
+	for {
+		op, _, err := c.receive()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("connection was not closed: %v", err)
+		}
+
+		if op == 0x9 {
+			pings++
+		}
+	}
+
+	if pings == 0 {
+		t.Error("no ping before disconnect")
+	}
+}
@@ -0,0 +1,308 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/http/handlers/api/events"
+	"gophermat/internal/http/websocket"
+	"gophermat/internal/models"
+	"gophermat/internal/settings"
+)
+
+// Темы, на которые подписывается клиент.
+const (
+	TopicOrders  = "orders"
+	TopicBalance = "balance"
+)
+
+// Действия клиента.
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+// Типы сообщений клиенту.
+const (
+	messageEvent        = "event"
+	messageSubscription = "subscription"
+	messageError        = "error"
+)
+
+// maxCommandSize наибольший размер сообщения клиента, команды подписки намного меньше.
+const maxCommandSize = 4 << 10
+
+var errSlowClient = errors.New("client is too slow")
+
+// eventTopics темы событий пользователя.
+var eventTopics = map[string]string{
+	models.EventOrderStatus: TopicOrders,
+	models.EventBalance:     TopicBalance,
+	models.EventWithdrawal:  TopicBalance,
+}
+
+// command сообщение клиента, например {"action":"subscribe","topics":["orders","balance"]}.
+type command struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+
+	err error
+}
+
+// message сообщение клиенту.
+type message struct {
+	Type   string            `json:"type"`
+	ID     int64             `json:"id,omitempty"`
+	Topic  string            `json:"topic,omitempty"`
+	Event  string            `json:"event,omitempty"`
+	Data   *events.EventData `json:"data,omitempty"`
+	Topics []string          `json:"topics,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// session соединение клиента. Сообщения клиента читает отдельная горутина, отправляет их другая
+// через ограниченную очередь send, а события и команды обрабатывает run.
+type session struct {
+	log   *zap.Logger
+	gmart gmart
+	conn  *websocket.Conn
+	set   settings.WebSocketSettings
+
+	send     chan []byte
+	commands chan command
+
+	after  int64
+	topics map[string]bool
+}
+
+func newSession(log *zap.Logger, gmart gmart, conn *websocket.Conn, set settings.WebSocketSettings, after int64) *session {
+	return &session{
+		log:      log,
+		gmart:    gmart,
+		conn:     conn,
+		set:      set,
+		send:     make(chan []byte, set.SendBuffer),
+		commands: make(chan command),
+		after:    after,
+		topics:   make(map[string]bool),
+	}
+}
+
+// run обслуживает соединение до его закрытия и возвращает код и причину закрытия.
+// Соединение закрывается по истечении срока действия токена через expiresIn.
+func (s *session) run(ctx context.Context, wakeCh <-chan struct{}, expiresIn time.Duration) (int, string) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		defer cancel()
+
+		s.read(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer cancel()
+
+		s.write(ctx)
+	}()
+
+	code, reason := s.serve(ctx, wakeCh, expiresIn)
+	if code != 0 {
+		_ = s.conn.WriteClose(code, reason)
+	}
+
+	cancel()
+
+	// чтение прерывается только закрытием соединения
+	_ = s.conn.Close()
+
+	wg.Wait()
+
+	return code, reason
+}
+
+// serve отправляет клиенту новые события и выполняет его команды. Код 0 означает, что соединение
+// уже разорвано и кадр закрытия отправлять не нужно.
+func (s *session) serve(ctx context.Context, wakeCh <-chan struct{}, expiresIn time.Duration) (int, string) {
+	expiry := time.NewTimer(expiresIn)
+	defer expiry.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err().Error()
+		case <-expiry.C:
+			return websocket.ClosePolicyViolation, "token expired"
+		case cmd := <-s.commands:
+			// после подписки клиент сразу получает события, пропущенные с last_event_id
+			if err = s.execute(cmd); err == nil {
+				err = s.sendEvents(ctx)
+			}
+		case <-wakeCh:
+			err = s.sendEvents(ctx)
+		}
+
+		switch {
+		case err == nil:
+		case errors.Is(err, errSlowClient):
+			return websocket.ClosePolicyViolation, err.Error()
+		case ctx.Err() != nil:
+			return 0, ctx.Err().Error()
+		default:
+			s.log.Error("cannot send events", zap.Error(err))
+
+			return websocket.CloseInternalError, "internal server error"
+		}
+	}
+}
+
+// execute меняет подписку клиента и отправляет ему текущий список тем или ошибку.
+func (s *session) execute(cmd command) error {
+	if cmd.err != nil {
+		return s.enqueue(message{Type: messageError, Error: "invalid message format"})
+	}
+
+	if cmd.Action != actionSubscribe && cmd.Action != actionUnsubscribe {
+		return s.enqueue(message{Type: messageError, Error: "unknown action: " + cmd.Action})
+	}
+
+	for _, topic := range cmd.Topics {
+		if topic != TopicOrders && topic != TopicBalance {
+			return s.enqueue(message{Type: messageError, Error: "unknown topic: " + topic})
+		}
+	}
+
+	for _, topic := range cmd.Topics {
+		if cmd.Action == actionSubscribe {
+			s.topics[topic] = true
+		} else {
+			delete(s.topics, topic)
+		}
+	}
+
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+
+	sort.Strings(topics)
+
+	return s.enqueue(message{Type: messageSubscription, Topics: topics})
+}
+
+// sendEvents ставит в очередь события после последнего отправленного по темам подписки.
+// События тем, на которые клиент не подписан, пропускаются.
+func (s *session) sendEvents(ctx context.Context) error {
+	for {
+		userEvents, err := s.gmart.GetEvents(ctx, s.after)
+		if err != nil {
+			return err
+		}
+
+		if len(userEvents) == 0 {
+			return nil
+		}
+
+		for _, e := range userEvents {
+			s.after = e.ID
+
+			topic := eventTopics[e.Kind]
+			if !s.topics[topic] {
+				continue
+			}
+
+			data := events.NewEventData(e)
+
+			err = s.enqueue(message{Type: messageEvent, ID: e.ID, Topic: topic, Event: e.Kind, Data: &data})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// enqueue ставит сообщение в очередь отправки. Переполненная очередь означает, что клиент
+// не успевает читать сообщения.
+func (s *session) enqueue(m message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.send <- b:
+		return nil
+	default:
+		return errSlowClient
+	}
+}
+
+// read читает команды клиента. Клиент, который не ответил на ping вовремя, считается отключившимся.
+func (s *session) read(ctx context.Context) {
+	s.conn.SetMaxMessageSize(maxCommandSize)
+
+	deadline := func() {
+		_ = s.conn.SetReadDeadline(time.Now().Add(s.set.PingInterval + s.set.PongTimeout))
+	}
+
+	deadline()
+	s.conn.SetPongHandler(deadline)
+
+	for {
+		_, b, err := s.conn.ReadMessage()
+		if err != nil {
+			s.log.Debug("websocket read stopped", zap.Error(err))
+
+			return
+		}
+
+		var cmd command
+		if err := json.Unmarshal(b, &cmd); err != nil {
+			cmd.err = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case s.commands <- cmd:
+		}
+	}
+}
+
+// write отправляет сообщения из очереди и периодически ping.
+func (s *session) write(ctx context.Context) {
+	ping := time.NewTicker(s.set.PingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+		case b := <-s.send:
+			err = s.conn.WriteMessage(websocket.TextMessage, b)
+		case <-ping.C:
+			err = s.conn.WritePing(nil)
+		}
+
+		if err != nil {
+			s.log.Debug("websocket write stopped", zap.Error(err))
+
+			return
+		}
+	}
+}
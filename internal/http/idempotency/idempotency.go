@@ -0,0 +1,187 @@
+// Package idempotency реализует поддержку заголовка Idempotency-Key: первый ответ на запрос
+// сохраняется и возвращается на повторные запросы с тем же ключом без повторного выполнения.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gophermat/internal/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLen   = 255
+	maxBodyLen  = 1 << 20
+	saveTimeout = time.Second * 5
+)
+
+type gmart interface {
+	BeginIdempotentRequest(ctx context.Context, key, requestHash string) (models.IdempotencyKey, error)
+	CompleteIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error
+	CancelIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error
+}
+
+type authorizer interface {
+	ParseToken(context.Context, string) (models.TokenPayload, error)
+}
+
+// Middleware обрабатывает POST запросы с заголовком Idempotency-Key. Ключи привязаны к пользователю,
+// поэтому запрос без действительного токена передаётся дальше без изменений и отклоняется обработчиком.
+func Middleware(log *zap.Logger, gmart gmart, auth authorizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if len(key) > maxKeyLen {
+				http.Error(w, "idempotency key is too long", http.StatusBadRequest)
+
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			tokenPayload, err := auth.ParseToken(r.Context(), token)
+			if err != nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			// тело читается на байт больше лимита: обрезанное тело дало бы одинаковый хэш разным запросам
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyLen+1))
+			if err != nil {
+				http.Error(w, "cannot read request body", http.StatusBadRequest)
+
+				return
+			}
+
+			if len(body) > maxBodyLen {
+				http.Error(w, "request body is too large", http.StatusRequestEntityTooLarge)
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := context.WithValue(r.Context(), models.CtxTokenPayload{}, tokenPayload)
+
+			saved, err := gmart.BeginIdempotentRequest(ctx, key, requestHash(r, body))
+			if err != nil {
+				switch {
+				case errors.Is(err, models.ErrIdempotencyKeyReused):
+					http.Error(w, "idempotency key is reused with another request", http.StatusUnprocessableEntity)
+				case errors.Is(err, models.ErrConflict):
+					http.Error(w, "request with this idempotency key is in progress", http.StatusConflict)
+				default:
+					http.Error(w, "internal server error", http.StatusInternalServerError)
+				}
+
+				return
+			}
+
+			if saved.StatusCode != 0 {
+				replay(w, saved)
+
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			// ответ сохраняется без контекста запроса, чтобы отключение клиента не оставило ключ занятым
+			saveCtx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+			defer cancel()
+
+			if rec.statusCode() >= http.StatusInternalServerError {
+				if err := gmart.CancelIdempotentRequest(saveCtx, saved); err != nil {
+					log.Warn("cannot cancel idempotent request", zap.Error(err))
+				}
+
+				return
+			}
+
+			saved.StatusCode = rec.statusCode()
+			saved.ContentType = rec.Header().Get("Content-Type")
+			saved.Response = rec.body.Bytes()
+
+			if err := gmart.CompleteIdempotentRequest(saveCtx, saved); err != nil {
+				log.Warn("cannot complete idempotent request", zap.Error(err))
+			}
+		})
+	}
+}
+
+// requestHash отличает повторы запроса от другого запроса с тем же ключом.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, saved models.IdempotencyKey) {
+	if saved.ContentType != "" {
+		w.Header().Set("Content-Type", saved.ContentType)
+	}
+
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(saved.StatusCode)
+	_, _ = w.Write(saved.Response)
+}
+
+// recorder передаёт ответ клиенту и запоминает код и тело ответа.
+type recorder struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+
+	return r.status
+}
@@ -0,0 +1,265 @@
+package idempotency
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+type testGMart struct {
+	mu       sync.Mutex
+	keys     map[string]models.IdempotencyKey
+	canceled int
+}
+
+func newTestGMart() *testGMart {
+	return &testGMart{keys: make(map[string]models.IdempotencyKey)}
+}
+
+func (g *testGMart) BeginIdempotentRequest(ctx context.Context, key, requestHash string) (models.IdempotencyKey, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tokenPayload, _ := ctx.Value(models.CtxTokenPayload{}).(models.TokenPayload)
+
+	saved, ok := g.keys[key]
+	if !ok {
+		saved = models.IdempotencyKey{UserID: tokenPayload.UserID, Key: key, RequestHash: requestHash}
+		g.keys[key] = saved
+
+		return saved, nil
+	}
+
+	if saved.RequestHash != requestHash {
+		return models.IdempotencyKey{}, models.ErrIdempotencyKeyReused
+	}
+
+	if saved.StatusCode == 0 {
+		return models.IdempotencyKey{}, models.ErrConflict
+	}
+
+	return saved, nil
+}
+
+func (g *testGMart) CompleteIdempotentRequest(_ context.Context, key models.IdempotencyKey) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.keys[key.Key] = key
+
+	return nil
+}
+
+func (g *testGMart) CancelIdempotentRequest(_ context.Context, key models.IdempotencyKey) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.keys, key.Key)
+	g.canceled++
+
+	return nil
+}
+
+type testAuth struct{}
+
+func (testAuth) ParseToken(_ context.Context, token string) (models.TokenPayload, error) {
+	if token != "valid" {
+		return models.TokenPayload{}, models.ErrInvalidToken
+	}
+
+	return models.TokenPayload{UserID: 1, Role: models.RoleUser}, nil
+}
+
+// countingHandler отвечает кодом status и считает вызовы.
+type countingHandler struct {
+	status int
+	calls  int
+	body   string
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+
+	b, _ := io.ReadAll(r.Body)
+	h.body = string(b)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(h.calls) + `}`))
+}
+
+func newRequest(key, token, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/user/balance/withdraw", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(HeaderKey, key)
+	}
+
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return r
+}
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestReplay(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(zap.NewNop(), newTestGMart(), testAuth{})(next)
+
+	body := `{"order":"2377225624","sum":751}`
+
+	first := serve(h, newRequest("k1", "valid", body))
+	if first.Code != http.StatusOK || first.Header().Get(HeaderReplayed) != "" {
+		t.Fatalf("first response: %d %v", first.Code, first.Header())
+	}
+
+	if next.body != body {
+		t.Errorf("handler got body %q, want %q", next.body, body)
+	}
+
+	second := serve(h, newRequest("k1", "valid", body))
+	if second.Code != http.StatusOK || second.Header().Get(HeaderReplayed) != "true" {
+		t.Fatalf("replayed response: %d %v", second.Code, second.Header())
+	}
+
+	if second.Body.String() != first.Body.String() || second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("replayed response = %q, want %q", second.Body.String(), first.Body.String())
+	}
+
+	if next.calls != 1 {
+		t.Errorf("handler calls = %d, want 1", next.calls)
+	}
+
+	if w := serve(h, newRequest("k1", "valid", `{"order":"2377225624","sum":1}`)); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reused key: status %d, want 422", w.Code)
+	}
+}
+
+func TestInProgress(t *testing.T) {
+	g := newTestGMart()
+	g.keys["k1"] = models.IdempotencyKey{Key: "k1", RequestHash: requestHash(newRequest("", "", ""), []byte("{}"))}
+
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(zap.NewNop(), g, testAuth{})(next)
+
+	if w := serve(h, newRequest("k1", "valid", "{}")); w.Code != http.StatusConflict {
+		t.Errorf("status %d, want 409", w.Code)
+	}
+
+	if next.calls != 0 {
+		t.Errorf("handler calls = %d, want 0", next.calls)
+	}
+}
+
+func TestServerErrorReleasesKey(t *testing.T) {
+	g := newTestGMart()
+	next := &countingHandler{status: http.StatusInternalServerError}
+	h := Middleware(zap.NewNop(), g, testAuth{})(next)
+
+	serve(h, newRequest("k1", "valid", "{}"))
+
+	next.status = http.StatusOK
+
+	if w := serve(h, newRequest("k1", "valid", "{}")); w.Code != http.StatusOK || w.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("retry after server error: %d %v", w.Code, w.Header())
+	}
+
+	if next.calls != 2 || g.canceled != 1 {
+		t.Errorf("handler calls = %d, canceled = %d, want 2 and 1", next.calls, g.canceled)
+	}
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	g := newTestGMart()
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(zap.NewNop(), g, testAuth{})(next)
+
+	body := strings.Repeat("a", maxBodyLen+1)
+
+	if w := serve(h, newRequest("k1", "valid", body)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status %d, want 413", w.Code)
+	}
+
+	if next.calls != 0 || len(g.keys) != 0 {
+		t.Errorf("oversized request reached handler or took key: calls %d, keys %v", next.calls, g.keys)
+	}
+
+	// тело длиной ровно в лимит принимается целиком
+	body = strings.Repeat("a", maxBodyLen)
+
+	if w := serve(h, newRequest("k2", "valid", body)); w.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", w.Code)
+	}
+
+	if len(next.body) != maxBodyLen {
+		t.Errorf("handler got %d bytes, want %d", len(next.body), maxBodyLen)
+	}
+}
+
+func TestPassThrough(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *http.Request
+	}{
+		{name: "no key", r: newRequest("", "valid", "{}")},
+		{name: "no token", r: newRequest("k1", "", "{}")},
+		{name: "invalid token", r: newRequest("k1", "expired", "{}")},
+		{name: "get request", r: httptest.NewRequest(http.MethodGet, "/api/user/orders", nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGMart()
+			next := &countingHandler{status: http.StatusOK}
+			h := Middleware(zap.NewNop(), g, testAuth{})(next)
+
+			serve(h, tt.r)
+			serve(h, tt.r)
+
+			if next.calls != 2 || len(g.keys) != 0 {
+				t.Errorf("handler calls = %d, keys = %v, want 2 calls and no keys", next.calls, g.keys)
+			}
+		})
+	}
+}
+
+func TestKeyTooLong(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(zap.NewNop(), newTestGMart(), testAuth{})(next)
+
+	if w := serve(h, newRequest(strings.Repeat("k", maxKeyLen+1), "valid", "{}")); w.Code != http.StatusBadRequest {
+		t.Errorf("status %d, want 400", w.Code)
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	withdraw := newRequest("", "", "")
+	orders := httptest.NewRequest(http.MethodPost, "/api/user/orders", nil)
+
+	if requestHash(withdraw, []byte("a")) != requestHash(withdraw, []byte("a")) {
+		t.Error("hash of the same request differs")
+	}
+
+	if requestHash(withdraw, []byte("a")) == requestHash(withdraw, []byte("b")) {
+		t.Error("hash does not depend on body")
+	}
+
+	if requestHash(withdraw, []byte("a")) == requestHash(orders, []byte("a")) {
+		t.Error("hash does not depend on path")
+	}
+}
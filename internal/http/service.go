@@ -7,14 +7,32 @@ import (
 	"net/http"
 	"time"
 
+	apiAdmin "gophermat/api/gen/admin"
 	apiBalance "gophermat/api/gen/balance"
+	apiNotifications "gophermat/api/gen/notifications"
 	apiOrders "gophermat/api/gen/orders"
+	apiPassword "gophermat/api/gen/password"
+	apiPromo "gophermat/api/gen/promo"
+	apiReferrals "gophermat/api/gen/referrals"
+	apiStatements "gophermat/api/gen/statements"
+	apiTransactions "gophermat/api/gen/transactions"
 	apiWithdrawal "gophermat/api/gen/withdrawals"
+	"gophermat/internal/http/handlers/api/admin"
 	"gophermat/internal/http/handlers/api/balance"
+	"gophermat/internal/http/handlers/api/events"
 	"gophermat/internal/http/handlers/api/login"
+	"gophermat/internal/http/handlers/api/notifications"
+	"gophermat/internal/http/handlers/api/oidc"
 	"gophermat/internal/http/handlers/api/orders"
+	"gophermat/internal/http/handlers/api/password"
+	"gophermat/internal/http/handlers/api/promo"
+	"gophermat/internal/http/handlers/api/referrals"
 	"gophermat/internal/http/handlers/api/register"
+	"gophermat/internal/http/handlers/api/statements"
+	"gophermat/internal/http/handlers/api/transactions"
 	"gophermat/internal/http/handlers/api/withdrawals"
+	"gophermat/internal/http/handlers/api/ws"
+	"gophermat/internal/http/idempotency"
 	"gophermat/internal/models"
 	"gophermat/internal/settings"
 
@@ -33,16 +51,67 @@ const (
 
 type gmart interface {
 	LoginUser(ctx context.Context, user models.User) (string, error)
-	RegisterUser(ctx context.Context, user models.User) (string, error)
-	LoadOrder(ctx context.Context, orderNumber string) error
+	RegisterUser(ctx context.Context, user models.User, referralCode string) (string, error)
+	LoadOrder(ctx context.Context, orderNumber, clientKey string) error
 	GetOrders(ctx context.Context) ([]models.Order, error)
 	GetBalance(ctx context.Context) (models.Balance, error)
+	GetAdjustments(ctx context.Context) ([]models.BalanceAdjustment, error)
 	DeductPoints(ctx context.Context, withdraw models.BalanceWithdraw) error
 	GetWithdrawals(ctx context.Context) ([]models.BalanceWithdrawal, error)
+	UnlockUser(ctx context.Context, login string) error
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) (string, error)
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	IssuePasswordReset(ctx context.Context, login string) (models.PasswordReset, error)
+	OIDCAuthURL(ctx context.Context) (string, error)
+	OIDCCallback(ctx context.Context, code, state string) (string, error)
+	SetUserRole(ctx context.Context, login string, role models.Role) error
+	SetUserShop(ctx context.Context, login, shop string) error
+	SearchUsers(ctx context.Context, login string, limit int) ([]models.User, error)
+	GetUserOrders(ctx context.Context, userID int) ([]models.Order, error)
+	GetUserBalance(ctx context.Context, userID int) (models.Balance, error)
+	GetUserWithdrawals(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error)
+	AdjustUserBalance(ctx context.Context, adj models.BalanceAdjustment) (models.BalanceAdjustment, error)
+	GetUserAdjustments(ctx context.Context, userID int) ([]models.BalanceAdjustment, error)
+	ApproveAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error)
+	RejectAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error)
+	ReverseAdjustment(ctx context.Context, id int64, note string) (models.BalanceAdjustment, error)
+	RefundWithdrawal(ctx context.Context, refund models.WithdrawalRefund) (models.BalanceWithdrawal, error)
+	ClawbackOrder(ctx context.Context, orderNumber, reason string) (models.OrderClawback, error)
+	GetNotifications(ctx context.Context) ([]models.Notification, error)
+	GetTransactions(ctx context.Context, page models.TransactionPage) ([]models.BalanceTransaction, error)
+	OpenStatement(ctx context.Context, month string) (models.Statement, error)
+	StreamStatement(ctx context.Context, st models.Statement, fn func(models.StatementEntry) error) error
+	RepollOrder(ctx context.Context, orderNumber string) error
+	InvalidateOrder(ctx context.Context, orderNumber string) error
+	BeginIdempotentRequest(ctx context.Context, key, requestHash string) (models.IdempotencyKey, error)
+	CompleteIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error
+	CancelIdempotentRequest(ctx context.Context, key models.IdempotencyKey) error
+	GetCampaigns(ctx context.Context) ([]models.Campaign, error)
+	CreateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	UpdateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error)
+	DryRunCampaigns(ctx context.Context, order models.CampaignOrder, draft *models.Campaign) ([]models.CampaignBonus, error)
+	RedeemPromoCode(ctx context.Context, code string) (models.PromoRedemption, error)
+	GeneratePromoCodes(ctx context.Context, req models.PromoGenerate) (models.PromoBatch, error)
+	ExportPromoBatch(ctx context.Context, id int64) (models.PromoBatch, error)
+	GetReferrals(ctx context.Context) (models.ReferralSummary, error)
+	TransferPoints(ctx context.Context, recipient string, amount int) (models.Transfer, error)
+	GetTransfers(ctx context.Context) ([]models.TransferEntry, error)
+	GetUserTransfers(ctx context.Context, userID int) ([]models.Transfer, error)
+	ReverseTransfer(ctx context.Context, id int64, note string) (models.Transfer, error)
+	CreateHold(ctx context.Context, withdraw models.BalanceWithdraw) (models.PointHold, error)
+	CaptureHold(ctx context.Context, id int64, sum int) (models.PointHold, error)
+	ReleaseHold(ctx context.Context, id int64) (models.PointHold, error)
+	SubscribeEvents(ctx context.Context) (<-chan struct{}, func(), error)
+	GetEvents(ctx context.Context, after int64) ([]models.UserEvent, error)
+	GetLastEventID(ctx context.Context) (int64, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error)
+	DisableWebhook(ctx context.Context, id int64) (models.Webhook, error)
+	GetWebhookDeliveries(ctx context.Context, id int64) ([]models.WebhookDelivery, error)
 }
 
 type authorizer interface {
-	ParseToken(string) (models.TokenPayload, error)
+	ParseToken(context.Context, string) (models.TokenPayload, error)
 }
 
 type Service struct {
@@ -55,6 +124,9 @@ type Service struct {
 type Route struct {
 	Pattern string
 	Handler http.Handler
+	// Stream ответ передаётся потоком дольше обычного времени обработки запроса, поэтому
+	// ограничение времени к нему не применяется.
+	Stream bool
 }
 
 func NewService(log *zap.Logger, set *settings.Settings, gmart gmart, auth authorizer) (*Service, error) {
@@ -62,18 +134,29 @@ func NewService(log *zap.Logger, set *settings.Settings, gmart gmart, auth autho
 
 	// A good base middleware stack
 	mux.Use(middleware.RequestID)
-	mux.Use(middleware.RealIP)
+	proxies, err := parseProxies(set.Login.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateService, err)
+	}
+
+	mux.Use(clientIP(proxies))
 	mux.Use(middleware.Logger)
 	mux.Use(middleware.Recoverer)
-	mux.Use(middleware.Timeout(60 * time.Second))
 
-	rs, err := createRoutes(log, gmart, auth)
+	timeout := middleware.Timeout(60 * time.Second)
+
+	rs, err := createRoutes(log, set, gmart, auth)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrCreateService, err)
 	}
 
 	for _, route := range rs {
-		mux.Mount(route.Pattern, route.Handler)
+		h := route.Handler
+		if !route.Stream {
+			h = timeout(h)
+		}
+
+		mux.Mount(route.Pattern, h)
 		log.Debug(fmt.Sprintf("added handler for %s", route.Pattern))
 	}
 
@@ -101,9 +184,11 @@ func (s *Service) Stop(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
 
-func createRoutes(log *zap.Logger, gmart gmart, auth authorizer) ([]Route, error) {
+func createRoutes(log *zap.Logger, set *settings.Settings, gmart gmart, auth authorizer) ([]Route, error) {
 	routes := make([]Route, 0)
 
+	idem := idempotency.Middleware(log, gmart, auth)
+
 	lh := login.NewHandler(log, gmart)
 
 	routes = append(routes, Route{
@@ -118,6 +203,18 @@ func createRoutes(log *zap.Logger, gmart gmart, auth authorizer) ([]Route, error
 		Handler: http.HandlerFunc(rh.Register),
 	})
 
+	if set.OIDC.Issuer != "" {
+		oidch := oidc.NewHandler(log, gmart)
+
+		routes = append(routes, Route{
+			Pattern: APIPathPrefix + oidc.APIOIDCLoginPath,
+			Handler: http.HandlerFunc(oidch.Login),
+		}, Route{
+			Pattern: APIPathPrefix + oidc.APIOIDCCallbackPath,
+			Handler: http.HandlerFunc(oidch.Callback),
+		})
+	}
+
 	oh := orders.NewHandler(log, gmart)
 	soh := orders.NewSecHandler(auth)
 	or, err := apiOrders.NewServer(oh, soh)
@@ -127,7 +224,7 @@ func createRoutes(log *zap.Logger, gmart gmart, auth authorizer) ([]Route, error
 
 	routes = append(routes, Route{
 		Pattern: APIPathPrefix + orders.APIOrdersPath,
-		Handler: or,
+		Handler: idem(or),
 	})
 
 	bh := balance.NewHandler(log, gmart)
@@ -139,7 +236,7 @@ func createRoutes(log *zap.Logger, gmart gmart, auth authorizer) ([]Route, error
 
 	routes = append(routes, Route{
 		Pattern: APIPathPrefix + balance.APIBalancePath,
-		Handler: br,
+		Handler: idem(br),
 	})
 
 	wh := withdrawals.NewHandler(log, gmart)
@@ -154,5 +251,106 @@ func createRoutes(log *zap.Logger, gmart gmart, auth authorizer) ([]Route, error
 		Handler: wr,
 	})
 
+	th := transactions.NewHandler(log, gmart)
+	sth := transactions.NewSecHandler(auth)
+	tr, err := apiTransactions.NewServer(th, sth)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + transactions.APITransactionsPath,
+		Handler: tr,
+	})
+
+	smh := statements.NewHandler(log, gmart)
+	ssmh := statements.NewSecHandler(auth)
+	smr, err := apiStatements.NewServer(smh, ssmh)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + statements.APIStatementsPath,
+		Handler: smr,
+		Stream:  true,
+	})
+
+	evh := events.NewHandler(log, gmart, auth, set.Events.KeepAlive)
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + events.APIEventsPath,
+		Handler: http.HandlerFunc(evh.Events),
+		Stream:  true,
+	})
+
+	wsh := ws.NewHandler(log, gmart, auth, set.WebSocket)
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + ws.APIWebSocketPath,
+		Handler: http.HandlerFunc(wsh.WebSocket),
+		Stream:  true,
+	})
+
+	nh := notifications.NewHandler(log, gmart)
+	snh := notifications.NewSecHandler(auth)
+	nr, err := apiNotifications.NewServer(nh, snh)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + notifications.APINotificationsPath,
+		Handler: nr,
+	})
+
+	prh := promo.NewHandler(log, gmart)
+	sprh := promo.NewSecHandler(auth)
+	prr, err := apiPromo.NewServer(prh, sprh)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + promo.APIPromoPath,
+		Handler: idem(prr),
+	})
+
+	rfh := referrals.NewHandler(log, gmart)
+	srfh := referrals.NewSecHandler(auth)
+	rfr, err := apiReferrals.NewServer(rfh, srfh)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + referrals.APIReferralsPath,
+		Handler: rfr,
+	})
+
+	ph := password.NewHandler(log, gmart)
+	sph := password.NewSecHandler(auth)
+	pr, err := apiPassword.NewServer(ph, sph)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: APIPathPrefix + password.APIPasswordPath,
+		Handler: pr,
+	})
+
+	ah := admin.NewHandler(log, gmart)
+	sah := admin.NewSecHandler(auth)
+	ar, err := apiAdmin.NewServer(ah, sah)
+	if err != nil {
+		return nil, err
+	}
+
+	routes = append(routes, Route{
+		Pattern: admin.APIAdminPath,
+		Handler: ar,
+	})
+
 	return routes, nil
 }
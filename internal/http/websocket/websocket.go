@@ -0,0 +1,477 @@
+// Package websocket реализует серверную сторону протокола WebSocket (RFC 6455) в объёме,
+// нужном API: рукопожатие, текстовые сообщения, фрагментация и управляющие кадры.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // алгоритм задан протоколом
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// acceptGUID добавляется к ключу клиента при вычислении Sec-WebSocket-Accept.
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Типы сообщений.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+)
+
+// Коды кадров.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// Коды закрытия соединения.
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+const (
+	// maxControlPayload наибольший размер данных управляющего кадра.
+	maxControlPayload = 125
+	// defaultMaxMessageSize ограничение размера сообщения клиента по умолчанию.
+	defaultMaxMessageSize = 64 << 10
+	// defaultWriteTimeout время, за которое должен быть записан кадр.
+	defaultWriteTimeout = time.Second * 10
+)
+
+var (
+	ErrHandshake      = errors.New("websocket handshake")
+	ErrProtocol       = errors.New("websocket protocol error")
+	ErrMessageTooBig  = errors.New("websocket message is too big")
+	ErrInvalidPayload = errors.New("websocket message is not valid utf-8")
+	ErrCloseSent      = errors.New("websocket close frame is sent")
+)
+
+// CloseError ошибка чтения, когда клиент закрыл соединение.
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket closed: %d %s", e.Code, e.Text)
+}
+
+// Conn соединение WebSocket. ReadMessage вызывается из одной горутины, методы записи
+// можно вызывать из нескольких.
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	maxMessageSize int64
+	writeTimeout   time.Duration
+	pongHandler    func()
+
+	wmu       sync.Mutex
+	closeSent bool
+}
+
+// Upgrade выполняет рукопожатие и переводит соединение запроса в протокол WebSocket.
+// Непустой protocol возвращается клиенту в Sec-WebSocket-Protocol и должен быть среди
+// предложенных им. При ошибке клиенту уже отправлен ответ.
+func Upgrade(w http.ResponseWriter, r *http.Request, protocol string) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return nil, fmt.Errorf("%w: method %s", ErrHandshake, r.Method)
+	}
+
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade is required", http.StatusUpgradeRequired)
+
+		return nil, fmt.Errorf("%w: not an upgrade request", ErrHandshake)
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+
+		return nil, fmt.Errorf("%w: unsupported version", ErrHandshake)
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "invalid Sec-WebSocket-Key", http.StatusBadRequest)
+
+		return nil, fmt.Errorf("%w: invalid key", ErrHandshake)
+	}
+
+	if protocol != "" && !headerContains(r.Header, "Sec-WebSocket-Protocol", protocol) {
+		http.Error(w, "unsupported websocket protocol", http.StatusBadRequest)
+
+		return nil, fmt.Errorf("%w: protocol %s is not offered", ErrHandshake, protocol)
+	}
+
+	conn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+
+		return nil, fmt.Errorf("%w: %w", ErrHandshake, err)
+	}
+
+	// сервер мог выставить ограничения времени для обычного запроса
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close() //nolint:errcheck
+
+		return nil, fmt.Errorf("%w: %w", ErrHandshake, err)
+	}
+
+	c := &Conn{
+		conn:           conn,
+		br:             brw.Reader,
+		maxMessageSize: defaultMaxMessageSize,
+		writeTimeout:   defaultWriteTimeout,
+	}
+
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n"
+
+	if protocol != "" {
+		resp += "Sec-WebSocket-Protocol: " + protocol + "\r\n"
+	}
+
+	resp += "\r\n"
+
+	if err := c.write([]byte(resp)); err != nil {
+		conn.Close() //nolint:errcheck
+
+		return nil, fmt.Errorf("%w: %w", ErrHandshake, err)
+	}
+
+	return c, nil
+}
+
+// Subprotocols возвращает подпротоколы, предложенные клиентом в Sec-WebSocket-Protocol, в порядке предложения.
+func Subprotocols(r *http.Request) []string {
+	protocols := make([]string, 0)
+
+	for _, v := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				protocols = append(protocols, p)
+			}
+		}
+	}
+
+	return protocols
+}
+
+// SetMaxMessageSize задаёт наибольший размер сообщения клиента. Соединение с клиентом,
+// приславшим сообщение больше, закрывается.
+func (c *Conn) SetMaxMessageSize(size int64) {
+	c.maxMessageSize = size
+}
+
+// SetWriteTimeout задаёт время, за которое должен быть записан кадр.
+func (c *Conn) SetWriteTimeout(d time.Duration) {
+	c.writeTimeout = d
+}
+
+// SetPongHandler задаёт функцию, которая вызывается из ReadMessage при получении pong.
+func (c *Conn) SetPongHandler(fn func()) {
+	c.pongHandler = fn
+}
+
+// SetReadDeadline задаёт срок, до которого должен прийти следующий кадр.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// ReadMessage читает очередное сообщение клиента. Ping, pong и close обрабатываются внутри:
+// на ping отправляется pong, на close ответный close и возвращается *CloseError.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		messageType int
+		message     []byte
+	)
+
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, c.fail(err)
+		}
+
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil && !errors.Is(err, ErrCloseSent) {
+				return 0, nil, err
+			}
+
+			continue
+		case opPong:
+			if c.pongHandler != nil {
+				c.pongHandler()
+			}
+
+			continue
+		case opClose:
+			return 0, nil, c.closed(payload)
+		case opText, opBinary:
+			if messageType != 0 {
+				return 0, nil, c.fail(fmt.Errorf("%w: unfinished fragmented message", ErrProtocol))
+			}
+
+			messageType = int(op)
+		case opContinuation:
+			if messageType == 0 {
+				return 0, nil, c.fail(fmt.Errorf("%w: unexpected continuation frame", ErrProtocol))
+			}
+		default:
+			return 0, nil, c.fail(fmt.Errorf("%w: unknown opcode %d", ErrProtocol, op))
+		}
+
+		if int64(len(message)+len(payload)) > c.maxMessageSize {
+			return 0, nil, c.fail(ErrMessageTooBig)
+		}
+
+		message = append(message, payload...)
+
+		if !fin {
+			continue
+		}
+
+		if messageType == TextMessage && !utf8.Valid(message) {
+			return 0, nil, c.fail(ErrInvalidPayload)
+		}
+
+		return messageType, message, nil
+	}
+}
+
+// WriteMessage отправляет сообщение одним кадром.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("%w: unknown message type %d", ErrProtocol, messageType)
+	}
+
+	return c.writeFrame(byte(messageType), data)
+}
+
+// WritePing отправляет ping, клиент должен ответить pong с теми же данными.
+func (c *Conn) WritePing(data []byte) error {
+	return c.writeFrame(opPing, data)
+}
+
+// WriteClose отправляет кадр закрытия. После него другие кадры не отправляются.
+func (c *Conn) WriteClose(code int, text string) error {
+	payload := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+
+	if len(text) > maxControlPayload-2 {
+		text = text[:maxControlPayload-2]
+	}
+
+	payload = append(payload, text...)
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+
+	c.closeSent = true
+
+	return c.writeLocked(opClose, payload)
+}
+
+// Close закрывает сетевое соединение без отправки кадра закрытия.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// readFrame читает кадр клиента и снимает с данных маску.
+func (c *Conn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	op := header[0] & 0x0f
+
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, fmt.Errorf("%w: reserved bits are set", ErrProtocol)
+	}
+
+	// кадры клиента всегда маскируются
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, fmt.Errorf("%w: frame is not masked", ErrProtocol)
+	}
+
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if op >= opClose && (!fin || length > maxControlPayload) {
+		return false, 0, nil, fmt.Errorf("%w: invalid control frame", ErrProtocol)
+	}
+
+	if length > uint64(c.maxMessageSize) {
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, op, payload, nil
+}
+
+// closed отвечает на закрытие соединения клиентом тем же кодом.
+func (c *Conn) closed(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatus}
+
+	switch {
+	case len(payload) == 1:
+		return c.fail(fmt.Errorf("%w: invalid close frame", ErrProtocol))
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+	}
+
+	code := closeErr.Code
+	if code == CloseNoStatus {
+		code = CloseNormal
+	}
+
+	_ = c.WriteClose(code, "")
+
+	return closeErr
+}
+
+// fail закрывает соединение с кодом, соответствующим ошибке чтения, и возвращает эту ошибку.
+func (c *Conn) fail(err error) error {
+	var code int
+
+	switch {
+	case errors.Is(err, ErrProtocol):
+		code = CloseProtocolError
+	case errors.Is(err, ErrMessageTooBig):
+		code = CloseMessageTooBig
+	case errors.Is(err, ErrInvalidPayload):
+		code = CloseInvalidPayload
+	default:
+		return err
+	}
+
+	_ = c.WriteClose(code, "")
+
+	return err
+}
+
+func (c *Conn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+
+	return c.writeLocked(op, payload)
+}
+
+// writeLocked записывает кадр без маски, вызывается под wmu.
+func (c *Conn) writeLocked(op byte, payload []byte) error {
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|op)
+
+	length := len(payload)
+
+	switch {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	frame = append(frame, payload...)
+
+	return c.write(frame)
+}
+
+func (c *Conn) write(b []byte) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+		return err
+	}
+
+	_, err := c.conn.Write(b)
+
+	return err
+}
+
+func acceptKey(key string) string {
+	h := sha1.New() //nolint:gosec // алгоритм задан протоколом
+	h.Write([]byte(key + acceptGUID))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerContains проверяет, что среди значений заголовка через запятую есть token.
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,598 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+// testClient клиент WebSocket поверх сырого соединения, который отправляет кадры как есть.
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dial поднимает сервер, который выполняет рукопожатие и передаёт соединение serve, и подключается к нему.
+func dial(t *testing.T, protocol string, header http.Header, serve func(*Conn)) (*testClient, *http.Response) {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := Upgrade(w, r, protocol)
+		if err != nil {
+			return
+		}
+
+		defer c.Close()
+
+		serve(c)
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("set deadline: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", testKey)
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+
+	return &testClient{t: t, conn: conn, br: br}, resp
+}
+
+// open подключается к серверу и проверяет, что рукопожатие прошло.
+func open(t *testing.T, serve func(*Conn)) *testClient {
+	t.Helper()
+
+	c, resp := dial(t, "", nil, serve)
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want 101", resp.StatusCode)
+	}
+
+	return c
+}
+
+// send отправляет кадр клиента, masked=false нарушает протокол.
+func (c *testClient) send(fin bool, op byte, payload []byte, masked bool) {
+	c.t.Helper()
+
+	frame := []byte{op, 0}
+	if fin {
+		frame[0] |= 0x80
+	}
+
+	switch length := len(payload); {
+	case length < 126:
+		frame[1] = byte(length)
+	case length <= 0xffff:
+		frame[1] = 126
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame[1] = 127
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	data := append([]byte(nil), payload...)
+
+	if masked {
+		mask := [4]byte{0x12, 0x34, 0x56, 0x78}
+
+		frame[1] |= 0x80
+		frame = append(frame, mask[:]...)
+
+		for i := range data {
+			data[i] ^= mask[i%4]
+		}
+	}
+
+	if _, err := c.conn.Write(append(frame, data...)); err != nil {
+		c.t.Fatalf("write frame: %v", err)
+	}
+}
+
+func (c *testClient) sendClose(code int, text string) {
+	c.t.Helper()
+
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	c.send(true, opClose, append(payload, text...), true)
+}
+
+// receive читает кадр сервера, сервер не должен маскировать кадры и фрагментировать сообщения.
+func (c *testClient) receive() (byte, []byte) {
+	c.t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		c.t.Fatalf("read frame: %v", err)
+	}
+
+	if header[0]&0x80 == 0 || header[1]&0x80 != 0 {
+		c.t.Fatalf("server frame header %x, want final and unmasked", header)
+	}
+
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			c.t.Fatalf("read frame length: %v", err)
+		}
+
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			c.t.Fatalf("read frame length: %v", err)
+		}
+
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		c.t.Fatalf("read frame payload: %v", err)
+	}
+
+	return header[0] & 0x0f, payload
+}
+
+// expectClose проверяет, что сервер закрыл соединение с кодом code.
+func (c *testClient) expectClose(code int) {
+	c.t.Helper()
+
+	op, payload := c.receive()
+	if op != opClose || len(payload) < 2 || int(binary.BigEndian.Uint16(payload)) != code {
+		c.t.Fatalf("frame %x %q, want close %d", op, payload, code)
+	}
+}
+
+// echo возвращает сообщения клиента обратно и передаёт ошибку чтения в errs.
+func echo(errs chan<- error) func(*Conn) {
+	return func(c *Conn) {
+		for {
+			messageType, message, err := c.ReadMessage()
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			if err := c.WriteMessage(messageType, message); err != nil {
+				errs <- err
+
+				return
+			}
+		}
+	}
+}
+
+func readErr(t *testing.T, errs <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop reading")
+
+		return nil
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	c, resp := dial(t, "", nil, func(*Conn) {})
+
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" ||
+		resp.Header.Get("Sec-WebSocket-Protocol") != "" {
+		t.Errorf("handshake response = %d %v", resp.StatusCode, resp.Header)
+	}
+
+	c.conn.Close()
+
+	// выбранный сервером подпротокол возвращается клиенту, остальные предложенные нет
+	header := http.Header{"Sec-WebSocket-Protocol": {"access_token, secret"}}
+
+	_, resp = dial(t, "access_token", header, func(*Conn) {})
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Protocol") != "access_token" {
+		t.Errorf("handshake with protocol = %d %v", resp.StatusCode, resp.Header)
+	}
+
+	_, resp = dial(t, "access_token", nil, func(*Conn) {})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("handshake without offered protocol = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestUpgradeInvalidRequest(t *testing.T) {
+	valid := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		r.Header.Set("Connection", "keep-alive, Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Version", "13")
+		r.Header.Set("Sec-WebSocket-Key", testKey)
+
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		change func(r *http.Request)
+		want   int
+	}{
+		{name: "post", change: func(r *http.Request) { r.Method = http.MethodPost }, want: http.StatusMethodNotAllowed},
+		{name: "no upgrade", change: func(r *http.Request) { r.Header.Del("Upgrade") }, want: http.StatusUpgradeRequired},
+		{name: "no connection upgrade", change: func(r *http.Request) { r.Header.Set("Connection", "keep-alive") },
+			want: http.StatusUpgradeRequired},
+		{name: "old version", change: func(r *http.Request) { r.Header.Set("Sec-WebSocket-Version", "8") },
+			want: http.StatusUpgradeRequired},
+		{name: "short key", change: func(r *http.Request) { r.Header.Set("Sec-WebSocket-Key", "c2hvcnQ=") },
+			want: http.StatusBadRequest},
+		{name: "invalid key", change: func(r *http.Request) { r.Header.Set("Sec-WebSocket-Key", "!") },
+			want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.change(r)
+
+			w := httptest.NewRecorder()
+
+			if _, err := Upgrade(w, r, ""); !errors.Is(err, ErrHandshake) {
+				t.Errorf("err = %v, want ErrHandshake", err)
+			}
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubprotocols(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Header.Add("Sec-WebSocket-Protocol", "access_token, a.b.c")
+	r.Header.Add("Sec-WebSocket-Protocol", " chat ,")
+
+	if got := strings.Join(Subprotocols(r), " "); got != "access_token a.b.c chat" {
+		t.Errorf("Subprotocols = %q", got)
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	errs := make(chan error, 1)
+	c := open(t, echo(errs))
+
+	c.send(true, opText, []byte("hello"), true)
+
+	if op, payload := c.receive(); op != opText || string(payload) != "hello" {
+		t.Errorf("echo = %x %q, want text hello", op, payload)
+	}
+
+	// сообщение длиннее 125 байт передаётся с расширенной длиной
+	long := []byte(strings.Repeat("x", 300))
+	c.send(true, opBinary, long, true)
+
+	if op, payload := c.receive(); op != opBinary || string(payload) != string(long) {
+		t.Errorf("echo = %x of %d bytes, want binary of %d", op, len(payload), len(long))
+	}
+
+	// фрагменты собираются в одно сообщение
+	c.send(false, opText, []byte("hel"), true)
+	c.send(false, opContinuation, []byte("lo "), true)
+	c.send(true, opContinuation, []byte("world"), true)
+
+	if op, payload := c.receive(); op != opText || string(payload) != "hello world" {
+		t.Errorf("echo = %x %q, want text hello world", op, payload)
+	}
+}
+
+// TestControlFrameInsideFragments проверяет, что управляющие кадры между фрагментами
+// обрабатываются сразу и не прерывают сборку сообщения.
+func TestControlFrameInsideFragments(t *testing.T) {
+	errs := make(chan error, 1)
+	pongs := make(chan struct{}, 1)
+
+	c := open(t, func(conn *Conn) {
+		conn.SetPongHandler(func() { pongs <- struct{}{} })
+		echo(errs)(conn)
+	})
+
+	c.send(false, opText, []byte("frag"), true)
+	c.send(true, opPing, []byte("ping"), true)
+	c.send(true, opPong, nil, true)
+	c.send(true, opContinuation, []byte("ment"), true)
+
+	if op, payload := c.receive(); op != opPong || string(payload) != "ping" {
+		t.Errorf("first frame = %x %q, want pong with ping data", op, payload)
+	}
+
+	if op, payload := c.receive(); op != opText || string(payload) != "fragment" {
+		t.Errorf("second frame = %x %q, want text fragment", op, payload)
+	}
+
+	select {
+	case <-pongs:
+	default:
+		t.Error("pong handler was not called")
+	}
+}
+
+func TestReadMessageProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		frames  func(c *testClient)
+		code    int
+		wantErr error
+	}{
+		{
+			name:    "unmasked frame",
+			frames:  func(c *testClient) { c.send(true, opText, []byte("hello"), false) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "reserved bits",
+			frames:  func(c *testClient) { c.send(true, 0x40|opText, []byte("hello"), true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "unknown opcode",
+			frames:  func(c *testClient) { c.send(true, 0x3, nil, true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "fragmented ping",
+			frames:  func(c *testClient) { c.send(false, opPing, []byte("ping"), true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "long ping",
+			frames:  func(c *testClient) { c.send(true, opPing, make([]byte, maxControlPayload+1), true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "continuation without message",
+			frames:  func(c *testClient) { c.send(true, opContinuation, []byte("lo"), true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name: "new message inside fragments",
+			frames: func(c *testClient) {
+				c.send(false, opText, []byte("hel"), true)
+				c.send(true, opText, []byte("lo"), true)
+			},
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "invalid utf-8",
+			frames:  func(c *testClient) { c.send(true, opText, []byte{0xff, 0xfe}, true) },
+			code:    CloseInvalidPayload,
+			wantErr: ErrInvalidPayload,
+		},
+		{
+			name:    "close without code",
+			frames:  func(c *testClient) { c.send(true, opClose, []byte{0x03}, true) },
+			code:    CloseProtocolError,
+			wantErr: ErrProtocol,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			c := open(t, echo(errs))
+
+			tt.frames(c)
+			c.expectClose(tt.code)
+
+			if err := readErr(t, errs); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessageTooBig(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames func(c *testClient)
+	}{
+		{
+			name:   "single frame",
+			frames: func(c *testClient) { c.send(true, opText, []byte(strings.Repeat("x", 11)), true) },
+		},
+		{
+			// каждый фрагмент в пределах ограничения, а всё сообщение нет
+			name: "fragments",
+			frames: func(c *testClient) {
+				c.send(false, opText, []byte("123456"), true)
+				c.send(true, opContinuation, []byte("789012"), true)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			c := open(t, func(conn *Conn) {
+				conn.SetMaxMessageSize(10)
+				echo(errs)(conn)
+			})
+
+			// сообщение на границе ограничения принимается
+			c.send(true, opText, []byte("1234567890"), true)
+
+			if op, payload := c.receive(); op != opText || string(payload) != "1234567890" {
+				t.Fatalf("echo = %x %q", op, payload)
+			}
+
+			tt.frames(c)
+			c.expectClose(CloseMessageTooBig)
+
+			if err := readErr(t, errs); !errors.Is(err, ErrMessageTooBig) {
+				t.Errorf("err = %v, want ErrMessageTooBig", err)
+			}
+		})
+	}
+}
+
+func TestCloseHandshake(t *testing.T) {
+	errs := make(chan error, 1)
+	writes := make(chan error, 1)
+
+	c := open(t, func(conn *Conn) {
+		_, _, err := conn.ReadMessage()
+		errs <- err
+		writes <- conn.WriteMessage(TextMessage, []byte("late"))
+	})
+
+	c.sendClose(CloseGoingAway, "bye")
+	c.expectClose(CloseGoingAway)
+
+	var closeErr *CloseError
+	if err := readErr(t, errs); !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway || closeErr.Text != "bye" {
+		t.Errorf("err = %v, want close 1001 bye", err)
+	}
+
+	// после кадра закрытия сервер ничего не отправляет
+	if err := readErr(t, writes); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("write after close: err = %v, want ErrCloseSent", err)
+	}
+
+	// закрытие без кода подтверждается кодом 1000
+	errs = make(chan error, 1)
+	c = open(t, echo(errs))
+
+	c.send(true, opClose, nil, true)
+	c.expectClose(CloseNormal)
+
+	if err := readErr(t, errs); !errors.As(err, &closeErr) || closeErr.Code != CloseNoStatus {
+		t.Errorf("err = %v, want close 1005", err)
+	}
+}
+
+// TestServerClose проверяет, что закрытие сервером отправляется один раз и обрезает длинную причину.
+func TestServerClose(t *testing.T) {
+	results := make(chan error, 2)
+
+	c := open(t, func(conn *Conn) {
+		results <- conn.WriteClose(ClosePolicyViolation, strings.Repeat("r", 200))
+		results <- conn.WriteClose(CloseNormal, "")
+	})
+
+	op, payload := c.receive()
+	if op != opClose || int(binary.BigEndian.Uint16(payload)) != ClosePolicyViolation || len(payload) != maxControlPayload {
+		t.Errorf("close frame = %x of %d bytes, want 1008 of %d", op, len(payload), maxControlPayload)
+	}
+
+	if err := readErr(t, results); err != nil {
+		t.Errorf("WriteClose: %v", err)
+	}
+
+	if err := readErr(t, results); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("repeated WriteClose: err = %v, want ErrCloseSent", err)
+	}
+}
+
+func TestPingPong(t *testing.T) {
+	errs := make(chan error, 1)
+	pongs := make(chan struct{}, 1)
+
+	c := open(t, func(conn *Conn) {
+		conn.SetPongHandler(func() { pongs <- struct{}{} })
+
+		if err := conn.WritePing([]byte("are you there")); err != nil {
+			errs <- err
+
+			return
+		}
+
+		echo(errs)(conn)
+	})
+
+	op, payload := c.receive()
+	if op != opPing || string(payload) != "are you there" {
+		t.Fatalf("frame = %x %q, want ping", op, payload)
+	}
+
+	c.send(true, opPong, payload, true)
+
+	select {
+	case <-pongs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pong handler was not called")
+	}
+
+	// на ping клиента сервер отвечает pong с теми же данными
+	c.send(true, opPing, []byte("hi"), true)
+
+	if op, payload := c.receive(); op != opPong || string(payload) != "hi" {
+		t.Errorf("frame = %x %q, want pong hi", op, payload)
+	}
+}
+
+// TestReadDeadline проверяет, что клиент, который молчит дольше срока чтения, отключается.
+func TestReadDeadline(t *testing.T) {
+	errs := make(chan error, 1)
+
+	open(t, func(conn *Conn) {
+		if err := conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
+			errs <- err
+
+			return
+		}
+
+		echo(errs)(conn)
+	})
+
+	var netErr net.Error
+	if err := readErr(t, errs); !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("err = %v, want timeout", err)
+	}
+}
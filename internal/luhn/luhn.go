@@ -1,70 +1,83 @@
+// Package luhn implements the Luhn algorithm over digit strings of any length.
+// Numbers are not converted to integers, so long numbers do not overflow and leading zeros are kept.
 package luhn
 
-import "strconv"
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+)
 
-// CalculateLuhn return the check number
-func CalculateLuhn(number int) int {
-	checkNumber := checksum(number)
+var ErrNotDigits = errors.New("number must contain only digits")
 
-	if checkNumber == 0 {
-		return 0
+// Valid check number is valid or not based on Luhn algorithm.
+// The last digit of the number is the check digit.
+func Valid(number string) bool {
+	if len(number) < 2 {
+		return false
 	}
-	return 10 - checkNumber
-}
 
-// Valid check number is valid or not based on Luhn algorithm
-func Valid(number int) bool {
-	return (number%10+checksum(number/10))%10 == 0
+	sum, ok := checksum(number, false)
+
+	return ok && sum == 0
 }
 
-func checksum(number int) int {
-	var luhn int
+// CheckDigit returns the check digit which makes the number valid when appended to it.
+func CheckDigit(number string) (int, error) {
+	if number == "" {
+		return 0, ErrNotDigits
+	}
 
-	for i := 0; number > 0; i++ {
-		cur := number % 10
+	sum, ok := checksum(number, true)
+	if !ok {
+		return 0, ErrNotDigits
+	}
 
-		if i%2 == 0 { // even
-			cur = cur * 2
-			if cur > 9 {
-				cur = cur%10 + cur/10
-			}
-		}
+	return (10 - sum) % 10, nil
+}
 
-		luhn += cur
-		number = number / 10
+// Generate returns a random valid number with the given count of digits including the check digit.
+func Generate(length int) string {
+	if length < 2 {
+		length = 2
 	}
-	return luhn % 10
-}
 
-func LuhnCheckDigit(s string) (int, error) {
-	number, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, err
+	digits := make([]byte, length)
+	for i := 0; i < length-1; i++ {
+		digits[i] = byte('0' + rand.Intn(10)) //nolint:gosec
 	}
 
-	checkNumber := luhnChecksum(number)
+	// cannot fail, all digits are generated above
+	check, _ := CheckDigit(string(digits[:length-1]))
+	digits[length-1] = strconv.Itoa(check)[0]
 
-	if checkNumber == 0 {
-		return 0, nil
-	}
-	return 10 - checkNumber, nil
+	return string(digits)
 }
 
-func luhnChecksum(number int) int {
-	var luhn int
+// checksum returns the Luhn sum modulo 10. Digits are doubled starting from the rightmost one
+// if doubleFirst is set, i.e. the number has no check digit yet, otherwise from the second rightmost one.
+// ok is false if the number contains a non-digit character.
+func checksum(number string, doubleFirst bool) (sum int, ok bool) {
+	double := doubleFirst
 
-	for i := 0; number > 0; i++ {
-		cur := number % 10
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+
+		cur := int(c - '0')
 
-		if i%2 == 0 { // even
-			cur = cur * 2
+		if double {
+			cur *= 2
 			if cur > 9 {
-				cur = cur%10 + cur/10
+				cur -= 9
 			}
 		}
 
-		luhn += cur
-		number = number / 10
+		sum += cur
+		double = !double
 	}
-	return luhn % 10
+
+	return sum % 10, true
 }
@@ -0,0 +1,133 @@
+package luhn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// referenceValid is a straightforward Luhn check written independently of checksum: it walks the digits
+// left to right and picks the doubling parity from the number length.
+func referenceValid(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+
+	doubled := [10]int{0, 2, 4, 6, 8, 1, 3, 5, 7, 9}
+	sum := 0
+
+	for i, c := range number {
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		d := int(c - '0')
+		if (len(number)-i)%2 == 0 {
+			d = doubled[d]
+		}
+
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{number: "79927398713", want: true},
+		{number: "79927398710", want: false},
+		{number: "4561261212345467", want: true},
+		{number: "4561261212345464", want: false},
+		{number: "0079927398713", want: true},
+		{number: "00", want: true},
+		{number: "0", want: false},
+		{number: "", want: false},
+		{number: "7992739871a", want: false},
+		{number: " 79927398713", want: false},
+		{number: "-79927398713", want: false},
+		{number: "123456789012345678901234567890123456789012345678907", want: false},
+		{number: "123456789012345678901234567890123456789012345678905", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := Valid(tt.number); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.number, got, tt.want)
+		}
+
+		if got := referenceValid(tt.number); got != tt.want {
+			t.Errorf("referenceValid(%q) = %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		number string
+		want   int
+	}{
+		{number: "7992739871", want: 3},
+		{number: "456126121234546", want: 7},
+		{number: "0", want: 0},
+		{number: "1", want: 8},
+	}
+
+	for _, tt := range tests {
+		got, err := CheckDigit(tt.number)
+		if err != nil || got != tt.want {
+			t.Errorf("CheckDigit(%q) = %d, %v, want %d", tt.number, got, err, tt.want)
+		}
+	}
+
+	for _, number := range []string{"", "12a", "1 2"} {
+		if _, err := CheckDigit(number); !errors.Is(err, ErrNotDigits) {
+			t.Errorf("CheckDigit(%q) error = %v, want ErrNotDigits", number, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for _, length := range []int{0, 1, 2, 11, 16, 19, 20, 64} {
+		want := length
+		if want < 2 {
+			want = 2
+		}
+
+		for i := 0; i < 100; i++ {
+			number := Generate(length)
+
+			if len(number) != want || strings.Trim(number, "0123456789") != "" {
+				t.Fatalf("Generate(%d) = %q", length, number)
+			}
+
+			if !Valid(number) {
+				t.Fatalf("Generate(%d) = %q is not valid", length, number)
+			}
+		}
+	}
+}
+
+func FuzzValid(f *testing.F) {
+	for _, seed := range []string{"", "0", "00", "79927398713", "79927398710", "4561261212345467",
+		"0079927398713", "7992739871a", "123456789012345678901234567890123456789012345678905"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, number string) {
+		if got, want := Valid(number), referenceValid(number); got != want {
+			t.Fatalf("Valid(%q) = %v, reference = %v", number, got, want)
+		}
+
+		check, err := CheckDigit(number)
+		if err != nil {
+			return
+		}
+
+		withCheck := number + string(rune('0'+check))
+		if !Valid(withCheck) || !referenceValid(withCheck) {
+			t.Fatalf("%q with check digit %d is not valid", number, check)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// AdjustmentReason код причины ручного изменения баланса.
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonGoodwill     AdjustmentReason = "goodwill"
+	AdjustmentReasonCompensation AdjustmentReason = "compensation"
+	AdjustmentReasonCorrection   AdjustmentReason = "correction"
+	AdjustmentReasonOther        AdjustmentReason = "other"
+	// AdjustmentReasonReversal назначается только записям, отменяющим другое изменение.
+	AdjustmentReasonReversal AdjustmentReason = "reversal"
+)
+
+// Valid сообщает, может ли сотрудник указать такую причину.
+func (r AdjustmentReason) Valid() bool {
+	switch r {
+	case AdjustmentReasonGoodwill, AdjustmentReasonCompensation, AdjustmentReasonCorrection, AdjustmentReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdjustmentStatus состояние ручного изменения баланса.
+type AdjustmentStatus string
+
+const (
+	// AdjustmentPending изменение ожидает подтверждения вторым сотрудником, баланс ещё не изменён.
+	AdjustmentPending AdjustmentStatus = "pending"
+	// AdjustmentApplied изменение применено к балансу.
+	AdjustmentApplied AdjustmentStatus = "applied"
+	// AdjustmentRejected изменение отклонено вторым сотрудником.
+	AdjustmentRejected AdjustmentStatus = "rejected"
+	// AdjustmentReversed изменение было применено, а затем отменено.
+	AdjustmentReversed AdjustmentStatus = "reversed"
+)
+
+// BalanceAdjustment ручное изменение баланса пользователя сотрудником. Сумма в копейках,
+// положительная сумма начисляет баллы, отрицательная списывает.
+type BalanceAdjustment struct {
+	ID         int64            `json:"id"`
+	UserID     int              `json:"user_id"`
+	Amount     int              `json:"amount"`
+	Reason     AdjustmentReason `json:"reason"`
+	Note       string           `json:"note"`
+	Status     AdjustmentStatus `json:"status"`
+	OperatorID int              `json:"operator_id"`
+	ApproverID int              `json:"approver_id"`
+	// ReversalOf id отменённого изменения, 0 для обычного изменения.
+	ReversalOf int64      `json:"reversal_of"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
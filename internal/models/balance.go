@@ -3,9 +3,23 @@ package models
 import "time"
 
 // Balance хранит информацию о текущем баланса баллов пользователя и списанные баллы. всё хранится в копейках.
+// Баллы хранятся партиями со сроком сгорания, списания расходуют партии начиная с самой ранней.
 type Balance struct {
 	Current  int `json:"current"`  //
 	Withdraw int `json:"withdraw"` //
+	// Held баллы, удержанные в оплату ещё не подтверждённых заказов. Они входят в Current,
+	// но недоступны для списания.
+	Held int `json:"held"`
+	// ExpiringSoon баллы, которые сгорят в ближайшее время, Expiring они же по дням.
+	ExpiringSoon int              `json:"expiring_soon"`
+	Expiring     []ExpiringPoints `json:"expiring"`
+	// Tier уровень программы лояльности, nil если уровни не настроены.
+	Tier *TierStatus `json:"tier"`
+}
+
+// Available возвращает баллы, доступные для списания.
+func (b Balance) Available() int {
+	return b.Current - b.Held
 }
 
 // BalanceWithdraw запрос на списание баллов со счёта.
@@ -14,9 +28,67 @@ type BalanceWithdraw struct {
 	Sum   int    `json:"sum"`
 }
 
+// WithdrawLimits ограничения на списания баллов за один заказ. Полностью возвращённые списания не учитываются.
+type WithdrawLimits struct {
+	// MaxPerOrder максимальное количество списаний за заказ, больше одного при оплате заказа частями.
+	MaxPerOrder int
+	// OrderCap максимальная сумма всех списаний за заказ в копейках, 0 без ограничения.
+	OrderCap int
+}
+
+// Состояния возврата списания.
+const (
+	WithdrawalWithdrawn         = "WITHDRAWN"
+	WithdrawalPartiallyRefunded = "PARTIALLY_REFUNDED"
+	WithdrawalRefunded          = "REFUNDED"
+)
+
 // BalanceWithdrawal показывает историю списаний баллов для каждого заказа.
 type BalanceWithdrawal struct {
 	Order       string    `json:"order"`
 	Sum         int       `json:"sum"`
+	Refunded    int       `json:"refunded"`
 	ProcessedAt time.Time `json:"processed_at"`
 }
+
+// Status возвращает состояние возврата списания.
+func (w BalanceWithdrawal) Status() string {
+	switch {
+	case w.Refunded == 0:
+		return WithdrawalWithdrawn
+	case w.Refunded < w.Sum:
+		return WithdrawalPartiallyRefunded
+	default:
+		return WithdrawalRefunded
+	}
+}
+
+// WithdrawalRefund возврат списанных за заказ баллов на баланс, например при отмене заказа магазином.
+// Нулевая сумма в запросе означает возврат всей ещё не возвращённой части списания.
+type WithdrawalRefund struct {
+	ID         int64     `json:"id"`
+	Order      string    `json:"order"`
+	UserID     int       `json:"user_id"`
+	Sum        int       `json:"sum"`
+	Reason     string    `json:"reason"`
+	OperatorID int       `json:"operator_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Источники партий баллов.
+const (
+	LotSourceInitial    = "initial"
+	LotSourceAccrual    = "accrual"
+	LotSourceAdjustment = "adjustment"
+	LotSourceRefund     = "refund"
+	LotSourceCampaign   = "campaign"
+	LotSourcePromo      = "promo"
+	LotSourceReferral   = "referral"
+	LotSourceTransfer   = "transfer"
+)
+
+// ExpiringPoints баллы, которые сгорят в один день. ExpiresAt ближайшее время сгорания в этот день.
+type ExpiringPoints struct {
+	Sum       int       `json:"sum"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "testing"
+
+func TestBalanceWithdrawalStatus(t *testing.T) {
+	tests := []struct {
+		refunded int
+		want     string
+	}{
+		{refunded: 0, want: WithdrawalWithdrawn},
+		{refunded: 1, want: WithdrawalPartiallyRefunded},
+		{refunded: 999, want: WithdrawalPartiallyRefunded},
+		{refunded: 1000, want: WithdrawalRefunded},
+	}
+
+	for _, tt := range tests {
+		w := BalanceWithdrawal{Sum: 1000, Refunded: tt.refunded}
+
+		if got := w.Status(); got != tt.want {
+			t.Errorf("Status() with refunded %d = %s, want %s", tt.refunded, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// CampaignKind способ расчёта бонуса по акции.
+type CampaignKind string
+
+const (
+	// CampaignPercent бонус в процентах от начисления за заказ, 100 удваивает начисление.
+	CampaignPercent CampaignKind = "percent"
+	// CampaignFixed фиксированный бонус в баллах.
+	CampaignFixed CampaignKind = "fixed"
+)
+
+// Valid проверяет, что способ расчёта известен.
+func (k CampaignKind) Valid() bool {
+	return k == CampaignPercent || k == CampaignFixed
+}
+
+// Campaign акция, которая начисляет бонус за обработанный заказ, если заказ подходит под все условия.
+// Условия с нулевым значением не проверяются.
+type Campaign struct {
+	ID    int64        `json:"id"`
+	Name  string       `json:"name"`
+	Kind  CampaignKind `json:"kind"`
+	Value float64      `json:"value"`
+	// StartsAt и EndsAt ограничивают время загрузки заказа.
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	// FirstOrder бонус только за первый обработанный заказ пользователя.
+	FirstOrder bool `json:"first_order"`
+	// MinAccrual минимальное начисление за заказ в копейках.
+	MinAccrual int `json:"min_accrual"`
+	// Channel канал загрузки заказа.
+	Channel   string    `json:"channel"`
+	Active    bool      `json:"active"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Valid проверяет настройки акции.
+func (c Campaign) Valid() bool {
+	if strings.TrimSpace(c.Name) == "" || !c.Kind.Valid() || c.Value <= 0 || c.MinAccrual < 0 {
+		return false
+	}
+
+	return c.StartsAt == nil || c.EndsAt == nil || c.StartsAt.Before(*c.EndsAt)
+}
+
+// CampaignOrder заказ, для которого проверяются условия акций.
+type CampaignOrder struct {
+	UserID     int       `json:"user_id"`
+	Number     string    `json:"number"`
+	Channel    string    `json:"channel"`
+	Accrual    int       `json:"accrual"`
+	UploadedAt time.Time `json:"uploaded_at"`
+	// ProcessedOrders количество уже обработанных заказов пользователя.
+	ProcessedOrders int `json:"processed_orders"`
+}
+
+// Matches проверяет, подходит ли заказ под условия акции.
+func (c Campaign) Matches(o CampaignOrder) bool {
+	switch {
+	case !c.Active:
+		return false
+	case c.StartsAt != nil && o.UploadedAt.Before(*c.StartsAt):
+		return false
+	case c.EndsAt != nil && !o.UploadedAt.Before(*c.EndsAt):
+		return false
+	case c.FirstOrder && o.ProcessedOrders > 0:
+		return false
+	case o.Accrual < c.MinAccrual:
+		return false
+	case c.Channel != "" && !strings.EqualFold(c.Channel, o.Channel):
+		return false
+	default:
+		return true
+	}
+}
+
+// Bonus возвращает бонус в копейках за заказ, 0 если заказ не подходит под условия акции.
+// Процент считается от начисления системы расчёта без учёта уровня пользователя.
+func (c Campaign) Bonus(o CampaignOrder) int {
+	if !c.Matches(o) {
+		return 0
+	}
+
+	if c.Kind == CampaignPercent {
+		return int(math.Round(float64(o.Accrual) * c.Value / 100))
+	}
+
+	return int(math.Round(c.Value * 100))
+}
+
+// CampaignBonus бонус, начисленный по акции за заказ. Хранится отдельной записью рядом с начислением.
+type CampaignBonus struct {
+	CampaignID int64     `json:"campaign_id"`
+	Name       string    `json:"name"`
+	UserID     int       `json:"user_id"`
+	Order      string    `json:"order"`
+	Amount     int       `json:"amount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CampaignAccrual включённые акции и заказ, к которому они применяются при начислении.
+// Количество обработанных заказов пользователя определяется в транзакции начисления, чтобы два
+// одновременно обработанных заказа не получили бонус за первый заказ оба.
+type CampaignAccrual struct {
+	Campaigns []Campaign
+	Order     CampaignOrder
+}
+
+// CampaignBonuses возвращает бонусы всех сработавших акций за заказ.
+func CampaignBonuses(campaigns []Campaign, o CampaignOrder) []CampaignBonus {
+	bonuses := make([]CampaignBonus, 0)
+
+	for _, c := range campaigns {
+		amount := c.Bonus(o)
+		if amount <= 0 {
+			continue
+		}
+
+		bonuses = append(bonuses, CampaignBonus{
+			CampaignID: c.ID,
+			Name:       c.Name,
+			UserID:     o.UserID,
+			Order:      o.Number,
+			Amount:     amount,
+		})
+	}
+
+	return bonuses
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCampaignBonus(t *testing.T) {
+	start := time.Date(2023, 12, 16, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+
+	order := CampaignOrder{Accrual: 1000, Channel: "app", UploadedAt: start.Add(time.Hour)}
+
+	tests := []struct {
+		name     string
+		campaign Campaign
+		order    func(o CampaignOrder) CampaignOrder
+		want     int
+	}{
+		{name: "percent", campaign: Campaign{Kind: CampaignPercent, Value: 5}, want: 50},
+		{name: "double points", campaign: Campaign{Kind: CampaignPercent, Value: 100}, want: 1000},
+		{name: "fixed", campaign: Campaign{Kind: CampaignFixed, Value: 100}, want: 10000},
+		{name: "inactive", campaign: Campaign{Kind: CampaignFixed, Value: 1, Active: false}, want: 0},
+		{name: "window", campaign: Campaign{Kind: CampaignFixed, Value: 1, StartsAt: &start, EndsAt: &end}, want: 100},
+		{
+			name:     "before window",
+			campaign: Campaign{Kind: CampaignFixed, Value: 1, StartsAt: &start},
+			order:    func(o CampaignOrder) CampaignOrder { o.UploadedAt = start.Add(-time.Second); return o },
+		},
+		{
+			name:     "window end is exclusive",
+			campaign: Campaign{Kind: CampaignFixed, Value: 1, EndsAt: &end},
+			order:    func(o CampaignOrder) CampaignOrder { o.UploadedAt = end; return o },
+		},
+		{name: "first order", campaign: Campaign{Kind: CampaignFixed, Value: 1, FirstOrder: true}, want: 100},
+		{
+			name:     "not first order",
+			campaign: Campaign{Kind: CampaignFixed, Value: 1, FirstOrder: true},
+			order:    func(o CampaignOrder) CampaignOrder { o.ProcessedOrders = 1; return o },
+		},
+		{name: "min accrual", campaign: Campaign{Kind: CampaignFixed, Value: 1, MinAccrual: 1000}, want: 100},
+		{name: "below min accrual", campaign: Campaign{Kind: CampaignFixed, Value: 1, MinAccrual: 1001}},
+		{name: "channel", campaign: Campaign{Kind: CampaignPercent, Value: 5, Channel: "APP"}, want: 50},
+		{name: "other channel", campaign: Campaign{Kind: CampaignPercent, Value: 5, Channel: "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name != "inactive" {
+				tt.campaign.Active = true
+			}
+
+			o := order
+			if tt.order != nil {
+				o = tt.order(o)
+			}
+
+			if got := tt.campaign.Bonus(o); got != tt.want {
+				t.Errorf("Bonus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCampaignBonuses(t *testing.T) {
+	campaigns := []Campaign{
+		{ID: 1, Name: "first", Kind: CampaignFixed, Value: 1, FirstOrder: true, Active: true},
+		{ID: 2, Name: "app", Kind: CampaignPercent, Value: 5, Channel: "app", Active: true},
+		{ID: 3, Name: "web", Kind: CampaignPercent, Value: 5, Channel: "web", Active: true},
+	}
+
+	bonuses := CampaignBonuses(campaigns, CampaignOrder{UserID: 7, Number: "1", Channel: "app", Accrual: 1000})
+
+	if len(bonuses) != 2 || bonuses[0].CampaignID != 1 || bonuses[1].CampaignID != 2 {
+		t.Fatalf("bonuses = %+v, want campaigns 1 and 2", bonuses)
+	}
+
+	if bonuses[1].UserID != 7 || bonuses[1].Order != "1" || bonuses[1].Amount != 50 || bonuses[1].Name != "app" {
+		t.Errorf("bonus = %+v", bonuses[1])
+	}
+}
+
+func TestCampaignValid(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		campaign Campaign
+		want     bool
+	}{
+		{campaign: Campaign{Name: "c", Kind: CampaignFixed, Value: 1}, want: true},
+		{campaign: Campaign{Name: "c", Kind: CampaignFixed, Value: 1, StartsAt: &start, EndsAt: &end}, want: true},
+		{campaign: Campaign{Name: "c", Kind: CampaignFixed, Value: 1, StartsAt: &end, EndsAt: &start}},
+		{campaign: Campaign{Name: " ", Kind: CampaignFixed, Value: 1}},
+		{campaign: Campaign{Name: "c", Kind: "bogus", Value: 1}},
+		{campaign: Campaign{Name: "c", Kind: CampaignFixed}},
+		{campaign: Campaign{Name: "c", Kind: CampaignFixed, Value: 1, MinAccrual: -1}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.campaign.Valid(); got != tt.want {
+			t.Errorf("Valid(%+v) = %v, want %v", tt.campaign, got, tt.want)
+		}
+	}
+}
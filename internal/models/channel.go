@@ -0,0 +1,65 @@
+package models
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxChannelLen   = 32
+	minClientKeyLen = 16
+)
+
+// ChannelClient клиент, через который загружаются заказы, например мобильное приложение.
+type ChannelClient struct {
+	Channel string
+	Key     string
+}
+
+// ChannelClients ключи клиентов, по которым определяется канал загрузки заказа. Ключи выдаются клиентам
+// при настройке, поэтому канал, который учитывают условия акций, пользователь не может указать сам.
+type ChannelClients []ChannelClient
+
+// NewChannelClients разбирает ключи клиентов из строк формата <канал>:<ключ>, например app:<ключ приложения>.
+// Пустой набор отключает каналы: заказы загружаются без канала.
+func NewChannelClients(clients []string) (ChannelClients, error) {
+	var c ChannelClients
+
+	for _, client := range clients {
+		client = strings.TrimSpace(client)
+		if client == "" {
+			continue
+		}
+
+		channel, key, ok := strings.Cut(client, ":")
+		channel = strings.ToLower(strings.TrimSpace(channel))
+
+		if !ok || channel == "" || len(channel) > maxChannelLen {
+			return nil, fmt.Errorf("%w: channel client %q", ErrInvalidInput, channel)
+		}
+
+		// ключ не выводится в ошибке, так как это секрет
+		if len(key) < minClientKeyLen {
+			return nil, fmt.Errorf("%w: channel %s key is shorter than %d", ErrInvalidInput, channel, minClientKeyLen)
+		}
+
+		c = append(c, ChannelClient{Channel: channel, Key: key})
+	}
+
+	return c, nil
+}
+
+// Channel возвращает канал клиента с ключом key или пустую строку, если ключ неизвестен.
+func (c ChannelClients) Channel(key string) string {
+	channel := ""
+
+	// ключи сравниваются за постоянное время, чтобы их нельзя было подобрать по времени ответа
+	for _, client := range c {
+		if subtle.ConstantTimeCompare([]byte(client.Key), []byte(key)) == 1 {
+			channel = client.Channel
+		}
+	}
+
+	return channel
+}
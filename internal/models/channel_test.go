@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChannelClients(t *testing.T) {
+	c, err := NewChannelClients([]string{" App:0123456789abcdef ", "", "kiosk:fedcba9876543210"})
+	if err != nil {
+		t.Fatalf("NewChannelClients: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "0123456789abcdef", want: "app"},
+		{key: "fedcba9876543210", want: "kiosk"},
+		{key: "app", want: ""},
+		{key: "0123456789abcde", want: ""},
+		{key: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := c.Channel(tt.key); got != tt.want {
+			t.Errorf("Channel(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	for _, clients := range [][]string{{"app"}, {":0123456789abcdef"}, {"app:short"}} {
+		if _, err := NewChannelClients(clients); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("NewChannelClients(%q) err = %v, want ErrInvalidInput", clients, err)
+		}
+	}
+}
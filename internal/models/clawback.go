@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// OrderClawback отзыв начисления по обработанному заказу, например при отмене заказа магазином.
+// Начисление списывается с баланса, даже если баллы уже потрачены, тогда баланс становится отрицательным.
+type OrderClawback struct {
+	ID     int64  `json:"id"`
+	Order  string `json:"order"`
+	UserID int    `json:"user_id"`
+	// Amount списанное начисление в копейках.
+	Amount int `json:"amount"`
+	// Debt часть списания в копейках, на которую баланс ушёл в минус.
+	Debt       int       `json:"debt"`
+	Reason     string    `json:"reason"`
+	OperatorID int       `json:"operator_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -12,4 +12,16 @@ var (
 	ErrOrderUploaded            = errors.New("the order has already been uploaded")
 	ErrOrderUploadedAnotherUser = errors.New("the order has already been uploaded another user")
 	ErrInsufficientBalance      = errors.New("insufficient funds on the balance sheet")
+	ErrTooManyAttempts          = errors.New("too many login attempts")
+	ErrInvalidToken             = errors.New("invalid or expired token")
+	ErrForbidden                = errors.New("forbidden")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key is reused with another request")
+	ErrOrderWithdrawn           = errors.New("points have already been withdrawn for the order")
+	ErrOrderWithdrawalCap       = errors.New("withdrawals for the order exceed the cap")
+	ErrPromoExpired             = errors.New("promo code has expired")
+	ErrPromoExhausted           = errors.New("promo code has been used up")
+	ErrPromoAlreadyRedeemed     = errors.New("promo code has already been redeemed by the user")
+	ErrTransferLimit            = errors.New("daily transfer limit exceeded")
+	ErrHoldNotActive            = errors.New("hold is already captured, released or expired")
 )
@@ -2,3 +2,6 @@ package models
 
 type CtxTokenPayload struct {
 }
+
+type CtxClientIP struct {
+}
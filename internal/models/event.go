@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Типы событий пользователя.
+const (
+	// EventOrderStatus изменился статус заказа.
+	EventOrderStatus = "order_status"
+	// EventBalance изменился баланс.
+	EventBalance = "balance"
+	// EventWithdrawal выполнено списание баллов в оплату заказа.
+	EventWithdrawal = "withdrawal"
+)
+
+// UserEvent событие, которое отправляется пользователю в потоке событий. События сохраняются,
+// поэтому переподключившийся клиент получает пропущенные события по id последнего полученного.
+type UserEvent struct {
+	ID     int64  `json:"id"`
+	UserID int    `json:"user_id"`
+	Kind   string `json:"kind"`
+	Order  string `json:"order"`
+	// Status новый статус заказа для EventOrderStatus.
+	Status string `json:"status"`
+	// Transaction тип движения баланса для EventBalance.
+	Transaction string `json:"transaction"`
+	// Amount начисление по заказу, изменение баланса или сумма списания в копейках.
+	Amount int `json:"amount"`
+	// Balance баланс после события в копейках для EventBalance и EventWithdrawal.
+	Balance   int       `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
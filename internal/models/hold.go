@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// HoldStatus состояние удержания баллов.
+type HoldStatus string
+
+const (
+	// HoldActive баллы удержаны и недоступны для других списаний.
+	HoldActive HoldStatus = "active"
+	// HoldCaptured удержанные баллы полностью или частично списаны, остаток возвращён.
+	HoldCaptured HoldStatus = "captured"
+	// HoldReleased удержание снято без списания.
+	HoldReleased HoldStatus = "released"
+	// HoldExpired удержание снято автоматически по истечении срока.
+	HoldExpired HoldStatus = "expired"
+)
+
+// PointHold удержание баллов в оплату заказа. Баллы резервируются при начале оплаты
+// и списываются только после подтверждения заказа. Суммы в копейках.
+type PointHold struct {
+	ID         int64      `json:"id"`
+	UserID     int        `json:"user_id"`
+	Order      string     `json:"order"`
+	Amount     int        `json:"amount"`
+	Captured   int        `json:"captured"`
+	Status     HoldStatus `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
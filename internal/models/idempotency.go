@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKey сохранённый результат запроса с заголовком Idempotency-Key.
+// Повторный запрос с тем же ключом получает сохранённый ответ вместо повторного выполнения.
+type IdempotencyKey struct {
+	UserID      int    `json:"user_id"`
+	Key         string `json:"key"`
+	RequestHash string `json:"request_hash"`
+	// StatusCode код первого ответа, 0 пока первый запрос выполняется.
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	Response    []byte    `json:"response"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// LoginAttempts хранит счётчик неудачных попыток входа для логина или ip адреса.
+type LoginAttempts struct {
+	Key           string     `json:"key"`
+	Failures      int        `json:"failures"`
+	LastFailureAt *time.Time `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until"`
+}
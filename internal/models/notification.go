@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	// NotificationAccrualRevoked начисление по заказу отозвано.
+	NotificationAccrualRevoked = "accrual_revoked"
+	// NotificationReferralRewarded начислены баллы за приглашение.
+	NotificationReferralRewarded = "referral_rewarded"
+	// NotificationTransferReceived получен перевод баллов от другого пользователя.
+	NotificationTransferReceived = "transfer_received"
+	// NotificationTransferReversed перевод баллов отменён сотрудником.
+	NotificationTransferReversed = "transfer_reversed"
+)
+
+// Notification уведомление пользователя о событии с его баллами.
+type Notification struct {
+	ID        int64     `json:"id"`
+	UserID    int       `json:"user_id"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// OIDCState хранит параметры начатого входа через внешнего провайдера до возврата пользователя.
+type OIDCState struct {
+	State        string    `json:"state"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Identity связывает пользователя с учётной записью у внешнего провайдера.
+type Identity struct {
+	UserID  int    `json:"user_id"`
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+}
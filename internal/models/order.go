@@ -9,4 +9,19 @@ type Order struct {
 	Status     string    `json:"status"`
 	Accrual    int       `json:"accrual"`
 	UploadedAt time.Time `json:"uploaded_at"`
+	// Channel канал, через который загружен заказ, например app.
+	Channel string `json:"channel"`
+}
+
+// OrderNumberError описывает, почему номер заказа не прошёл проверку по схеме магазина.
+type OrderNumberError struct {
+	Reason string
+}
+
+func (e *OrderNumberError) Error() string {
+	return ErrInvalidOrderNumber.Error() + ": " + e.Reason
+}
+
+func (e *OrderNumberError) Unwrap() error {
+	return ErrInvalidOrderNumber
 }
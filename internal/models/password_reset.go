@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PasswordReset одноразовый токен для сброса пароля, выданный администратором.
+// В хранилище попадает только хэш токена.
+type PasswordReset struct {
+	UserID    int       `json:"user_id"`
+	Token     string    `json:"token"`
+	TokenHash string    `json:"token_hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// PromoCode код, который начисляет пользователю фиксированное количество баллов.
+// Одноразовый код имеет MaxRedemptions = 1, многоразовый больше 1 или 0 без общего ограничения.
+type PromoCode struct {
+	ID      int64  `json:"id"`
+	BatchID int64  `json:"batch_id"`
+	Code    string `json:"code"`
+	// Points начисляемые баллы в копейках.
+	Points int `json:"points"`
+	// MaxRedemptions сколько раз всего можно использовать код, 0 без ограничения.
+	MaxRedemptions int `json:"max_redemptions"`
+	// PerUserLimit сколько раз код может использовать один пользователь, 0 без ограничения.
+	PerUserLimit int        `json:"per_user_limit"`
+	Redemptions  int        `json:"redemptions"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// PromoBatch выпуск кодов с одинаковыми условиями.
+type PromoBatch struct {
+	ID        int64       `json:"id"`
+	Name      string      `json:"name"`
+	CreatedBy int         `json:"created_by"`
+	CreatedAt time.Time   `json:"created_at"`
+	Codes     []PromoCode `json:"codes"`
+}
+
+// PromoRedemption использование кода пользователем.
+type PromoRedemption struct {
+	Code      string    `json:"code"`
+	UserID    int       `json:"user_id"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PromoGenerate запрос на выпуск кодов. Если задан Code, выпускается один код с этим значением,
+// иначе Count случайных кодов с префиксом Prefix.
+type PromoGenerate struct {
+	Name           string     `json:"name"`
+	Count          int        `json:"count"`
+	Prefix         string     `json:"prefix"`
+	Code           string     `json:"code"`
+	Points         int        `json:"points"`
+	MaxRedemptions int        `json:"max_redemptions"`
+	PerUserLimit   int        `json:"per_user_limit"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
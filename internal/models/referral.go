@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// ReferralStatus состояние приглашения.
+type ReferralStatus string
+
+const (
+	// ReferralPending приглашённый зарегистрировался, баллы будут начислены после его первого обработанного заказа.
+	ReferralPending ReferralStatus = "pending"
+	// ReferralRewarded баллы начислены обоим пользователям.
+	ReferralRewarded ReferralStatus = "rewarded"
+	// ReferralRejected баллы не начисляются, потому что пригласивший превысил лимит приглашений.
+	ReferralRejected ReferralStatus = "rejected"
+)
+
+// Referral приглашение пользователя по реферальному коду.
+type Referral struct {
+	ID           int64          `json:"id"`
+	ReferrerID   int            `json:"referrer_id"`
+	RefereeID    int            `json:"referee_id"`
+	RefereeLogin string         `json:"referee_login"`
+	Status       ReferralStatus `json:"status"`
+	// ReferrerBonus и RefereeBonus баллы в копейках, зафиксированные при регистрации приглашённого.
+	ReferrerBonus int        `json:"referrer_bonus"`
+	RefereeBonus  int        `json:"referee_bonus"`
+	OrderNumber   string     `json:"order_number"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RewardedAt    *time.Time `json:"rewarded_at"`
+}
+
+// ReferralPolicy условия реферальной программы.
+type ReferralPolicy struct {
+	// ReferrerBonus и RefereeBonus баллы в копейках пригласившему и приглашённому.
+	ReferrerBonus int
+	RefereeBonus  int
+	// MaxPerUser сколько приглашений пользователя вознаграждается, 0 без ограничения.
+	MaxPerUser int
+}
+
+// ReferralSignup регистрация по реферальному коду.
+type ReferralSignup struct {
+	Code   string
+	Policy ReferralPolicy
+}
+
+// ReferralSummary реферальный код пользователя и его приглашения.
+type ReferralSummary struct {
+	Code      string     `json:"code"`
+	Referrals []Referral `json:"referrals"`
+}
@@ -0,0 +1,79 @@
+package models
+
+// Role определяет набор прав пользователя.
+type Role string
+
+const (
+	RoleUser    Role = "user"
+	RoleSupport Role = "support"
+	RoleAdmin   Role = "admin"
+	// RoleService учётная запись внешней системы, например магазина, который при отмене заказа
+	// возвращает списанные баллы и отзывает начисленные.
+	RoleService Role = "service"
+)
+
+// Permission право на выполнение действия.
+type Permission int
+
+const (
+	// PermViewUsers просмотр заказов, баланса и списаний любого пользователя.
+	PermViewUsers Permission = iota + 1
+	// PermManageUsers разблокировка входа и выдача токенов сброса пароля.
+	PermManageUsers
+	// PermManageRoles изменение ролей пользователей.
+	PermManageRoles
+	// PermAdjustBalance ручное изменение баланса пользователя.
+	PermAdjustBalance
+	// PermRepollOrders повторный запрос начислений по заказу.
+	PermRepollOrders
+	// PermInvalidateOrders перевод заказа в статус INVALID.
+	PermInvalidateOrders
+	// PermRefundWithdrawals возврат списанных баллов на баланс.
+	PermRefundWithdrawals
+	// PermClawbackOrders отзыв начисления по обработанному заказу.
+	PermClawbackOrders
+	// PermManageCampaigns создание и изменение акций.
+	PermManageCampaigns
+	// PermManagePromoCodes выпуск и выгрузка промокодов.
+	PermManagePromoCodes
+	// PermReverseTransfers отмена перевода баллов между пользователями.
+	PermReverseTransfers
+	// PermManageWebhooks регистрация адресов для уведомления магазинов о начислениях и списаниях.
+	PermManageWebhooks
+	// PermManageAllWebhooks управление адресами всех магазинов и регистрация адресов для любых заказов.
+	PermManageAllWebhooks
+)
+
+// Valid проверяет, что роль известна.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleUser, RoleSupport, RoleAdmin, RoleService:
+		return true
+	default:
+		return false
+	}
+}
+
+// Can проверяет, есть ли у роли право. Пустая роль из токенов, выданных до появления ролей, считается RoleUser.
+func (r Role) Can(p Permission) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleSupport:
+		return p == PermViewUsers || p == PermManageUsers || p == PermRepollOrders
+	case RoleService:
+		return p == PermRefundWithdrawals || p == PermClawbackOrders || p == PermManageWebhooks
+	case RoleUser:
+		return false
+	default:
+		return false
+	}
+}
+
+// RoleChange запись аудита об изменении роли пользователя.
+type RoleChange struct {
+	UserID    int  `json:"user_id"`
+	OldRole   Role `json:"old_role"`
+	NewRole   Role `json:"new_role"`
+	ChangedBy int  `json:"changed_by"`
+}
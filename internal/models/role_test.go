@@ -0,0 +1,47 @@
+package models
+
+import "testing"
+
+func TestRoleCan(t *testing.T) {
+	all := []Permission{
+		PermViewUsers, PermManageUsers, PermManageRoles, PermAdjustBalance, PermRepollOrders,
+		PermInvalidateOrders, PermRefundWithdrawals, PermClawbackOrders, PermManageCampaigns,
+		PermManagePromoCodes, PermReverseTransfers, PermManageWebhooks, PermManageAllWebhooks,
+	}
+
+	allowed := map[Role][]Permission{
+		RoleAdmin:   all,
+		RoleSupport: {PermViewUsers, PermManageUsers, PermRepollOrders},
+		RoleService: {PermRefundWithdrawals, PermClawbackOrders, PermManageWebhooks},
+		RoleUser:    nil,
+		"":          nil,
+		"root":      nil,
+	}
+
+	for role, perms := range allowed {
+		want := make(map[Permission]bool, len(perms))
+		for _, p := range perms {
+			want[p] = true
+		}
+
+		for _, p := range all {
+			if got := role.Can(p); got != want[p] {
+				t.Errorf("Role(%q).Can(%d) = %v, want %v", role, p, got, want[p])
+			}
+		}
+	}
+}
+
+func TestRoleValid(t *testing.T) {
+	for _, r := range []Role{RoleUser, RoleSupport, RoleAdmin, RoleService} {
+		if !r.Valid() {
+			t.Errorf("Role(%q) must be valid", r)
+		}
+	}
+
+	for _, r := range []Role{"", "root", "Admin"} {
+		if r.Valid() {
+			t.Errorf("Role(%q) must be invalid", r)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Statement выписка по счёту пользователя за период [From, To). Opening баланс на начало периода в копейках.
+type Statement struct {
+	UserID  int       `json:"user_id"`
+	Login   string    `json:"login"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Opening int       `json:"opening"`
+}
+
+// StatementEntry операция в выписке: движение баланса из журнала с типом BalanceTransaction.Kind.
+// Amount отрицательный при списании, Balance баланс после операции, всё в копейках.
+type StatementEntry struct {
+	Kind    string    `json:"kind"`
+	Order   string    `json:"order"`
+	Amount  int       `json:"amount"`
+	Balance int       `json:"balance"`
+	Time    time.Time `json:"time"`
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Основа для определения уровня: начисленные или потраченные баллы за скользящий период.
+const (
+	TierBasisAccrued = "accrued"
+	TierBasisSpent   = "spent"
+)
+
+// Tier уровень программы лояльности. Уровень достигается, когда баллы за период не меньше Threshold,
+// и увеличивает начисления за заказы в Multiplier раз.
+type Tier struct {
+	Name       string  `json:"name"`
+	Threshold  int     `json:"threshold"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// TierPolicy набор уровней, упорядоченный по возрастанию порога. Уровень определяется по баллам,
+// начисленным или потраченным за последние Window.
+type TierPolicy struct {
+	Tiers  []Tier
+	Basis  string
+	Window time.Duration
+}
+
+// NewTierPolicy разбирает уровни из строк формата <название>:<порог в баллах>:<множитель>,
+// например silver:5000:1.25. Пустой набор отключает уровни.
+func NewTierPolicy(rules []string, basis string, window time.Duration) (TierPolicy, error) {
+	if basis != TierBasisAccrued && basis != TierBasisSpent {
+		return TierPolicy{}, fmt.Errorf("%w: tier basis %q", ErrInvalidInput, basis)
+	}
+
+	if window <= 0 {
+		return TierPolicy{}, fmt.Errorf("%w: tier window must be positive", ErrInvalidInput)
+	}
+
+	p := TierPolicy{Basis: basis, Window: window}
+
+	for _, r := range rules {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+
+		parts := strings.Split(r, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return TierPolicy{}, fmt.Errorf("%w: tier rule %q", ErrInvalidInput, r)
+		}
+
+		threshold, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || threshold < 0 {
+			return TierPolicy{}, fmt.Errorf("%w: tier %s threshold %q", ErrInvalidInput, parts[0], parts[1])
+		}
+
+		multiplier, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || multiplier <= 0 {
+			return TierPolicy{}, fmt.Errorf("%w: tier %s multiplier %q", ErrInvalidInput, parts[0], parts[2])
+		}
+
+		p.Tiers = append(p.Tiers, Tier{
+			Name:       parts[0],
+			Threshold:  int(math.Round(threshold * 100)),
+			Multiplier: multiplier,
+		})
+	}
+
+	sort.SliceStable(p.Tiers, func(i, j int) bool {
+		return p.Tiers[i].Threshold < p.Tiers[j].Threshold
+	})
+
+	return p, nil
+}
+
+// Enabled сообщает, настроены ли уровни.
+func (p TierPolicy) Enabled() bool {
+	return len(p.Tiers) > 0
+}
+
+// Status возвращает уровень для баллов за период и следующий уровень.
+// Пока не достигнут самый низкий порог, уровень пустой и множитель равен 1.
+func (p TierPolicy) Status(progress int) TierStatus {
+	status := TierStatus{
+		Tier:     Tier{Multiplier: 1},
+		Progress: progress,
+	}
+
+	for i, t := range p.Tiers {
+		if progress < t.Threshold {
+			next := p.Tiers[i]
+			status.Next = &next
+
+			break
+		}
+
+		status.Tier = t
+	}
+
+	return status
+}
+
+// TierStatus текущий уровень пользователя и продвижение к следующему уровню.
+type TierStatus struct {
+	Tier     Tier  `json:"tier"`
+	Next     *Tier `json:"next"`
+	Progress int   `json:"progress"`
+}
+
+// ToNext возвращает баллы, которых не хватает до следующего уровня, 0 для самого высокого уровня.
+func (s TierStatus) ToNext() int {
+	if s.Next == nil {
+		return 0
+	}
+
+	return s.Next.Threshold - s.Progress
+}
+
+// TierChange запись об изменении уровня пользователя.
+type TierChange struct {
+	UserID    int       `json:"user_id"`
+	OldTier   string    `json:"old_tier"`
+	NewTier   string    `json:"new_tier"`
+	Progress  int       `json:"progress"`
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -1,5 +1,11 @@
 package models
 
+import "time"
+
 type TokenPayload struct {
-	UserID int `json:"user_id"`
+	UserID       int  `json:"user_id"`
+	TokenVersion int  `json:"token_version"`
+	Role         Role `json:"role"`
+	// Expiry срок действия токена, заполняется при разборе токена и в токен не записывается.
+	Expiry time.Time `json:"-"`
 }
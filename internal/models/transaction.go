@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Типы движений баланса. Зачисления и списания по изменению баланса, переводу, возврату и т.д.
+// имеют тип, совпадающий с источником партии баллов, например LotSourceAccrual.
+const (
+	TransactionWithdrawal = "withdrawal"
+	TransactionClawback   = "clawback"
+	TransactionExpiration = "expiration"
+)
+
+// TransactionHoldExpired тип движения в событии EventBalance, когда истёкшие удержания сняты и доступный
+// баланс вырос на Amount. В истории операций такого движения нет, текущий баланс не меняется.
+const TransactionHoldExpired = "hold_expired"
+
+// BalanceTransaction движение баланса пользователя. Amount отрицательный при списании,
+// Balance баланс после движения, всё в копейках.
+type BalanceTransaction struct {
+	ID        int64     `json:"id"`
+	UserID    int       `json:"user_id"`
+	Kind      string    `json:"kind"`
+	Amount    int       `json:"amount"`
+	Order     string    `json:"order"`
+	Reference string    `json:"reference"`
+	Balance   int       `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TransactionPage страница журнала движений: не более Limit движений после движения с id After.
+type TransactionPage struct {
+	After int64
+	Limit int
+}
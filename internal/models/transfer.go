@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// TransferStatus состояние перевода баллов.
+type TransferStatus string
+
+const (
+	// TransferCompleted баллы переведены получателю.
+	TransferCompleted TransferStatus = "completed"
+	// TransferReversed перевод отменён сотрудником, баллы возвращены отправителю.
+	TransferReversed TransferStatus = "reversed"
+)
+
+// Transfer перевод баллов от одного пользователя другому. Сумма в копейках.
+type Transfer struct {
+	ID             int64          `json:"id"`
+	SenderID       int            `json:"sender_id"`
+	SenderLogin    string         `json:"sender_login"`
+	RecipientID    int            `json:"recipient_id"`
+	RecipientLogin string         `json:"recipient_login"`
+	Amount         int            `json:"amount"`
+	Status         TransferStatus `json:"status"`
+	ReversalNote   string         `json:"reversal_note"`
+	ReversedBy     int            `json:"reversed_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ReversedAt     *time.Time     `json:"reversed_at"`
+}
+
+// TransferLimits ограничения на переводы одного отправителя. Суммы в копейках.
+type TransferLimits struct {
+	// MinAmount минимальная сумма перевода.
+	MinAmount int
+	// DailyAmount максимальная сумма переводов за последние сутки, 0 без ограничения.
+	DailyAmount int
+	// DailyCount максимальное количество переводов за последние сутки, 0 без ограничения.
+	DailyCount int
+}
+
+// TransferDirection направление перевода для одного из его участников.
+type TransferDirection string
+
+const (
+	TransferSent     TransferDirection = "sent"
+	TransferReceived TransferDirection = "received"
+)
+
+// TransferEntry перевод в истории одного из его участников.
+type TransferEntry struct {
+	Transfer
+	Direction TransferDirection `json:"direction"`
+	// Counterparty логин другого участника перевода.
+	Counterparty string `json:"counterparty"`
+}
+
+// EntryFor возвращает перевод со стороны пользователя userID.
+func (t Transfer) EntryFor(userID int) TransferEntry {
+	if t.SenderID == userID {
+		return TransferEntry{Transfer: t, Direction: TransferSent, Counterparty: t.RecipientLogin}
+	}
+
+	return TransferEntry{Transfer: t, Direction: TransferReceived, Counterparty: t.SenderLogin}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"strings"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -9,14 +11,16 @@ const (
 	// stringShortLen defines length limit for string fields with short values.
 	stringShortLen = 64
 
-	// passwordMinLen defines minimum length limit for password fields.
-	passwordMinLen = 5
+	// DefaultPasswordMinLen defines default minimum length limit for password fields.
+	DefaultPasswordMinLen = 5
 )
 
 type User struct {
-	ID       int    `json:"id"`
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	ID           int    `json:"id"`
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	TokenVersion int    `json:"token_version"`
+	Role         Role   `json:"role"`
 }
 
 func (u *User) Validate() error {
@@ -25,7 +29,7 @@ func (u *User) Validate() error {
 			validation.Required),
 		validation.Field(&u.Password,
 			validation.Required,
-			validation.Length(passwordMinLen, stringShortLen)),
+			validation.Length(0, stringShortLen)),
 	)
 
 	if err != nil {
@@ -34,3 +38,60 @@ func (u *User) Validate() error {
 
 	return nil
 }
+
+// ValidateWithPolicy validates user and checks the password against the password policy.
+func (u *User) ValidateWithPolicy(policy PasswordPolicy) error {
+	if err := u.Validate(); err != nil {
+		return err
+	}
+
+	return policy.Validate(u.Password)
+}
+
+// PasswordPolicy defines requirements for new passwords.
+type PasswordPolicy struct {
+	MinLen int
+	Banned map[string]struct{}
+}
+
+// NewPasswordPolicy creates password policy. Banned passwords are compared case-insensitively.
+func NewPasswordPolicy(minLen int, banned []string) PasswordPolicy {
+	if minLen <= 0 {
+		minLen = DefaultPasswordMinLen
+	}
+
+	p := PasswordPolicy{
+		MinLen: minLen,
+		Banned: make(map[string]struct{}, len(banned)),
+	}
+
+	for _, b := range banned {
+		b = strings.TrimSpace(b)
+		if b != "" {
+			p.Banned[strings.ToLower(b)] = struct{}{}
+		}
+	}
+
+	return p
+}
+
+// Validate checks the password against the policy.
+func (p PasswordPolicy) Validate(password string) error {
+	err := validation.Validate(password,
+		validation.Required,
+		validation.Length(p.MinLen, stringShortLen),
+		validation.By(func(_ any) error {
+			if _, ok := p.Banned[strings.ToLower(password)]; ok {
+				return validation.NewError("validation_password_banned", "the password is too common")
+			}
+
+			return nil
+		}),
+	)
+
+	if err != nil {
+		return fmt.Errorf("password validation error: %w", err)
+	}
+
+	return nil
+}
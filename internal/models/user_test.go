@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := NewPasswordPolicy(8, []string{"password", " Qwerty123 ", ""})
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "valid", password: "correct horse", wantErr: false},
+		{name: "empty", password: "", wantErr: true},
+		{name: "too short", password: "abc1234", wantErr: true},
+		{name: "min length", password: "abcd1234", wantErr: false},
+		{name: "too long", password: strings.Repeat("a", stringShortLen+1), wantErr: true},
+		{name: "banned", password: "password", wantErr: true},
+		{name: "banned in other case", password: "QWERTY123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyDefaultMinLen(t *testing.T) {
+	policy := NewPasswordPolicy(0, nil)
+
+	if policy.MinLen != DefaultPasswordMinLen {
+		t.Fatalf("MinLen = %d, want %d", policy.MinLen, DefaultPasswordMinLen)
+	}
+
+	if err := policy.Validate("abcd"); err == nil {
+		t.Error("expected error for password shorter than default minimum")
+	}
+}
+
+func TestUserValidateWithPolicy(t *testing.T) {
+	policy := NewPasswordPolicy(5, []string{"12345"})
+
+	u := User{Login: "", Password: "secret"}
+	if err := u.ValidateWithPolicy(policy); err == nil {
+		t.Error("expected error for empty login")
+	}
+
+	u = User{Login: "alice", Password: "12345"}
+	if err := u.ValidateWithPolicy(policy); err == nil {
+		t.Error("expected error for banned password")
+	}
+
+	u = User{Login: "alice", Password: "secret"}
+	if err := u.ValidateWithPolicy(policy); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Типы событий, о которых магазины узнают по webhook.
+const (
+	// WebhookAccrual баллы за заказ начислены покупателю.
+	WebhookAccrual = "accrual"
+	// WebhookWithdrawal баллы покупателя списаны в оплату заказа.
+	WebhookWithdrawal = "withdrawal"
+)
+
+// WebhookDeliveryStatus состояние доставки события на адрес магазина.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookPending событие ожидает очередной попытки доставки.
+	WebhookPending WebhookDeliveryStatus = "pending"
+	// WebhookDelivered получатель ответил кодом 2xx.
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookFailed попытки доставки исчерпаны.
+	WebhookFailed WebhookDeliveryStatus = "failed"
+)
+
+// Webhook адрес магазина, на который отправляются события по его заказам. Shop префикс номеров
+// заказов магазина, к которому привязана учётная запись магазина. Пустой префикс означает все заказы
+// и доступен только администратору. Secret ключ подписи HMAC тела запроса.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	Shop      string    `json:"shop"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedBy int       `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Valid проверяет адрес и типы событий. Адрес во внутренней сети не допускается, чтобы через webhook
+// нельзя было обращаться к внутренним сервисам. Имя хоста здесь не разрешается, адрес, в который
+// оно разрешилось, проверяет клиент при подключении.
+func (w Webhook) Valid() bool {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !PublicIP(ip) {
+		return false
+	}
+
+	if len(w.Events) == 0 {
+		return false
+	}
+
+	for _, e := range w.Events {
+		if e != WebhookAccrual && e != WebhookWithdrawal {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PublicIP сообщает, что адрес не относится к внутренней сети: не локальный, не частный по RFC 1918
+// и RFC 4193, не link-local (в том числе 169.254.169.254) и не групповой.
+func PublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
+
+// WebhookEvent событие из outbox, сохранённое в одной транзакции с начислением или списанием.
+// Amount в копейках.
+type WebhookEvent struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	Order     string    `json:"order"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery доставка события на адрес магазина. History попытки доставки, заполняется
+// только при просмотре доставок.
+type WebhookDelivery struct {
+	ID            int64                 `json:"id"`
+	WebhookID     int64                 `json:"webhook_id"`
+	URL           string                `json:"-"`
+	Secret        string                `json:"-"`
+	Event         WebhookEvent          `json:"event"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	LastError     string                `json:"last_error"`
+	CreatedAt     time.Time             `json:"created_at"`
+	DeliveredAt   *time.Time            `json:"delivered_at"`
+	History       []WebhookAttempt      `json:"history"`
+}
+
+// WebhookAttempt попытка доставки события. StatusCode 0, если ответ не получен.
+type WebhookAttempt struct {
+	DeliveryID int64         `json:"delivery_id"`
+	Attempt    int           `json:"attempt"`
+	StatusCode int           `json:"status_code"`
+	Error      string        `json:"error"`
+	Duration   time.Duration `json:"duration"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
@@ -0,0 +1,299 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	discoveryPath     = "/.well-known/openid-configuration"
+	httpClientTimeout = time.Second * 10
+	leewayDuration    = time.Second * 30
+	maxResponseSize   = 1 << 20
+)
+
+var (
+	ErrDiscovery      = errors.New("cannot discover provider")
+	ErrExchange       = errors.New("cannot exchange authorization code")
+	ErrIDToken        = errors.New("invalid id token")
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+)
+
+// Config describes the client registered at the identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims contains id token claims used to link external subject with local user.
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// discovery is a subset of the provider metadata.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// Provider implements authorization code flow with PKCE against OpenID Connect provider.
+// Provider metadata and signing keys are fetched lazily and cached.
+type Provider struct {
+	log *zap.Logger
+	cfg Config
+	dc  *http.Client
+
+	mu   sync.Mutex
+	meta *discovery
+	keys map[string]any
+}
+
+func NewProvider(log *zap.Logger, cfg Config) *Provider {
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid"}
+	}
+
+	return &Provider{
+		log: log,
+		cfg: cfg,
+		dc: &http.Client{
+			Timeout: httpClientTimeout,
+		},
+	}
+}
+
+// Issuer returns issuer identifier of the provider.
+func (p *Provider) Issuer() string {
+	return p.cfg.Issuer
+}
+
+// AuthCodeURL returns url of the provider login page.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.cfg.ClientID)
+	v.Set("redirect_uri", p.cfg.RedirectURL)
+	v.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", CodeChallenge(codeVerifier))
+	v.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return meta.AuthorizationEndpoint + sep + v.Encode(), nil
+}
+
+// Exchange exchanges authorization code for tokens and returns validated id token claims.
+// Nonce must be checked by the caller.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", p.cfg.RedirectURL)
+	v.Set("client_id", p.cfg.ClientID)
+	v.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(v.Encode()))
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: cannot prepare request: %w", ErrExchange, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var tr tokenResponse
+	if err := p.do(req, &tr); err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrExchange, err)
+	}
+
+	if tr.IDToken == "" {
+		return Claims{}, fmt.Errorf("%w: id token is missing in response", ErrExchange)
+	}
+
+	return p.Verify(ctx, tr.IDToken)
+}
+
+// Verify checks id token signature, issuer, audience and expiration.
+func (p *Provider) Verify(ctx context.Context, rawIDToken string) (Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	claims := Claims{}
+
+	_, err = jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leewayDuration))
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrIDToken, err)
+	}
+
+	if claims.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: subject is missing", ErrIDToken)
+	}
+
+	return claims, nil
+}
+
+// CodeChallenge returns PKCE S256 code challenge for the verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *Provider) discover(ctx context.Context) (discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return *p.meta, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+discoveryPath, http.NoBody)
+	if err != nil {
+		return discovery{}, fmt.Errorf("%w: cannot prepare request: %w", ErrDiscovery, err)
+	}
+
+	var meta discovery
+	if err := p.do(req, &meta); err != nil {
+		return discovery{}, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+
+	if strings.TrimSuffix(meta.Issuer, "/") != p.cfg.Issuer {
+		return discovery{}, fmt.Errorf("%w: issuer mismatch: %s", ErrDiscovery, meta.Issuer)
+	}
+
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return discovery{}, fmt.Errorf("%w: provider metadata is incomplete", ErrDiscovery)
+	}
+
+	p.log.Debug("oidc provider discovered",
+		zap.String("issuer", meta.Issuer),
+		zap.String("authorization endpoint", meta.AuthorizationEndpoint),
+		zap.String("token endpoint", meta.TokenEndpoint))
+
+	p.meta = &meta
+
+	return meta, nil
+}
+
+// key returns the signing key by id. Keys are fetched again if the id is unknown, so key rotation is supported.
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := p.fetchKeys(ctx, meta.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		// провайдер с единственным ключом может не указывать kid
+		if kid == "" && len(keys) == 1 {
+			for _, k := range keys {
+				return k, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+func (p *Provider) do(req *http.Request, v any) error {
+	resp, err := p.dc.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot do request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("cannot read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+
+	return nil
+}
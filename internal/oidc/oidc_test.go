@@ -0,0 +1,449 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	testClientID     = "gophermart"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "http://localhost/api/user/oidc/callback"
+)
+
+// stubIdP minimal identity provider: discovery, jwks and token endpoints.
+type stubIdP struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	keys      map[string]any
+	idToken   string
+	verifier  string
+	jwksCalls int
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+
+	idp := &stubIdP{keys: make(map[string]any)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *stubIdP) issuer() string {
+	return idp.server.URL
+}
+
+func (idp *stubIdP) provider() *Provider {
+	return NewProvider(zap.NewNop(), Config{
+		Issuer:       idp.issuer() + "/",
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	})
+}
+
+// setKeys replaces published keys, private keys are converted to public ones.
+func (idp *stubIdP) setKeys(keys map[string]any) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	idp.keys = keys
+}
+
+func (idp *stubIdP) setIDToken(raw string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	idp.idToken = raw
+}
+
+// state returns the number of jwks requests and the last received code verifier.
+func (idp *stubIdP) state() (int, string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	return idp.jwksCalls, idp.verifier
+}
+
+func (idp *stubIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, discovery{
+		Issuer:                idp.issuer(),
+		AuthorizationEndpoint: idp.issuer() + "/authorize",
+		TokenEndpoint:         idp.issuer() + "/token",
+		JWKSURI:               idp.issuer() + "/jwks",
+	})
+}
+
+func (idp *stubIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	idp.jwksCalls++
+
+	set := jwks{Keys: make([]jwk, 0, len(idp.keys))}
+
+	for kid, key := range idp.keys {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				N:   encodeBigInt(k.N),
+				E:   encodeBigInt(big.NewInt(int64(k.E))),
+			})
+		case *ecdsa.PrivateKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "EC",
+				Kid: kid,
+				Crv: k.Curve.Params().Name,
+				X:   encodeBigInt(k.X),
+				Y:   encodeBigInt(k.Y),
+			})
+		}
+	}
+
+	writeJSON(w, set)
+}
+
+func (idp *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok || user != testClientID || password != testClientSecret {
+		http.Error(w, "invalid client", http.StatusUnauthorized)
+
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != "code" {
+		http.Error(w, "invalid grant", http.StatusBadRequest)
+
+		return
+	}
+
+	idp.verifier = r.PostForm.Get("code_verifier")
+
+	writeJSON(w, tokenResponse{AccessToken: "access", IDToken: idp.idToken, TokenType: "Bearer"})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+
+	return key
+}
+
+func (idp *stubIdP) claims() Claims {
+	now := time.Now()
+
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.issuer(),
+			Subject:   "subject-1",
+			Audience:  jwt.ClaimStrings{testClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Nonce: "nonce",
+		Email: "alice@example.com",
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return raw
+}
+
+func TestVerify(t *testing.T) {
+	idp := newStubIdP(t)
+
+	key := newRSAKey(t)
+	other := newRSAKey(t)
+	idp.setKeys(map[string]any{"k1": key})
+
+	tests := []struct {
+		name   string
+		token  func(c Claims) string
+		wantOK bool
+	}{
+		{
+			name:   "valid",
+			token:  func(c Claims) string { return sign(t, jwt.SigningMethodRS256, "k1", key, c) },
+			wantOK: true,
+		},
+		{
+			name:  "bad signature",
+			token: func(c Claims) string { return sign(t, jwt.SigningMethodRS256, "k1", other, c) },
+		},
+		{
+			name: "tampered payload",
+			token: func(c Claims) string {
+				raw := sign(t, jwt.SigningMethodRS256, "k1", key, c)
+				c.Subject = "admin"
+				forged := sign(t, jwt.SigningMethodRS256, "k1", other, c)
+
+				parts, forgedParts := strings.Split(raw, "."), strings.Split(forged, ".")
+
+				return parts[0] + "." + forgedParts[1] + "." + parts[2]
+			},
+		},
+		{
+			name: "wrong audience",
+			token: func(c Claims) string {
+				c.Audience = jwt.ClaimStrings{"other-client"}
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+		},
+		{
+			name: "wrong issuer",
+			token: func(c Claims) string {
+				c.Issuer = "https://evil.example.com"
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+		},
+		{
+			name: "expired",
+			token: func(c Claims) string {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+		},
+		{
+			name: "expired within leeway",
+			token: func(c Claims) string {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-leewayDuration / 2))
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+			wantOK: true,
+		},
+		{
+			name: "without expiration",
+			token: func(c Claims) string {
+				c.ExpiresAt = nil
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+		},
+		{
+			name: "without subject",
+			token: func(c Claims) string {
+				c.Subject = ""
+
+				return sign(t, jwt.SigningMethodRS256, "k1", key, c)
+			},
+		},
+		{
+			name:  "unknown key",
+			token: func(c Claims) string { return sign(t, jwt.SigningMethodRS256, "k2", key, c) },
+		},
+		{
+			name: "symmetric algorithm",
+			token: func(c Claims) string {
+				return sign(t, jwt.SigningMethodHS256, "k1", []byte(testClientSecret), c)
+			},
+		},
+		{
+			name: "none algorithm",
+			token: func(c Claims) string {
+				return sign(t, jwt.SigningMethodNone, "k1", jwt.UnsafeAllowNoneSignatureType, c)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := idp.provider()
+
+			claims, err := p.Verify(context.Background(), tt.token(idp.claims()))
+			if !tt.wantOK {
+				if !errors.Is(err, ErrIDToken) {
+					t.Fatalf("got %v, want ErrIDToken", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if claims.Subject != "subject-1" || claims.Nonce != "nonce" {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyECKey(t *testing.T) {
+	idp := newStubIdP(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+
+	idp.setKeys(map[string]any{"ec": key})
+
+	// provider with a single key may omit kid
+	raw := sign(t, jwt.SigningMethodES256, "", key, idp.claims())
+
+	if _, err := idp.provider().Verify(context.Background(), raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	idp := newStubIdP(t)
+	p := idp.provider()
+
+	old := newRSAKey(t)
+	idp.setKeys(map[string]any{"old": old})
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "old", old, idp.claims())); err != nil {
+			t.Fatalf("old key: %v", err)
+		}
+	}
+
+	if calls, _ := idp.state(); calls != 1 {
+		t.Fatalf("jwks fetched %d times, want 1: keys must be cached", calls)
+	}
+
+	next := newRSAKey(t)
+	idp.setKeys(map[string]any{"new": next})
+
+	if _, err := p.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "new", next, idp.claims())); err != nil {
+		t.Fatalf("rotated key: %v", err)
+	}
+
+	if calls, _ := idp.state(); calls != 2 {
+		t.Fatalf("jwks fetched %d times, want 2: unknown kid must refresh keys", calls)
+	}
+
+	// key removed by the provider is not accepted anymore
+	_, err := p.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "old", old, idp.claims()))
+	if !errors.Is(err, ErrIDToken) || !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("removed key: got %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	idp := newStubIdP(t)
+	p := idp.provider()
+
+	key := newRSAKey(t)
+	idp.setKeys(map[string]any{"k1": key})
+	idp.setIDToken(sign(t, jwt.SigningMethodRS256, "k1", key, idp.claims()))
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+
+	q := u.Query()
+	if q.Get("state") != "state" || q.Get("nonce") != "nonce" || q.Get("client_id") != testClientID ||
+		q.Get("code_challenge") != CodeChallenge("verifier") || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("unexpected auth url %s", authURL)
+	}
+
+	claims, err := p.Exchange(context.Background(), "code", "verifier")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if _, verifier := idp.state(); verifier != "verifier" {
+		t.Errorf("code verifier = %q", verifier)
+	}
+
+	// nonce is checked by the caller, provider only returns it
+	if claims.Nonce != "nonce" || claims.Email != "alice@example.com" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if _, err := p.Exchange(context.Background(), "other", "verifier"); !errors.Is(err, ErrExchange) {
+		t.Errorf("invalid code: got %v, want ErrExchange", err)
+	}
+
+	idp.setIDToken("")
+
+	if _, err := p.Exchange(context.Background(), "code", "verifier"); !errors.Is(err, ErrExchange) {
+		t.Errorf("missing id token: got %v, want ErrExchange", err)
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	idp := newStubIdP(t)
+
+	p := NewProvider(zap.NewNop(), Config{Issuer: strings.Replace(idp.issuer(), "127.0.0.1", "localhost", 1)})
+
+	if _, err := p.AuthCodeURL(context.Background(), "state", "nonce", "verifier"); !errors.Is(err, ErrDiscovery) {
+		t.Fatalf("got %v, want ErrDiscovery", err)
+	}
+}
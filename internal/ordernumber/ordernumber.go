@@ -0,0 +1,223 @@
+// Package ordernumber validates order numbers of partner shops. Shops use different numbering schemes,
+// so a scheme can be configured for every shop, which is recognized by the order number prefix.
+package ordernumber
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gophermat/internal/luhn"
+	"gophermat/internal/models"
+)
+
+const (
+	SchemeLuhn         = "luhn"
+	SchemeRegexp       = "regex"
+	SchemePrefixLuhn   = "prefix-luhn"
+	SchemeAlphanumeric = "alnum"
+
+	alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+var (
+	ErrUnknownScheme = errors.New("unknown order number scheme")
+	ErrInvalidScheme = errors.New("invalid order number scheme")
+)
+
+// Validator checks an order number. The returned error is *models.OrderNumberError with the reason.
+type Validator interface {
+	Validate(number string) error
+}
+
+// Luhn accepts numeric order numbers of any length with the Luhn check digit.
+type Luhn struct{}
+
+func (Luhn) Validate(number string) error {
+	if number == "" || strings.Trim(number, "0123456789") != "" {
+		return invalid("must contain only digits")
+	}
+
+	if !luhn.Valid(number) {
+		return invalid("luhn check digit mismatch")
+	}
+
+	return nil
+}
+
+// Regexp accepts order numbers matching the whole pattern.
+type Regexp struct {
+	re *regexp.Regexp
+}
+
+func NewRegexp(pattern string) (*Regexp, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScheme, err)
+	}
+
+	return &Regexp{re: re}, nil
+}
+
+func (r *Regexp) Validate(number string) error {
+	if !r.re.MatchString(number) {
+		return invalid(fmt.Sprintf("does not match pattern %s", r.re.String()))
+	}
+
+	return nil
+}
+
+// PrefixLuhn accepts order numbers starting with the prefix followed by digits with the Luhn check digit.
+type PrefixLuhn struct {
+	Prefix string
+}
+
+func (p PrefixLuhn) Validate(number string) error {
+	rest, ok := strings.CutPrefix(number, p.Prefix)
+	if !ok {
+		return invalid(fmt.Sprintf("must start with %s", p.Prefix))
+	}
+
+	return Luhn{}.Validate(rest)
+}
+
+// Alphanumeric accepts order numbers of digits and latin letters, case insensitive, where the last character
+// is the check character computed with the Luhn mod 36 algorithm.
+type Alphanumeric struct{}
+
+func (Alphanumeric) Validate(number string) error {
+	if len(number) < 2 {
+		return invalid("too short")
+	}
+
+	number = strings.ToUpper(number)
+
+	sum := 0
+	double := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		v := strings.IndexByte(alphabet, number[i])
+		if v < 0 {
+			return invalid("must contain only digits and latin letters")
+		}
+
+		if double {
+			v *= 2
+		}
+
+		sum += v/len(alphabet) + v%len(alphabet)
+		double = !double
+	}
+
+	if sum%len(alphabet) != 0 {
+		return invalid("check character mismatch")
+	}
+
+	return nil
+}
+
+// Set chooses a validator by the order number prefix, the longest matching prefix wins.
+// The shop prefix only selects the scheme, so the validator checks the rest of the number after it.
+// Numbers without a matching prefix are checked by the default validator as is.
+type Set struct {
+	rules []rule
+	def   Validator
+}
+
+type rule struct {
+	prefix    string
+	validator Validator
+}
+
+func (s *Set) Validate(number string) error {
+	for _, r := range s.rules {
+		if rest, ok := strings.CutPrefix(number, r.prefix); ok {
+			return r.validator.Validate(rest)
+		}
+	}
+
+	if s.def == nil {
+		return invalid("unknown shop prefix")
+	}
+
+	return s.def.Validate(number)
+}
+
+// Parse builds a validator from config entries of form [<shop prefix>=]<scheme>[:<argument>]:
+//
+//	luhn                     numeric number with the Luhn check digit
+//	regex:<pattern>          number matching the regular expression
+//	prefix-luhn:<prefix>     prefix followed by numeric number with the Luhn check digit
+//	alnum                    alphanumeric number with the Luhn mod 36 check character
+//
+// The scheme of an entry with a shop prefix applies to the number without that prefix, e.g. AB=luhn accepts
+// AB79927398713. An entry without a shop prefix sets the default validator. Empty config means luhn for all numbers.
+func Parse(entries []string) (*Set, error) {
+	s := &Set{}
+
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+
+		prefix, scheme, found := strings.Cut(e, "=")
+		if !found {
+			prefix, scheme = "", e
+		}
+
+		v, err := parseScheme(scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		if prefix == "" {
+			s.def = v
+
+			continue
+		}
+
+		s.rules = append(s.rules, rule{prefix: prefix, validator: v})
+	}
+
+	if s.def == nil && len(s.rules) == 0 {
+		s.def = Luhn{}
+	}
+
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		return len(s.rules[i].prefix) > len(s.rules[j].prefix)
+	})
+
+	return s, nil
+}
+
+func parseScheme(scheme string) (Validator, error) {
+	name, arg, _ := strings.Cut(scheme, ":")
+
+	switch name {
+	case SchemeLuhn:
+		return Luhn{}, nil
+	case SchemeRegexp:
+		if arg == "" {
+			return nil, fmt.Errorf("%w: %s requires a pattern", ErrInvalidScheme, name)
+		}
+
+		return NewRegexp(arg)
+	case SchemePrefixLuhn:
+		if arg == "" {
+			return nil, fmt.Errorf("%w: %s requires a prefix", ErrInvalidScheme, name)
+		}
+
+		return PrefixLuhn{Prefix: arg}, nil
+	case SchemeAlphanumeric:
+		return Alphanumeric{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScheme, name)
+	}
+}
+
+func invalid(reason string) error {
+	return &models.OrderNumberError{Reason: reason}
+}
@@ -0,0 +1,160 @@
+package ordernumber
+
+import (
+	"errors"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+type validatorTest struct {
+	number string
+	valid  bool
+}
+
+func checkValidator(t *testing.T, v Validator, tests []validatorTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		err := v.Validate(tt.number)
+
+		if tt.valid {
+			if err != nil {
+				t.Errorf("Validate(%q) = %v, want valid", tt.number, err)
+			}
+
+			continue
+		}
+
+		var numberErr *models.OrderNumberError
+
+		if !errors.As(err, &numberErr) || !errors.Is(err, models.ErrInvalidOrderNumber) || numberErr.Reason == "" {
+			t.Errorf("Validate(%q) = %v, want OrderNumberError with reason", tt.number, err)
+		}
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	checkValidator(t, Luhn{}, []validatorTest{
+		{number: "79927398713", valid: true},
+		{number: "0079927398713", valid: true},
+		{number: "79927398710"},
+		{number: "7992739871a"},
+		{number: ""},
+	})
+}
+
+func TestRegexp(t *testing.T) {
+	v, err := NewRegexp(`ORD-\d{6}`)
+	if err != nil {
+		t.Fatalf("NewRegexp: %v", err)
+	}
+
+	checkValidator(t, v, []validatorTest{
+		{number: "ORD-123456", valid: true},
+		{number: "ORD-12345"},
+		{number: "xORD-123456"},
+		{number: "ORD-1234567"},
+		{number: ""},
+	})
+
+	// альтернативы в выражении тоже должны совпадать с номером целиком
+	v, err = NewRegexp(`A\d|B\d`)
+	if err != nil {
+		t.Fatalf("NewRegexp: %v", err)
+	}
+
+	checkValidator(t, v, []validatorTest{
+		{number: "A1", valid: true},
+		{number: "B2", valid: true},
+		{number: "A1x"},
+		{number: "xB2"},
+	})
+
+	if _, err := NewRegexp(`(`); !errors.Is(err, ErrInvalidScheme) {
+		t.Errorf("invalid pattern: got %v, want ErrInvalidScheme", err)
+	}
+}
+
+func TestPrefixLuhn(t *testing.T) {
+	checkValidator(t, PrefixLuhn{Prefix: "MX"}, []validatorTest{
+		{number: "MX79927398713", valid: true},
+		{number: "MX79927398710"},
+		{number: "79927398713"},
+		{number: "mx79927398713"},
+		{number: "MX"},
+	})
+}
+
+func TestAlphanumeric(t *testing.T) {
+	checkValidator(t, Alphanumeric{}, []validatorTest{
+		{number: "A0Q", valid: true},
+		{number: "a0q", valid: true},
+		{number: "00", valid: true},
+		{number: "A0B"},
+		{number: "A-0A"},
+		{number: "A"},
+	})
+}
+
+func TestSet(t *testing.T) {
+	s, err := Parse([]string{"AB=luhn", "ABC=regex:[A-Z]{3}\\d{3}", "XY=alnum", "regex:\\d{4}"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	checkValidator(t, s, []validatorTest{
+		// префикс магазина выбирает схему и не входит в проверяемый номер
+		{number: "AB79927398713", valid: true},
+		{number: "AB79927398710"},
+		{number: "AB"},
+		// побеждает самый длинный префикс
+		{number: "ABCXYZ123", valid: true},
+		{number: "ABC79927398713"},
+		{number: "XYA0Q", valid: true},
+		{number: "XYA0B"},
+		// номер без префикса проверяется схемой по умолчанию целиком
+		{number: "1234", valid: true},
+		{number: "12345"},
+	})
+}
+
+func TestSetWithoutDefault(t *testing.T) {
+	s, err := Parse([]string{"AB=luhn"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	checkValidator(t, s, []validatorTest{
+		{number: "AB79927398713", valid: true},
+		{number: "79927398713"},
+	})
+}
+
+func TestParse(t *testing.T) {
+	s, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	checkValidator(t, s, []validatorTest{
+		{number: "79927398713", valid: true},
+		{number: "79927398710"},
+	})
+
+	tests := []struct {
+		entries []string
+		wantErr error
+	}{
+		{entries: []string{"crc32"}, wantErr: ErrUnknownScheme},
+		{entries: []string{"AB=regex"}, wantErr: ErrInvalidScheme},
+		{entries: []string{"regex:("}, wantErr: ErrInvalidScheme},
+		{entries: []string{"prefix-luhn"}, wantErr: ErrInvalidScheme},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(tt.entries); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.entries, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const adjustmentColumns = `id, user_id, amount, reason, note, status, coalesce(operator_id, 0), coalesce(approver_id, 0),
+	coalesce(reversal_of, 0), created_at, resolved_at`
+
+// AddAdjustment сохраняет ручное изменение баланса. Если изменение сразу применяется,
+// в той же транзакции изменяется текущий баланс пользователя.
+func (s *Storage) AddAdjustment(
+	ctx context.Context,
+	adj models.BalanceAdjustment,
+) (models.BalanceAdjustment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	adj, err = insertAdjustment(ctx, tx, adj)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	if adj.Status == models.AdjustmentApplied {
+		if err := s.changeBalance(ctx, tx, adj.UserID, adj.Amount, models.LotSourceAdjustment, adjustmentReference(adj.ID)); err != nil {
+			return models.BalanceAdjustment{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot commit balance adjustment: %w", err)
+	}
+
+	return adj, nil
+}
+
+func (s *Storage) GetAdjustment(ctx context.Context, id int64) (models.BalanceAdjustment, error) {
+	q := "SELECT " + adjustmentColumns + " FROM balance_adjustments WHERE id = $1"
+
+	adj, err := scanAdjustment(s.pool.QueryRow(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.BalanceAdjustment{}, models.ErrNotFound
+		}
+
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot get balance adjustment: %w", err)
+	}
+
+	return adj, nil
+}
+
+// GetAdjustments возвращает ручные изменения баланса пользователя в порядке создания.
+// Если statuses не пустой, возвращаются только изменения в указанных состояниях.
+func (s *Storage) GetAdjustments(
+	ctx context.Context,
+	userID int,
+	statuses ...models.AdjustmentStatus,
+) ([]models.BalanceAdjustment, error) {
+	q := "SELECT " + adjustmentColumns + ` FROM balance_adjustments
+			WHERE user_id = $1 AND (cardinality($2::text[]) = 0 OR status = ANY($2))
+			ORDER BY created_at, id`
+
+	filter := make([]string, 0, len(statuses))
+	for _, st := range statuses {
+		filter = append(filter, string(st))
+	}
+
+	rows, err := s.pool.Query(ctx, q, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get balance adjustments: %w", err)
+	}
+
+	defer rows.Close()
+
+	adjustments := make([]models.BalanceAdjustment, 0)
+
+	for rows.Next() {
+		adj, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan balance adjustment: %w", err)
+		}
+
+		adjustments = append(adjustments, adj)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get balance adjustments: %w", err)
+	}
+
+	if len(adjustments) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return adjustments, nil
+}
+
+// ResolveAdjustment подтверждает или отклоняет изменение, ожидающее подтверждения.
+// При подтверждении в той же транзакции изменяется текущий баланс пользователя, а отменённое
+// изменение, если подтверждается отмена, помечается отменённым.
+// Если изменение уже не ожидает подтверждения, возвращается models.ErrConflict.
+func (s *Storage) ResolveAdjustment(
+	ctx context.Context,
+	id int64,
+	approverID int,
+	approve bool,
+) (models.BalanceAdjustment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	adj, err := lockAdjustment(ctx, tx, id)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	if adj.Status != models.AdjustmentPending {
+		return models.BalanceAdjustment{}, models.ErrConflict
+	}
+
+	status := models.AdjustmentRejected
+
+	if approve {
+		if adj.ReversalOf != 0 {
+			if err := markReversed(ctx, tx, adj.ReversalOf); err != nil {
+				return models.BalanceAdjustment{}, err
+			}
+		}
+
+		if err := s.changeBalance(ctx, tx, adj.UserID, adj.Amount, models.LotSourceAdjustment, adjustmentReference(adj.ID)); err != nil {
+			return models.BalanceAdjustment{}, err
+		}
+
+		status = models.AdjustmentApplied
+	}
+
+	q := "UPDATE balance_adjustments SET status = $1, approver_id = $2, resolved_at = now() WHERE id = $3 RETURNING " +
+		adjustmentColumns
+
+	adj, err = scanAdjustment(tx.QueryRow(ctx, q, status, approverID, id))
+	if err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot update balance adjustment: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot commit balance adjustment: %w", err)
+	}
+
+	return adj, nil
+}
+
+// ReverseAdjustment отменяет применённое изменение. Отменяющая запись сохраняется в состоянии status.
+// Применённая отмена в одной транзакции возвращает баланс к прежнему значению и помечает исходное
+// изменение отменённым. Отмена, ожидающая подтверждения, баланс не меняет: исходное изменение
+// отменяется при её подтверждении.
+// Отменить можно только применённое изменение, которое само не является отменой и для которого
+// нет другой отмены, ожидающей подтверждения.
+func (s *Storage) ReverseAdjustment(
+	ctx context.Context,
+	id int64,
+	operatorID int,
+	note string,
+	status models.AdjustmentStatus,
+) (models.BalanceAdjustment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	// блокировка исходного изменения не даёт создать две отмены одновременно
+	orig, err := lockAdjustment(ctx, tx, id)
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	if orig.Status != models.AdjustmentApplied || orig.ReversalOf != 0 {
+		return models.BalanceAdjustment{}, models.ErrConflict
+	}
+
+	var pending bool
+
+	q := "SELECT EXISTS (SELECT 1 FROM balance_adjustments WHERE reversal_of = $1 AND status = $2)"
+
+	if err := tx.QueryRow(ctx, q, id, models.AdjustmentPending).Scan(&pending); err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot get balance adjustment reversal: %w", err)
+	}
+
+	if pending {
+		return models.BalanceAdjustment{}, models.ErrConflict
+	}
+
+	reversal, err := insertAdjustment(ctx, tx, models.BalanceAdjustment{
+		UserID:     orig.UserID,
+		Amount:     -orig.Amount,
+		Reason:     models.AdjustmentReasonReversal,
+		Note:       note,
+		Status:     status,
+		OperatorID: operatorID,
+		ReversalOf: orig.ID,
+	})
+	if err != nil {
+		return models.BalanceAdjustment{}, err
+	}
+
+	if status == models.AdjustmentApplied {
+		if err := markReversed(ctx, tx, orig.ID); err != nil {
+			return models.BalanceAdjustment{}, err
+		}
+
+		if err := s.changeBalance(ctx, tx, orig.UserID, -orig.Amount, models.LotSourceAdjustment,
+			adjustmentReference(reversal.ID)); err != nil {
+			return models.BalanceAdjustment{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot commit balance adjustment: %w", err)
+	}
+
+	return reversal, nil
+}
+
+// markReversed помечает применённое изменение отменённым. Если изменение уже не применено,
+// например отменено другой записью, возвращается models.ErrConflict.
+func markReversed(ctx context.Context, tx pgx.Tx, id int64) error {
+	q := "UPDATE balance_adjustments SET status = $1 WHERE id = $2 AND status = $3"
+
+	tag, err := tx.Exec(ctx, q, models.AdjustmentReversed, id, models.AdjustmentApplied)
+	if err != nil {
+		return fmt.Errorf("cannot update balance adjustment: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+
+	return nil
+}
+
+func insertAdjustment(ctx context.Context, tx pgx.Tx, adj models.BalanceAdjustment) (models.BalanceAdjustment, error) {
+	q := `INSERT INTO balance_adjustments (user_id, amount, reason, note, status, operator_id, reversal_of, created_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0), NULLIF($7, 0), now())
+			RETURNING ` + adjustmentColumns
+
+	adj, err := scanAdjustment(tx.QueryRow(ctx, q,
+		adj.UserID, adj.Amount, adj.Reason, adj.Note, adj.Status, adj.OperatorID, adj.ReversalOf))
+	if err != nil {
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot insert balance adjustment: %w", err)
+	}
+
+	return adj, nil
+}
+
+func lockAdjustment(ctx context.Context, tx pgx.Tx, id int64) (models.BalanceAdjustment, error) {
+	q := "SELECT " + adjustmentColumns + " FROM balance_adjustments WHERE id = $1 FOR UPDATE"
+
+	adj, err := scanAdjustment(tx.QueryRow(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.BalanceAdjustment{}, models.ErrNotFound
+		}
+
+		return models.BalanceAdjustment{}, fmt.Errorf("cannot get balance adjustment: %w", err)
+	}
+
+	return adj, nil
+}
+
+func scanAdjustment(row pgx.Row) (models.BalanceAdjustment, error) {
+	adj := models.BalanceAdjustment{}
+
+	err := row.Scan(&adj.ID, &adj.UserID, &adj.Amount, &adj.Reason, &adj.Note, &adj.Status,
+		&adj.OperatorID, &adj.ApproverID, &adj.ReversalOf, &adj.CreatedAt, &adj.ResolvedAt)
+
+	return adj, err
+}
+
+// changeBalance изменяет текущий баланс пользователя на amount копеек внутри транзакции.
+// Зачисление сохраняется партией баллов с источником source, списание расходует партии начиная с самой ранней.
+// Изменение записывается в журнал движений баланса с типом source.
+// Если списание превышает доступный баланс без удержанных баллов, возвращается models.ErrInsufficientBalance.
+func (s *Storage) changeBalance(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID, amount int,
+	source, reference string,
+) error {
+	current, err := updateBalance(ctx, tx, userID, amount, source, reference)
+	if err != nil {
+		return err
+	}
+
+	if amount < 0 {
+		_, err = consumeLots(ctx, tx, userID, -amount)
+
+		return err
+	}
+
+	return s.addLot(ctx, tx, userID, amount, current, source, reference, s.lotExpiry())
+}
+
+// updateBalance блокирует баланс пользователя, изменяет его на amount копеек и записывает изменение
+// в журнал движений баланса с типом source. Партии баллов не меняются. Возвращает баланс до изменения.
+// Если списание превышает доступный баланс без удержанных баллов, возвращается models.ErrInsufficientBalance.
+func updateBalance(ctx context.Context, tx pgx.Tx, userID, amount int, source, reference string) (int, error) {
+	q := "INSERT INTO balance (user_id, current, withdraw) VALUES ($1, 0, 0) ON CONFLICT (user_id) DO NOTHING"
+
+	_, err := tx.Exec(ctx, q, userID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot init balance: %w", err)
+	}
+
+	var current, held int
+
+	q = "SELECT coalesce(current, 0), held FROM balance WHERE user_id = $1 FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, userID).Scan(&current, &held)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get balance: %w", err)
+	}
+
+	// удержанные баллы списать нельзя
+	if amount < 0 && current-held+amount < 0 {
+		return 0, models.ErrInsufficientBalance
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE balance SET current = $1 WHERE user_id = $2", current+amount, userID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:    userID,
+		Kind:      source,
+		Amount:    amount,
+		Reference: reference,
+		Balance:   current + amount,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// adjustmentReference ссылка на изменение баланса в партии баллов.
+func adjustmentReference(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+func addTestAdjustment(t *testing.T, s *Storage, userID, amount int, status models.AdjustmentStatus) models.BalanceAdjustment {
+	t.Helper()
+
+	adj, err := s.AddAdjustment(context.Background(), models.BalanceAdjustment{
+		UserID:     userID,
+		Amount:     amount,
+		Reason:     models.AdjustmentReasonGoodwill,
+		Status:     status,
+		OperatorID: userID,
+	})
+	if err != nil {
+		t.Fatalf("AddAdjustment: %v", err)
+	}
+
+	return adj
+}
+
+func testAdjustmentStatus(t *testing.T, s *Storage, id int64) models.AdjustmentStatus {
+	t.Helper()
+
+	adj, err := s.GetAdjustment(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetAdjustment: %v", err)
+	}
+
+	return adj.Status
+}
+
+func TestAdjustment(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	op := addTestUser(t, s, "operator")
+
+	applied := addTestAdjustment(t, s, u.ID, 1000, models.AdjustmentApplied)
+	pending := addTestAdjustment(t, s, u.ID, 50000, models.AdjustmentPending)
+
+	if b := testBalance(t, s, u.ID); b.Current != 1000 {
+		t.Fatalf("balance = %d, want 1000: pending adjustment must not change balance", b.Current)
+	}
+
+	if _, err := addTestAdjustmentErr(s, u.ID, -2000); !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Fatalf("overdraft: got %v, want ErrInsufficientBalance", err)
+	}
+
+	adj, err := s.ResolveAdjustment(ctx, pending.ID, op.ID, true)
+	if err != nil {
+		t.Fatalf("ResolveAdjustment: %v", err)
+	}
+
+	if adj.Status != models.AdjustmentApplied || adj.ApproverID != op.ID {
+		t.Errorf("approved adjustment = %+v", adj)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 51000 {
+		t.Fatalf("balance = %d, want 51000", b.Current)
+	}
+
+	if _, err := s.ResolveAdjustment(ctx, pending.ID, op.ID, true); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("second approval: got %v, want ErrConflict", err)
+	}
+
+	reversal, err := s.ReverseAdjustment(ctx, applied.ID, op.ID, "mistake", models.AdjustmentApplied)
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if reversal.Amount != -1000 || reversal.ReversalOf != applied.ID || reversal.Reason != models.AdjustmentReasonReversal {
+		t.Errorf("reversal = %+v", reversal)
+	}
+
+	if st := testAdjustmentStatus(t, s, applied.ID); st != models.AdjustmentReversed {
+		t.Errorf("reversed adjustment status = %s", st)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 50000 {
+		t.Fatalf("balance = %d, want 50000", b.Current)
+	}
+
+	if _, err := s.ReverseAdjustment(ctx, applied.ID, op.ID, "", models.AdjustmentApplied); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("second reversal: got %v, want ErrConflict", err)
+	}
+
+	if _, err := s.ReverseAdjustment(ctx, reversal.ID, op.ID, "", models.AdjustmentApplied); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("reversal of reversal: got %v, want ErrConflict", err)
+	}
+}
+
+func addTestAdjustmentErr(s *Storage, userID, amount int) (models.BalanceAdjustment, error) {
+	return s.AddAdjustment(context.Background(), models.BalanceAdjustment{
+		UserID: userID,
+		Amount: amount,
+		Reason: models.AdjustmentReasonCorrection,
+		Status: models.AdjustmentApplied,
+	})
+}
+
+func TestPendingReversal(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	op := addTestUser(t, s, "operator")
+	approver := addTestUser(t, s, "approver")
+	large := addTestAdjustment(t, s, u.ID, 100000, models.AdjustmentApplied)
+
+	reversal, err := s.ReverseAdjustment(ctx, large.ID, op.ID, "", models.AdjustmentPending)
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if reversal.Status != models.AdjustmentPending {
+		t.Fatalf("reversal status = %s, want pending", reversal.Status)
+	}
+
+	// до подтверждения баланс и исходное изменение не меняются
+	if b := testBalance(t, s, u.ID); b.Current != 100000 {
+		t.Fatalf("balance = %d, want 100000", b.Current)
+	}
+
+	if st := testAdjustmentStatus(t, s, large.ID); st != models.AdjustmentApplied {
+		t.Fatalf("adjustment status = %s, want applied", st)
+	}
+
+	if _, err := s.ReverseAdjustment(ctx, large.ID, op.ID, "", models.AdjustmentPending); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("second pending reversal: got %v, want ErrConflict", err)
+	}
+
+	if _, err := s.ResolveAdjustment(ctx, reversal.ID, approver.ID, false); err != nil {
+		t.Fatalf("reject reversal: %v", err)
+	}
+
+	if st := testAdjustmentStatus(t, s, large.ID); st != models.AdjustmentApplied {
+		t.Fatalf("adjustment status after rejected reversal = %s, want applied", st)
+	}
+
+	// после отклонения отмену можно запросить снова
+	reversal, err = s.ReverseAdjustment(ctx, large.ID, op.ID, "", models.AdjustmentPending)
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if _, err := s.ResolveAdjustment(ctx, reversal.ID, approver.ID, true); err != nil {
+		t.Fatalf("approve reversal: %v", err)
+	}
+
+	if st := testAdjustmentStatus(t, s, large.ID); st != models.AdjustmentReversed {
+		t.Errorf("adjustment status = %s, want reversed", st)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 0 {
+		t.Errorf("balance = %d, want 0", b.Current)
+	}
+}
+
+func TestPendingReversalOfReversedAdjustment(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	op := addTestUser(t, s, "operator")
+	approver := addTestUser(t, s, "approver")
+	adj := addTestAdjustment(t, s, u.ID, 100000, models.AdjustmentApplied)
+
+	pending, err := s.ReverseAdjustment(ctx, adj.ID, op.ID, "", models.AdjustmentPending)
+	if err != nil {
+		t.Fatalf("ReverseAdjustment: %v", err)
+	}
+
+	if _, err := s.ReverseAdjustment(ctx, adj.ID, op.ID, "", models.AdjustmentApplied); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("reversal while another one is pending: got %v, want ErrConflict", err)
+	}
+
+	if _, err := s.ResolveAdjustment(ctx, pending.ID, approver.ID, true); err != nil {
+		t.Fatalf("approve reversal: %v", err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 0 {
+		t.Errorf("balance = %d, want 0: adjustment must be reversed once", b.Current)
+	}
+}
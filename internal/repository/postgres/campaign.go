@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const campaignColumns = `id, name, kind, value, starts_at, ends_at, first_order, min_accrual, channel, active,
+	coalesce(created_by, 0), created_at`
+
+func (s *Storage) AddCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error) {
+	q := `INSERT INTO campaigns (name, kind, value, starts_at, ends_at, first_order, min_accrual, channel, active,
+				created_by, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), now())
+			RETURNING ` + campaignColumns
+
+	c, err := scanCampaign(s.pool.QueryRow(ctx, q, c.Name, c.Kind, c.Value, c.StartsAt, c.EndsAt, c.FirstOrder,
+		c.MinAccrual, c.Channel, c.Active, c.CreatedBy))
+	if err != nil {
+		return models.Campaign{}, fmt.Errorf("cannot insert campaign: %w", err)
+	}
+
+	return c, nil
+}
+
+// UpdateCampaign изменяет условия акции. Уже начисленные бонусы не пересчитываются.
+func (s *Storage) UpdateCampaign(ctx context.Context, c models.Campaign) (models.Campaign, error) {
+	q := `UPDATE campaigns SET name = $1, kind = $2, value = $3, starts_at = $4, ends_at = $5, first_order = $6,
+				min_accrual = $7, channel = $8, active = $9
+			WHERE id = $10
+			RETURNING ` + campaignColumns
+
+	c, err := scanCampaign(s.pool.QueryRow(ctx, q, c.Name, c.Kind, c.Value, c.StartsAt, c.EndsAt, c.FirstOrder,
+		c.MinAccrual, c.Channel, c.Active, c.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Campaign{}, models.ErrNotFound
+		}
+
+		return models.Campaign{}, fmt.Errorf("cannot update campaign: %w", err)
+	}
+
+	return c, nil
+}
+
+// GetCampaigns возвращает акции в порядке создания, только включённые при activeOnly.
+func (s *Storage) GetCampaigns(ctx context.Context, activeOnly bool) ([]models.Campaign, error) {
+	q := "SELECT " + campaignColumns + " FROM campaigns WHERE active OR NOT $1 ORDER BY id"
+
+	rows, err := s.pool.Query(ctx, q, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get campaigns: %w", err)
+	}
+
+	defer rows.Close()
+
+	campaigns := make([]models.Campaign, 0)
+
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan campaign: %w", err)
+		}
+
+		campaigns = append(campaigns, c)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// CountProcessedOrders возвращает количество обработанных заказов пользователя.
+func (s *Storage) CountProcessedOrders(ctx context.Context, userID int) (int, error) {
+	q := "SELECT count(*) FROM orders WHERE user_id = $1 AND status = 'PROCESSED'"
+
+	var count int
+
+	if err := s.pool.QueryRow(ctx, q, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("cannot count processed orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// campaignBonuses возвращает бонусы акций за обработанный заказ orderNumber пользователя userID.
+// Баланс пользователя блокируется до конца транзакции, поэтому заказы пользователя, обработанные
+// одновременно, считают уже обработанные заказы по очереди и бонус за первый заказ получает только один.
+func campaignBonuses(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int,
+	orderNumber string,
+	campaigns models.CampaignAccrual,
+) ([]models.CampaignBonus, error) {
+	if err := lockBalances(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	var processed int
+
+	q := "SELECT count(*) FROM orders WHERE user_id = $1 AND status = 'PROCESSED' AND order_number <> $2"
+
+	if err := tx.QueryRow(ctx, q, userID, orderNumber).Scan(&processed); err != nil {
+		return nil, fmt.Errorf("cannot count processed orders: %w", err)
+	}
+
+	order := campaigns.Order
+	order.UserID = userID
+	order.Number = orderNumber
+	order.ProcessedOrders = processed
+
+	return models.CampaignBonuses(campaigns.Campaigns, order), nil
+}
+
+func scanCampaign(row pgx.Row) (models.Campaign, error) {
+	c := models.Campaign{}
+
+	err := row.Scan(&c.ID, &c.Name, &c.Kind, &c.Value, &c.StartsAt, &c.EndsAt, &c.FirstOrder, &c.MinAccrual,
+		&c.Channel, &c.Active, &c.CreatedBy, &c.CreatedAt)
+
+	return c, err
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+func addTestCampaign(t *testing.T, s *Storage, c models.Campaign) models.Campaign {
+	t.Helper()
+
+	c.Active = true
+
+	c, err := s.AddCampaign(context.Background(), c)
+	if err != nil {
+		t.Fatalf("AddCampaign: %v", err)
+	}
+
+	return c
+}
+
+func testCampaignAccrual(campaigns []models.Campaign, accrual int) models.CampaignAccrual {
+	return models.CampaignAccrual{
+		Campaigns: campaigns,
+		Order:     models.CampaignOrder{Accrual: accrual, UploadedAt: time.Now()},
+	}
+}
+
+func TestAccrueOrderCampaigns(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	campaigns := []models.Campaign{
+		addTestCampaign(t, s, models.Campaign{Name: "first order", Kind: models.CampaignFixed, Value: 1, FirstOrder: true}),
+		addTestCampaign(t, s, models.Campaign{Name: "double", Kind: models.CampaignPercent, Value: 100}),
+	}
+
+	addTestOrder(t, s, u.ID, "79927398713")
+	addTestOrder(t, s, u.ID, "12345678903")
+
+	bonuses, err := s.AccrueOrder(ctx, "79927398713", processedStatusOrder, 500, testCampaignAccrual(campaigns, 500))
+	if err != nil {
+		t.Fatalf("AccrueOrder: %v", err)
+	}
+
+	if len(bonuses) != 2 || bonuses[0].Amount != 100 || bonuses[1].Amount != 500 {
+		t.Errorf("first order bonuses = %+v, want 100 and 500", bonuses)
+	}
+
+	bonuses, err = s.AccrueOrder(ctx, "12345678903", processedStatusOrder, 300, testCampaignAccrual(campaigns, 300))
+	if err != nil {
+		t.Fatalf("AccrueOrder: %v", err)
+	}
+
+	if len(bonuses) != 1 || bonuses[0].CampaignID != campaigns[1].ID || bonuses[0].Amount != 300 {
+		t.Errorf("second order bonuses = %+v, want only 300 of double points", bonuses)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 1700 {
+		t.Errorf("balance = %d, want 1700", b.Current)
+	}
+
+	// заказ в конечном статусе повторно не начисляется
+	bonuses, err = s.AccrueOrder(ctx, "12345678903", processedStatusOrder, 300, testCampaignAccrual(campaigns, 300))
+	if err != nil || len(bonuses) != 0 {
+		t.Errorf("repeated accrual: %+v, %v", bonuses, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 1700 {
+		t.Errorf("balance after repeated accrual = %d, want 1700", b.Current)
+	}
+}
+
+// TestFirstOrderBonusOnce проверяет, что из одновременно обработанных заказов пользователя бонус
+// за первый заказ получает только один.
+func TestFirstOrderBonusOnce(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	campaigns := []models.Campaign{
+		addTestCampaign(t, s, models.Campaign{Name: "first order", Kind: models.CampaignFixed, Value: 1, FirstOrder: true}),
+	}
+
+	const orders = 10
+
+	for i := 0; i < orders; i++ {
+		addTestOrder(t, s, u.ID, fmt.Sprintf("order-%d", i))
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		granted int
+	)
+
+	for i := 0; i < orders; i++ {
+		wg.Add(1)
+
+		go func(number string) {
+			defer wg.Done()
+
+			bonuses, err := s.AccrueOrder(ctx, number, processedStatusOrder, 100, testCampaignAccrual(campaigns, 100))
+			if err != nil {
+				t.Errorf("AccrueOrder: %v", err)
+
+				return
+			}
+
+			mu.Lock()
+			granted += len(bonuses)
+			mu.Unlock()
+		}(fmt.Sprintf("order-%d", i))
+	}
+
+	wg.Wait()
+
+	if granted != 1 {
+		t.Errorf("first order bonuses granted = %d, want 1", granted)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != orders*100+100 {
+		t.Errorf("balance = %d, want %d", b.Current, orders*100+100)
+	}
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	processedStatusOrder = "PROCESSED"
+	revokedStatusOrder   = "REVOKED"
+)
+
+// ClawbackOrder в одной транзакции отзывает начисление по обработанному заказу вместе с бонусами по акциям:
+// списывает их с баланса, переводит заказ в статус REVOKED, сохраняет запись об отзыве и уведомление.
+// Баланс может стать отрицательным, если начисленные баллы уже потрачены.
+// Повторный отзыв того же заказа ничего не меняет и возвращает первую запись об отзыве с created = false.
+func (s *Storage) ClawbackOrder(
+	ctx context.Context,
+	clawback models.OrderClawback,
+	notification models.Notification,
+) (models.OrderClawback, bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var status string
+
+	q := "SELECT user_id, status, coalesce(accrual, 0) FROM orders WHERE order_number = $1 FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, clawback.Order).Scan(&clawback.UserID, &status, &clawback.Amount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrderClawback{}, false, models.ErrNotFound
+		}
+
+		return models.OrderClawback{}, false, fmt.Errorf("cannot get order: %w", err)
+	}
+
+	// заказ заблокирован, поэтому параллельный отзыв дождётся завершения транзакции и увидит запись
+	q = `SELECT id, order_number, user_id, amount, debt, reason, coalesce(operator_id, 0), created_at
+			FROM order_clawbacks WHERE order_number = $1`
+
+	existing := models.OrderClawback{}
+
+	err = tx.QueryRow(ctx, q, clawback.Order).Scan(&existing.ID, &existing.Order, &existing.UserID,
+		&existing.Amount, &existing.Debt, &existing.Reason, &existing.OperatorID, &existing.CreatedAt)
+	if err == nil {
+		return existing, false, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot get order clawback: %w", err)
+	}
+
+	if status != processedStatusOrder {
+		return models.OrderClawback{}, false, models.ErrConflict
+	}
+
+	var bonuses int
+
+	q = "SELECT coalesce(sum(amount), 0) FROM campaign_bonuses WHERE order_number = $1"
+
+	if err := tx.QueryRow(ctx, q, clawback.Order).Scan(&bonuses); err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot get campaign bonuses: %w", err)
+	}
+
+	clawback.Amount += bonuses
+
+	q = "INSERT INTO balance (user_id, current, withdraw) VALUES ($1, 0, 0) ON CONFLICT (user_id) DO NOTHING"
+
+	_, err = tx.Exec(ctx, q, clawback.UserID)
+	if err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot init balance: %w", err)
+	}
+
+	var current int
+
+	q = "UPDATE balance SET current = coalesce(current, 0) - $1 WHERE user_id = $2 RETURNING current"
+
+	err = tx.QueryRow(ctx, q, clawback.Amount, clawback.UserID).Scan(&current)
+	if err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	clawback.Debt = negativePart(current) - negativePart(current+clawback.Amount)
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:  clawback.UserID,
+		Kind:    models.TransactionClawback,
+		Amount:  -clawback.Amount,
+		Order:   clawback.Order,
+		Balance: current,
+	})
+	if err != nil {
+		return models.OrderClawback{}, false, err
+	}
+
+	if _, err := consumeLots(ctx, tx, clawback.UserID, clawback.Amount); err != nil {
+		return models.OrderClawback{}, false, err
+	}
+
+	if err := shrinkHolds(ctx, tx, clawback.UserID, current); err != nil {
+		return models.OrderClawback{}, false, err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE orders SET status = $1 WHERE order_number = $2", revokedStatusOrder, clawback.Order)
+	if err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot update order: %w", err)
+	}
+
+	err = insertEvent(ctx, tx, models.UserEvent{
+		UserID: clawback.UserID,
+		Kind:   models.EventOrderStatus,
+		Order:  clawback.Order,
+		Status: revokedStatusOrder,
+	})
+	if err != nil {
+		return models.OrderClawback{}, false, err
+	}
+
+	q = `INSERT INTO order_clawbacks (order_number, user_id, amount, debt, reason, operator_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0), now()) RETURNING id, created_at`
+
+	err = tx.QueryRow(ctx, q, clawback.Order, clawback.UserID, clawback.Amount, clawback.Debt,
+		clawback.Reason, clawback.OperatorID).Scan(&clawback.ID, &clawback.CreatedAt)
+	if err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot insert order clawback: %w", err)
+	}
+
+	notification.UserID = clawback.UserID
+
+	if err := insertNotification(ctx, tx, notification); err != nil {
+		return models.OrderClawback{}, false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.OrderClawback{}, false, fmt.Errorf("cannot commit order clawback: %w", err)
+	}
+
+	return clawback, true, nil
+}
+
+func negativePart(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return 0
+}
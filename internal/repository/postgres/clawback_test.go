@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+func testClawback(t *testing.T, s *Storage, number string) (models.OrderClawback, bool) {
+	t.Helper()
+
+	c, created, err := s.ClawbackOrder(context.Background(), models.OrderClawback{Order: number, Reason: "order revoked"},
+		models.Notification{Kind: models.NotificationAccrualRevoked, Message: "revoked"})
+	if err != nil {
+		t.Fatalf("ClawbackOrder: %v", err)
+	}
+
+	return c, created
+}
+
+func TestClawbackOrder(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 700)
+	addTestAccrual(t, s, u.ID, "12345678903", 300)
+
+	c, created := testClawback(t, s, "79927398713")
+	if !created || c.Amount != 700 || c.Debt != 0 || c.UserID != u.ID {
+		t.Fatalf("clawback = %+v, created = %v", c, created)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 300 {
+		t.Errorf("balance = %d, want 300", b.Current)
+	}
+
+	if status := testOrderStatus(t, s, "79927398713"); status != revokedStatusOrder {
+		t.Errorf("order status = %s, want REVOKED", status)
+	}
+
+	// повторный отзыв возвращает первую запись и не меняет баланс
+	again, created := testClawback(t, s, "79927398713")
+	if created || again.ID != c.ID {
+		t.Errorf("repeated clawback = %+v, created = %v", again, created)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 300 {
+		t.Errorf("balance after repeated clawback = %d, want 300", b.Current)
+	}
+
+	notifications, err := s.GetNotifications(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetNotifications: %v", err)
+	}
+
+	if len(notifications) != 1 || notifications[0].Kind != models.NotificationAccrualRevoked {
+		t.Errorf("notifications = %+v, want one accrual revoked", notifications)
+	}
+}
+
+func TestClawbackSpentAccrual(t *testing.T) {
+	s := newTestStorage(t)
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 800)
+
+	c, _ := testClawback(t, s, "79927398713")
+	if c.Amount != 1000 || c.Debt != 800 {
+		t.Errorf("clawback = %+v, want amount 1000, debt 800", c)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != -800 {
+		t.Errorf("balance = %d, want -800", b.Current)
+	}
+
+	// долг погашается следующим начислением
+	addTestAccrual(t, s, u.ID, "12345678903", 500)
+
+	if b := testBalance(t, s, u.ID); b.Current != -300 {
+		t.Errorf("balance after accrual = %d, want -300", b.Current)
+	}
+}
+
+// TestClawbackShrinksHolds проверяет, что после отзыва удержано не больше, чем осталось на балансе.
+func TestClawbackShrinksHolds(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 700)
+	addTestAccrual(t, s, u.ID, "12345678903", 300)
+
+	older := addTestHold(t, s, u.ID, "2377225624", 200)
+	newer := addTestHold(t, s, u.ID, "2377225632", 400)
+	latest := addTestHold(t, s, u.ID, "4561261212345467", 100)
+
+	testClawback(t, s, "79927398713")
+
+	if b := testBalance(t, s, u.ID); b.Current != 300 || b.Held != 300 {
+		t.Errorf("balance = %+v, want 300 and 300 held", b)
+	}
+
+	// последнее удержание снято целиком, предыдущее уменьшено, первое не тронуто
+	if _, err := s.ReleaseHold(ctx, u.ID, latest.ID); !errors.Is(err, models.ErrHoldNotActive) {
+		t.Errorf("latest hold: err = %v, want ErrHoldNotActive", err)
+	}
+
+	if h, err := s.ReleaseHold(ctx, u.ID, newer.ID); err != nil || h.Amount != 100 {
+		t.Errorf("newer hold = %+v, %v, want 100", h, err)
+	}
+
+	if h, err := s.ReleaseHold(ctx, u.ID, older.ID); err != nil || h.Amount != 200 {
+		t.Errorf("older hold = %+v, %v, want 200", h, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 300 || b.Held != 0 {
+		t.Errorf("balance after release = %+v, want 300 and nothing held", b)
+	}
+}
+
+func TestClawbackNotProcessedOrder(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestOrder(t, s, u.ID, "79927398713")
+
+	_, _, err := s.ClawbackOrder(ctx, models.OrderClawback{Order: "79927398713", Reason: "r"}, models.Notification{})
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("new order: got %v, want ErrConflict", err)
+	}
+
+	_, _, err = s.ClawbackOrder(ctx, models.OrderClawback{Order: "12345678903", Reason: "r"}, models.Notification{})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown order: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestConcurrentClawback(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	const calls = 10
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, ok, err := s.ClawbackOrder(ctx, models.OrderClawback{Order: "79927398713", Reason: "r"},
+				models.Notification{Kind: models.NotificationAccrualRevoked})
+			if err != nil {
+				t.Errorf("ClawbackOrder: %v", err)
+
+				return
+			}
+
+			if ok {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("created clawbacks = %d, want 1", created)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 0 {
+		t.Errorf("balance = %d, want 0", b.Current)
+	}
+}
+
+func TestNegativePart(t *testing.T) {
+	for v, want := range map[int]int{-5: 5, 0: 0, 7: 0} {
+		if got := negativePart(v); got != want {
+			t.Errorf("negativePart(%d) = %d, want %d", v, got, want)
+		}
+	}
+}
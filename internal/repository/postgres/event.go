@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// eventsChannel канал LISTEN/NOTIFY, в который при сохранении события отправляется id получателя.
+const eventsChannel = "user_events"
+
+// GetEvents возвращает не более limit событий пользователя после события с id after. События пользователя
+// сохраняются под блокировкой его баланса, поэтому позже зафиксированное событие не окажется раньше after.
+func (s *Storage) GetEvents(ctx context.Context, userID int, after int64, limit int) ([]models.UserEvent, error) {
+	q := `SELECT id, user_id, kind, order_number, status, transaction_kind, amount, balance, created_at
+			FROM user_events WHERE user_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q, userID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get events: %w", err)
+	}
+
+	defer rows.Close()
+
+	events := make([]models.UserEvent, 0)
+
+	for rows.Next() {
+		e := models.UserEvent{}
+
+		err = rows.Scan(&e.ID, &e.UserID, &e.Kind, &e.Order, &e.Status, &e.Transaction, &e.Amount, &e.Balance,
+			&e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetLastEventID возвращает id последнего события пользователя, 0 если событий нет.
+func (s *Storage) GetLastEventID(ctx context.Context, userID int) (int64, error) {
+	var id int64
+
+	q := "SELECT coalesce(max(id), 0) FROM user_events WHERE user_id = $1"
+
+	if err := s.pool.QueryRow(ctx, q, userID).Scan(&id); err != nil {
+		return 0, fmt.Errorf("cannot get last event id: %w", err)
+	}
+
+	return id, nil
+}
+
+// DeleteEventsBefore удаляет события, созданные раньше before.
+func (s *Storage) DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, "DELETE FROM user_events WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("cannot delete events: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ListenEvents подписывается на сохранение событий и вызывает fn с id получателя каждого события,
+// в том числе сохранённого другими экземплярами сервиса. listening вызывается, когда подписка оформлена.
+// Соединение с базой занимается на всё время подписки. Возвращается при отмене ctx или потере соединения.
+func (s *Storage) ListenEvents(ctx context.Context, listening func(), fn func(userID int)) error {
+	pc, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot acquire connection: %w", err)
+	}
+
+	// после LISTEN соединение нельзя возвращать в пул, поэтому оно забирается из пула и закрывается
+	conn := pc.Hijack()
+
+	defer conn.Close(context.Background()) //nolint:errcheck
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+		return fmt.Errorf("cannot listen events: %w", err)
+	}
+
+	listening()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("cannot wait event: %w", err)
+		}
+
+		userID, err := strconv.Atoi(n.Payload)
+		if err != nil {
+			s.log.Warn(fmt.Sprintf("unexpected event payload: %s", n.Payload))
+
+			continue
+		}
+
+		fn(userID)
+	}
+}
+
+// insertEvent сохраняет событие пользователя и уведомляет об этом подписчиков. Уведомление
+// доставляется после фиксации транзакции, при откате транзакции оно не отправляется.
+// Перед сохранением блокируется баланс пользователя: события одного пользователя сохраняются по очереди,
+// поэтому id события, зафиксированного позже, больше id уже видимых событий и поток, продолженный
+// с последнего полученного id, их не пропускает. Если транзакция блокирует балансы нескольких
+// пользователей, она должна заблокировать их до сохранения событий.
+func insertEvent(ctx context.Context, tx pgx.Tx, e models.UserEvent) error {
+	if err := lockBalances(ctx, tx, e.UserID); err != nil {
+		return err
+	}
+
+	q := `WITH e AS (
+				INSERT INTO user_events (user_id, kind, order_number, status, transaction_kind, amount, balance, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, now()) RETURNING user_id
+			)
+			SELECT pg_notify($8, user_id::text) FROM e`
+
+	_, err := tx.Exec(ctx, q, e.UserID, e.Kind, e.Order, e.Status, e.Transaction, e.Amount, e.Balance, eventsChannel)
+	if err != nil {
+		return fmt.Errorf("cannot insert event: %w", err)
+	}
+
+	return nil
+}
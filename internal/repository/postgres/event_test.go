@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+// TestEventsOfAccrual проверяет, что начисление сохраняет события статуса заказа и изменения баланса.
+func TestEventsOfAccrual(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	if id, err := s.GetLastEventID(ctx, u.ID); err != nil || id != 0 {
+		t.Fatalf("GetLastEventID without events = %d, %v, want 0", id, err)
+	}
+
+	addTestAccrual(t, s, u.ID, "79927398713", 500)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 200)
+
+	events, err := s.GetEvents(ctx, u.ID, 0, 10)
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+
+	want := []models.UserEvent{
+		{Kind: models.EventOrderStatus, Order: "79927398713", Status: processedStatusOrder, Amount: 500},
+		{Kind: models.EventBalance, Order: "79927398713", Transaction: models.LotSourceAccrual, Amount: 500, Balance: 500},
+		{Kind: models.EventBalance, Order: "2377225624", Transaction: models.TransactionWithdrawal, Amount: -200, Balance: 300},
+	}
+
+	// кроме изменения баланса списание сохраняет собственное событие, его порядок относительно
+	// движения баланса не важен
+	got := make([]models.UserEvent, 0, len(events))
+
+	for _, e := range events {
+		if e.Kind != models.EventWithdrawal {
+			got = append(got, e)
+		}
+	}
+
+	if len(got) != len(want) || len(events) != len(want)+1 {
+		t.Fatalf("events = %+v", events)
+	}
+
+	for i, w := range want {
+		g := got[i]
+		if g.UserID != u.ID || g.Kind != w.Kind || g.Order != w.Order || g.Status != w.Status ||
+			g.Transaction != w.Transaction || g.Amount != w.Amount || g.Balance != w.Balance {
+			t.Errorf("event %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	last, err := s.GetLastEventID(ctx, u.ID)
+	if err != nil || last != events[len(events)-1].ID {
+		t.Errorf("GetLastEventID = %d, %v, want %d", last, err, events[len(events)-1].ID)
+	}
+
+	page, err := s.GetEvents(ctx, u.ID, events[0].ID, 2)
+	if err != nil || len(page) != 2 || page[0].ID != events[1].ID {
+		t.Errorf("page after the first event = %+v, %v", page, err)
+	}
+
+	if other, err := s.GetEvents(ctx, u.ID+100, 0, 10); err != nil || len(other) != 0 {
+		t.Errorf("events of another user = %+v, %v", other, err)
+	}
+}
+
+func TestDeleteEventsBefore(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 500)
+
+	if deleted, err := s.DeleteEventsBefore(ctx, time.Now().Add(-time.Hour)); err != nil || deleted != 0 {
+		t.Errorf("DeleteEventsBefore an hour ago = %d, %v, want 0", deleted, err)
+	}
+
+	if deleted, err := s.DeleteEventsBefore(ctx, time.Now().Add(time.Minute)); err != nil || deleted != 2 {
+		t.Errorf("DeleteEventsBefore now = %d, %v, want 2", deleted, err)
+	}
+
+	if events, err := s.GetEvents(ctx, u.ID, 0, 10); err != nil || len(events) != 0 {
+		t.Errorf("events after cleanup = %+v, %v", events, err)
+	}
+}
+
+// TestEventsCommitOrder проверяет, что событие, сохранённое одновременно с ещё не зафиксированным
+// событием того же пользователя, получает больший id и не пропускается потоком, продолженным с последнего id.
+func TestEventsCommitOrder(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestOrder(t, s, u.ID, "79927398713")
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("cannot begin transaction: %v", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := insertEvent(ctx, tx, models.UserEvent{UserID: u.ID, Kind: models.EventBalance}); err != nil {
+		t.Fatalf("insertEvent: %v", err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.SetOrderStatus(ctx, "79927398713", "PROCESSING", testFinalStatuses)
+	}()
+
+	// изменение статуса ждёт фиксации первой транзакции
+	select {
+	case err := <-done:
+		t.Fatalf("SetOrderStatus finished before the first event was committed: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if events, err := s.GetEvents(ctx, u.ID, 0, 10); err != nil || len(events) != 0 {
+		t.Fatalf("events before commit = %+v, %v", events, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("cannot commit: %v", err)
+	}
+
+	first, err := s.GetEvents(ctx, u.ID, 0, 10)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("events after commit = %+v, %v", first, err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("SetOrderStatus: %v", err)
+	}
+
+	next, err := s.GetEvents(ctx, u.ID, first[0].ID, 10)
+	if err != nil || len(next) != 1 || next[0].Kind != models.EventOrderStatus {
+		t.Errorf("events after the first one = %+v, %v", next, err)
+	}
+}
+
+// TestListenEvents проверяет, что подписчик получает id пользователя, для которого сохранено событие.
+func TestListenEvents(t *testing.T) {
+	s := newTestStorage(t)
+
+	u := addTestUser(t, s, "alice")
+	addTestOrder(t, s, u.ID, "79927398713")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listening := make(chan struct{})
+	notified := make(chan int, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.ListenEvents(ctx, func() { close(listening) }, func(userID int) { notified <- userID })
+	}()
+
+	select {
+	case <-listening:
+	case err := <-done:
+		t.Fatalf("ListenEvents: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not subscribe")
+	}
+
+	if _, err := s.AccrueOrder(context.Background(), "79927398713", processedStatusOrder, 500, models.CampaignAccrual{}); err != nil {
+		t.Fatalf("AccrueOrder: %v", err)
+	}
+
+	select {
+	case userID := <-notified:
+		if userID != u.ID {
+			t.Errorf("notified user = %d, want %d", userID, u.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification after the accrual")
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ListenEvents after cancel: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not stop")
+	}
+}
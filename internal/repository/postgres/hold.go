@@ -0,0 +1,380 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const holdColumns = `id, user_id, order_number, amount, captured, status, created_at, expires_at, resolved_at`
+
+// CreateHold удерживает баллы пользователя: уменьшает доступный баланс, не изменяя текущий.
+// Если доступных баллов не хватает, возвращается models.ErrInsufficientBalance.
+func (s *Storage) CreateHold(ctx context.Context, hold models.PointHold) (models.PointHold, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var available int
+
+	q := "SELECT coalesce(current, 0) - held FROM balance WHERE user_id = $1 FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, hold.UserID).Scan(&available)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PointHold{}, models.ErrInsufficientBalance
+		}
+
+		return models.PointHold{}, fmt.Errorf("cannot get balance: %w", err)
+	}
+
+	if available < hold.Amount {
+		return models.PointHold{}, models.ErrInsufficientBalance
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE balance SET held = held + $1 WHERE user_id = $2", hold.Amount, hold.UserID)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	q = `INSERT INTO point_holds (user_id, order_number, amount, status, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, now(), $5) RETURNING ` + holdColumns
+
+	hold, err = scanHold(tx.QueryRow(ctx, q, hold.UserID, hold.Order, hold.Amount, models.HoldActive, hold.ExpiresAt))
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot insert hold: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot commit hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+// CaptureHold списывает sum удержанных баллов в оплату заказа так же, как Withdraw, и снимает удержание
+// с остатка. Нулевая сумма списывает всё удержание. Снятое или просроченное удержание
+// возвращает models.ErrHoldNotActive.
+func (s *Storage) CaptureHold(
+	ctx context.Context,
+	userID int,
+	id int64,
+	sum int,
+	limits models.WithdrawLimits,
+) (models.PointHold, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	hold, err := lockActiveHold(ctx, tx, userID, id)
+	if err != nil {
+		return models.PointHold{}, err
+	}
+
+	if sum == 0 {
+		sum = hold.Amount
+	}
+
+	if sum < 0 || sum > hold.Amount {
+		return models.PointHold{}, models.ErrInvalidInput
+	}
+
+	if err := lockOrderWithdrawals(ctx, tx, hold.Order); err != nil {
+		return models.PointHold{}, err
+	}
+
+	// удержание снимается до списания, чтобы удержанные баллы стали доступны для него
+	_, err = tx.Exec(ctx, "UPDATE balance SET held = held - $1 WHERE user_id = $2", hold.Amount, userID)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	if err := s.withdraw(ctx, tx, userID, models.BalanceWithdraw{Order: hold.Order, Sum: sum}, limits); err != nil {
+		return models.PointHold{}, err
+	}
+
+	hold, err = resolveHold(ctx, tx, id, models.HoldCaptured, sum)
+	if err != nil {
+		return models.PointHold{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot commit hold capture: %w", err)
+	}
+
+	return hold, nil
+}
+
+// ReleaseHold снимает удержание без списания баллов.
+func (s *Storage) ReleaseHold(ctx context.Context, userID int, id int64) (models.PointHold, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	hold, err := lockActiveHold(ctx, tx, userID, id)
+	if err != nil {
+		return models.PointHold{}, err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE balance SET held = held - $1 WHERE user_id = $2", hold.Amount, userID)
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	hold, err = resolveHold(ctx, tx, id, models.HoldReleased, 0)
+	if err != nil {
+		return models.PointHold{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot commit hold release: %w", err)
+	}
+
+	return hold, nil
+}
+
+// ExpireHolds снимает удержания с истёкшим сроком и возвращает их количество. Удержания снимаются
+// отдельной транзакцией для каждого пользователя, чтобы баланс блокировался так же, как при списании.
+func (s *Storage) ExpireHolds(ctx context.Context) (int, error) {
+	q := "SELECT DISTINCT user_id FROM point_holds WHERE status = $1 AND expires_at <= now() ORDER BY user_id"
+
+	rows, err := s.pool.Query(ctx, q, models.HoldActive)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get expired holds: %w", err)
+	}
+
+	users := make([]int, 0)
+
+	for rows.Next() {
+		var userID int
+
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+
+			return 0, fmt.Errorf("cannot scan expired holds: %w", err)
+		}
+
+		users = append(users, userID)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("cannot get expired holds: %w", err)
+	}
+
+	count := 0
+
+	for _, userID := range users {
+		holds, err := s.expireUserHolds(ctx, userID)
+		if err != nil {
+			return count, err
+		}
+
+		count += holds
+	}
+
+	return count, nil
+}
+
+// expireUserHolds снимает истёкшие удержания пользователя и сообщает об этом событием баланса.
+func (s *Storage) expireUserHolds(ctx context.Context, userID int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var current int
+
+	err = tx.QueryRow(ctx, "SELECT coalesce(current, 0) FROM balance WHERE user_id = $1 FOR UPDATE", userID).Scan(&current)
+	if err != nil {
+		return 0, fmt.Errorf("cannot lock balance: %w", err)
+	}
+
+	var holds, amount int
+
+	q := `WITH expired AS (
+				UPDATE point_holds SET status = $1, resolved_at = now()
+				WHERE user_id = $2 AND status = $3 AND expires_at <= now()
+				RETURNING amount
+			)
+			SELECT count(*), coalesce(sum(amount), 0) FROM expired`
+
+	err = tx.QueryRow(ctx, q, models.HoldExpired, userID, models.HoldActive).Scan(&holds, &amount)
+	if err != nil {
+		return 0, fmt.Errorf("cannot expire holds: %w", err)
+	}
+
+	// удержания могли успеть списать или снять параллельно
+	if holds == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE balance SET held = held - $1 WHERE user_id = $2", amount, userID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = insertEvent(ctx, tx, models.UserEvent{
+		UserID:      userID,
+		Kind:        models.EventBalance,
+		Transaction: models.TransactionHoldExpired,
+		Amount:      amount,
+		Balance:     current,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("cannot commit expired holds: %w", err)
+	}
+
+	return holds, nil
+}
+
+// shrinkHolds уменьшает действующие удержания пользователя, начиная с последних, чтобы удержано было
+// не больше текущего баланса current: после отзыва начисления удержанных баллов может не остаться.
+// Удержание, от которого ничего не осталось, снимается. Баланс пользователя должен быть заблокирован.
+func shrinkHolds(ctx context.Context, tx pgx.Tx, userID, current int) error {
+	var held int
+
+	if err := tx.QueryRow(ctx, "SELECT held FROM balance WHERE user_id = $1", userID).Scan(&held); err != nil {
+		return fmt.Errorf("cannot get balance: %w", err)
+	}
+
+	excess := held - current
+	if current < 0 {
+		excess = held
+	}
+
+	if excess <= 0 {
+		return nil
+	}
+
+	type hold struct {
+		id     int64
+		amount int
+	}
+
+	q := `SELECT id, amount FROM point_holds WHERE user_id = $1 AND status = $2
+			ORDER BY created_at DESC, id DESC FOR UPDATE`
+
+	rows, err := tx.Query(ctx, q, userID, models.HoldActive)
+	if err != nil {
+		return fmt.Errorf("cannot get holds: %w", err)
+	}
+
+	holds := make([]hold, 0)
+
+	for rows.Next() {
+		h := hold{}
+
+		if err := rows.Scan(&h.id, &h.amount); err != nil {
+			rows.Close()
+
+			return fmt.Errorf("cannot scan hold: %w", err)
+		}
+
+		holds = append(holds, h)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("cannot get holds: %w", err)
+	}
+
+	left := excess
+
+	for _, h := range holds {
+		if left == 0 {
+			break
+		}
+
+		if h.amount <= left {
+			q = "UPDATE point_holds SET status = $1, resolved_at = now() WHERE id = $2"
+
+			_, err = tx.Exec(ctx, q, models.HoldReleased, h.id)
+			left -= h.amount
+		} else {
+			_, err = tx.Exec(ctx, "UPDATE point_holds SET amount = amount - $1 WHERE id = $2", left, h.id)
+			left = 0
+		}
+
+		if err != nil {
+			return fmt.Errorf("cannot update hold: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE balance SET held = held - $1 WHERE user_id = $2", excess-left, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	return nil
+}
+
+// lockActiveHold блокирует действующее удержание пользователя.
+func lockActiveHold(ctx context.Context, tx pgx.Tx, userID int, id int64) (models.PointHold, error) {
+	q := "SELECT " + holdColumns + " FROM point_holds WHERE id = $1 AND user_id = $2 FOR UPDATE"
+
+	hold, err := scanHold(tx.QueryRow(ctx, q, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PointHold{}, models.ErrNotFound
+		}
+
+		return models.PointHold{}, fmt.Errorf("cannot get hold: %w", err)
+	}
+
+	// просроченное удержание, которое ещё не снято фоновой задачей, тоже недействительно
+	if hold.Status != models.HoldActive || !hold.ExpiresAt.After(time.Now()) {
+		return models.PointHold{}, models.ErrHoldNotActive
+	}
+
+	return hold, nil
+}
+
+func resolveHold(
+	ctx context.Context,
+	tx pgx.Tx,
+	id int64,
+	status models.HoldStatus,
+	captured int,
+) (models.PointHold, error) {
+	q := `UPDATE point_holds SET status = $1, captured = $2, resolved_at = now() WHERE id = $3
+			RETURNING ` + holdColumns
+
+	hold, err := scanHold(tx.QueryRow(ctx, q, status, captured, id))
+	if err != nil {
+		return models.PointHold{}, fmt.Errorf("cannot update hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+func scanHold(row pgx.Row) (models.PointHold, error) {
+	h := models.PointHold{}
+
+	err := row.Scan(&h.ID, &h.UserID, &h.Order, &h.Amount, &h.Captured, &h.Status, &h.CreatedAt, &h.ExpiresAt,
+		&h.ResolvedAt)
+
+	return h, err
+}
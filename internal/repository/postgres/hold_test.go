@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+func addTestHold(t *testing.T, s *Storage, userID int, number string, amount int) models.PointHold {
+	t.Helper()
+
+	hold, err := s.CreateHold(context.Background(), models.PointHold{
+		UserID:    userID,
+		Order:     number,
+		Amount:    amount,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+
+	return hold
+}
+
+// setHoldExpiry переносит срок удержания, чтобы проверить просроченные удержания.
+func setHoldExpiry(t *testing.T, s *Storage, id int64, expiresAt time.Time) {
+	t.Helper()
+
+	if _, err := s.pool.Exec(context.Background(), "UPDATE point_holds SET expires_at = $1 WHERE id = $2", expiresAt, id); err != nil {
+		t.Fatalf("cannot set hold expiry: %v", err)
+	}
+}
+
+func TestCreateHold(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	_, err := s.CreateHold(ctx, models.PointHold{UserID: u.ID, Order: "2377225624", Amount: 100, ExpiresAt: time.Now().Add(time.Hour)})
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("hold without balance: err = %v, want ErrInsufficientBalance", err)
+	}
+
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	hold := addTestHold(t, s, u.ID, "2377225624", 600)
+	if hold.ID == 0 || hold.Status != models.HoldActive || hold.Amount != 600 || hold.ResolvedAt != nil {
+		t.Errorf("hold = %+v", hold)
+	}
+
+	// удержанные баллы входят в баланс, но недоступны для других удержаний и списаний
+	if b := testBalance(t, s, u.ID); b.Current != 1000 || b.Held != 600 {
+		t.Errorf("balance = %+v, want 1000 with 600 held", b)
+	}
+
+	_, err = s.CreateHold(ctx, models.PointHold{UserID: u.ID, Order: "2377225632", Amount: 500, ExpiresAt: time.Now().Add(time.Hour)})
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("hold over available balance: err = %v, want ErrInsufficientBalance", err)
+	}
+
+	err = s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: "2377225632", Sum: 500}, testWithdrawLimits)
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("withdrawal of held points: err = %v, want ErrInsufficientBalance", err)
+	}
+
+	addTestWithdrawal(t, s, u.ID, "2377225632", 400)
+}
+
+func TestCaptureHold(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	// частичное списание снимает остаток удержания
+	hold := addTestHold(t, s, u.ID, "2377225624", 600)
+
+	if _, err := s.CaptureHold(ctx, u.ID, hold.ID, 700, testWithdrawLimits); !errors.Is(err, models.ErrInvalidInput) {
+		t.Errorf("capture over hold: err = %v, want ErrInvalidInput", err)
+	}
+
+	captured, err := s.CaptureHold(ctx, u.ID, hold.ID, 400, testWithdrawLimits)
+	if err != nil {
+		t.Fatalf("CaptureHold: %v", err)
+	}
+
+	if captured.Status != models.HoldCaptured || captured.Captured != 400 || captured.ResolvedAt == nil {
+		t.Errorf("captured hold = %+v", captured)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 600 || b.Withdraw != 400 || b.Held != 0 {
+		t.Errorf("balance = %+v, want 600 with 400 withdrawn and nothing held", b)
+	}
+
+	if _, err := s.CaptureHold(ctx, u.ID, hold.ID, 0, testWithdrawLimits); !errors.Is(err, models.ErrHoldNotActive) {
+		t.Errorf("repeated capture: err = %v, want ErrHoldNotActive", err)
+	}
+
+	// нулевая сумма списывает всё удержание
+	hold = addTestHold(t, s, u.ID, "2377225632", 200)
+
+	captured, err = s.CaptureHold(ctx, u.ID, hold.ID, 0, testWithdrawLimits)
+	if err != nil || captured.Captured != 200 {
+		t.Fatalf("full capture = %+v, %v", captured, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 400 || b.Withdraw != 600 || b.Held != 0 {
+		t.Errorf("balance = %+v, want 400 with 600 withdrawn and nothing held", b)
+	}
+
+	// удержание по заказу, который уже оплачен, не списывается и остаётся действующим
+	hold = addTestHold(t, s, u.ID, "4561261212345467", 100)
+	addTestWithdrawal(t, s, u.ID, "4561261212345467", 100)
+	addTestWithdrawal(t, s, u.ID, "4561261212345467", 100)
+
+	if _, err := s.CaptureHold(ctx, u.ID, hold.ID, 0, testWithdrawLimits); !errors.Is(err, models.ErrOrderWithdrawn) {
+		t.Errorf("capture for a paid order: err = %v, want ErrOrderWithdrawn", err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Held != 100 {
+		t.Errorf("held after failed capture = %d, want 100", b.Held)
+	}
+
+	// чужое удержание не видно
+	other := addTestUser(t, s, "bob")
+
+	if _, err := s.CaptureHold(ctx, other.ID, hold.ID, 0, testWithdrawLimits); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("capture of a foreign hold: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	hold := addTestHold(t, s, u.ID, "2377225624", 600)
+
+	released, err := s.ReleaseHold(ctx, u.ID, hold.ID)
+	if err != nil {
+		t.Fatalf("ReleaseHold: %v", err)
+	}
+
+	if released.Status != models.HoldReleased || released.Captured != 0 || released.ResolvedAt == nil {
+		t.Errorf("released hold = %+v", released)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 1000 || b.Held != 0 || b.Withdraw != 0 {
+		t.Errorf("balance = %+v, want 1000 and nothing held", b)
+	}
+
+	if _, err := s.ReleaseHold(ctx, u.ID, hold.ID); !errors.Is(err, models.ErrHoldNotActive) {
+		t.Errorf("repeated release: err = %v, want ErrHoldNotActive", err)
+	}
+
+	if _, err := s.CaptureHold(ctx, u.ID, hold.ID, 0, testWithdrawLimits); !errors.Is(err, models.ErrHoldNotActive) {
+		t.Errorf("capture after release: err = %v, want ErrHoldNotActive", err)
+	}
+
+	if _, err := s.ReleaseHold(ctx, u.ID, hold.ID+100); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown hold: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+	addTestAccrual(t, s, bob.ID, "12345678903", 1000)
+
+	first := addTestHold(t, s, alice.ID, "2377225624", 300)
+	second := addTestHold(t, s, alice.ID, "2377225632", 200)
+	third := addTestHold(t, s, bob.ID, "4561261212345467", 400)
+	active := addTestHold(t, s, bob.ID, "79927398713", 100)
+
+	for _, id := range []int64{first.ID, second.ID, third.ID} {
+		setHoldExpiry(t, s, id, time.Now().Add(-time.Minute))
+	}
+
+	// просроченное удержание недействительно ещё до того, как его сняла фоновая задача
+	if _, err := s.CaptureHold(ctx, alice.ID, first.ID, 0, testWithdrawLimits); !errors.Is(err, models.ErrHoldNotActive) {
+		t.Errorf("capture of an expired hold: err = %v, want ErrHoldNotActive", err)
+	}
+
+	count, err := s.ExpireHolds(ctx)
+	if err != nil || count != 3 {
+		t.Fatalf("ExpireHolds = %d, %v, want 3", count, err)
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Held != 0 {
+		t.Errorf("alice held = %d, want 0", b.Held)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Held != 100 {
+		t.Errorf("bob held = %d, want 100", b.Held)
+	}
+
+	events, err := s.GetEvents(ctx, alice.ID, 0, 100)
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+
+	expired := make([]models.UserEvent, 0)
+
+	for _, e := range events {
+		if e.Transaction == models.TransactionHoldExpired {
+			expired = append(expired, e)
+		}
+	}
+
+	if len(expired) != 1 || expired[0].Kind != models.EventBalance || expired[0].Amount != 500 || expired[0].Balance != 1000 {
+		t.Errorf("hold expiration events = %+v, want one with 500", expired)
+	}
+
+	if count, err := s.ExpireHolds(ctx); err != nil || count != 0 {
+		t.Errorf("repeated ExpireHolds = %d, %v, want 0", count, err)
+	}
+
+	if _, err := s.ReleaseHold(ctx, bob.ID, active.ID); err != nil {
+		t.Errorf("ReleaseHold of an active hold: %v", err)
+	}
+}
+
+// TestCreateHoldConcurrent проверяет, что одновременные удержания не превышают доступный баланс.
+func TestCreateHoldConcurrent(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	const holds = 10
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 350)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+
+	for i := 0; i < holds; i++ {
+		wg.Add(1)
+
+		go func(number string) {
+			defer wg.Done()
+
+			_, err := s.CreateHold(ctx, models.PointHold{UserID: u.ID, Order: number, Amount: 100,
+				ExpiresAt: time.Now().Add(time.Hour)})
+			if err != nil && !errors.Is(err, models.ErrInsufficientBalance) {
+				t.Errorf("CreateHold: %v", err)
+			}
+
+			if err == nil {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}(fmt.Sprintf("order-%d", i))
+	}
+
+	wg.Wait()
+
+	if created != 3 {
+		t.Errorf("holds created = %d, want 3", created)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Held != 300 {
+		t.Errorf("held = %d, want 300", b.Held)
+	}
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SaveIdempotencyKey занимает ключ для нового запроса. Ключ с истёкшим сроком занимается заново.
+// Если ключ уже занят, возвращается сохранённая запись и created = false.
+func (s *Storage) SaveIdempotencyKey(
+	ctx context.Context,
+	key models.IdempotencyKey,
+) (models.IdempotencyKey, bool, error) {
+	q := `INSERT INTO idempotency_keys (user_id, key, request_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, now(), $4)
+			ON CONFLICT (user_id, key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = '', response = NULL,
+				created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at < now()`
+
+	tag, err := s.pool.Exec(ctx, q, key.UserID, key.Key, key.RequestHash, key.ExpiresAt)
+	if err != nil {
+		return models.IdempotencyKey{}, false, fmt.Errorf("cannot save idempotency key: %w", err)
+	}
+
+	if tag.RowsAffected() == 1 {
+		return key, true, nil
+	}
+
+	q = `SELECT user_id, key, request_hash, coalesce(status_code, 0), content_type, response, expires_at
+			FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+
+	saved := models.IdempotencyKey{}
+
+	err = s.pool.QueryRow(ctx, q, key.UserID, key.Key).Scan(&saved.UserID, &saved.Key, &saved.RequestHash,
+		&saved.StatusCode, &saved.ContentType, &saved.Response, &saved.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// ключ удалили между запросами, например после ошибки первого запроса
+			return models.IdempotencyKey{}, false, models.ErrConflict
+		}
+
+		return models.IdempotencyKey{}, false, fmt.Errorf("cannot get idempotency key: %w", err)
+	}
+
+	return saved, false, nil
+}
+
+// SaveIdempotentResponse сохраняет ответ на первый запрос с ключом.
+func (s *Storage) SaveIdempotentResponse(ctx context.Context, key models.IdempotencyKey) error {
+	q := `UPDATE idempotency_keys SET status_code = $1, content_type = $2, response = $3
+			WHERE user_id = $4 AND key = $5`
+
+	_, err := s.pool.Exec(ctx, q, key.StatusCode, key.ContentType, key.Response, key.UserID, key.Key)
+	if err != nil {
+		return fmt.Errorf("cannot save idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, userID int, key string) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2", userID, key)
+	if err != nil {
+		return fmt.Errorf("cannot delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at < now()")
+	if err != nil {
+		return 0, fmt.Errorf("cannot delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
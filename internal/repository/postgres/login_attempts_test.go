@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+func TestReserveLoginAttempt(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	a, err := s.ReserveLoginAttempt(ctx, "login:alice", 0, time.Minute)
+	if err != nil || a.Failures != 1 || a.LastFailureAt == nil {
+		t.Fatalf("first attempt = %+v, %v, want one failure", a, err)
+	}
+
+	// попытка, прочитавшая устаревший счётчик, уже занята параллельной
+	if _, err := s.ReserveLoginAttempt(ctx, "login:alice", 0, time.Minute); !errors.Is(err, models.ErrConflict) {
+		t.Errorf("stale counter: err = %v, want ErrConflict", err)
+	}
+
+	if a, err := s.ReserveLoginAttempt(ctx, "login:alice", 1, time.Minute); err != nil || a.Failures != 2 {
+		t.Fatalf("second attempt = %+v, %v, want two failures", a, err)
+	}
+
+	if err := s.ReleaseLoginAttempt(ctx, "login:alice"); err != nil {
+		t.Fatalf("ReleaseLoginAttempt: %v", err)
+	}
+
+	if a, err := s.GetLoginAttempts(ctx, "login:alice"); err != nil || a.Failures != 1 {
+		t.Errorf("after release = %+v, %v, want one failure", a, err)
+	}
+
+	if err := s.LockLogin(ctx, "login:alice", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("LockLogin: %v", err)
+	}
+
+	if _, err := s.ReserveLoginAttempt(ctx, "login:alice", 1, time.Minute); !errors.Is(err, models.ErrConflict) {
+		t.Errorf("locked login: err = %v, want ErrConflict", err)
+	}
+
+	// после окончания блокировки счётчик начинается заново
+	if err := s.LockLogin(ctx, "login:alice", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("LockLogin: %v", err)
+	}
+
+	a, err = s.ReserveLoginAttempt(ctx, "login:alice", 1, time.Minute)
+	if err != nil || a.Failures != 1 || a.LockedUntil != nil {
+		t.Errorf("attempt after lockout = %+v, %v, want a new window", a, err)
+	}
+}
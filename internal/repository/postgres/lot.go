@@ -0,0 +1,423 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AccrueOrder в одной транзакции обновляет статус и начисление заказа и зачисляет на баланс начисление
+// и бонусы по акциям отдельными партиями баллов. Бонусы определяются для обработанного заказа в этой же
+// транзакции, сохраняются отдельными записями и возвращаются.
+// Для обработанного заказа начисляются баллы за приглашение, если они ещё не начислены.
+// Заказ в конечном статусе не изменяется, поэтому начисление не зачисляется дважды.
+// При изменении статуса сохраняется событие для пользователя, для обработанного заказа также событие
+// для магазина о начислении.
+func (s *Storage) AccrueOrder(
+	ctx context.Context,
+	orderNumber, status string,
+	accrual int,
+	campaigns models.CampaignAccrual,
+) ([]models.CampaignBonus, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var (
+		userID     int
+		prevStatus string
+	)
+
+	// prev видит строку заказа до изменения, по ней определяется, изменился ли статус
+	q := `UPDATE orders o SET (status, accrual) = ($1, $2) FROM orders prev
+			WHERE prev.id = o.id AND o.order_number = $3 AND o.status NOT IN ('INVALID', 'PROCESSED', 'REVOKED')
+			RETURNING o.user_id, coalesce(prev.status, '')`
+
+	err = tx.QueryRow(ctx, q, status, accrual, orderNumber).Scan(&userID, &prevStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("cannot update order: %w", err)
+	}
+
+	// баланс пригласившего блокируется до события и начисления вместе с балансом пользователя в порядке id,
+	// как при переводах, иначе награда за приглашение и встречный перевод могут заблокировать друг друга
+	if status == processedStatusOrder {
+		if err := lockReferralBalances(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if prevStatus != status {
+		err = insertEvent(ctx, tx, models.UserEvent{
+			UserID: userID,
+			Kind:   models.EventOrderStatus,
+			Order:  orderNumber,
+			Status: status,
+			Amount: accrual,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.credit(ctx, tx, userID, accrual, models.LotSourceAccrual, orderNumber); err != nil {
+		return nil, err
+	}
+
+	var bonuses []models.CampaignBonus
+
+	if status == processedStatusOrder && len(campaigns.Campaigns) > 0 {
+		bonuses, err = campaignBonuses(ctx, tx, userID, orderNumber, campaigns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range bonuses {
+		q = `INSERT INTO campaign_bonuses (campaign_id, user_id, order_number, amount, created_at)
+				VALUES ($1, $2, $3, $4, now())`
+
+		_, err = tx.Exec(ctx, q, b.CampaignID, userID, orderNumber, b.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("cannot insert campaign bonus: %w", err)
+		}
+
+		if err := s.credit(ctx, tx, userID, b.Amount, models.LotSourceCampaign, orderNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	// первый обработанный заказ приглашённого пользователя вознаграждает его и пригласившего
+	if status == processedStatusOrder {
+		if err := s.rewardReferral(ctx, tx, userID, orderNumber); err != nil {
+			return nil, err
+		}
+
+		credited := accrual
+		for _, b := range bonuses {
+			credited += b.Amount
+		}
+
+		if err := insertWebhookEvent(ctx, tx, models.WebhookAccrual, orderNumber, credited); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("cannot commit order accrual: %w", err)
+	}
+
+	return bonuses, nil
+}
+
+// credit зачисляет баллы на баланс, сохраняет их партией и записывает движение в журнал.
+func (s *Storage) credit(ctx context.Context, tx pgx.Tx, userID, amount int, source, reference string) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	q := "INSERT INTO balance (user_id, current, withdraw) VALUES ($1, 0, 0) ON CONFLICT (user_id) DO NOTHING"
+
+	_, err := tx.Exec(ctx, q, userID)
+	if err != nil {
+		return fmt.Errorf("cannot init balance: %w", err)
+	}
+
+	var current int
+
+	q = "UPDATE balance SET current = coalesce(current, 0) + $1 WHERE user_id = $2 RETURNING current"
+
+	err = tx.QueryRow(ctx, q, amount, userID).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:    userID,
+		Kind:      source,
+		Amount:    amount,
+		Order:     transactionOrder(source, reference),
+		Reference: reference,
+		Balance:   current,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.addLot(ctx, tx, userID, amount, current-amount, source, reference, s.lotExpiry())
+}
+
+// GetExpiringPoints возвращает баллы пользователя, которые сгорят до before, по дням сгорания.
+func (s *Storage) GetExpiringPoints(ctx context.Context, userID int, before time.Time) ([]models.ExpiringPoints, error) {
+	q := `SELECT min(expires_at), sum(remaining) FROM point_lots
+			WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+			GROUP BY expires_at::date ORDER BY 1`
+
+	rows, err := s.pool.Query(ctx, q, userID, before)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get expiring points: %w", err)
+	}
+
+	defer rows.Close()
+
+	expiring := make([]models.ExpiringPoints, 0)
+
+	for rows.Next() {
+		e := models.ExpiringPoints{}
+
+		if err := rows.Scan(&e.ExpiresAt, &e.Sum); err != nil {
+			return nil, fmt.Errorf("cannot scan expiring points: %w", err)
+		}
+
+		expiring = append(expiring, e)
+	}
+
+	return expiring, rows.Err()
+}
+
+// ExpirePoints сжигает не более limit партий с истёкшим сроком: списывает их остаток с баланса
+// и сохраняет запись о сгорании. Каждая партия сжигается в отдельной транзакции.
+// Возвращает количество сожжённых партий и сумму сгоревших баллов.
+func (s *Storage) ExpirePoints(ctx context.Context, limit int) (int, int, error) {
+	type lot struct {
+		id     int64
+		userID int
+	}
+
+	// пользователи, у которых все баллы удержаны, пропускаются, пока удержания не сняты
+	q := `SELECT l.id, l.user_id FROM point_lots l JOIN balance b ON b.user_id = l.user_id
+			WHERE l.remaining > 0 AND l.expires_at <= now() AND coalesce(b.current, 0) > b.held
+			ORDER BY l.expires_at LIMIT $1`
+
+	rows, err := s.pool.Query(ctx, q, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot get expired lots: %w", err)
+	}
+
+	lots := make([]lot, 0)
+
+	for rows.Next() {
+		l := lot{}
+
+		if err := rows.Scan(&l.id, &l.userID); err != nil {
+			rows.Close()
+
+			return 0, 0, fmt.Errorf("cannot scan expired lot: %w", err)
+		}
+
+		lots = append(lots, l)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("cannot get expired lots: %w", err)
+	}
+
+	count, total := 0, 0
+
+	for _, l := range lots {
+		amount, err := s.expireLot(ctx, l.id, l.userID)
+		if err != nil {
+			return count, total, err
+		}
+
+		if amount > 0 {
+			count++
+			total += amount
+		}
+	}
+
+	return count, total, nil
+}
+
+// expireLot сжигает остаток партии и возвращает сгоревшую сумму. Удержанные баллы не сгорают: сжигается
+// не больше, чем доступно сверх удержаний, остальное остаётся в партии до списания или снятия удержания.
+// Партия, которую успели потратить или сжечь параллельно, пропускается.
+func (s *Storage) expireLot(ctx context.Context, id int64, userID int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var current, held int
+
+	// баланс блокируется раньше партии, как и при списании, чтобы не было взаимной блокировки
+	q := "SELECT coalesce(current, 0), held FROM balance WHERE user_id = $1 FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, userID).Scan(&current, &held)
+	if err != nil {
+		return 0, fmt.Errorf("cannot lock balance: %w", err)
+	}
+
+	var remaining int
+
+	q = "SELECT remaining FROM point_lots WHERE id = $1 AND remaining > 0 AND expires_at <= now() FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, id).Scan(&remaining)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("cannot get lot: %w", err)
+	}
+
+	amount := remaining
+	if amount > current-held {
+		amount = current - held
+	}
+
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE point_lots SET remaining = remaining - $1 WHERE id = $2", amount, id)
+	if err != nil {
+		return 0, fmt.Errorf("cannot update lot: %w", err)
+	}
+
+	q = "UPDATE balance SET current = current - $1 WHERE user_id = $2 RETURNING current"
+
+	err = tx.QueryRow(ctx, q, amount, userID).Scan(&current)
+	if err != nil {
+		return 0, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:    userID,
+		Kind:      models.TransactionExpiration,
+		Amount:    -amount,
+		Reference: strconv.FormatInt(id, 10),
+		Balance:   current,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	q = "INSERT INTO point_expirations (lot_id, user_id, amount, created_at) VALUES ($1, $2, $3, now())"
+
+	_, err = tx.Exec(ctx, q, id, userID, amount)
+	if err != nil {
+		return 0, fmt.Errorf("cannot insert point expiration: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("cannot commit point expiration: %w", err)
+	}
+
+	return amount, nil
+}
+
+// addLot сохраняет партию полученных баллов со сроком сгорания expiresAt, nil если баллы не сгорают.
+// before баланс до получения: если он был отрицательным, полученные баллы сначала погашают долг
+// и в партию попадает только остаток.
+func (s *Storage) addLot(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID, amount, before int,
+	source, reference string,
+	expiresAt *time.Time,
+) error {
+	remaining := amount - negativePart(before)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	q := `INSERT INTO point_lots (user_id, source, reference, amount, remaining, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, now(), $6)`
+
+	_, err := tx.Exec(ctx, q, userID, source, reference, amount, remaining, expiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot insert lot: %w", err)
+	}
+
+	return nil
+}
+
+// lotExpiry возвращает срок сгорания новых баллов, nil если баллы не сгорают.
+func (s *Storage) lotExpiry() *time.Time {
+	if s.pointsTTL <= 0 {
+		return nil
+	}
+
+	t := time.Now().Add(s.pointsTTL)
+
+	return &t
+}
+
+// lotPart часть партии баллов, израсходованная одним списанием.
+type lotPart struct {
+	lotID     int64
+	amount    int
+	expiresAt *time.Time
+}
+
+// consumeLots расходует до amount баллов из партий пользователя, начиная с партий, которые сгорят раньше,
+// и возвращает израсходованные части партий в порядке расходования.
+// Баланс пользователя должен быть заблокирован в той же транзакции.
+func consumeLots(ctx context.Context, tx pgx.Tx, userID, amount int) ([]lotPart, error) {
+	q := `SELECT id, remaining, expires_at FROM point_lots WHERE user_id = $1 AND remaining > 0
+			ORDER BY expires_at NULLS LAST, created_at, id FOR UPDATE`
+
+	rows, err := tx.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get lots: %w", err)
+	}
+
+	lots := make([]lotPart, 0)
+
+	for rows.Next() {
+		l := lotPart{}
+
+		if err := rows.Scan(&l.lotID, &l.amount, &l.expiresAt); err != nil {
+			rows.Close()
+
+			return nil, fmt.Errorf("cannot scan lot: %w", err)
+		}
+
+		lots = append(lots, l)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get lots: %w", err)
+	}
+
+	parts := make([]lotPart, 0)
+
+	for _, l := range lots {
+		if amount == 0 {
+			break
+		}
+
+		if l.amount > amount {
+			l.amount = amount
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE point_lots SET remaining = remaining - $1 WHERE id = $2", l.amount, l.lotID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot update lot: %w", err)
+		}
+
+		parts = append(parts, l)
+		amount -= l.amount
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,301 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+type testLot struct {
+	id        int64
+	source    string
+	amount    int
+	remaining int
+	expiresAt *time.Time
+}
+
+// testLots возвращает партии баллов пользователя в порядке получения.
+func testLots(t *testing.T, s *Storage, userID int) []testLot {
+	t.Helper()
+
+	q := "SELECT id, source, amount, remaining, expires_at FROM point_lots WHERE user_id = $1 ORDER BY id"
+
+	rows, err := s.pool.Query(context.Background(), q, userID)
+	if err != nil {
+		t.Fatalf("cannot get lots: %v", err)
+	}
+
+	defer rows.Close()
+
+	lots := make([]testLot, 0)
+
+	for rows.Next() {
+		l := testLot{}
+
+		if err := rows.Scan(&l.id, &l.source, &l.amount, &l.remaining, &l.expiresAt); err != nil {
+			t.Fatalf("cannot scan lot: %v", err)
+		}
+
+		lots = append(lots, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatalf("cannot get lots: %v", err)
+	}
+
+	return lots
+}
+
+// setLotExpiry задаёт срок сгорания партии, nil означает, что партия не сгорает.
+func setLotExpiry(t *testing.T, s *Storage, id int64, expiresAt *time.Time) {
+	t.Helper()
+
+	if _, err := s.pool.Exec(context.Background(), "UPDATE point_lots SET expires_at = $1 WHERE id = $2", expiresAt, id); err != nil {
+		t.Fatalf("cannot set lot expiry: %v", err)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Sub(*b).Abs() < time.Millisecond
+}
+
+func timeAt(d time.Duration) *time.Time {
+	t := time.Now().Add(d).Truncate(time.Microsecond)
+
+	return &t
+}
+
+func TestConsumeLotsFIFO(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = time.Hour
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 300)
+	addTestAccrual(t, s, u.ID, "12345678903", 500)
+	addTestAccrual(t, s, u.ID, "4561261212345467", 200)
+
+	lots := testLots(t, s, u.ID)
+	if len(lots) != 3 {
+		t.Fatalf("lots = %+v, want 3", lots)
+	}
+
+	// вторая партия сгорит раньше первой, третья не сгорает
+	setLotExpiry(t, s, lots[0].id, timeAt(2*time.Hour))
+	setLotExpiry(t, s, lots[1].id, timeAt(time.Hour))
+	setLotExpiry(t, s, lots[2].id, nil)
+
+	addTestWithdrawal(t, s, u.ID, "2377225624", 600)
+
+	lots = testLots(t, s, u.ID)
+	if lots[0].remaining != 200 || lots[1].remaining != 0 || lots[2].remaining != 200 {
+		t.Errorf("remaining = %d, %d, %d, want 200, 0, 200", lots[0].remaining, lots[1].remaining, lots[2].remaining)
+	}
+
+	addTestWithdrawal(t, s, u.ID, "2377225632", 300)
+
+	lots = testLots(t, s, u.ID)
+	if lots[0].remaining != 0 || lots[2].remaining != 100 {
+		t.Errorf("remaining = %d, %d, %d, want 0, 0, 100", lots[0].remaining, lots[1].remaining, lots[2].remaining)
+	}
+}
+
+func TestExpirePoints(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = time.Hour
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+	addTestAccrual(t, s, u.ID, "12345678903", 500)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 300)
+
+	lots := testLots(t, s, u.ID)
+	setLotExpiry(t, s, lots[0].id, timeAt(-time.Minute))
+
+	expiring, err := s.GetExpiringPoints(ctx, u.ID, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetExpiringPoints: %v", err)
+	}
+
+	total := 0
+	for _, e := range expiring {
+		total += e.Sum
+	}
+
+	if total != 1200 {
+		t.Errorf("expiring points = %d, want 1200", total)
+	}
+
+	count, sum, err := s.ExpirePoints(ctx, 100)
+	if err != nil {
+		t.Fatalf("ExpirePoints: %v", err)
+	}
+
+	if count != 1 || sum != 700 {
+		t.Errorf("expired %d lots with %d, want 1 lot with 700", count, sum)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 500 {
+		t.Errorf("balance = %d, want 500", b.Current)
+	}
+
+	// повторный запуск ничего не сжигает
+	if count, _, err := s.ExpirePoints(ctx, 100); err != nil || count != 0 {
+		t.Errorf("second run expired %d lots, %v", count, err)
+	}
+
+	transactions, err := s.GetTransactions(ctx, u.ID, models.TransactionPage{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetTransactions: %v", err)
+	}
+
+	expirations := make([]models.BalanceTransaction, 0)
+
+	for _, tr := range transactions {
+		if tr.Kind == models.TransactionExpiration {
+			expirations = append(expirations, tr)
+		}
+	}
+
+	if len(expirations) != 1 || expirations[0].Amount != -700 || expirations[0].Balance != 500 {
+		t.Errorf("expiration transactions = %+v", expirations)
+	}
+}
+
+func TestExpirePointsBatch(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = time.Hour
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	for _, number := range []string{"79927398713", "12345678903", "4561261212345467"} {
+		addTestAccrual(t, s, u.ID, number, 100)
+	}
+
+	for _, l := range testLots(t, s, u.ID) {
+		setLotExpiry(t, s, l.id, timeAt(-time.Minute))
+	}
+
+	if count, sum, err := s.ExpirePoints(ctx, 2); err != nil || count != 2 || sum != 200 {
+		t.Fatalf("first batch: %d lots, %d, %v", count, sum, err)
+	}
+
+	if count, sum, err := s.ExpirePoints(ctx, 2); err != nil || count != 1 || sum != 100 {
+		t.Fatalf("second batch: %d lots, %d, %v", count, sum, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 0 {
+		t.Errorf("balance = %d, want 0", b.Current)
+	}
+}
+
+// TestExpirePointsHeld проверяет, что сгорание партии не трогает удержанные баллы.
+func TestExpirePointsHeld(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = time.Hour
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	hold := addTestHold(t, s, u.ID, "2377225624", 600)
+
+	lots := testLots(t, s, u.ID)
+	setLotExpiry(t, s, lots[0].id, timeAt(-time.Minute))
+
+	if count, sum, err := s.ExpirePoints(ctx, 100); err != nil || count != 1 || sum != 400 {
+		t.Fatalf("ExpirePoints = %d lots, %d, %v, want 1 lot with 400", count, sum, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 600 || b.Held != 600 {
+		t.Errorf("balance = %+v, want 600 and 600 held", b)
+	}
+
+	if lots = testLots(t, s, u.ID); lots[0].remaining != 600 {
+		t.Errorf("remaining = %d, want 600", lots[0].remaining)
+	}
+
+	// пока баллы удержаны, сжигать нечего
+	if count, _, err := s.ExpirePoints(ctx, 100); err != nil || count != 0 {
+		t.Errorf("run with held points expired %d lots, %v", count, err)
+	}
+
+	if _, err := s.ReleaseHold(ctx, u.ID, hold.ID); err != nil {
+		t.Fatalf("ReleaseHold: %v", err)
+	}
+
+	if count, sum, err := s.ExpirePoints(ctx, 100); err != nil || count != 1 || sum != 600 {
+		t.Errorf("run after release = %d lots, %d, %v, want 1 lot with 600", count, sum, err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 0 || b.Held != 0 {
+		t.Errorf("balance after release = %+v, want 0", b)
+	}
+}
+
+// TestRefundRestoresLotExpiry проверяет, что возвращённые баллы сгорают в тот же срок, что и списанные.
+func TestRefundRestoresLotExpiry(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = 24 * time.Hour
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 300)
+	addTestAccrual(t, s, u.ID, "12345678903", 500)
+
+	soon, later := timeAt(time.Hour), timeAt(2*time.Hour)
+
+	lots := testLots(t, s, u.ID)
+	setLotExpiry(t, s, lots[0].id, soon)
+	setLotExpiry(t, s, lots[1].id, later)
+
+	// 300 из первой партии и 300 из второй
+	addTestWithdrawal(t, s, u.ID, "2377225624", 600)
+
+	// частичный возврат возвращает сначала баллы с поздним сроком
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Sum: 200, Reason: "r"}); err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Reason: "r"}); err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	refunds := make([]testLot, 0)
+
+	for _, l := range testLots(t, s, u.ID) {
+		if l.source == models.LotSourceRefund {
+			refunds = append(refunds, l)
+		}
+	}
+
+	if len(refunds) != 3 {
+		t.Fatalf("refund lots = %+v, want 3", refunds)
+	}
+
+	want := []struct {
+		amount    int
+		expiresAt *time.Time
+	}{
+		{amount: 200, expiresAt: later},
+		{amount: 100, expiresAt: later},
+		{amount: 300, expiresAt: soon},
+	}
+
+	for i, w := range want {
+		if refunds[i].amount != w.amount || refunds[i].remaining != w.amount || !sameTime(refunds[i].expiresAt, w.expiresAt) {
+			t.Errorf("refund lot %d = %+v, want %d expiring at %v", i, refunds[i], w.amount, *w.expiresAt)
+		}
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 800 {
+		t.Errorf("balance = %d, want 800", b.Current)
+	}
+}
@@ -0,0 +1,6 @@
+CREATE TABLE login_attempts (
+    key TEXT PRIMARY KEY, -- логин или ip адрес, для которых считаются попытки
+    failures INT NOT NULL DEFAULT 0, -- количество неудачных попыток подряд
+    last_failure_at TIMESTAMP WITH TIME ZONE, -- время последней неудачной попытки
+    locked_until TIMESTAMP WITH TIME ZONE -- время, до которого вход заблокирован
+);
@@ -0,0 +1,3 @@
+DROP TABLE password_resets;
+
+ALTER TABLE users DROP COLUMN token_version;
@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN token_version INT NOT NULL DEFAULT 0; -- версия токенов, увеличивается при смене пароля
+
+CREATE TABLE password_resets (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя
+    token_hash TEXT NOT NULL UNIQUE, -- хэш одноразового токена
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время, до которого токен действителен
+    used_at TIMESTAMP WITH TIME ZONE -- время использования токена
+);
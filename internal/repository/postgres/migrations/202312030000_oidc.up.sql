@@ -0,0 +1,14 @@
+CREATE TABLE oidc_states (
+    state TEXT PRIMARY KEY, -- значение параметра state
+    nonce TEXT NOT NULL, -- nonce, который должен вернуться в id токене
+    code_verifier TEXT NOT NULL, -- PKCE code verifier
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL -- время, до которого можно завершить вход
+);
+
+CREATE TABLE user_identities (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя
+    issuer TEXT NOT NULL, -- идентификатор провайдера
+    subject TEXT NOT NULL, -- идентификатор пользователя у провайдера
+    UNIQUE (issuer, subject)
+);
@@ -0,0 +1,3 @@
+DROP TABLE role_changes;
+
+ALTER TABLE users DROP COLUMN role;
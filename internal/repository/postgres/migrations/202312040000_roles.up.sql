@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'support', 'admin')); -- роль пользователя
+
+CREATE TABLE role_changes (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, которому изменили роль
+    old_role TEXT NOT NULL, -- прежняя роль
+    new_role TEXT NOT NULL, -- новая роль
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL, -- кто изменил роль, NULL при изменении из настроек
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL -- время изменения
+);
+
+CREATE INDEX role_changes_user_idx ON role_changes (user_id);
@@ -0,0 +1,10 @@
+CREATE TABLE balance_adjustments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, которому изменили баланс
+    amount INT NOT NULL, -- сумма изменения в копейках, отрицательная при списании
+    reason TEXT NOT NULL, -- причина изменения
+    operator_id INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник, изменивший баланс
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время изменения
+);
+
+CREATE INDEX balance_adjustments_user_idx ON balance_adjustments (user_id);
@@ -0,0 +1,11 @@
+DROP INDEX balance_adjustments_pending_idx;
+
+ALTER TABLE balance_adjustments DROP CONSTRAINT balance_adjustments_reason_check;
+
+UPDATE balance_adjustments SET reason = note WHERE reason = 'other' AND note <> '';
+
+ALTER TABLE balance_adjustments DROP COLUMN reversal_of;
+ALTER TABLE balance_adjustments DROP COLUMN resolved_at;
+ALTER TABLE balance_adjustments DROP COLUMN approver_id;
+ALTER TABLE balance_adjustments DROP COLUMN status;
+ALTER TABLE balance_adjustments DROP COLUMN note;
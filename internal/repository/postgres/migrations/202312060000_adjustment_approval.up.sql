@@ -0,0 +1,15 @@
+ALTER TABLE balance_adjustments ADD COLUMN note TEXT NOT NULL DEFAULT ''; -- комментарий сотрудника
+ALTER TABLE balance_adjustments ADD COLUMN status TEXT NOT NULL DEFAULT 'applied'
+    CHECK (status IN ('pending', 'applied', 'rejected', 'reversed')); -- состояние изменения
+ALTER TABLE balance_adjustments ADD COLUMN approver_id INT REFERENCES users(id) ON DELETE SET NULL; -- сотрудник, подтвердивший или отклонивший изменение
+ALTER TABLE balance_adjustments ADD COLUMN resolved_at TIMESTAMP WITH TIME ZONE; -- время подтверждения или отклонения
+ALTER TABLE balance_adjustments ADD COLUMN reversal_of BIGINT UNIQUE REFERENCES balance_adjustments(id); -- отменяемое изменение
+
+-- раньше причина была произвольным текстом, теперь это код причины, а текст переносится в комментарий
+UPDATE balance_adjustments SET note = reason, reason = 'other'
+    WHERE reason NOT IN ('goodwill', 'compensation', 'correction', 'other');
+
+ALTER TABLE balance_adjustments ADD CONSTRAINT balance_adjustments_reason_check
+    CHECK (reason IN ('goodwill', 'compensation', 'correction', 'reversal', 'other'));
+
+CREATE INDEX balance_adjustments_pending_idx ON balance_adjustments (created_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+UPDATE users SET role = 'user' WHERE role = 'service';
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'support', 'admin'));
+
+DROP INDEX history_order_number_idx;
+DROP TABLE withdrawal_refunds;
+
+ALTER TABLE history DROP COLUMN refunded;
@@ -0,0 +1,17 @@
+ALTER TABLE history ADD COLUMN refunded INT NOT NULL DEFAULT 0; -- возвращённая на баланс часть списания в копейках
+
+CREATE TABLE withdrawal_refunds (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    history_id BIGINT NOT NULL REFERENCES history(id) ON DELETE CASCADE, -- возвращаемое списание
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, которому вернули баллы
+    sum INT NOT NULL CHECK (sum > 0), -- возвращённая сумма в копейках
+    reason TEXT NOT NULL, -- причина возврата
+    operator_id INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник или сервис, выполнивший возврат
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время возврата
+);
+
+CREATE INDEX withdrawal_refunds_history_idx ON withdrawal_refunds (history_id);
+CREATE INDEX history_order_number_idx ON history (order_number);
+
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'support', 'admin', 'service'));
@@ -0,0 +1,20 @@
+CREATE TABLE order_clawbacks (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    order_number TEXT NOT NULL UNIQUE, -- заказ, начисление по которому отозвано, отзыв выполняется один раз
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, у которого списано начисление
+    amount INT NOT NULL, -- списанное начисление в копейках
+    debt INT NOT NULL DEFAULT 0, -- часть списания, на которую баланс ушёл в минус, в копейках
+    reason TEXT NOT NULL, -- причина отзыва
+    operator_id INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник или сервис, отозвавший начисление
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время отзыва
+);
+
+CREATE TABLE notifications (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id получателя
+    kind TEXT NOT NULL, -- тип уведомления
+    message TEXT NOT NULL, -- текст уведомления
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время создания
+);
+
+CREATE INDEX notifications_user_idx ON notifications (user_id, created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, отправившего запрос
+    key TEXT NOT NULL, -- значение заголовка Idempotency-Key
+    request_hash TEXT NOT NULL, -- хэш метода, пути и тела первого запроса
+    status_code INT, -- код первого ответа, NULL пока запрос выполняется
+    content_type TEXT NOT NULL DEFAULT '', -- тип содержимого первого ответа
+    response BYTEA, -- тело первого ответа
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время первого запроса
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время, после которого ключ можно использовать повторно
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_idx ON idempotency_keys (expires_at);
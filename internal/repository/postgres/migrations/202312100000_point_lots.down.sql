@@ -0,0 +1,2 @@
+DROP TABLE point_expirations;
+DROP TABLE point_lots;
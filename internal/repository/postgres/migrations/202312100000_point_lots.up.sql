@@ -0,0 +1,27 @@
+CREATE TABLE point_lots (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id владельца баллов
+    source TEXT NOT NULL, -- откуда получены баллы: начисление за заказ, изменение баланса, возврат списания
+    reference TEXT NOT NULL DEFAULT '', -- номер заказа или id изменения баланса
+    amount INT NOT NULL, -- полученные баллы в копейках
+    remaining INT NOT NULL CHECK (remaining >= 0), -- ещё не потраченная часть в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время получения
+    expires_at TIMESTAMP WITH TIME ZONE -- время сгорания, NULL если баллы не сгорают
+);
+
+CREATE INDEX point_lots_user_idx ON point_lots (user_id, expires_at) WHERE remaining > 0;
+CREATE INDEX point_lots_expires_idx ON point_lots (expires_at) WHERE remaining > 0;
+
+CREATE TABLE point_expirations (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    lot_id BIGINT NOT NULL REFERENCES point_lots(id) ON DELETE CASCADE, -- сгоревшая партия
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id владельца баллов
+    amount INT NOT NULL, -- сгоревшие баллы в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время сгорания
+);
+
+CREATE INDEX point_expirations_user_idx ON point_expirations (user_id, created_at);
+
+-- баллы, накопленные до появления партий, не сгорают
+INSERT INTO point_lots (user_id, source, amount, remaining, created_at)
+SELECT user_id, 'initial', current, current, now() FROM balance WHERE current > 0;
@@ -0,0 +1,3 @@
+DROP INDEX point_lots_accrual_idx;
+DROP TABLE tier_changes;
+ALTER TABLE users DROP COLUMN tier;
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN tier TEXT NOT NULL DEFAULT ''; -- текущий уровень программы лояльности
+
+CREATE TABLE tier_changes (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, у которого изменился уровень
+    old_tier TEXT NOT NULL, -- прежний уровень
+    new_tier TEXT NOT NULL, -- новый уровень
+    progress INT NOT NULL, -- баллы за скользящий период, по которым определён уровень, в копейках
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL -- время изменения
+);
+
+CREATE INDEX tier_changes_user_idx ON tier_changes (user_id);
+CREATE INDEX point_lots_accrual_idx ON point_lots (user_id, created_at) WHERE source = 'accrual';
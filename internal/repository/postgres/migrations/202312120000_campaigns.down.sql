@@ -0,0 +1,3 @@
+DROP TABLE campaign_bonuses;
+DROP TABLE campaigns;
+ALTER TABLE orders DROP COLUMN channel;
@@ -0,0 +1,28 @@
+ALTER TABLE orders ADD COLUMN channel TEXT NOT NULL DEFAULT ''; -- канал, через который загружен заказ, например app
+
+CREATE TABLE campaigns (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL, -- название акции
+    kind TEXT NOT NULL CHECK (kind IN ('percent', 'fixed')), -- процент от начисления или фиксированная сумма
+    value DOUBLE PRECISION NOT NULL CHECK (value > 0), -- процент или сумма в баллах
+    starts_at TIMESTAMP WITH TIME ZONE, -- начало акции по времени загрузки заказа, NULL без ограничения
+    ends_at TIMESTAMP WITH TIME ZONE, -- окончание акции, NULL без ограничения
+    first_order BOOLEAN NOT NULL DEFAULT false, -- только за первый обработанный заказ пользователя
+    min_accrual INT NOT NULL DEFAULT 0, -- минимальное начисление за заказ в копейках
+    channel TEXT NOT NULL DEFAULT '', -- канал загрузки заказа, пустой для любого канала
+    active BOOLEAN NOT NULL DEFAULT true, -- акция включена
+    created_by INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник, создавший акцию
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время создания
+);
+
+CREATE TABLE campaign_bonuses (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    campaign_id BIGINT NOT NULL REFERENCES campaigns(id), -- сработавшая акция
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, получившего бонус
+    order_number TEXT NOT NULL, -- заказ, за который начислен бонус
+    amount INT NOT NULL, -- бонус в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время начисления
+    UNIQUE (campaign_id, order_number)
+);
+
+CREATE INDEX campaign_bonuses_order_idx ON campaign_bonuses (order_number);
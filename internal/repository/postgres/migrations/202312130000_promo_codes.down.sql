@@ -0,0 +1,3 @@
+DROP TABLE promo_redemptions;
+DROP TABLE promo_codes;
+DROP TABLE promo_batches;
@@ -0,0 +1,30 @@
+CREATE TABLE promo_batches (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL, -- название выпуска кодов, например название рассылки
+    created_by INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник, создавший коды
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время создания
+);
+
+CREATE TABLE promo_codes (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    batch_id BIGINT NOT NULL REFERENCES promo_batches(id) ON DELETE CASCADE, -- выпуск, в котором создан код
+    code TEXT NOT NULL UNIQUE, -- код в верхнем регистре
+    points INT NOT NULL CHECK (points > 0), -- начисляемые баллы в копейках
+    max_redemptions INT NOT NULL DEFAULT 1, -- сколько раз всего можно использовать код, 0 без ограничения
+    per_user_limit INT NOT NULL DEFAULT 1, -- сколько раз код может использовать один пользователь, 0 без ограничения
+    redemptions INT NOT NULL DEFAULT 0, -- сколько раз код уже использован
+    expires_at TIMESTAMP WITH TIME ZONE, -- срок действия, NULL без ограничения
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время создания
+);
+
+CREATE INDEX promo_codes_batch_idx ON promo_codes (batch_id);
+
+CREATE TABLE promo_redemptions (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    code_id BIGINT NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE, -- использованный код
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя, использовавшего код
+    amount INT NOT NULL, -- начисленные баллы в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время использования
+);
+
+CREATE INDEX promo_redemptions_code_user_idx ON promo_redemptions (code_id, user_id);
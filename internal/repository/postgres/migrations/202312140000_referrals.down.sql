@@ -0,0 +1,2 @@
+DROP TABLE referrals;
+ALTER TABLE users DROP COLUMN referral_code;
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN referral_code TEXT UNIQUE; -- реферальный код пользователя, создаётся при первом запросе
+
+CREATE TABLE referrals (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    referrer_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пригласившего пользователя
+    referee_id INT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE, -- id приглашённого пользователя
+    status TEXT NOT NULL, -- pending ожидает первого заказа, rewarded баллы начислены, rejected превышен лимит приглашений
+    referrer_bonus INT NOT NULL, -- баллы пригласившему в копейках
+    referee_bonus INT NOT NULL, -- баллы приглашённому в копейках
+    order_number TEXT, -- первый обработанный заказ приглашённого
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время регистрации приглашённого
+    rewarded_at TIMESTAMP WITH TIME ZONE, -- время начисления баллов
+    CHECK (referrer_id <> referee_id)
+);
+
+CREATE INDEX referrals_referrer_idx ON referrals (referrer_id);
@@ -0,0 +1,15 @@
+CREATE TABLE transfers (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    sender_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id отправителя
+    recipient_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id получателя
+    amount INT NOT NULL CHECK (amount > 0), -- переведённые баллы в копейках
+    status TEXT NOT NULL, -- completed выполнен, reversed отменён сотрудником
+    reversal_note TEXT NOT NULL DEFAULT '', -- причина отмены
+    reversed_by INT REFERENCES users(id) ON DELETE SET NULL, -- сотрудник, отменивший перевод
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время перевода
+    reversed_at TIMESTAMP WITH TIME ZONE, -- время отмены
+    CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX transfers_sender_idx ON transfers (sender_id, created_at);
+CREATE INDEX transfers_recipient_idx ON transfers (recipient_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE point_holds;
+ALTER TABLE balance DROP COLUMN held;
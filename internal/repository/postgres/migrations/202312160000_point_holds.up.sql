@@ -0,0 +1,16 @@
+ALTER TABLE balance ADD COLUMN held INT NOT NULL DEFAULT 0; -- удержанные баллы в копейках, недоступные для списания
+
+CREATE TABLE point_holds (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id пользователя
+    order_number TEXT NOT NULL, -- заказ, в оплату которого удержаны баллы
+    amount INT NOT NULL CHECK (amount > 0), -- удержанные баллы в копейках
+    captured INT NOT NULL DEFAULT 0, -- списанные баллы в копейках
+    status TEXT NOT NULL, -- active, captured, released или expired
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время удержания
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время автоматического снятия удержания
+    resolved_at TIMESTAMP WITH TIME ZONE -- время списания или снятия удержания
+);
+
+CREATE INDEX point_holds_user_idx ON point_holds (user_id);
+CREATE INDEX point_holds_active_idx ON point_holds (expires_at) WHERE status = 'active';
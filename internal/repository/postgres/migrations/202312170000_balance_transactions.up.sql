@@ -0,0 +1,57 @@
+CREATE TABLE balance_transactions (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id владельца баланса
+    kind TEXT NOT NULL, -- тип движения: начисление, списание, возврат, изменение баланса, перевод и т.д.
+    amount INT NOT NULL, -- изменение баланса в копейках, отрицательное при списании
+    order_number TEXT NOT NULL DEFAULT '', -- заказ, к которому относится движение
+    reference TEXT NOT NULL DEFAULT '', -- id изменения баланса, перевода, приглашения или промокод
+    balance INT NOT NULL, -- баланс после движения в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время движения
+);
+
+CREATE INDEX balance_transactions_user_idx ON balance_transactions (user_id, id);
+
+-- движения, совершённые до появления журнала, восстанавливаются из истории операций
+INSERT INTO balance_transactions (user_id, kind, amount, order_number, reference, balance, created_at)
+SELECT user_id, kind, amount, order_number, reference,
+       sum(amount) OVER (PARTITION BY user_id ORDER BY seq ROWS UNBOUNDED PRECEDING), created_at
+FROM (
+    SELECT m.*, row_number() OVER (ORDER BY created_at, kind, reference) AS seq
+    FROM (
+        SELECT user_id, 'accrual' AS kind, accrual AS amount, order_number, order_number AS reference,
+               uploaded_at AS created_at
+        FROM orders WHERE status IN ('PROCESSED', 'REVOKED') AND accrual > 0
+        UNION ALL
+        SELECT user_id, 'campaign', amount, order_number, order_number, created_at FROM campaign_bonuses
+        UNION ALL
+        SELECT r.user_id, 'promo', r.amount, '', c.code, r.created_at
+        FROM promo_redemptions r JOIN promo_codes c ON c.id = r.code_id
+        UNION ALL
+        SELECT referee_id, 'referral', referee_bonus, '', id::text, rewarded_at
+        FROM referrals WHERE status = 'rewarded' AND referee_bonus > 0
+        UNION ALL
+        SELECT referrer_id, 'referral', referrer_bonus, '', id::text, rewarded_at
+        FROM referrals WHERE status = 'rewarded' AND referrer_bonus > 0
+        UNION ALL
+        SELECT sender_id, 'transfer', -amount, '', id::text, created_at FROM transfers
+        UNION ALL
+        SELECT recipient_id, 'transfer', amount, '', id::text, created_at FROM transfers
+        UNION ALL
+        SELECT recipient_id, 'transfer', -amount, '', id::text, reversed_at FROM transfers WHERE status = 'reversed'
+        UNION ALL
+        SELECT sender_id, 'transfer', amount, '', id::text, reversed_at FROM transfers WHERE status = 'reversed'
+        UNION ALL
+        SELECT user_id, 'adjustment', amount, '', id::text, coalesce(resolved_at, created_at)
+        FROM balance_adjustments WHERE status IN ('applied', 'reversed')
+        UNION ALL
+        SELECT user_id, 'withdrawal', -sum, order_number, '', processed_at FROM history
+        UNION ALL
+        SELECT r.user_id, 'refund', r.sum, h.order_number, h.order_number, r.created_at
+        FROM withdrawal_refunds r JOIN history h ON h.id = r.history_id
+        UNION ALL
+        SELECT user_id, 'clawback', -amount, order_number, '', created_at FROM order_clawbacks
+        UNION ALL
+        SELECT user_id, 'expiration', -amount, '', lot_id::text, created_at FROM point_expirations
+    ) m
+) t
+ORDER BY seq;
@@ -0,0 +1,14 @@
+CREATE TABLE user_events (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, -- id события, по нему клиент продолжает поток
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- id получателя
+    kind TEXT NOT NULL, -- order_status, balance или withdrawal
+    order_number TEXT NOT NULL DEFAULT '', -- заказ, к которому относится событие
+    status TEXT NOT NULL DEFAULT '', -- новый статус заказа
+    transaction_kind TEXT NOT NULL DEFAULT '', -- тип движения баланса
+    amount INT NOT NULL DEFAULT 0, -- начисление по заказу, изменение баланса или сумма списания в копейках
+    balance INT NOT NULL DEFAULT 0, -- баланс после события в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL -- время события
+);
+
+CREATE INDEX user_events_user_idx ON user_events (user_id, id);
+CREATE INDEX user_events_created_idx ON user_events (created_at);
@@ -0,0 +1,49 @@
+CREATE TABLE webhooks (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    shop TEXT NOT NULL DEFAULT '', -- префикс номеров заказов магазина, пустой для всех заказов
+    url TEXT NOT NULL, -- адрес, на который отправляются события
+    secret TEXT NOT NULL, -- ключ подписи HMAC
+    events TEXT[] NOT NULL, -- типы событий: accrual, withdrawal
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INT NOT NULL REFERENCES users(id), -- id пользователя, зарегистрировавшего адрес
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- события записываются в одной транзакции с изменением заказа или баланса и затем рассылаются
+CREATE TABLE webhook_outbox (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, -- id события, передаётся получателю
+    kind TEXT NOT NULL, -- accrual или withdrawal
+    order_number TEXT NOT NULL, -- заказ, по которому начислены или списаны баллы
+    amount INT NOT NULL, -- начисленные или списанные баллы в копейках
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время события
+    dispatched_at TIMESTAMP WITH TIME ZONE -- время создания доставок по зарегистрированным адресам
+);
+
+CREATE INDEX webhook_outbox_pending_idx ON webhook_outbox (id) WHERE dispatched_at IS NULL;
+
+CREATE TABLE webhook_deliveries (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES webhook_outbox(id) ON DELETE CASCADE,
+    status TEXT NOT NULL, -- pending, delivered или failed
+    attempts INT NOT NULL DEFAULT 0, -- количество попыток доставки
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL, -- время следующей попытки
+    last_error TEXT NOT NULL DEFAULT '', -- ошибка последней попытки
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE webhook_attempts (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INT NOT NULL, -- номер попытки
+    status_code INT NOT NULL DEFAULT 0, -- код ответа получателя, 0 если ответ не получен
+    error TEXT NOT NULL DEFAULT '', -- ошибка попытки
+    duration_ms INT NOT NULL, -- длительность запроса в миллисекундах
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX webhook_attempts_delivery_idx ON webhook_attempts (delivery_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS balance_adjustments_reversal_idx;
+
+DELETE FROM balance_adjustments WHERE reversal_of IS NOT NULL AND status = 'rejected';
+
+ALTER TABLE balance_adjustments ADD CONSTRAINT balance_adjustments_reversal_of_key UNIQUE (reversal_of);
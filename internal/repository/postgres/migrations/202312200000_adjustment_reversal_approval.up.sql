@@ -0,0 +1,6 @@
+-- отмена крупного изменения ждёт подтверждения и может быть отклонена, после чего её можно запросить снова,
+-- поэтому уникальна только действующая отмена: ожидающая подтверждения или применённая
+ALTER TABLE balance_adjustments DROP CONSTRAINT balance_adjustments_reversal_of_key;
+
+CREATE UNIQUE INDEX balance_adjustments_reversal_idx ON balance_adjustments (reversal_of)
+    WHERE status IN ('pending', 'applied');
@@ -0,0 +1 @@
+DROP TABLE withdrawal_lots;
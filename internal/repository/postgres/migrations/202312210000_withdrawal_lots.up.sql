@@ -0,0 +1,10 @@
+CREATE TABLE withdrawal_lots (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    history_id BIGINT NOT NULL REFERENCES history(id) ON DELETE CASCADE, -- списание
+    lot_id BIGINT REFERENCES point_lots(id) ON DELETE SET NULL, -- израсходованная партия
+    amount INT NOT NULL, -- израсходованная часть партии в копейках
+    refunded INT NOT NULL DEFAULT 0 CHECK (refunded <= amount), -- возвращённая часть в копейках
+    expires_at TIMESTAMP WITH TIME ZONE -- срок сгорания партии, возвращённые баллы получают его же
+);
+
+CREATE INDEX withdrawal_lots_history_idx ON withdrawal_lots (history_id);
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN shop TEXT NOT NULL DEFAULT ''; -- префикс номеров заказов магазина учётной записи магазина
+
+-- адреса, зарегистрированные учётными записями магазинов до привязки к магазину, могли получать события
+-- чужих заказов, поэтому выключаются и регистрируются заново после привязки
+UPDATE webhooks w SET active = FALSE FROM users u WHERE u.id = w.created_by AND u.role <> 'admin';
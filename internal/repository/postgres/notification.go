@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Storage) GetNotifications(ctx context.Context, userID int) ([]models.Notification, error) {
+	q := "SELECT id, user_id, kind, message, created_at FROM notifications WHERE user_id = $1 ORDER BY created_at, id"
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	defer rows.Close()
+
+	notifications := make([]models.Notification, 0)
+
+	for rows.Next() {
+		n := models.Notification{}
+
+		err = rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.Message, &n.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan notification: %w", err)
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get notifications: %w", err)
+	}
+
+	if len(notifications) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return notifications, nil
+}
+
+// insertNotification сохраняет уведомление в транзакции, изменяющей баланс,
+// чтобы пользователь получил его только при успешном изменении.
+func insertNotification(ctx context.Context, tx pgx.Tx, n models.Notification) error {
+	q := "INSERT INTO notifications (user_id, kind, message, created_at) VALUES ($1, $2, $3, now())"
+
+	_, err := tx.Exec(ctx, q, n.UserID, n.Kind, n.Message)
+	if err != nil {
+		return fmt.Errorf("cannot insert notification: %w", err)
+	}
+
+	return nil
+}
@@ -33,9 +33,11 @@ var (
 type Storage struct {
 	log  *zap.Logger
 	pool *pgxpool.Pool
+	// pointsTTL срок жизни полученных баллов, 0 если баллы не сгорают.
+	pointsTTL time.Duration
 }
 
-func NewStorage(ctx context.Context, log *zap.Logger, databaseURI string) (*Storage, error) {
+func NewStorage(ctx context.Context, log *zap.Logger, databaseURI string, pointsTTL time.Duration) (*Storage, error) {
 	log.Debug(fmt.Sprintf("Storage: database uri: %s", databaseURI))
 	pool, err := pgxpool.New(ctx, databaseURI)
 
@@ -46,8 +48,9 @@ func NewStorage(ctx context.Context, log *zap.Logger, databaseURI string) (*Stor
 	}
 
 	s := &Storage{
-		log:  log,
-		pool: pool,
+		log:       log,
+		pool:      pool,
+		pointsTTL: pointsTTL,
 	}
 
 	if err = s.migrate(); err != nil {
@@ -90,29 +93,35 @@ func (s *Storage) Stop() {
 }
 
 func (s *Storage) RegisterUser(ctx context.Context, user models.User) (models.User, error) {
-	q := "INSERT INTO users (login, password) VALUES ($1, $2) RETURNING id"
+	q := "INSERT INTO users (login, password) VALUES ($1, $2) RETURNING id, role"
 
-	var id int
+	var (
+		id   int
+		role models.Role
+	)
 
-	err := s.pool.QueryRow(ctx, q, user.Login, user.Password).Scan(&id)
+	err := s.pool.QueryRow(ctx, q, user.Login, user.Password).Scan(&id, &role)
 	if err != nil {
 		return models.User{}, fmt.Errorf("cannot user register: %w", err)
 	}
 
 	user.ID = id
+	user.Role = role
 
 	return user, nil
 }
 
 func (s *Storage) GetUser(ctx context.Context, user models.User) (models.User, error) {
-	q := "SELECT id, password FROM users WHERE login = $1"
+	q := "SELECT id, password, token_version, role FROM users WHERE login = $1"
 
 	var (
-		id       int
-		password string
+		id           int
+		password     string
+		tokenVersion int
+		role         models.Role
 	)
 
-	err := s.pool.QueryRow(ctx, q, user.Login).Scan(&id, &password)
+	err := s.pool.QueryRow(ctx, q, user.Login).Scan(&id, &password, &tokenVersion, &role)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.User{}, models.ErrNotFound
@@ -123,12 +132,126 @@ func (s *Storage) GetUser(ctx context.Context, user models.User) (models.User, e
 
 	user.ID = id
 	user.Password = password
+	user.TokenVersion = tokenVersion
+	user.Role = role
 
 	return user, nil
 }
 
+func (s *Storage) GetUserByID(ctx context.Context, userID int) (models.User, error) {
+	q := "SELECT id, login, password, token_version, role FROM users WHERE id = $1"
+
+	u := models.User{}
+
+	err := s.pool.QueryRow(ctx, q, userID).Scan(&u.ID, &u.Login, &u.Password, &u.TokenVersion, &u.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, models.ErrNotFound
+		}
+
+		return models.User{}, fmt.Errorf("cannot get user by id: %w", err)
+	}
+
+	return u, nil
+}
+
+func (s *Storage) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	q := "SELECT token_version FROM users WHERE id = $1"
+
+	var version int
+
+	err := s.pool.QueryRow(ctx, q, userID).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrNotFound
+		}
+
+		return 0, fmt.Errorf("cannot get token version: %w", err)
+	}
+
+	return version, nil
+}
+
+// UpdatePassword меняет пароль пользователя и увеличивает версию токенов, отзывая выданные ранее токены.
+func (s *Storage) UpdatePassword(ctx context.Context, userID int, password string) (int, error) {
+	q := "UPDATE users SET (password, token_version) = ($1, token_version + 1) WHERE id = $2 RETURNING token_version"
+
+	var version int
+
+	err := s.pool.QueryRow(ctx, q, password, userID).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrNotFound
+		}
+
+		return 0, fmt.Errorf("cannot update password: %w", err)
+	}
+
+	return version, nil
+}
+
+// UpdatePasswordHash заменяет хэш пароля без отзыва токенов. Используется при обновлении алгоритма хэширования.
+func (s *Storage) UpdatePasswordHash(ctx context.Context, userID int, password string) error {
+	q := "UPDATE users SET password = $1 WHERE id = $2"
+
+	_, err := s.pool.Exec(ctx, q, password, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update password hash: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SavePasswordReset(ctx context.Context, reset models.PasswordReset) error {
+	q := "INSERT INTO password_resets (user_id, token_hash, expires_at) VALUES ($1, $2, $3)"
+
+	_, err := s.pool.Exec(ctx, q, reset.UserID, reset.TokenHash, reset.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot save password reset: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword в одной транзакции помечает токен сброса использованным и меняет пароль пользователя.
+func (s *Storage) ResetPassword(ctx context.Context, tokenHash, password string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := `UPDATE password_resets SET used_at = now()
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() RETURNING user_id`
+
+	var userID int
+
+	err = tx.QueryRow(ctx, q, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrInvalidToken
+		}
+
+		return fmt.Errorf("cannot use password reset: %w", err)
+	}
+
+	q = "UPDATE users SET (password, token_version) = ($1, token_version + 1) WHERE id = $2"
+
+	_, err = tx.Exec(ctx, q, password, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update password: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit password reset: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Storage) GetOrder(ctx context.Context, orderNumber string) (models.Order, error) {
-	q := "SELECT id, user_id, order_number, status, accrual, uploaded_at FROM orders WHERE order_number=$1"
+	q := "SELECT id, user_id, order_number, status, accrual, uploaded_at, channel FROM orders WHERE order_number=$1"
 
 	var o models.Order
 
@@ -138,7 +261,8 @@ func (s *Storage) GetOrder(ctx context.Context, orderNumber string) (models.Orde
 		&o.Number,
 		&o.Status,
 		&o.Accrual,
-		&o.UploadedAt)
+		&o.UploadedAt,
+		&o.Channel)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Order{}, models.ErrNotFound
@@ -151,9 +275,11 @@ func (s *Storage) GetOrder(ctx context.Context, orderNumber string) (models.Orde
 }
 
 func (s *Storage) SaveOrder(ctx context.Context, order models.Order) error {
-	q := "INSERT INTO orders (user_id, order_number, status, accrual, uploaded_at) VALUES ($1, $2, $3, $4, $5)"
+	q := `INSERT INTO orders (user_id, order_number, status, accrual, uploaded_at, channel)
+			VALUES ($1, $2, $3, $4, $5, $6)`
 
-	_, err := s.pool.Exec(ctx, q, order.UserID, order.Number, order.Status, order.Accrual, order.UploadedAt)
+	_, err := s.pool.Exec(ctx, q, order.UserID, order.Number, order.Status, order.Accrual, order.UploadedAt,
+		order.Channel)
 	if err != nil {
 		return fmt.Errorf("cannot save order: %w", err)
 	}
@@ -201,7 +327,8 @@ func (s *Storage) GetNotProcessOrders() ([]models.Order, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), storeDuration)
 	defer cancel()
 
-	q := "SELECT id, user_id, order_number, status FROM orders WHERE status not in ('INVALID', 'PROCESSED')"
+	q := `SELECT id, user_id, order_number, status, uploaded_at, channel FROM orders
+			WHERE status not in ('INVALID', 'PROCESSED', 'REVOKED')`
 
 	rows, err := s.pool.Query(ctx, q)
 	if err != nil {
@@ -219,7 +346,9 @@ func (s *Storage) GetNotProcessOrders() ([]models.Order, error) {
 			&order.ID,
 			&order.UserID,
 			&order.Number,
-			&order.Status)
+			&order.Status,
+			&order.UploadedAt,
+			&order.Channel)
 		if err != nil {
 			return nil, fmt.Errorf("cannot scan orders: %w", err)
 		}
@@ -238,83 +367,368 @@ func (s *Storage) GetNotProcessOrders() ([]models.Order, error) {
 	return orders, nil
 }
 
-func (s *Storage) UpdateOrder(ctx context.Context, orderNumber, status string, accrual int) error {
-	q := "UPDATE orders SET(status, accrual) = ($1, $2) WHERE order_number = $3"
+func (s *Storage) GetBalance(ctx context.Context, userID int) (models.Balance, error) {
+	q := "SELECT current, withdraw, held FROM balance WHERE user_id = $1"
+
+	b := models.Balance{}
 
-	_, err := s.pool.Exec(ctx, q, status, accrual, orderNumber)
+	err := s.pool.QueryRow(ctx, q, userID).Scan(&b.Current, &b.Withdraw, &b.Held)
 	if err != nil {
-		return fmt.Errorf("cannot update order: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Balance{}, models.ErrNotFound
+		}
+
+		return models.Balance{}, fmt.Errorf("cannot get balance: %w", err)
 	}
 
-	return nil
+	return b, nil
 }
 
-func (s *Storage) GetBalance(ctx context.Context, userID int) (models.Balance, error) {
-	q := "SELECT current, withdraw FROM balance WHERE user_id = $1"
+func (s *Storage) GetBalanceHistory(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error) {
+	q := "SELECT order_number, sum, refunded, processed_at FROM history WHERE user_id = $1 ORDER BY processed_at"
 
-	b := models.Balance{}
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get balance history: %w", err)
+	}
+
+	defer rows.Close()
+
+	history := make([]models.BalanceWithdrawal, 0)
+
+	for rows.Next() {
+		h := models.BalanceWithdrawal{}
 
-	err := s.pool.QueryRow(ctx, q, userID).Scan(&b.Current, &b.Withdraw)
+		err = rows.Scan(&h.Order, &h.Sum, &h.Refunded, &h.ProcessedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan balance history: %w", err)
+		}
+
+		history = append(history, h)
+	}
+
+	if len(history) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return history, rows.Err()
+}
+
+func (s *Storage) GetLoginAttempts(ctx context.Context, key string) (models.LoginAttempts, error) {
+	q := "SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1"
+
+	a := models.LoginAttempts{}
+
+	err := s.pool.QueryRow(ctx, q, key).Scan(&a.Key, &a.Failures, &a.LastFailureAt, &a.LockedUntil)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Balance{}, models.ErrNotFound
+			return models.LoginAttempts{}, models.ErrNotFound
 		}
 
-		return models.Balance{}, fmt.Errorf("cannot get balance: %w", err)
+		return models.LoginAttempts{}, fmt.Errorf("cannot get login attempts: %w", err)
 	}
 
-	return b, nil
+	return a, nil
 }
 
-func (s *Storage) UpdateBalance(ctx context.Context, balance models.Balance, userID int) error {
-	q := `INSERT INTO balance (user_id, current, withdraw) VALUES ($1, $2, $3) ON CONFLICT (user_id) DO UPDATE 
-			SET (current, withdraw) = ($2, $3)`
+// ReserveLoginAttempt засчитывает попытку входа как неудачную до проверки пароля. Попытка засчитывается,
+// только если вход не заблокирован и счётчик не изменился с тех пор, как было прочитано значение failures,
+// иначе возвращается models.ErrConflict: параллельная попытка уже заняла это место.
+func (s *Storage) ReserveLoginAttempt(
+	ctx context.Context, key string, failures int, window time.Duration,
+) (models.LoginAttempts, error) {
+	// если последняя неудачная попытка была раньше окна или блокировка закончилась, то счётчик начинается заново
+	q := `INSERT INTO login_attempts AS a (key, failures, last_failure_at) VALUES ($1, 1, now()) ON CONFLICT (key) DO UPDATE
+			SET failures = CASE WHEN a.last_failure_at < now() - make_interval(secs => $3) OR a.locked_until <= now()
+				THEN 1 ELSE a.failures + 1 END,
+				last_failure_at = now(),
+				locked_until = CASE WHEN a.locked_until <= now() THEN NULL ELSE a.locked_until END
+			WHERE a.failures = $2 AND (a.locked_until IS NULL OR a.locked_until <= now())
+			RETURNING key, failures, last_failure_at, locked_until`
+
+	a := models.LoginAttempts{}
+
+	err := s.pool.QueryRow(ctx, q, key, failures, window.Seconds()).Scan(&a.Key, &a.Failures, &a.LastFailureAt, &a.LockedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.LoginAttempts{}, models.ErrConflict
+		}
+
+		return models.LoginAttempts{}, fmt.Errorf("cannot reserve login attempt: %w", err)
+	}
+
+	return a, nil
+}
 
-	_, err := s.pool.Exec(ctx, q, userID, balance.Current, balance.Withdraw)
+// ReleaseLoginAttempt возвращает попытку, засчитанную ReserveLoginAttempt, если она не была неудачной.
+func (s *Storage) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	q := "UPDATE login_attempts SET failures = GREATEST(failures - 1, 0) WHERE key = $1"
+
+	_, err := s.pool.Exec(ctx, q, key)
 	if err != nil {
-		return fmt.Errorf("cannot update balance: %w", err)
+		return fmt.Errorf("cannot release login attempt: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) AddBalanceHistory(ctx context.Context, orderNumber string, sum, userID int) error {
-	q := "INSERT INTO history (user_id, order_number, sum, processed_at) VALUES ($1, $2, $3, now())"
+func (s *Storage) LockLogin(ctx context.Context, key string, until time.Time) error {
+	q := "UPDATE login_attempts SET locked_until = $1 WHERE key = $2"
 
-	_, err := s.pool.Exec(ctx, q, userID, orderNumber, sum)
+	_, err := s.pool.Exec(ctx, q, until, key)
 	if err != nil {
-		return fmt.Errorf("cannot insert balance history: %w", err)
+		return fmt.Errorf("cannot lock login: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) GetBalanceHistory(ctx context.Context, userID int) ([]models.BalanceWithdrawal, error) {
-	q := "SELECT order_number, sum, processed_at FROM history WHERE user_id = $1 ORDER BY processed_at"
+func (s *Storage) ResetLoginAttempts(ctx context.Context, key string) error {
+	q := "DELETE FROM login_attempts WHERE key = $1"
 
-	rows, err := s.pool.Query(ctx, q, userID)
+	_, err := s.pool.Exec(ctx, q, key)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get balance history: %w", err)
+		return fmt.Errorf("cannot reset login attempts: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveOIDCState(ctx context.Context, state models.OIDCState) error {
+	// заодно удаляем просроченные состояния незавершённых входов
+	_, err := s.pool.Exec(ctx, "DELETE FROM oidc_states WHERE expires_at < now()")
+	if err != nil {
+		return fmt.Errorf("cannot delete expired oidc states: %w", err)
+	}
+
+	q := "INSERT INTO oidc_states (state, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4)"
+
+	_, err = s.pool.Exec(ctx, q, state.State, state.Nonce, state.CodeVerifier, state.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("cannot save oidc state: %w", err)
+	}
+
+	return nil
+}
+
+// TakeOIDCState возвращает и удаляет состояние входа, чтобы его нельзя было использовать повторно.
+func (s *Storage) TakeOIDCState(ctx context.Context, state string) (models.OIDCState, error) {
+	q := "DELETE FROM oidc_states WHERE state = $1 AND expires_at > now() RETURNING state, nonce, code_verifier, expires_at"
+
+	st := models.OIDCState{}
+
+	err := s.pool.QueryRow(ctx, q, state).Scan(&st.State, &st.Nonce, &st.CodeVerifier, &st.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OIDCState{}, models.ErrNotFound
+		}
+
+		return models.OIDCState{}, fmt.Errorf("cannot take oidc state: %w", err)
+	}
+
+	return st, nil
+}
+
+func (s *Storage) GetUserByIdentity(ctx context.Context, issuer, subject string) (models.User, error) {
+	q := `SELECT u.id, u.login, u.password, u.token_version, u.role FROM users u
+			JOIN user_identities i ON i.user_id = u.id WHERE i.issuer = $1 AND i.subject = $2`
+
+	u := models.User{}
+
+	err := s.pool.QueryRow(ctx, q, issuer, subject).Scan(&u.ID, &u.Login, &u.Password, &u.TokenVersion, &u.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, models.ErrNotFound
+		}
+
+		return models.User{}, fmt.Errorf("cannot get user by identity: %w", err)
+	}
+
+	return u, nil
+}
+
+// RegisterIdentityUser в одной транзакции создаёт пользователя и связывает его с учётной записью провайдера.
+func (s *Storage) RegisterIdentityUser(ctx context.Context, user models.User, identity models.Identity) (models.User, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.User{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := "INSERT INTO users (login, password) VALUES ($1, $2) ON CONFLICT (login) DO NOTHING RETURNING id, role"
+
+	err = tx.QueryRow(ctx, q, user.Login, user.Password).Scan(&user.ID, &user.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, models.ErrConflict
+		}
+
+		return models.User{}, fmt.Errorf("cannot user register: %w", err)
+	}
+
+	q = "INSERT INTO user_identities (user_id, issuer, subject) VALUES ($1, $2, $3)"
+
+	_, err = tx.Exec(ctx, q, user.ID, identity.Issuer, identity.Subject)
+	if err != nil {
+		return models.User{}, fmt.Errorf("cannot save identity: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.User{}, fmt.Errorf("cannot commit identity user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetUserRole в одной транзакции меняет роль пользователя, отзывает его токены и записывает изменение в аудит.
+// Если роль не меняется, ничего не делает.
+func (s *Storage) SetUserRole(ctx context.Context, change models.RoleChange) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var oldRole models.Role
+
+	err = tx.QueryRow(ctx, "SELECT role FROM users WHERE id = $1 FOR UPDATE", change.UserID).Scan(&oldRole)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrNotFound
+		}
+
+		return fmt.Errorf("cannot get user role: %w", err)
+	}
+
+	if oldRole == change.NewRole {
+		return nil
+	}
+
+	q := "UPDATE users SET (role, token_version) = ($1, token_version + 1) WHERE id = $2"
+
+	_, err = tx.Exec(ctx, q, change.NewRole, change.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot update user role: %w", err)
+	}
+
+	q = `INSERT INTO role_changes (user_id, old_role, new_role, changed_by, changed_at)
+			VALUES ($1, $2, $3, NULLIF($4, 0), now())`
+
+	_, err = tx.Exec(ctx, q, change.UserID, oldRole, change.NewRole, change.ChangedBy)
+	if err != nil {
+		return fmt.Errorf("cannot insert role change: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit role change: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SearchUsers(ctx context.Context, login string, limit int) ([]models.User, error) {
+	q := "SELECT id, login, role FROM users WHERE login ILIKE '%' || $1 || '%' ORDER BY login LIMIT $2"
+
+	rows, err := s.pool.Query(ctx, q, login, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot search users: %w", err)
 	}
 
 	defer rows.Close()
 
-	history := make([]models.BalanceWithdrawal, 0)
+	users := make([]models.User, 0)
 
 	for rows.Next() {
-		h := models.BalanceWithdrawal{}
+		u := models.User{}
 
-		err = rows.Scan(&h.Order, &h.Sum, &h.ProcessedAt)
+		err = rows.Scan(&u.ID, &u.Login, &u.Role)
 		if err != nil {
-			return nil, fmt.Errorf("cannot scan balance history: %w", err)
+			return nil, fmt.Errorf("cannot scan users: %w", err)
 		}
 
-		history = append(history, h)
+		users = append(users, u)
 	}
 
-	if len(history) == 0 {
-		return nil, models.ErrNotFound
+	return users, rows.Err()
+}
+
+// SetOrderStatus задаёт статус заказа и сохраняет событие об изменении статуса для владельца заказа.
+// Заказ в одном из статусов final не меняется: статус проверяется в том же запросе, что и изменяется,
+// поэтому заказ, обработанный одновременно с изменением, останется обработанным. В этом случае
+// возвращается models.ErrConflict.
+func (s *Storage) SetOrderStatus(ctx context.Context, orderNumber, status string, final []string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
 	}
 
-	return history, rows.Err()
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := "UPDATE orders SET status = $1 WHERE order_number = $2 AND status <> ALL($3) RETURNING user_id"
+
+	rows, err := tx.Query(ctx, q, status, orderNumber, final)
+	if err != nil {
+		return fmt.Errorf("cannot set order status: %w", err)
+	}
+
+	userIDs := make([]int, 0, 1)
+
+	for rows.Next() {
+		var userID int
+
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+
+			return fmt.Errorf("cannot scan order: %w", err)
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("cannot set order status: %w", err)
+	}
+
+	if len(userIDs) == 0 {
+		var exists bool
+
+		q = "SELECT EXISTS (SELECT 1 FROM orders WHERE order_number = $1)"
+
+		if err := tx.QueryRow(ctx, q, orderNumber).Scan(&exists); err != nil {
+			return fmt.Errorf("cannot get order: %w", err)
+		}
+
+		if exists {
+			return models.ErrConflict
+		}
+
+		return models.ErrNotFound
+	}
+
+	// балансы блокируются все сразу в порядке id, события сохраняются под этой блокировкой
+	if err := lockBalances(ctx, tx, userIDs...); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		err = insertEvent(ctx, tx, models.UserEvent{
+			UserID: userID,
+			Kind:   models.EventOrderStatus,
+			Order:  orderNumber,
+			Status: status,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit order status: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gophermat/internal/models"
+)
+
+// testDatabaseEnv переменная окружения с адресом тестовой базы данных. Тесты хранилища очищают все
+// таблицы, поэтому им нужна отдельная база. Без переменной тесты хранилища пропускаются.
+const testDatabaseEnv = "TEST_DATABASE_URI"
+
+// newTestStorage подключается к тестовой базе, применяет миграции и очищает таблицы.
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	uri := os.Getenv(testDatabaseEnv)
+	if uri == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	ctx := context.Background()
+
+	s, err := NewStorage(ctx, zap.NewNop(), uri, 0)
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+
+	t.Cleanup(s.Stop)
+
+	var tables string
+
+	q := `SELECT string_agg(format('%I', tablename), ', ') FROM pg_tables
+			WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'`
+
+	if err := s.pool.QueryRow(ctx, q).Scan(&tables); err != nil {
+		t.Fatalf("cannot list tables: %v", err)
+	}
+
+	if _, err := s.pool.Exec(ctx, "TRUNCATE "+tables+" RESTART IDENTITY CASCADE"); err != nil {
+		t.Fatalf("cannot truncate tables: %v", err)
+	}
+
+	return s
+}
+
+func addTestUser(t *testing.T, s *Storage, login string) models.User {
+	t.Helper()
+
+	u, err := s.RegisterUser(context.Background(), models.User{Login: login, Password: "hash"})
+	if err != nil {
+		t.Fatalf("cannot register user: %v", err)
+	}
+
+	return u
+}
+
+func addTestOrder(t *testing.T, s *Storage, userID int, number string) {
+	t.Helper()
+
+	err := s.SaveOrder(context.Background(), models.Order{
+		UserID:     userID,
+		Number:     number,
+		Status:     "NEW",
+		UploadedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("cannot save order: %v", err)
+	}
+}
+
+// addTestAccrual загружает обработанный заказ и начисляет по нему amount копеек.
+func addTestAccrual(t *testing.T, s *Storage, userID int, number string, amount int) {
+	t.Helper()
+
+	addTestOrder(t, s, userID, number)
+
+	if _, err := s.AccrueOrder(context.Background(), number, processedStatusOrder, amount, models.CampaignAccrual{}); err != nil {
+		t.Fatalf("cannot accrue order: %v", err)
+	}
+}
+
+// testBalance возвращает баланс пользователя, у пользователя без начислений он нулевой.
+func testBalance(t *testing.T, s *Storage, userID int) models.Balance {
+	t.Helper()
+
+	b, err := s.GetBalance(context.Background(), userID)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("cannot get balance: %v", err)
+	}
+
+	return b
+}
+
+func testOrderStatus(t *testing.T, s *Storage, number string) string {
+	t.Helper()
+
+	o, err := s.GetOrder(context.Background(), number)
+	if err != nil {
+		t.Fatalf("cannot get order: %v", err)
+	}
+
+	return o.Status
+}
+
+var testFinalStatuses = []string{processedStatusOrder, "REVOKED"}
+
+func TestSetOrderStatus(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestOrder(t, s, u.ID, "12345678903")
+	addTestAccrual(t, s, u.ID, "79927398713", 500)
+
+	if err := s.SetOrderStatus(ctx, "12345678903", "INVALID", testFinalStatuses); err != nil {
+		t.Fatalf("SetOrderStatus: %v", err)
+	}
+
+	if status := testOrderStatus(t, s, "12345678903"); status != "INVALID" {
+		t.Errorf("status = %s, want INVALID", status)
+	}
+
+	// заказ INVALID можно вернуть в опрос
+	if err := s.SetOrderStatus(ctx, "12345678903", "NEW", testFinalStatuses); err != nil {
+		t.Fatalf("SetOrderStatus: %v", err)
+	}
+
+	err := s.SetOrderStatus(ctx, "79927398713", "NEW", testFinalStatuses)
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("processed order: got %v, want ErrConflict", err)
+	}
+
+	if status := testOrderStatus(t, s, "79927398713"); status != processedStatusOrder {
+		t.Errorf("status = %s, want PROCESSED", status)
+	}
+
+	if err := s.SetOrderStatus(ctx, "4561261212345467", "NEW", testFinalStatuses); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown order: got %v, want ErrNotFound", err)
+	}
+
+	events, err := s.GetEvents(ctx, u.ID, 0, 100)
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+
+	statuses := make([]string, 0)
+
+	for _, e := range events {
+		if e.Kind == models.EventOrderStatus && e.Order == "12345678903" {
+			statuses = append(statuses, e.Status)
+		}
+	}
+
+	if fmt.Sprint(statuses) != "[INVALID NEW]" {
+		t.Errorf("status events = %v", statuses)
+	}
+}
+
+// TestRepollRace проверяет, что возврат заказа в опрос одновременно с его обработкой не сбрасывает
+// обработанный заказ в NEW, иначе баллы по нему будут начислены повторно.
+func TestRepollRace(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	const orders = 20
+
+	for i := 0; i < orders; i++ {
+		addTestOrder(t, s, u.ID, fmt.Sprintf("order-%d", i))
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < orders; i++ {
+		number := fmt.Sprintf("order-%d", i)
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := s.AccrueOrder(ctx, number, processedStatusOrder, 100, models.CampaignAccrual{}); err != nil {
+				t.Errorf("AccrueOrder: %v", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			err := s.SetOrderStatus(ctx, number, "NEW", testFinalStatuses)
+			if err != nil && !errors.Is(err, models.ErrConflict) {
+				t.Errorf("SetOrderStatus: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for i := 0; i < orders; i++ {
+		if status := testOrderStatus(t, s, fmt.Sprintf("order-%d", i)); status != processedStatusOrder {
+			t.Errorf("order-%d status = %s, want PROCESSED", i, status)
+		}
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != orders*100 {
+		t.Errorf("balance = %d, want %d", b.Current, orders*100)
+	}
+}
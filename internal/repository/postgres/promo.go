@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const promoCodeColumns = `id, batch_id, code, points, max_redemptions, per_user_limit, redemptions, expires_at,
+	created_at`
+
+// AddPromoBatch в одной транзакции сохраняет выпуск и все его коды.
+// Если хотя бы один код уже существует, ничего не сохраняется и возвращается models.ErrConflict.
+func (s *Storage) AddPromoBatch(ctx context.Context, batch models.PromoBatch) (models.PromoBatch, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.PromoBatch{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := `INSERT INTO promo_batches (name, created_by, created_at) VALUES ($1, NULLIF($2, 0), now())
+			RETURNING id, created_at`
+
+	err = tx.QueryRow(ctx, q, batch.Name, batch.CreatedBy).Scan(&batch.ID, &batch.CreatedAt)
+	if err != nil {
+		return models.PromoBatch{}, fmt.Errorf("cannot insert promo batch: %w", err)
+	}
+
+	q = `INSERT INTO promo_codes (batch_id, code, points, max_redemptions, per_user_limit, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, now())
+			ON CONFLICT (code) DO NOTHING
+			RETURNING ` + promoCodeColumns
+
+	for i, c := range batch.Codes {
+		c, err = scanPromoCode(tx.QueryRow(ctx, q, batch.ID, c.Code, c.Points, c.MaxRedemptions, c.PerUserLimit,
+			c.ExpiresAt))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.PromoBatch{}, models.ErrConflict
+			}
+
+			return models.PromoBatch{}, fmt.Errorf("cannot insert promo code: %w", err)
+		}
+
+		batch.Codes[i] = c
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PromoBatch{}, fmt.Errorf("cannot commit promo batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// GetPromoBatch возвращает выпуск со всеми кодами.
+func (s *Storage) GetPromoBatch(ctx context.Context, id int64) (models.PromoBatch, error) {
+	batch := models.PromoBatch{}
+
+	q := "SELECT id, name, coalesce(created_by, 0), created_at FROM promo_batches WHERE id = $1"
+
+	err := s.pool.QueryRow(ctx, q, id).Scan(&batch.ID, &batch.Name, &batch.CreatedBy, &batch.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PromoBatch{}, models.ErrNotFound
+		}
+
+		return models.PromoBatch{}, fmt.Errorf("cannot get promo batch: %w", err)
+	}
+
+	rows, err := s.pool.Query(ctx, "SELECT "+promoCodeColumns+" FROM promo_codes WHERE batch_id = $1 ORDER BY id", id)
+	if err != nil {
+		return models.PromoBatch{}, fmt.Errorf("cannot get promo codes: %w", err)
+	}
+
+	defer rows.Close()
+
+	batch.Codes = make([]models.PromoCode, 0)
+
+	for rows.Next() {
+		c, err := scanPromoCode(rows)
+		if err != nil {
+			return models.PromoBatch{}, fmt.Errorf("cannot scan promo code: %w", err)
+		}
+
+		batch.Codes = append(batch.Codes, c)
+	}
+
+	return batch, rows.Err()
+}
+
+// RedeemPromoCode в одной транзакции проверяет ограничения кода, записывает использование и зачисляет баллы.
+// Код блокируется до конца транзакции, поэтому параллельные запросы не могут превысить ограничения.
+func (s *Storage) RedeemPromoCode(ctx context.Context, code string, userID int) (models.PromoRedemption, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.PromoRedemption{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	c, err := scanPromoCode(tx.QueryRow(ctx, "SELECT "+promoCodeColumns+" FROM promo_codes WHERE code = $1 FOR UPDATE",
+		code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PromoRedemption{}, models.ErrNotFound
+		}
+
+		return models.PromoRedemption{}, fmt.Errorf("cannot get promo code: %w", err)
+	}
+
+	if c.ExpiresAt != nil && !time.Now().Before(*c.ExpiresAt) {
+		return models.PromoRedemption{}, models.ErrPromoExpired
+	}
+
+	if c.MaxRedemptions > 0 && c.Redemptions >= c.MaxRedemptions {
+		return models.PromoRedemption{}, models.ErrPromoExhausted
+	}
+
+	if c.PerUserLimit > 0 {
+		var used int
+
+		q := "SELECT count(*) FROM promo_redemptions WHERE code_id = $1 AND user_id = $2"
+
+		if err := tx.QueryRow(ctx, q, c.ID, userID).Scan(&used); err != nil {
+			return models.PromoRedemption{}, fmt.Errorf("cannot count promo redemptions: %w", err)
+		}
+
+		if used >= c.PerUserLimit {
+			return models.PromoRedemption{}, models.ErrPromoAlreadyRedeemed
+		}
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE promo_codes SET redemptions = redemptions + 1 WHERE id = $1", c.ID)
+	if err != nil {
+		return models.PromoRedemption{}, fmt.Errorf("cannot update promo code: %w", err)
+	}
+
+	r := models.PromoRedemption{Code: c.Code, UserID: userID, Amount: c.Points}
+
+	q := `INSERT INTO promo_redemptions (code_id, user_id, amount, created_at) VALUES ($1, $2, $3, now())
+			RETURNING created_at`
+
+	if err := tx.QueryRow(ctx, q, c.ID, userID, c.Points).Scan(&r.CreatedAt); err != nil {
+		return models.PromoRedemption{}, fmt.Errorf("cannot insert promo redemption: %w", err)
+	}
+
+	if err := s.credit(ctx, tx, userID, c.Points, models.LotSourcePromo, c.Code); err != nil {
+		return models.PromoRedemption{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PromoRedemption{}, fmt.Errorf("cannot commit promo redemption: %w", err)
+	}
+
+	return r, nil
+}
+
+func scanPromoCode(row pgx.Row) (models.PromoCode, error) {
+	c := models.PromoCode{}
+
+	err := row.Scan(&c.ID, &c.BatchID, &c.Code, &c.Points, &c.MaxRedemptions, &c.PerUserLimit, &c.Redemptions,
+		&c.ExpiresAt, &c.CreatedAt)
+
+	return c, err
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+func addTestPromoCode(t *testing.T, s *Storage, c models.PromoCode) models.PromoCode {
+	t.Helper()
+
+	batch, err := s.AddPromoBatch(context.Background(), models.PromoBatch{
+		Name:  c.Code,
+		Codes: []models.PromoCode{c},
+	})
+	if err != nil {
+		t.Fatalf("AddPromoBatch: %v", err)
+	}
+
+	return batch.Codes[0]
+}
+
+func TestAddPromoBatch(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	admin := addTestUser(t, s, "admin")
+
+	batch, err := s.AddPromoBatch(ctx, models.PromoBatch{
+		Name:      "launch",
+		CreatedBy: admin.ID,
+		Codes:     []models.PromoCode{{Code: "FIRST", Points: 100}, {Code: "SECOND", Points: 100, MaxRedemptions: 5}},
+	})
+	if err != nil {
+		t.Fatalf("AddPromoBatch: %v", err)
+	}
+
+	got, err := s.GetPromoBatch(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("GetPromoBatch: %v", err)
+	}
+
+	if got.Name != "launch" || got.CreatedBy != admin.ID || len(got.Codes) != 2 ||
+		got.Codes[0].Code != "FIRST" || got.Codes[1].MaxRedemptions != 5 {
+		t.Errorf("batch = %+v", got)
+	}
+
+	// выпуск с уже существующим кодом не сохраняется целиком
+	_, err = s.AddPromoBatch(ctx, models.PromoBatch{
+		Name:  "duplicate",
+		Codes: []models.PromoCode{{Code: "THIRD", Points: 100}, {Code: "FIRST", Points: 100}},
+	})
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("duplicate code: err = %v, want ErrConflict", err)
+	}
+
+	if _, err := s.RedeemPromoCode(ctx, "THIRD", admin.ID); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("code of the rejected batch: err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := s.GetPromoBatch(ctx, batch.ID+100); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown batch: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRedeemPromoCodeLimits(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+
+	addTestPromoCode(t, s, models.PromoCode{Code: "SINGLE", Points: 100, MaxRedemptions: 1})
+	addTestPromoCode(t, s, models.PromoCode{Code: "TWICE", Points: 200, MaxRedemptions: 3, PerUserLimit: 2})
+
+	expiry := time.Now().Add(-time.Minute)
+	addTestPromoCode(t, s, models.PromoCode{Code: "EXPIRED", Points: 300, ExpiresAt: &expiry})
+
+	r, err := s.RedeemPromoCode(ctx, "SINGLE", alice.ID)
+	if err != nil {
+		t.Fatalf("RedeemPromoCode: %v", err)
+	}
+
+	if r.Code != "SINGLE" || r.UserID != alice.ID || r.Amount != 100 || r.CreatedAt.IsZero() {
+		t.Errorf("redemption = %+v", r)
+	}
+
+	tests := []struct {
+		code   string
+		userID int
+		want   error
+	}{
+		{"SINGLE", bob.ID, models.ErrPromoExhausted},
+		{"TWICE", alice.ID, nil},
+		{"TWICE", alice.ID, nil},
+		{"TWICE", alice.ID, models.ErrPromoAlreadyRedeemed},
+		{"TWICE", bob.ID, nil},
+		{"TWICE", bob.ID, models.ErrPromoExhausted},
+		{"EXPIRED", bob.ID, models.ErrPromoExpired},
+		{"UNKNOWN", bob.ID, models.ErrNotFound},
+	}
+
+	for i, tt := range tests {
+		if _, err := s.RedeemPromoCode(ctx, tt.code, tt.userID); !errors.Is(err, tt.want) {
+			t.Errorf("%d: redeem %s by %d: err = %v, want %v", i, tt.code, tt.userID, err, tt.want)
+		}
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 500 {
+		t.Errorf("alice balance = %d, want 500", b.Current)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != 200 {
+		t.Errorf("bob balance = %d, want 200", b.Current)
+	}
+}
+
+// TestRedeemPromoCodeConcurrent проверяет, что одновременные запросы не превышают ограничения кода.
+func TestRedeemPromoCodeConcurrent(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	const (
+		users = 10
+		limit = 3
+	)
+
+	addTestPromoCode(t, s, models.PromoCode{Code: "LIMITED", Points: 100, MaxRedemptions: limit})
+	addTestPromoCode(t, s, models.PromoCode{Code: "ONCE", Points: 100, PerUserLimit: 1})
+
+	ids := make([]int, users)
+	for i := range ids {
+		ids[i] = addTestUser(t, s, fmt.Sprintf("user-%d", i)).ID
+	}
+
+	once := addTestUser(t, s, "once")
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		redeemed    int
+		redeemedOne int
+	)
+
+	for i := 0; i < users; i++ {
+		wg.Add(2)
+
+		go func(userID int) {
+			defer wg.Done()
+
+			_, err := s.RedeemPromoCode(ctx, "LIMITED", userID)
+			if err != nil && !errors.Is(err, models.ErrPromoExhausted) {
+				t.Errorf("RedeemPromoCode: %v", err)
+			}
+
+			if err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			}
+		}(ids[i])
+
+		go func() {
+			defer wg.Done()
+
+			_, err := s.RedeemPromoCode(ctx, "ONCE", once.ID)
+			if err != nil && !errors.Is(err, models.ErrPromoAlreadyRedeemed) {
+				t.Errorf("RedeemPromoCode: %v", err)
+			}
+
+			if err == nil {
+				mu.Lock()
+				redeemedOne++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if redeemed != limit || redeemedOne != 1 {
+		t.Errorf("redeemed = %d and %d, want %d and 1", redeemed, redeemedOne, limit)
+	}
+
+	total := 0
+	for _, id := range ids {
+		total += testBalance(t, s, id).Current
+	}
+
+	if total != limit*100 {
+		t.Errorf("credited = %d, want %d", total, limit*100)
+	}
+
+	if b := testBalance(t, s, once.ID); b.Current != 100 {
+		t.Errorf("balance = %d, want 100", b.Current)
+	}
+}
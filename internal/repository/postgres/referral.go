@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RegisterReferredUser в одной транзакции регистрирует пользователя и сохраняет приглашение по реферальному коду.
+// Если пригласивший уже достиг лимита приглашений, приглашение сохраняется в статусе rejected без баллов.
+// Неизвестный код возвращает models.ErrNotFound.
+func (s *Storage) RegisterReferredUser(
+	ctx context.Context,
+	user models.User,
+	signup models.ReferralSignup,
+) (models.User, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.User{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var referrerID int
+
+	// пригласивший блокируется, чтобы параллельные регистрации не превысили лимит приглашений
+	q := "SELECT id FROM users WHERE referral_code = $1 FOR UPDATE"
+
+	if err := tx.QueryRow(ctx, q, signup.Code).Scan(&referrerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, models.ErrNotFound
+		}
+
+		return models.User{}, fmt.Errorf("cannot get referrer: %w", err)
+	}
+
+	q = "INSERT INTO users (login, password) VALUES ($1, $2) RETURNING id, role"
+
+	if err := tx.QueryRow(ctx, q, user.Login, user.Password).Scan(&user.ID, &user.Role); err != nil {
+		return models.User{}, fmt.Errorf("cannot user register: %w", err)
+	}
+
+	status := models.ReferralPending
+	referrerBonus, refereeBonus := signup.Policy.ReferrerBonus, signup.Policy.RefereeBonus
+
+	if signup.Policy.MaxPerUser > 0 {
+		var count int
+
+		q = "SELECT count(*) FROM referrals WHERE referrer_id = $1 AND status <> $2"
+
+		if err := tx.QueryRow(ctx, q, referrerID, models.ReferralRejected).Scan(&count); err != nil {
+			return models.User{}, fmt.Errorf("cannot count referrals: %w", err)
+		}
+
+		if count >= signup.Policy.MaxPerUser {
+			status = models.ReferralRejected
+			referrerBonus, refereeBonus = 0, 0
+		}
+	}
+
+	q = `INSERT INTO referrals (referrer_id, referee_id, status, referrer_bonus, referee_bonus, created_at)
+			VALUES ($1, $2, $3, $4, $5, now())`
+
+	_, err = tx.Exec(ctx, q, referrerID, user.ID, status, referrerBonus, refereeBonus)
+	if err != nil {
+		return models.User{}, fmt.Errorf("cannot insert referral: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.User{}, fmt.Errorf("cannot commit referred user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetReferralCode задаёт пользователю реферальный код, если он ещё не задан, и возвращает код пользователя.
+// Если код уже занят другим пользователем, возвращается models.ErrConflict.
+func (s *Storage) SetReferralCode(ctx context.Context, userID int, code string) (string, error) {
+	q := `UPDATE users SET referral_code = $2 WHERE id = $1 AND referral_code IS NULL
+			AND NOT EXISTS (SELECT 1 FROM users WHERE referral_code = $2)`
+
+	if _, err := s.pool.Exec(ctx, q, userID, code); err != nil {
+		return "", fmt.Errorf("cannot set referral code: %w", err)
+	}
+
+	var current *string
+
+	err := s.pool.QueryRow(ctx, "SELECT referral_code FROM users WHERE id = $1", userID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrUserNotFound
+		}
+
+		return "", fmt.Errorf("cannot get referral code: %w", err)
+	}
+
+	if current == nil {
+		return "", models.ErrConflict
+	}
+
+	return *current, nil
+}
+
+// GetReferrals возвращает приглашения пользователя, новые первыми.
+func (s *Storage) GetReferrals(ctx context.Context, referrerID int) ([]models.Referral, error) {
+	q := `SELECT r.id, r.referrer_id, r.referee_id, u.login, r.status, r.referrer_bonus, r.referee_bonus,
+			coalesce(r.order_number, ''), r.created_at, r.rewarded_at
+			FROM referrals r JOIN users u ON u.id = r.referee_id
+			WHERE r.referrer_id = $1 ORDER BY r.created_at DESC, r.id DESC`
+
+	rows, err := s.pool.Query(ctx, q, referrerID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get referrals: %w", err)
+	}
+
+	defer rows.Close()
+
+	referrals := make([]models.Referral, 0)
+
+	for rows.Next() {
+		r := models.Referral{}
+
+		err = rows.Scan(&r.ID, &r.ReferrerID, &r.RefereeID, &r.RefereeLogin, &r.Status, &r.ReferrerBonus,
+			&r.RefereeBonus, &r.OrderNumber, &r.CreatedAt, &r.RewardedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan referral: %w", err)
+		}
+
+		referrals = append(referrals, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get referrals: %w", err)
+	}
+
+	return referrals, nil
+}
+
+// rewardReferral начисляет баллы за приглашение пользователю и пригласившему его, если приглашение
+// ожидает первого обработанного заказа. Вызывается в транзакции начисления по заказу.
+func (s *Storage) rewardReferral(ctx context.Context, tx pgx.Tx, refereeID int, orderNumber string) error {
+	r := models.Referral{}
+
+	q := `UPDATE referrals SET status = $1, order_number = $2, rewarded_at = now()
+			WHERE referee_id = $3 AND status = $4
+			RETURNING id, referrer_id, referrer_bonus, referee_bonus`
+
+	err := tx.QueryRow(ctx, q, models.ReferralRewarded, orderNumber, refereeID, models.ReferralPending).
+		Scan(&r.ID, &r.ReferrerID, &r.ReferrerBonus, &r.RefereeBonus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		return fmt.Errorf("cannot update referral: %w", err)
+	}
+
+	reference := referralReference(r.ID)
+
+	// балансы обоих пользователей уже заблокированы lockReferralBalances в порядке id,
+	// повторная блокировка в той же транзакции ничего не ждёт
+	if err := lockBalances(ctx, tx, refereeID, r.ReferrerID); err != nil {
+		return err
+	}
+
+	if err := s.credit(ctx, tx, refereeID, r.RefereeBonus, models.LotSourceReferral, reference); err != nil {
+		return err
+	}
+
+	if err := s.credit(ctx, tx, r.ReferrerID, r.ReferrerBonus, models.LotSourceReferral, reference); err != nil {
+		return err
+	}
+
+	if r.RefereeBonus > 0 {
+		err = insertNotification(ctx, tx, models.Notification{
+			UserID:  refereeID,
+			Kind:    models.NotificationReferralRewarded,
+			Message: fmt.Sprintf("%.2f points credited for signing up by invitation", float64(r.RefereeBonus)/100),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.ReferrerBonus > 0 {
+		err = insertNotification(ctx, tx, models.Notification{
+			UserID:  r.ReferrerID,
+			Kind:    models.NotificationReferralRewarded,
+			Message: fmt.Sprintf("%.2f points credited for an invited user", float64(r.ReferrerBonus)/100),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockReferralBalances блокирует баланс пользователя и, если его приглашение ожидает награды,
+// баланс пригласившего. Балансы блокируются через lockBalances в порядке id.
+func lockReferralBalances(ctx context.Context, tx pgx.Tx, refereeID int) error {
+	var referrerID int
+
+	q := "SELECT referrer_id FROM referrals WHERE referee_id = $1 AND status = $2"
+
+	err := tx.QueryRow(ctx, q, refereeID, models.ReferralPending).Scan(&referrerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return lockBalances(ctx, tx, refereeID)
+		}
+
+		return fmt.Errorf("cannot get referral: %w", err)
+	}
+
+	return lockBalances(ctx, tx, refereeID, referrerID)
+}
+
+func referralReference(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
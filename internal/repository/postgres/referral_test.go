@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+var testReferralPolicy = models.ReferralPolicy{ReferrerBonus: 300, RefereeBonus: 100}
+
+func addTestReferee(t *testing.T, s *Storage, login, code string, policy models.ReferralPolicy) models.User {
+	t.Helper()
+
+	u, err := s.RegisterReferredUser(context.Background(), models.User{Login: login, Password: "hash"},
+		models.ReferralSignup{Code: code, Policy: policy})
+	if err != nil {
+		t.Fatalf("RegisterReferredUser: %v", err)
+	}
+
+	return u
+}
+
+func testReferralCode(t *testing.T, s *Storage, userID int, code string) {
+	t.Helper()
+
+	if _, err := s.SetReferralCode(context.Background(), userID, code); err != nil {
+		t.Fatalf("SetReferralCode: %v", err)
+	}
+}
+
+func TestRegisterReferredUser(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	testReferralCode(t, s, alice.ID, "ALICE")
+
+	policy := testReferralPolicy
+	policy.MaxPerUser = 1
+
+	addTestReferee(t, s, "bob", "ALICE", policy)
+	addTestReferee(t, s, "carol", "ALICE", policy)
+
+	_, err := s.RegisterReferredUser(ctx, models.User{Login: "dave", Password: "hash"},
+		models.ReferralSignup{Code: "UNKNOWN", Policy: policy})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown code: err = %v, want ErrNotFound", err)
+	}
+
+	referrals, err := s.GetReferrals(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("GetReferrals: %v", err)
+	}
+
+	// приглашения сверх лимита сохраняются отклонёнными и без баллов
+	if len(referrals) != 2 ||
+		referrals[0].RefereeLogin != "carol" || referrals[0].Status != models.ReferralRejected ||
+		referrals[0].ReferrerBonus != 0 || referrals[0].RefereeBonus != 0 ||
+		referrals[1].RefereeLogin != "bob" || referrals[1].Status != models.ReferralPending ||
+		referrals[1].ReferrerBonus != 300 || referrals[1].RefereeBonus != 100 {
+		t.Errorf("referrals = %+v", referrals)
+	}
+}
+
+func TestRewardReferral(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	testReferralCode(t, s, alice.ID, "ALICE")
+
+	bob := addTestReferee(t, s, "bob", "ALICE", testReferralPolicy)
+
+	// необработанный заказ не вознаграждает приглашение
+	addTestOrder(t, s, bob.ID, "79927398713")
+
+	if _, err := s.AccrueOrder(ctx, "79927398713", "PROCESSING", 0, models.CampaignAccrual{}); err != nil {
+		t.Fatalf("AccrueOrder: %v", err)
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 0 {
+		t.Errorf("referrer balance before processed order = %d, want 0", b.Current)
+	}
+
+	if _, err := s.AccrueOrder(ctx, "79927398713", processedStatusOrder, 500, models.CampaignAccrual{}); err != nil {
+		t.Fatalf("AccrueOrder: %v", err)
+	}
+
+	addTestAccrual(t, s, bob.ID, "12345678903", 500)
+
+	if b := testBalance(t, s, alice.ID); b.Current != 300 {
+		t.Errorf("referrer balance = %d, want 300", b.Current)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != 1100 {
+		t.Errorf("referee balance = %d, want 1100", b.Current)
+	}
+
+	referrals, err := s.GetReferrals(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("GetReferrals: %v", err)
+	}
+
+	if len(referrals) != 1 || referrals[0].Status != models.ReferralRewarded ||
+		referrals[0].OrderNumber != "79927398713" || referrals[0].RewardedAt == nil {
+		t.Errorf("referrals = %+v, want rewarded by the first processed order", referrals)
+	}
+}
+
+// TestRewardReferralConcurrentTransfers проверяет, что награда за приглашение и одновременные переводы
+// между пригласившим и приглашёнными блокируют балансы в одном порядке и не приводят к взаимной блокировке.
+func TestRewardReferralConcurrentTransfers(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	const referees = 10
+
+	alice := addTestUser(t, s, "alice")
+	testReferralCode(t, s, alice.ID, "ALICE")
+	addTestAccrual(t, s, alice.ID, "order-alice", referees*100)
+
+	ids := make([]int, referees)
+	for i := range ids {
+		ids[i] = addTestReferee(t, s, fmt.Sprintf("user-%d", i), "ALICE", testReferralPolicy).ID
+		addTestOrder(t, s, ids[i], fmt.Sprintf("order-%d", i))
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range ids {
+		wg.Add(2)
+
+		go func(number string) {
+			defer wg.Done()
+
+			if _, err := s.AccrueOrder(ctx, number, processedStatusOrder, 0, models.CampaignAccrual{}); err != nil {
+				t.Errorf("AccrueOrder: %v", err)
+			}
+		}(fmt.Sprintf("order-%d", i))
+
+		go func(recipient string) {
+			defer wg.Done()
+
+			_, err := s.Transfer(ctx, models.Transfer{SenderID: alice.ID, RecipientLogin: recipient, Amount: 100},
+				models.TransferLimits{})
+			if err != nil {
+				t.Errorf("Transfer: %v", err)
+			}
+		}(fmt.Sprintf("user-%d", i))
+	}
+
+	wg.Wait()
+
+	if b := testBalance(t, s, alice.ID); b.Current != referees*300 {
+		t.Errorf("referrer balance = %d, want %d", b.Current, referees*300)
+	}
+
+	for _, id := range ids {
+		if b := testBalance(t, s, id); b.Current != 200 {
+			t.Errorf("referee %d balance = %d, want 200", id, b.Current)
+		}
+	}
+}
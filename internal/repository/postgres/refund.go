@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RefundWithdrawal в одной транзакции возвращает на баланс баллы, списанные за заказ, и сохраняет
+// запись о возврате для каждого затронутого списания. Если за заказ было несколько списаний,
+// возврат распределяется по ним начиная с самого раннего. Возвращённые баллы получают срок сгорания партий,
+// из которых были списаны.
+// Возвращает models.ErrNotFound, если списаний за заказ нет, и models.ErrConflict, если сумма возврата
+// больше невозвращённой части или списания за заказ принадлежат разным пользователям.
+func (s *Storage) RefundWithdrawal(
+	ctx context.Context,
+	refund models.WithdrawalRefund,
+) (models.BalanceWithdrawal, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	type withdrawal struct {
+		id       int64
+		userID   int
+		sum      int
+		refunded int
+	}
+
+	q := `SELECT id, user_id, sum, refunded FROM history WHERE order_number = $1
+			ORDER BY processed_at, id FOR UPDATE`
+
+	rows, err := tx.Query(ctx, q, refund.Order)
+	if err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot get withdrawals: %w", err)
+	}
+
+	withdrawals := make([]withdrawal, 0)
+
+	for rows.Next() {
+		w := withdrawal{}
+
+		if err := rows.Scan(&w.id, &w.userID, &w.sum, &w.refunded); err != nil {
+			rows.Close()
+
+			return models.BalanceWithdrawal{}, fmt.Errorf("cannot scan withdrawal: %w", err)
+		}
+
+		withdrawals = append(withdrawals, w)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot get withdrawals: %w", err)
+	}
+
+	if len(withdrawals) == 0 {
+		return models.BalanceWithdrawal{}, models.ErrNotFound
+	}
+
+	remaining := 0
+
+	for _, w := range withdrawals {
+		if w.userID != withdrawals[0].userID {
+			return models.BalanceWithdrawal{}, models.ErrConflict
+		}
+
+		remaining += w.sum - w.refunded
+	}
+
+	if refund.Sum == 0 {
+		refund.Sum = remaining
+	}
+
+	if remaining == 0 || refund.Sum > remaining {
+		return models.BalanceWithdrawal{}, models.ErrConflict
+	}
+
+	userID := withdrawals[0].userID
+	left := refund.Sum
+	lots := make([]lotPart, 0)
+
+	for _, w := range withdrawals {
+		part := w.sum - w.refunded
+		if part > left {
+			part = left
+		}
+
+		if part == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE history SET refunded = refunded + $1 WHERE id = $2", part, w.id)
+		if err != nil {
+			return models.BalanceWithdrawal{}, fmt.Errorf("cannot update withdrawal: %w", err)
+		}
+
+		q = `INSERT INTO withdrawal_refunds (history_id, user_id, sum, reason, operator_id, created_at)
+				VALUES ($1, $2, $3, $4, NULLIF($5, 0), now())`
+
+		_, err = tx.Exec(ctx, q, w.id, userID, part, refund.Reason, refund.OperatorID)
+		if err != nil {
+			return models.BalanceWithdrawal{}, fmt.Errorf("cannot insert withdrawal refund: %w", err)
+		}
+
+		parts, err := s.refundLots(ctx, tx, w.id, part)
+		if err != nil {
+			return models.BalanceWithdrawal{}, err
+		}
+
+		lots = append(lots, parts...)
+
+		left -= part
+		if left == 0 {
+			break
+		}
+	}
+
+	var current int
+
+	q = "UPDATE balance SET current = current + $1, withdraw = withdraw - $1 WHERE user_id = $2 RETURNING current"
+
+	err = tx.QueryRow(ctx, q, refund.Sum, userID).Scan(&current)
+	if err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:    userID,
+		Kind:      models.LotSourceRefund,
+		Amount:    refund.Sum,
+		Order:     refund.Order,
+		Reference: refund.Order,
+		Balance:   current,
+	})
+	if err != nil {
+		return models.BalanceWithdrawal{}, err
+	}
+
+	// возвращённые баллы сохраняют срок сгорания партий, из которых были списаны
+	before := current - refund.Sum
+
+	for _, p := range lots {
+		err = s.addLot(ctx, tx, userID, p.amount, before, models.LotSourceRefund, refund.Order, p.expiresAt)
+		if err != nil {
+			return models.BalanceWithdrawal{}, err
+		}
+
+		before += p.amount
+	}
+
+	result := models.BalanceWithdrawal{Order: refund.Order}
+
+	q = `SELECT coalesce(sum(sum), 0), coalesce(sum(refunded), 0), min(processed_at) FROM history
+			WHERE order_number = $1`
+
+	err = tx.QueryRow(ctx, q, refund.Order).Scan(&result.Sum, &result.Refunded, &result.ProcessedAt)
+	if err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot get withdrawal: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.BalanceWithdrawal{}, fmt.Errorf("cannot commit withdrawal refund: %w", err)
+	}
+
+	return result, nil
+}
+
+// refundLots отмечает возврат amount копеек списания historyID и возвращает части партий, из которых
+// были списаны возвращаемые баллы. Части возвращаются в порядке, обратном расходованию, поэтому
+// первыми возвращаются баллы с самым поздним сроком сгорания. Баллы списаний, для которых партии
+// не сохранены, получают срок сгорания новых баллов.
+func (s *Storage) refundLots(ctx context.Context, tx pgx.Tx, historyID int64, amount int) ([]lotPart, error) {
+	type withdrawalLot struct {
+		id        int64
+		amount    int
+		expiresAt *time.Time
+	}
+
+	q := `SELECT id, amount - refunded, expires_at FROM withdrawal_lots
+			WHERE history_id = $1 AND refunded < amount ORDER BY id DESC FOR UPDATE`
+
+	rows, err := tx.Query(ctx, q, historyID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get withdrawal lots: %w", err)
+	}
+
+	lots := make([]withdrawalLot, 0)
+
+	for rows.Next() {
+		l := withdrawalLot{}
+
+		if err := rows.Scan(&l.id, &l.amount, &l.expiresAt); err != nil {
+			rows.Close()
+
+			return nil, fmt.Errorf("cannot scan withdrawal lot: %w", err)
+		}
+
+		lots = append(lots, l)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get withdrawal lots: %w", err)
+	}
+
+	parts := make([]lotPart, 0, len(lots)+1)
+
+	for _, l := range lots {
+		if amount == 0 {
+			break
+		}
+
+		part := l.amount
+		if part > amount {
+			part = amount
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE withdrawal_lots SET refunded = refunded + $1 WHERE id = $2", part, l.id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot update withdrawal lot: %w", err)
+		}
+
+		parts = append(parts, lotPart{amount: part, expiresAt: l.expiresAt})
+		amount -= part
+	}
+
+	if amount > 0 {
+		parts = append(parts, lotPart{amount: amount, expiresAt: s.lotExpiry()})
+	}
+
+	return parts, nil
+}
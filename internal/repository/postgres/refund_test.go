@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+var testWithdrawLimits = models.WithdrawLimits{MaxPerOrder: 2}
+
+// addTestWithdrawal списывает sum копеек в оплату заказа number.
+func addTestWithdrawal(t *testing.T, s *Storage, userID int, number string, sum int) {
+	t.Helper()
+
+	err := s.Withdraw(context.Background(), userID, models.BalanceWithdraw{Order: number, Sum: sum}, testWithdrawLimits)
+	if err != nil {
+		t.Fatalf("cannot withdraw: %v", err)
+	}
+}
+
+func TestRefundWithdrawal(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	op := addTestUser(t, s, "shop")
+
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 600)
+
+	w, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{
+		Order: "2377225624", Sum: 200, Reason: "item returned", OperatorID: op.ID,
+	})
+	if err != nil {
+		t.Fatalf("partial refund: %v", err)
+	}
+
+	if w.Sum != 600 || w.Refunded != 200 || w.Status() != models.WithdrawalPartiallyRefunded {
+		t.Errorf("withdrawal after partial refund = %+v", w)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 600 || b.Withdraw != 400 {
+		t.Errorf("balance = %+v, want current 600, withdraw 400", b)
+	}
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{
+		Order: "2377225624", Sum: 500, Reason: "too much",
+	}); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("refund above remaining part: got %v, want ErrConflict", err)
+	}
+
+	// нулевая сумма возвращает всю оставшуюся часть
+	w, err = s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Reason: "order cancelled"})
+	if err != nil {
+		t.Fatalf("full refund: %v", err)
+	}
+
+	if w.Refunded != 600 || w.Status() != models.WithdrawalRefunded {
+		t.Errorf("withdrawal after full refund = %+v", w)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 1000 || b.Withdraw != 0 {
+		t.Errorf("balance = %+v, want current 1000, withdraw 0", b)
+	}
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{
+		Order: "2377225624", Reason: "again",
+	}); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("refund of refunded withdrawal: got %v, want ErrConflict", err)
+	}
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{
+		Order: "12345678903", Reason: "unknown",
+	}); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("unknown order: got %v, want ErrNotFound", err)
+	}
+
+	history, err := s.GetBalanceHistory(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetBalanceHistory: %v", err)
+	}
+
+	if len(history) != 1 || history[0].Status() != models.WithdrawalRefunded {
+		t.Errorf("history = %+v", history)
+	}
+}
+
+// TestRefundSeveralWithdrawals проверяет, что возврат по заказу, оплаченному частями,
+// распределяется по списаниям начиная с самого раннего.
+func TestRefundSeveralWithdrawals(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 300)
+	addTestWithdrawal(t, s, u.ID, "2377225624", 400)
+
+	w, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Sum: 500, Reason: "partial"})
+	if err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	if w.Sum != 700 || w.Refunded != 500 {
+		t.Errorf("withdrawal = %+v, want sum 700, refunded 500", w)
+	}
+
+	history, err := s.GetBalanceHistory(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetBalanceHistory: %v", err)
+	}
+
+	if len(history) != 2 || history[0].Refunded != 300 || history[1].Refunded != 200 {
+		t.Errorf("history = %+v, want refunds 300 and 200", history)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 800 || b.Withdraw != 200 {
+		t.Errorf("balance = %+v, want current 800, withdraw 200", b)
+	}
+
+	// после полного возврата заказ можно снова оплатить баллами
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Reason: "rest"}); err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	addTestWithdrawal(t, s, u.ID, "2377225624", 100)
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetStatementBalance возвращает баланс пользователя на момент before: баланс после последнего движения
+// из журнала, совершённого раньше before.
+func (s *Storage) GetStatementBalance(ctx context.Context, userID int, before time.Time) (int, error) {
+	q := `SELECT balance FROM balance_transactions WHERE user_id = $1 AND created_at < $2
+			ORDER BY id DESC LIMIT 1`
+
+	var balance int
+
+	if err := s.pool.QueryRow(ctx, q, userID, before).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("cannot get statement balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// StreamStatement передаёт в fn движения баланса пользователя из журнала за период [from, to) в порядке
+// их совершения по мере чтения из базы, не загружая их в память. Ошибка fn прерывает чтение.
+func (s *Storage) StreamStatement(
+	ctx context.Context,
+	userID int,
+	from, to time.Time,
+	fn func(models.StatementEntry) error,
+) error {
+	// движения одного пользователя записываются под блокировкой его баланса, поэтому порядок id
+	// совпадает с порядком изменения баланса
+	q := `SELECT kind, order_number, amount, balance, created_at FROM balance_transactions
+			WHERE user_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY id`
+
+	rows, err := s.pool.Query(ctx, q, userID, from, to)
+	if err != nil {
+		return fmt.Errorf("cannot get statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		e := models.StatementEntry{}
+
+		if err := rows.Scan(&e.Kind, &e.Order, &e.Amount, &e.Balance, &e.Time); err != nil {
+			return fmt.Errorf("cannot scan statement entry: %w", err)
+		}
+
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("cannot get statement: %w", err)
+	}
+
+	return nil
+}
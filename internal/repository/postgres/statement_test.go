@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+// setTransactionTimes переносит движения пользователя из журнала на заданное время в порядке их совершения.
+func setTransactionTimes(t *testing.T, s *Storage, userID int, times ...time.Time) {
+	t.Helper()
+
+	transactions := testTransactions(t, s, userID)
+	if len(transactions) != len(times) {
+		t.Fatalf("transactions = %d, want %d", len(transactions), len(times))
+	}
+
+	for i, tr := range transactions {
+		_, err := s.pool.Exec(context.Background(), "UPDATE balance_transactions SET created_at = $1 WHERE id = $2",
+			times[i], tr.ID)
+		if err != nil {
+			t.Fatalf("cannot set transaction time: %v", err)
+		}
+	}
+}
+
+func testStatement(t *testing.T, s *Storage, userID int, from time.Time) (int, []models.StatementEntry) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	opening, err := s.GetStatementBalance(ctx, userID, from)
+	if err != nil {
+		t.Fatalf("GetStatementBalance: %v", err)
+	}
+
+	entries := make([]models.StatementEntry, 0)
+
+	err = s.StreamStatement(ctx, userID, from, from.AddDate(0, 1, 0), func(e models.StatementEntry) error {
+		entries = append(entries, e)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamStatement: %v", err)
+	}
+
+	return opening, entries
+}
+
+// TestStatementFromJournal проверяет, что выписка строится по журналу движений баланса:
+// в неё попадают все типы движений с балансом после каждого.
+func TestStatementFromJournal(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+	addTestWithdrawal(t, s, alice.ID, "2377225624", 300)
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Sum: 100, Reason: "r"}); err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	addTestTransfer(t, s, alice.ID, bob.ID, 200)
+	addTestAccrual(t, s, alice.ID, "12345678903", 500)
+
+	jan := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	mar := feb.AddDate(0, 1, 0)
+
+	setTransactionTimes(t, s, alice.ID,
+		jan.AddDate(0, 0, 14), jan.AddDate(0, 0, 19), feb.AddDate(0, 0, 2), feb.AddDate(0, 0, 9), mar)
+
+	tests := []struct {
+		month   time.Time
+		opening int
+		entries []models.StatementEntry
+	}{
+		{
+			month:   jan,
+			opening: 0,
+			entries: []models.StatementEntry{
+				{Kind: models.LotSourceAccrual, Order: "79927398713", Amount: 1000, Balance: 1000},
+				{Kind: models.TransactionWithdrawal, Order: "2377225624", Amount: -300, Balance: 700},
+			},
+		},
+		{
+			month:   feb,
+			opening: 700,
+			entries: []models.StatementEntry{
+				{Kind: models.LotSourceRefund, Order: "2377225624", Amount: 100, Balance: 800},
+				{Kind: models.LotSourceTransfer, Amount: -200, Balance: 600},
+			},
+		},
+		{
+			month:   mar,
+			opening: 600,
+			entries: []models.StatementEntry{
+				{Kind: models.LotSourceAccrual, Order: "12345678903", Amount: 500, Balance: 1100},
+			},
+		},
+		{month: mar.AddDate(0, 1, 0), opening: 1100, entries: []models.StatementEntry{}},
+	}
+
+	for _, tt := range tests {
+		opening, entries := testStatement(t, s, alice.ID, tt.month)
+
+		if opening != tt.opening || len(entries) != len(tt.entries) {
+			t.Errorf("%s: opening %d, entries %+v, want %d and %d entries",
+				tt.month.Format("2006-01"), opening, entries, tt.opening, len(tt.entries))
+
+			continue
+		}
+
+		for i, w := range tt.entries {
+			e := entries[i]
+			if e.Kind != w.Kind || e.Order != w.Order || e.Amount != w.Amount || e.Balance != w.Balance ||
+				e.Time.Before(tt.month) || !e.Time.Before(tt.month.AddDate(0, 1, 0)) {
+				t.Errorf("%s: entry %d = %+v, want %+v", tt.month.Format("2006-01"), i, e, w)
+			}
+		}
+	}
+
+	// получатель видит перевод в своей выписке за месяц, в котором он совершён
+	now := time.Now().UTC()
+
+	opening, entries := testStatement(t, s, bob.ID, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC))
+	if opening != 0 || len(entries) != 1 || entries[0].Amount != 200 || entries[0].Balance != 200 {
+		t.Errorf("recipient statement: opening %d, entries %+v", opening, entries)
+	}
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTierProgress возвращает баллы пользователя, начисленные за заказы или потраченные начиная с since.
+// Начисления по отозванным заказам и возвращённые списания не учитываются.
+func (s *Storage) GetTierProgress(ctx context.Context, userID int, basis string, since time.Time) (int, error) {
+	var q string
+
+	switch basis {
+	case models.TierBasisAccrued:
+		q = `SELECT coalesce(sum(l.amount), 0) FROM point_lots l
+				JOIN orders o ON o.order_number = l.reference AND o.status = 'PROCESSED'
+				WHERE l.user_id = $1 AND l.source = 'accrual' AND l.created_at >= $2`
+	case models.TierBasisSpent:
+		q = "SELECT coalesce(sum(sum - refunded), 0) FROM history WHERE user_id = $1 AND processed_at >= $2"
+	default:
+		return 0, fmt.Errorf("%w: tier basis %q", models.ErrInvalidInput, basis)
+	}
+
+	var progress int
+
+	if err := s.pool.QueryRow(ctx, q, userID, since).Scan(&progress); err != nil {
+		return 0, fmt.Errorf("cannot get tier progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// UpdateUserTier сохраняет уровень пользователя и записывает изменение в историю.
+// Если уровень не изменился, ничего не сохраняется и возвращается changed = false.
+func (s *Storage) UpdateUserTier(ctx context.Context, change models.TierChange) (models.TierChange, bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.TierChange{}, false, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	err = tx.QueryRow(ctx, "SELECT tier FROM users WHERE id = $1 FOR UPDATE", change.UserID).Scan(&change.OldTier)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TierChange{}, false, models.ErrUserNotFound
+		}
+
+		return models.TierChange{}, false, fmt.Errorf("cannot get user tier: %w", err)
+	}
+
+	if change.OldTier == change.NewTier {
+		return change, false, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET tier = $1 WHERE id = $2", change.NewTier, change.UserID)
+	if err != nil {
+		return models.TierChange{}, false, fmt.Errorf("cannot update user tier: %w", err)
+	}
+
+	q := `INSERT INTO tier_changes (user_id, old_tier, new_tier, progress, changed_at)
+			VALUES ($1, $2, $3, $4, now()) RETURNING changed_at`
+
+	err = tx.QueryRow(ctx, q, change.UserID, change.OldTier, change.NewTier, change.Progress).Scan(&change.ChangedAt)
+	if err != nil {
+		return models.TierChange{}, false, fmt.Errorf("cannot insert tier change: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.TierChange{}, false, fmt.Errorf("cannot commit tier change: %w", err)
+	}
+
+	return change, true, nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTransactions возвращает страницу движений баланса пользователя в хронологическом порядке.
+func (s *Storage) GetTransactions(
+	ctx context.Context,
+	userID int,
+	page models.TransactionPage,
+) ([]models.BalanceTransaction, error) {
+	q := `SELECT id, user_id, kind, amount, order_number, reference, balance, created_at
+			FROM balance_transactions WHERE user_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q, userID, page.After, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get balance transactions: %w", err)
+	}
+
+	defer rows.Close()
+
+	transactions := make([]models.BalanceTransaction, 0)
+
+	for rows.Next() {
+		t := models.BalanceTransaction{}
+
+		err = rows.Scan(&t.ID, &t.UserID, &t.Kind, &t.Amount, &t.Order, &t.Reference, &t.Balance, &t.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan balance transaction: %w", err)
+		}
+
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get balance transactions: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return transactions, nil
+}
+
+// recordTransaction сохраняет движение баланса в журнал и событие об изменении баланса для пользователя.
+// Вызывается в транзакции, изменившей баланс, t.Balance баланс после изменения.
+func recordTransaction(ctx context.Context, tx pgx.Tx, t models.BalanceTransaction) error {
+	if t.Amount == 0 {
+		return nil
+	}
+
+	q := `INSERT INTO balance_transactions (user_id, kind, amount, order_number, reference, balance, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, now())`
+
+	_, err := tx.Exec(ctx, q, t.UserID, t.Kind, t.Amount, t.Order, t.Reference, t.Balance)
+	if err != nil {
+		return fmt.Errorf("cannot insert balance transaction: %w", err)
+	}
+
+	return insertEvent(ctx, tx, models.UserEvent{
+		UserID:      t.UserID,
+		Kind:        models.EventBalance,
+		Order:       t.Order,
+		Transaction: t.Kind,
+		Amount:      t.Amount,
+		Balance:     t.Balance,
+	})
+}
+
+// transactionOrder возвращает заказ движения: у начислений по заказу ссылка партии баллов — номер заказа.
+func transactionOrder(source, reference string) string {
+	switch source {
+	case models.LotSourceAccrual, models.LotSourceCampaign, models.LotSourceRefund:
+		return reference
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+// testTransactions возвращает все движения баланса пользователя.
+func testTransactions(t *testing.T, s *Storage, userID int) []models.BalanceTransaction {
+	t.Helper()
+
+	transactions, err := s.GetTransactions(context.Background(), userID, models.TransactionPage{Limit: 100})
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("GetTransactions: %v", err)
+	}
+
+	return transactions
+}
+
+// TestTransactionJournal проверяет, что каждое изменение баланса попадает в журнал с балансом после него.
+func TestTransactionJournal(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+	addTestWithdrawal(t, s, alice.ID, "2377225624", 300)
+
+	if _, err := s.RefundWithdrawal(ctx, models.WithdrawalRefund{Order: "2377225624", Sum: 100, Reason: "r"}); err != nil {
+		t.Fatalf("RefundWithdrawal: %v", err)
+	}
+
+	tr := addTestTransfer(t, s, alice.ID, bob.ID, 200)
+
+	// остаток первой партии сгорает
+	lot := testLots(t, s, alice.ID)[0]
+	setLotExpiry(t, s, lot.id, timeAt(-time.Minute))
+
+	if _, _, err := s.ExpirePoints(ctx, 100); err != nil {
+		t.Fatalf("ExpirePoints: %v", err)
+	}
+
+	want := []models.BalanceTransaction{
+		{Kind: models.LotSourceAccrual, Amount: 1000, Order: "79927398713", Reference: "79927398713", Balance: 1000},
+		{Kind: models.TransactionWithdrawal, Amount: -300, Order: "2377225624", Balance: 700},
+		{Kind: models.LotSourceRefund, Amount: 100, Order: "2377225624", Reference: "2377225624", Balance: 800},
+		{Kind: models.LotSourceTransfer, Amount: -200, Reference: strconv.FormatInt(tr.ID, 10), Balance: 600},
+		{Kind: models.TransactionExpiration, Amount: -500, Reference: strconv.FormatInt(lot.id, 10), Balance: 100},
+	}
+
+	got := testTransactions(t, s, alice.ID)
+	if len(got) != len(want) {
+		t.Fatalf("transactions = %+v, want %d", got, len(want))
+	}
+
+	for i, w := range want {
+		g := got[i]
+		if g.UserID != alice.ID || g.Kind != w.Kind || g.Amount != w.Amount || g.Order != w.Order ||
+			g.Reference != w.Reference || g.Balance != w.Balance || g.CreatedAt.IsZero() {
+			t.Errorf("transaction %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != got[len(got)-1].Balance {
+		t.Errorf("balance = %d, journal ends with %d", b.Current, got[len(got)-1].Balance)
+	}
+
+	// получатель видит только своё зачисление
+	got = testTransactions(t, s, bob.ID)
+	if len(got) != 1 || got[0].Kind != models.LotSourceTransfer || got[0].Amount != 200 || got[0].Balance != 200 {
+		t.Errorf("recipient transactions = %+v", got)
+	}
+}
+
+func TestGetTransactionsPages(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+
+	if _, err := s.GetTransactions(ctx, u.ID, models.TransactionPage{Limit: 10}); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("empty journal: err = %v, want ErrNotFound", err)
+	}
+
+	for _, number := range []string{"79927398713", "12345678903", "4561261212345467"} {
+		addTestAccrual(t, s, u.ID, number, 100)
+	}
+
+	first, err := s.GetTransactions(ctx, u.ID, models.TransactionPage{Limit: 2})
+	if err != nil || len(first) != 2 || first[0].Order != "79927398713" || first[1].Order != "12345678903" {
+		t.Fatalf("first page = %+v, %v", first, err)
+	}
+
+	second, err := s.GetTransactions(ctx, u.ID, models.TransactionPage{After: first[1].ID, Limit: 2})
+	if err != nil || len(second) != 1 || second[0].Order != "4561261212345467" || second[0].Balance != 300 {
+		t.Fatalf("second page = %+v, %v", second, err)
+	}
+
+	_, err = s.GetTransactions(ctx, u.ID, models.TransactionPage{After: second[0].ID, Limit: 2})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("page after the last transaction: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,283 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const transferColumns = `t.id, t.sender_id, s.login, t.recipient_id, r.login, t.amount, t.status, t.reversal_note,
+	coalesce(t.reversed_by, 0), t.created_at, t.reversed_at`
+
+const transferJoins = `transfers t JOIN users s ON s.id = t.sender_id JOIN users r ON r.id = t.recipient_id`
+
+// Transfer в одной транзакции списывает баллы отправителя, зачисляет их получателю с логином RecipientLogin
+// отдельной партией и сохраняет перевод и уведомление получателя. Балансы обоих пользователей блокируются
+// до проверки ограничений, поэтому параллельные переводы одного отправителя не могут их обойти.
+// Неизвестный получатель и перевод самому себе проверяются после ограничений и баланса и возвращают
+// models.ErrInvalidInput, чтобы отказ не выдавал, существует ли логин.
+func (s *Storage) Transfer(
+	ctx context.Context,
+	t models.Transfer,
+	limits models.TransferLimits,
+) (models.Transfer, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE login = $1", t.RecipientLogin).Scan(&t.RecipientID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return models.Transfer{}, fmt.Errorf("cannot get recipient: %w", err)
+	}
+
+	userIDs := []int{t.SenderID}
+	if t.RecipientID != 0 && t.RecipientID != t.SenderID {
+		userIDs = append(userIDs, t.RecipientID)
+	}
+
+	if err := lockBalances(ctx, tx, userIDs...); err != nil {
+		return models.Transfer{}, err
+	}
+
+	if limits.DailyAmount > 0 || limits.DailyCount > 0 {
+		var count, total int
+
+		q := `SELECT count(*), coalesce(sum(amount), 0) FROM transfers
+				WHERE sender_id = $1 AND status = $2 AND created_at > now() - interval '1 day'`
+
+		err = tx.QueryRow(ctx, q, t.SenderID, models.TransferCompleted).Scan(&count, &total)
+		if err != nil {
+			return models.Transfer{}, fmt.Errorf("cannot get daily transfers: %w", err)
+		}
+
+		if (limits.DailyCount > 0 && count >= limits.DailyCount) ||
+			(limits.DailyAmount > 0 && total+t.Amount > limits.DailyAmount) {
+			return models.Transfer{}, models.ErrTransferLimit
+		}
+	}
+
+	var available int
+
+	err = tx.QueryRow(ctx, "SELECT coalesce(current, 0) - held FROM balance WHERE user_id = $1", t.SenderID).
+		Scan(&available)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot get balance: %w", err)
+	}
+
+	if available < t.Amount {
+		return models.Transfer{}, models.ErrInsufficientBalance
+	}
+
+	if len(userIDs) == 1 {
+		return models.Transfer{}, fmt.Errorf("%w: invalid recipient", models.ErrInvalidInput)
+	}
+
+	q := `INSERT INTO transfers (sender_id, recipient_id, amount, status, created_at)
+			VALUES ($1, $2, $3, $4, now()) RETURNING id, status, created_at`
+
+	err = tx.QueryRow(ctx, q, t.SenderID, t.RecipientID, t.Amount, models.TransferCompleted).
+		Scan(&t.ID, &t.Status, &t.CreatedAt)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot insert transfer: %w", err)
+	}
+
+	reference := transferReference(t.ID)
+
+	if err := s.moveBalance(ctx, tx, t.SenderID, t.RecipientID, t.Amount, reference); err != nil {
+		return models.Transfer{}, err
+	}
+
+	err = insertNotification(ctx, tx, models.Notification{
+		UserID:  t.RecipientID,
+		Kind:    models.NotificationTransferReceived,
+		Message: fmt.Sprintf("%.2f points received from %s", float64(t.Amount)/100, t.SenderLogin),
+	})
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot commit transfer: %w", err)
+	}
+
+	return t, nil
+}
+
+// ReverseTransfer отменяет выполненный перевод: в одной транзакции списывает баллы получателя,
+// возвращает их отправителю и уведомляет обоих. Если получатель уже потратил баллы,
+// возвращается models.ErrInsufficientBalance.
+func (s *Storage) ReverseTransfer(ctx context.Context, id int64, operatorID int, note string) (models.Transfer, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	t, err := scanTransfer(tx.QueryRow(ctx, "SELECT "+transferColumns+" FROM "+transferJoins+
+		" WHERE t.id = $1 FOR UPDATE OF t", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Transfer{}, models.ErrNotFound
+		}
+
+		return models.Transfer{}, fmt.Errorf("cannot get transfer: %w", err)
+	}
+
+	if t.Status != models.TransferCompleted {
+		return models.Transfer{}, models.ErrConflict
+	}
+
+	if err := lockBalances(ctx, tx, t.SenderID, t.RecipientID); err != nil {
+		return models.Transfer{}, err
+	}
+
+	reference := transferReference(t.ID)
+
+	if err := s.moveBalance(ctx, tx, t.RecipientID, t.SenderID, t.Amount, reference); err != nil {
+		return models.Transfer{}, err
+	}
+
+	q := `UPDATE transfers SET status = $1, reversal_note = $2, reversed_by = NULLIF($3, 0), reversed_at = now()
+			WHERE id = $4 RETURNING status, reversal_note, coalesce(reversed_by, 0), reversed_at`
+
+	err = tx.QueryRow(ctx, q, models.TransferReversed, note, operatorID, id).
+		Scan(&t.Status, &t.ReversalNote, &t.ReversedBy, &t.ReversedAt)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot update transfer: %w", err)
+	}
+
+	notifications := []models.Notification{
+		{
+			UserID:  t.SenderID,
+			Kind:    models.NotificationTransferReversed,
+			Message: fmt.Sprintf("Transfer of %.2f points to %s was reversed", float64(t.Amount)/100, t.RecipientLogin),
+		},
+		{
+			UserID:  t.RecipientID,
+			Kind:    models.NotificationTransferReversed,
+			Message: fmt.Sprintf("Transfer of %.2f points from %s was reversed", float64(t.Amount)/100, t.SenderLogin),
+		},
+	}
+
+	for _, n := range notifications {
+		if err := insertNotification(ctx, tx, n); err != nil {
+			return models.Transfer{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Transfer{}, fmt.Errorf("cannot commit transfer reversal: %w", err)
+	}
+
+	return t, nil
+}
+
+// GetTransfers возвращает отправленные и полученные пользователем переводы в порядке создания.
+func (s *Storage) GetTransfers(ctx context.Context, userID int) ([]models.Transfer, error) {
+	q := "SELECT " + transferColumns + " FROM " + transferJoins +
+		" WHERE t.sender_id = $1 OR t.recipient_id = $1 ORDER BY t.created_at, t.id"
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get transfers: %w", err)
+	}
+
+	defer rows.Close()
+
+	transfers := make([]models.Transfer, 0)
+
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan transfer: %w", err)
+		}
+
+		transfers = append(transfers, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func scanTransfer(row pgx.Row) (models.Transfer, error) {
+	t := models.Transfer{}
+
+	err := row.Scan(&t.ID, &t.SenderID, &t.SenderLogin, &t.RecipientID, &t.RecipientLogin, &t.Amount, &t.Status,
+		&t.ReversalNote, &t.ReversedBy, &t.CreatedAt, &t.ReversedAt)
+
+	return t, err
+}
+
+// moveBalance переводит amount копеек с баланса fromID на баланс toID. Части партий, израсходованные
+// у отправителя, становятся партиями получателя с прежним сроком сгорания, поэтому перевод не продлевает
+// срок жизни баллов. Баллы без сохранённых партий получают срок сгорания новых баллов.
+func (s *Storage) moveBalance(ctx context.Context, tx pgx.Tx, fromID, toID, amount int, reference string) error {
+	if _, err := updateBalance(ctx, tx, fromID, -amount, models.LotSourceTransfer, reference); err != nil {
+		return err
+	}
+
+	parts, err := consumeLots(ctx, tx, fromID, amount)
+	if err != nil {
+		return err
+	}
+
+	before, err := updateBalance(ctx, tx, toID, amount, models.LotSourceTransfer, reference)
+	if err != nil {
+		return err
+	}
+
+	left := amount
+
+	for _, p := range parts {
+		err = s.addLot(ctx, tx, toID, p.amount, before, models.LotSourceTransfer, reference, p.expiresAt)
+		if err != nil {
+			return err
+		}
+
+		before += p.amount
+		left -= p.amount
+	}
+
+	if left > 0 {
+		return s.addLot(ctx, tx, toID, left, before, models.LotSourceTransfer, reference, s.lotExpiry())
+	}
+
+	return nil
+}
+
+// lockBalances создаёт недостающие балансы пользователей и блокирует их в порядке id,
+// чтобы встречные переводы не приводили к взаимной блокировке.
+func lockBalances(ctx context.Context, tx pgx.Tx, userIDs ...int) error {
+	for _, id := range userIDs {
+		q := "INSERT INTO balance (user_id, current, withdraw) VALUES ($1, 0, 0) ON CONFLICT (user_id) DO NOTHING"
+
+		if _, err := tx.Exec(ctx, q, id); err != nil {
+			return fmt.Errorf("cannot init balance: %w", err)
+		}
+	}
+
+	q := "SELECT user_id FROM balance WHERE user_id = ANY($1) ORDER BY user_id FOR UPDATE"
+
+	if _, err := tx.Exec(ctx, q, userIDs); err != nil {
+		return fmt.Errorf("cannot lock balances: %w", err)
+	}
+
+	return nil
+}
+
+// transferReference ссылка на перевод в партии баллов.
+func transferReference(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,308 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+func addTestTransfer(t *testing.T, s *Storage, senderID, recipientID, amount int) models.Transfer {
+	t.Helper()
+
+	recipient, err := s.GetUserByID(context.Background(), recipientID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+
+	tr, err := s.Transfer(context.Background(),
+		models.Transfer{SenderID: senderID, RecipientLogin: recipient.Login, Amount: amount}, models.TransferLimits{})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	return tr
+}
+
+// transferLots возвращает партии пользователя, полученные переводами.
+func transferLots(t *testing.T, s *Storage, userID int) []testLot {
+	t.Helper()
+
+	lots := make([]testLot, 0)
+
+	for _, l := range testLots(t, s, userID) {
+		if l.source == models.LotSourceTransfer {
+			lots = append(lots, l)
+		}
+	}
+
+	return lots
+}
+
+func TestTransfer(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+
+	tr := addTestTransfer(t, s, alice.ID, bob.ID, 300)
+	if tr.ID == 0 || tr.Status != models.TransferCompleted {
+		t.Errorf("transfer = %+v", tr)
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 700 {
+		t.Errorf("sender balance = %d, want 700", b.Current)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != 300 {
+		t.Errorf("recipient balance = %d, want 300", b.Current)
+	}
+
+	_, err := s.Transfer(ctx, models.Transfer{SenderID: alice.ID, RecipientLogin: "bob", Amount: 800},
+		models.TransferLimits{})
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("transfer over balance: err = %v, want ErrInsufficientBalance", err)
+	}
+
+	transfers, err := s.GetTransfers(ctx, bob.ID)
+	if err != nil {
+		t.Fatalf("GetTransfers: %v", err)
+	}
+
+	if len(transfers) != 1 || transfers[0].SenderLogin != "alice" || transfers[0].RecipientLogin != "bob" {
+		t.Errorf("transfers = %+v", transfers)
+	}
+}
+
+// TestTransferRecipient проверяет, что неизвестный получатель отклоняется так же, как перевод самому себе,
+// и только после ограничений и баланса, поэтому отказ не выдаёт, существует ли логин.
+func TestTransferRecipient(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	addTestUser(t, s, "bob")
+	carol := addTestUser(t, s, "carol")
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+
+	for _, recipient := range []string{"ghost", "alice"} {
+		_, err := s.Transfer(ctx, models.Transfer{SenderID: alice.ID, RecipientLogin: recipient, Amount: 100},
+			models.TransferLimits{})
+		if !errors.Is(err, models.ErrInvalidInput) {
+			t.Errorf("transfer to %s: err = %v, want ErrInvalidInput", recipient, err)
+		}
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 1000 {
+		t.Errorf("sender balance = %d, want 1000", b.Current)
+	}
+
+	// без баллов на балансе существующий и неизвестный логины неотличимы
+	for _, recipient := range []string{"bob", "ghost"} {
+		_, err := s.Transfer(ctx, models.Transfer{SenderID: carol.ID, RecipientLogin: recipient, Amount: 100},
+			models.TransferLimits{})
+		if !errors.Is(err, models.ErrInsufficientBalance) {
+			t.Errorf("transfer to %s without points: err = %v, want ErrInsufficientBalance", recipient, err)
+		}
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+
+	limits := models.TransferLimits{DailyAmount: 500, DailyCount: 2}
+	transfer := models.Transfer{SenderID: alice.ID, RecipientLogin: bob.Login}
+
+	tests := []struct {
+		amount int
+		want   error
+	}{
+		{amount: 300, want: nil},
+		{amount: 300, want: models.ErrTransferLimit},
+		{amount: 200, want: nil},
+		{amount: 10, want: models.ErrTransferLimit},
+	}
+
+	for i, tt := range tests {
+		transfer.Amount = tt.amount
+
+		if _, err := s.Transfer(ctx, transfer, limits); !errors.Is(err, tt.want) {
+			t.Errorf("%d: transfer %d: err = %v, want %v", i, tt.amount, err, tt.want)
+		}
+	}
+
+	// отменённые переводы не учитываются в лимите
+	transfers, err := s.GetTransfers(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("GetTransfers: %v", err)
+	}
+
+	if _, err := s.ReverseTransfer(ctx, transfers[0].ID, 0, "mistake"); err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+
+	transfer.Amount = 300
+
+	if _, err := s.Transfer(ctx, transfer, limits); err != nil {
+		t.Errorf("transfer after reversal: %v", err)
+	}
+}
+
+// TestTransferKeepsLotExpiry проверяет, что переведённые баллы сгорают в тот же срок, что и у отправителя.
+func TestTransferKeepsLotExpiry(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = 24 * time.Hour
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	addTestAccrual(t, s, alice.ID, "79927398713", 300)
+	addTestAccrual(t, s, alice.ID, "12345678903", 500)
+
+	soon, later := timeAt(time.Hour), timeAt(2*time.Hour)
+
+	lots := testLots(t, s, alice.ID)
+	setLotExpiry(t, s, lots[0].id, soon)
+	setLotExpiry(t, s, lots[1].id, later)
+
+	// 300 из первой партии и 100 из второй
+	addTestTransfer(t, s, alice.ID, bob.ID, 400)
+
+	received := transferLots(t, s, bob.ID)
+	if len(received) != 2 ||
+		received[0].remaining != 300 || !sameTime(received[0].expiresAt, soon) ||
+		received[1].remaining != 100 || !sameTime(received[1].expiresAt, later) {
+		t.Fatalf("recipient lots = %+v, want 300 expiring at %v and 100 at %v", received, *soon, *later)
+	}
+
+	lots = testLots(t, s, alice.ID)
+	if lots[0].remaining != 0 || lots[1].remaining != 400 {
+		t.Errorf("sender remaining = %d, %d, want 0, 400", lots[0].remaining, lots[1].remaining)
+	}
+
+	// перевод обратно тоже не продлевает срок
+	addTestTransfer(t, s, bob.ID, alice.ID, 350)
+
+	returned := transferLots(t, s, alice.ID)
+	if len(returned) != 2 ||
+		returned[0].remaining != 300 || !sameTime(returned[0].expiresAt, soon) ||
+		returned[1].remaining != 50 || !sameTime(returned[1].expiresAt, later) {
+		t.Errorf("returned lots = %+v, want 300 expiring at %v and 50 at %v", returned, *soon, *later)
+	}
+}
+
+func TestReverseTransfer(t *testing.T) {
+	s := newTestStorage(t)
+	s.pointsTTL = 24 * time.Hour
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	admin := addTestUser(t, s, "admin")
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+
+	soon := timeAt(time.Hour)
+	setLotExpiry(t, s, testLots(t, s, alice.ID)[0].id, soon)
+
+	tr := addTestTransfer(t, s, alice.ID, bob.ID, 400)
+
+	reversed, err := s.ReverseTransfer(ctx, tr.ID, admin.ID, "sent by mistake")
+	if err != nil {
+		t.Fatalf("ReverseTransfer: %v", err)
+	}
+
+	if reversed.Status != models.TransferReversed || reversed.ReversedBy != admin.ID ||
+		reversed.ReversalNote != "sent by mistake" || reversed.ReversedAt == nil {
+		t.Errorf("reversed transfer = %+v", reversed)
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 1000 {
+		t.Errorf("sender balance = %d, want 1000", b.Current)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != 0 {
+		t.Errorf("recipient balance = %d, want 0", b.Current)
+	}
+
+	// возвращённые отправителю баллы сгорают в прежний срок
+	for _, l := range transferLots(t, s, alice.ID) {
+		if !sameTime(l.expiresAt, soon) {
+			t.Errorf("returned lot %+v, want expiring at %v", l, *soon)
+		}
+	}
+
+	if _, err := s.ReverseTransfer(ctx, tr.ID, admin.ID, "again"); !errors.Is(err, models.ErrConflict) {
+		t.Errorf("repeated reversal: err = %v, want ErrConflict", err)
+	}
+
+	if _, err := s.ReverseTransfer(ctx, tr.ID+100, admin.ID, "unknown"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown transfer: err = %v, want ErrNotFound", err)
+	}
+
+	// получатель уже потратил баллы
+	tr = addTestTransfer(t, s, alice.ID, bob.ID, 400)
+	addTestWithdrawal(t, s, bob.ID, "2377225624", 100)
+
+	if _, err := s.ReverseTransfer(ctx, tr.ID, admin.ID, "spent"); !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Errorf("reversal of spent points: err = %v, want ErrInsufficientBalance", err)
+	}
+}
+
+// TestTransferConcurrentOpposite проверяет, что встречные переводы не приводят к взаимной блокировке
+// и не теряют баллы.
+func TestTransferConcurrentOpposite(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	const transfers = 20
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+	addTestAccrual(t, s, alice.ID, "79927398713", transfers*100)
+	addTestAccrual(t, s, bob.ID, "12345678903", transfers*100)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < transfers; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := s.Transfer(ctx, models.Transfer{SenderID: alice.ID, RecipientLogin: "bob", Amount: 100},
+				models.TransferLimits{})
+			if err != nil {
+				t.Errorf("Transfer: %v", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := s.Transfer(ctx, models.Transfer{SenderID: bob.ID, RecipientLogin: "alice", Amount: 50},
+				models.TransferLimits{})
+			if err != nil {
+				t.Errorf("Transfer: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if b := testBalance(t, s, alice.ID); b.Current != transfers*50 {
+		t.Errorf("alice balance = %d, want %d", b.Current, transfers*50)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != transfers*150 {
+		t.Errorf("bob balance = %d, want %d", b.Current, transfers*150)
+	}
+}
@@ -0,0 +1,347 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const webhookColumns = `id, shop, url, secret, events, active, created_by, created_at`
+
+// deliveryColumns колонки доставки вместе с адресом и событием, запрос должен соединять
+// webhook_deliveries d, webhooks w и webhook_outbox e.
+const deliveryColumns = `d.id, d.webhook_id, w.url, w.secret, e.id, e.kind, e.order_number, e.amount, e.created_at,
+		d.status, d.attempts, d.next_attempt_at, d.last_error, d.created_at, d.delivered_at`
+
+// AddWebhook регистрирует адрес магазина.
+func (s *Storage) AddWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error) {
+	q := `INSERT INTO webhooks (shop, url, secret, events, active, created_by, created_at)
+			VALUES ($1, $2, $3, $4, TRUE, $5, now()) RETURNING ` + webhookColumns
+
+	w, err := scanWebhook(s.pool.QueryRow(ctx, q, w.Shop, w.URL, w.Secret, w.Events, w.CreatedBy))
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("cannot insert webhook: %w", err)
+	}
+
+	return w, nil
+}
+
+// GetWebhooks возвращает адреса, зарегистрированные пользователем createdBy, или все адреса, если createdBy 0.
+func (s *Storage) GetWebhooks(ctx context.Context, createdBy int) ([]models.Webhook, error) {
+	q := "SELECT " + webhookColumns + " FROM webhooks WHERE created_by = $1 OR $1 = 0 ORDER BY id"
+
+	rows, err := s.pool.Query(ctx, q, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get webhooks: %w", err)
+	}
+
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func (s *Storage) GetWebhook(ctx context.Context, id int64) (models.Webhook, error) {
+	q := "SELECT " + webhookColumns + " FROM webhooks WHERE id = $1"
+
+	w, err := scanWebhook(s.pool.QueryRow(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Webhook{}, models.ErrNotFound
+		}
+
+		return models.Webhook{}, fmt.Errorf("cannot get webhook: %w", err)
+	}
+
+	return w, nil
+}
+
+// DisableWebhook выключает адрес. Недоставленные события на него больше не отправляются.
+func (s *Storage) DisableWebhook(ctx context.Context, id int64) (models.Webhook, error) {
+	q := "UPDATE webhooks SET active = FALSE WHERE id = $1 RETURNING " + webhookColumns
+
+	w, err := scanWebhook(s.pool.QueryRow(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Webhook{}, models.ErrNotFound
+		}
+
+		return models.Webhook{}, fmt.Errorf("cannot disable webhook: %w", err)
+	}
+
+	return w, nil
+}
+
+// GetWebhookDeliveries возвращает последние доставки на адрес вместе с попытками, новые первыми.
+func (s *Storage) GetWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]models.WebhookDelivery, error) {
+	q := `SELECT ` + deliveryColumns + `
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			JOIN webhook_outbox e ON e.id = d.event_id
+			WHERE d.webhook_id = $1 ORDER BY d.id DESC LIMIT $2`
+
+	deliveries, err := s.queryDeliveries(ctx, q, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	index := make(map[int64]int, len(deliveries))
+
+	for i, d := range deliveries {
+		ids = append(ids, d.ID)
+		index[d.ID] = i
+	}
+
+	q = `SELECT delivery_id, attempt, status_code, error, duration_ms, created_at
+			FROM webhook_attempts WHERE delivery_id = ANY($1) ORDER BY id`
+
+	rows, err := s.pool.Query(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get webhook attempts: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			a          models.WebhookAttempt
+			durationMs int64
+		)
+
+		err = rows.Scan(&a.DeliveryID, &a.Attempt, &a.StatusCode, &a.Error, &durationMs, &a.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan webhook attempt: %w", err)
+		}
+
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+
+		i := index[a.DeliveryID]
+		deliveries[i].History = append(deliveries[i].History, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get webhook attempts: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// DispatchWebhookEvents создаёт доставки событий outbox на включённые адреса магазинов, которым принадлежат
+// заказы и которые подписаны на тип события. Адрес учётной записи магазина получает события, только пока
+// его префикс совпадает с магазином, к которому она привязана, префиксы других адресов задал администратор.
+// Возвращает количество обработанных событий.
+func (s *Storage) DispatchWebhookEvents(ctx context.Context, limit int) (int, error) {
+	q := `WITH events AS (
+				SELECT id, kind, order_number FROM webhook_outbox
+				WHERE dispatched_at IS NULL ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+			), deliveries AS (
+				INSERT INTO webhook_deliveries (webhook_id, event_id, status, next_attempt_at, created_at)
+				SELECT w.id, e.id, $2, now(), now() FROM events e
+				JOIN webhooks w ON w.active AND e.kind = ANY(w.events)
+					AND left(e.order_number, length(w.shop)) = w.shop
+				JOIN users u ON u.id = w.created_by AND (u.role = $3 OR (u.shop <> '' AND u.shop = w.shop))
+				ON CONFLICT (webhook_id, event_id) DO NOTHING
+			)
+			UPDATE webhook_outbox o SET dispatched_at = now() FROM events e WHERE o.id = e.id`
+
+	tag, err := s.pool.Exec(ctx, q, limit, models.WebhookPending, models.RoleAdmin)
+	if err != nil {
+		return 0, fmt.Errorf("cannot dispatch webhook events: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// SetUserShop привязывает учётную запись магазина к префиксу номеров заказов, пустой префикс снимает привязку.
+// Префикс не должен пересекаться с префиксами других учётных записей, иначе магазин получал бы события
+// чужих заказов.
+func (s *Storage) SetUserShop(ctx context.Context, userID int, shop string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	// привязки выполняются по очереди, чтобы одновременно не привязать пересекающиеся префиксы
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('users.shop'))"); err != nil {
+		return fmt.Errorf("cannot lock shops: %w", err)
+	}
+
+	if shop != "" {
+		var overlaps bool
+
+		q := `SELECT EXISTS (SELECT 1 FROM users WHERE id <> $1 AND shop <> ''
+				AND (left($2, length(shop)) = shop OR left(shop, length($2)) = $2))`
+
+		if err = tx.QueryRow(ctx, q, userID, shop).Scan(&overlaps); err != nil {
+			return fmt.Errorf("cannot check shop: %w", err)
+		}
+
+		if overlaps {
+			return models.ErrConflict
+		}
+	}
+
+	tag, err := tx.Exec(ctx, "UPDATE users SET shop = $1 WHERE id = $2", shop, userID)
+	if err != nil {
+		return fmt.Errorf("cannot update user shop: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit user shop: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserShop возвращает префикс номеров заказов магазина, к которому привязана учётная запись.
+func (s *Storage) GetUserShop(ctx context.Context, userID int) (string, error) {
+	var shop string
+
+	err := s.pool.QueryRow(ctx, "SELECT shop FROM users WHERE id = $1", userID).Scan(&shop)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrNotFound
+		}
+
+		return "", fmt.Errorf("cannot get user shop: %w", err)
+	}
+
+	return shop, nil
+}
+
+// ClaimWebhookDeliveries выбирает доставки, время попытки которых наступило, и откладывает их на lease,
+// чтобы их не выбрал другой экземпляр сервиса. Если попытка не будет записана, например из-за остановки
+// сервиса, доставка повторится после lease. Счётчик попыток увеличивается при выборе.
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	q := `WITH due AS (
+				SELECT d.id FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id
+				WHERE d.status = $1 AND d.next_attempt_at <= now() AND w.active
+				ORDER BY d.next_attempt_at LIMIT $2 FOR UPDATE OF d SKIP LOCKED
+			)
+			UPDATE webhook_deliveries d
+			SET attempts = d.attempts + 1, next_attempt_at = now() + make_interval(secs => $3)
+			FROM due, webhooks w, webhook_outbox e
+			WHERE d.id = due.id AND w.id = d.webhook_id AND e.id = d.event_id
+			RETURNING ` + deliveryColumns
+
+	return s.queryDeliveries(ctx, q, models.WebhookPending, limit, lease.Seconds())
+}
+
+// SaveWebhookAttempt записывает попытку доставки и новое состояние доставки. Следующая попытка
+// назначается на next, если доставка остаётся в ожидании.
+func (s *Storage) SaveWebhookAttempt(
+	ctx context.Context,
+	attempt models.WebhookAttempt,
+	status models.WebhookDeliveryStatus,
+	next time.Time,
+) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := `INSERT INTO webhook_attempts (delivery_id, attempt, status_code, error, duration_ms, created_at)
+			VALUES ($1, $2, $3, $4, $5, now())`
+
+	_, err = tx.Exec(ctx, q, attempt.DeliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error,
+		attempt.Duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("cannot insert webhook attempt: %w", err)
+	}
+
+	q = `UPDATE webhook_deliveries SET status = $1, last_error = $2, next_attempt_at = $3,
+				delivered_at = CASE WHEN $1 = $4 THEN now() END
+			WHERE id = $5`
+
+	_, err = tx.Exec(ctx, q, status, attempt.Error, next, models.WebhookDelivered, attempt.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("cannot update webhook delivery: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+// insertWebhookEvent сохраняет событие для магазинов в outbox в транзакции начисления или списания.
+func insertWebhookEvent(ctx context.Context, tx pgx.Tx, kind, orderNumber string, amount int) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	q := "INSERT INTO webhook_outbox (kind, order_number, amount, created_at) VALUES ($1, $2, $3, now())"
+
+	if _, err := tx.Exec(ctx, q, kind, orderNumber, amount); err != nil {
+		return fmt.Errorf("cannot insert webhook event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) queryDeliveries(ctx context.Context, q string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := s.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get webhook deliveries: %w", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+
+	for rows.Next() {
+		d := models.WebhookDelivery{}
+
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.Event.ID, &d.Event.Kind, &d.Event.Order,
+			&d.Event.Amount, &d.Event.CreatedAt, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError,
+			&d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("cannot get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row pgx.Row) (models.Webhook, error) {
+	w := models.Webhook{}
+
+	err := row.Scan(&w.ID, &w.Shop, &w.URL, &w.Secret, &w.Events, &w.Active, &w.CreatedBy, &w.CreatedAt)
+
+	return w, err
+}
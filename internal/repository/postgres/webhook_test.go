@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gophermat/internal/models"
+)
+
+// addTestRoleUser регистрирует пользователя с ролью role.
+func addTestRoleUser(t *testing.T, s *Storage, login string, role models.Role) models.User {
+	t.Helper()
+
+	u := addTestUser(t, s, login)
+
+	err := s.SetUserRole(context.Background(), models.RoleChange{UserID: u.ID, OldRole: u.Role, NewRole: role})
+	if err != nil {
+		t.Fatalf("SetUserRole: %v", err)
+	}
+
+	u.Role = role
+
+	return u
+}
+
+// addTestShop регистрирует учётную запись магазина, привязанную к префиксу shop.
+func addTestShop(t *testing.T, s *Storage, login, shop string) models.User {
+	t.Helper()
+
+	u := addTestRoleUser(t, s, login, models.RoleService)
+
+	if err := s.SetUserShop(context.Background(), u.ID, shop); err != nil {
+		t.Fatalf("SetUserShop: %v", err)
+	}
+
+	return u
+}
+
+func addTestWebhook(t *testing.T, s *Storage, createdBy int, shop string, events ...string) models.Webhook {
+	t.Helper()
+
+	w, err := s.AddWebhook(context.Background(), models.Webhook{
+		Shop:      shop,
+		URL:       "https://shop.example/hook",
+		Secret:    "secret",
+		Events:    events,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		t.Fatalf("AddWebhook: %v", err)
+	}
+
+	return w
+}
+
+func testDeliveries(t *testing.T, s *Storage, webhookID int64) []models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := s.GetWebhookDeliveries(context.Background(), webhookID, 100)
+	if err != nil {
+		t.Fatalf("GetWebhookDeliveries: %v", err)
+	}
+
+	return deliveries
+}
+
+func TestSetUserShop(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	first := addTestShop(t, s, "first", "123")
+	second := addTestRoleUser(t, s, "second", models.RoleService)
+
+	if shop, err := s.GetUserShop(ctx, first.ID); err != nil || shop != "123" {
+		t.Errorf("GetUserShop = %q, %v, want 123", shop, err)
+	}
+
+	if shop, err := s.GetUserShop(ctx, second.ID); err != nil || shop != "" {
+		t.Errorf("GetUserShop of an unbound account = %q, %v, want empty", shop, err)
+	}
+
+	// префикс, который содержит чужой или содержится в нём, захватывал бы чужие заказы
+	for _, shop := range []string{"123", "1234", "12"} {
+		if err := s.SetUserShop(ctx, second.ID, shop); !errors.Is(err, models.ErrConflict) {
+			t.Errorf("shop %s: err = %v, want ErrConflict", shop, err)
+		}
+	}
+
+	if err := s.SetUserShop(ctx, second.ID, "124"); err != nil {
+		t.Errorf("SetUserShop: %v", err)
+	}
+
+	// своя привязка не мешает сменить префикс
+	if err := s.SetUserShop(ctx, first.ID, "1235"); err != nil {
+		t.Errorf("SetUserShop of the same account: %v", err)
+	}
+
+	if err := s.SetUserShop(ctx, first.ID+100, "999"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("unknown user: err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := s.GetUserShop(ctx, first.ID+100); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("GetUserShop of unknown user: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestDispatchWebhookEvents проверяет, что магазин получает события только заказов своего магазина,
+// даже если адрес зарегистрирован с пустым или чужим префиксом в обход проверки сервиса.
+func TestDispatchWebhookEvents(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	admin := addTestRoleUser(t, s, "admin", models.RoleAdmin)
+	shop123 := addTestShop(t, s, "shop123", "123")
+	shop456 := addTestShop(t, s, "shop456", "456")
+	unbound := addTestRoleUser(t, s, "unbound", models.RoleService)
+	bob := addTestUser(t, s, "bob")
+
+	all := addTestWebhook(t, s, admin.ID, "", models.WebhookAccrual, models.WebhookWithdrawal)
+	own := addTestWebhook(t, s, shop123.ID, "123", models.WebhookAccrual)
+	foreign := addTestWebhook(t, s, shop123.ID, "456", models.WebhookAccrual)
+	empty := addTestWebhook(t, s, shop456.ID, "", models.WebhookAccrual)
+	unboundHook := addTestWebhook(t, s, unbound.ID, "", models.WebhookAccrual)
+	disabled := addTestWebhook(t, s, shop456.ID, "456", models.WebhookAccrual)
+
+	if _, err := s.DisableWebhook(ctx, disabled.ID); err != nil {
+		t.Fatalf("DisableWebhook: %v", err)
+	}
+
+	addTestAccrual(t, s, bob.ID, "12345678903", 1000)
+	addTestAccrual(t, s, bob.ID, "4561261212345467", 500)
+	addTestWithdrawal(t, s, bob.ID, "1230000000", 300)
+
+	count, err := s.DispatchWebhookEvents(ctx, 100)
+	if err != nil || count != 3 {
+		t.Fatalf("DispatchWebhookEvents = %d, %v, want 3", count, err)
+	}
+
+	if d := testDeliveries(t, s, all.ID); len(d) != 3 {
+		t.Errorf("admin webhook deliveries = %d, want all 3 events", len(d))
+	}
+
+	d := testDeliveries(t, s, own.ID)
+	if len(d) != 1 || d[0].Event.Order != "12345678903" || d[0].Event.Kind != models.WebhookAccrual ||
+		d[0].Event.Amount != 1000 || d[0].Status != models.WebhookPending {
+		t.Errorf("own shop deliveries = %+v, want the accrual of order 12345678903", d)
+	}
+
+	for name, w := range map[string]models.Webhook{
+		"foreign prefix": foreign, "empty prefix": empty, "unbound account": unboundHook, "disabled": disabled,
+	} {
+		if d := testDeliveries(t, s, w.ID); len(d) != 0 {
+			t.Errorf("%s webhook deliveries = %+v, want none", name, d)
+		}
+	}
+
+	if count, err := s.DispatchWebhookEvents(ctx, 100); err != nil || count != 0 {
+		t.Errorf("repeated dispatch = %d, %v, want 0", count, err)
+	}
+
+	// после отвязки от магазина адрес больше не получает события
+	if err := s.SetUserShop(ctx, shop123.ID, ""); err != nil {
+		t.Fatalf("SetUserShop: %v", err)
+	}
+
+	addTestAccrual(t, s, bob.ID, "1239999999", 100)
+
+	if _, err := s.DispatchWebhookEvents(ctx, 100); err != nil {
+		t.Fatalf("DispatchWebhookEvents: %v", err)
+	}
+
+	if d := testDeliveries(t, s, own.ID); len(d) != 1 {
+		t.Errorf("deliveries after unbinding = %d, want still 1", len(d))
+	}
+}
+
+func TestWebhookDeliveryAttempts(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	shop := addTestShop(t, s, "shop", "123")
+	bob := addTestUser(t, s, "bob")
+	w := addTestWebhook(t, s, shop.ID, "123", models.WebhookAccrual)
+
+	addTestAccrual(t, s, bob.ID, "12345678903", 1000)
+
+	if _, err := s.DispatchWebhookEvents(ctx, 100); err != nil {
+		t.Fatalf("DispatchWebhookEvents: %v", err)
+	}
+
+	claimed, err := s.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimWebhookDeliveries = %+v, %v, want 1", claimed, err)
+	}
+
+	d := claimed[0]
+	if d.WebhookID != w.ID || d.URL != w.URL || d.Secret != "secret" || d.Attempts != 1 {
+		t.Errorf("claimed delivery = %+v", d)
+	}
+
+	// доставка отложена на время попытки и не выбирается повторно
+	if again, err := s.ClaimWebhookDeliveries(ctx, 10, time.Minute); err != nil || len(again) != 0 {
+		t.Errorf("claim during lease = %+v, %v, want none", again, err)
+	}
+
+	failed := models.WebhookAttempt{DeliveryID: d.ID, Attempt: 1, StatusCode: 500, Error: "unexpected status code 500",
+		Duration: 20 * time.Millisecond}
+
+	if err := s.SaveWebhookAttempt(ctx, failed, models.WebhookPending, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("SaveWebhookAttempt: %v", err)
+	}
+
+	claimed, err = s.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	if err != nil || len(claimed) != 1 || claimed[0].Attempts != 2 || claimed[0].LastError != failed.Error {
+		t.Fatalf("retry claim = %+v, %v, want the second attempt", claimed, err)
+	}
+
+	delivered := models.WebhookAttempt{DeliveryID: d.ID, Attempt: 2, StatusCode: 200}
+
+	if err := s.SaveWebhookAttempt(ctx, delivered, models.WebhookDelivered, time.Now()); err != nil {
+		t.Fatalf("SaveWebhookAttempt: %v", err)
+	}
+
+	deliveries := testDeliveries(t, s, w.ID)
+	if len(deliveries) != 1 {
+		t.Fatalf("deliveries = %+v, want 1", deliveries)
+	}
+
+	got := deliveries[0]
+	if got.Status != models.WebhookDelivered || got.DeliveredAt == nil || len(got.History) != 2 ||
+		got.History[0].StatusCode != 500 || got.History[0].Duration != 20*time.Millisecond ||
+		got.History[1].StatusCode != 200 {
+		t.Errorf("delivery = %+v", got)
+	}
+
+	if again, err := s.ClaimWebhookDeliveries(ctx, 10, time.Minute); err != nil || len(again) != 0 {
+		t.Errorf("claim after delivery = %+v, %v, want none", again, err)
+	}
+}
+
+// TestClaimDisabledWebhook проверяет, что доставки на выключенный адрес не отправляются.
+func TestClaimDisabledWebhook(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	shop := addTestShop(t, s, "shop", "123")
+	w := addTestWebhook(t, s, shop.ID, "123", models.WebhookAccrual)
+
+	addTestAccrual(t, s, addTestUser(t, s, "bob").ID, "12345678903", 1000)
+
+	if _, err := s.DispatchWebhookEvents(ctx, 100); err != nil {
+		t.Fatalf("DispatchWebhookEvents: %v", err)
+	}
+
+	if _, err := s.DisableWebhook(ctx, w.ID); err != nil {
+		t.Fatalf("DisableWebhook: %v", err)
+	}
+
+	if claimed, err := s.ClaimWebhookDeliveries(ctx, 10, time.Minute); err != nil || len(claimed) != 0 {
+		t.Errorf("claimed = %+v, %v, want none", claimed, err)
+	}
+}
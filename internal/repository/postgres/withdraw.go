@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gophermat/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Withdraw в одной транзакции списывает баллы в оплату заказа и записывает списание в историю.
+// Списание расходует партии баллов начиная с тех, которые сгорят раньше.
+// Списания за один заказ сериализуются блокировкой по номеру заказа, поэтому параллельные запросы
+// не могут обойти ограничения. Заказ, оплаченный баллами другого пользователя, повторно не оплачивается.
+func (s *Storage) Withdraw(
+	ctx context.Context,
+	userID int,
+	withdraw models.BalanceWithdraw,
+	limits models.WithdrawLimits,
+) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockOrderWithdrawals(ctx, tx, withdraw.Order); err != nil {
+		return err
+	}
+
+	if err := s.withdraw(ctx, tx, userID, withdraw, limits); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("cannot commit withdrawal: %w", err)
+	}
+
+	return nil
+}
+
+// lockOrderWithdrawals сериализует списания за один заказ до конца транзакции.
+func lockOrderWithdrawals(ctx context.Context, tx pgx.Tx, order string) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", order)
+	if err != nil {
+		return fmt.Errorf("cannot lock order: %w", err)
+	}
+
+	return nil
+}
+
+// withdraw проверяет ограничения по заказу и доступный баланс, списывает баллы и записывает списание в историю,
+// журнал движений баланса, события пользователя и outbox событий для магазинов.
+// Списания за заказ должны быть заблокированы в той же транзакции.
+func (s *Storage) withdraw(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int,
+	withdraw models.BalanceWithdraw,
+	limits models.WithdrawLimits,
+) error {
+	var count, total, otherUsers int
+
+	q := `SELECT count(*) FILTER (WHERE user_id = $2),
+				coalesce(sum(sum - refunded) FILTER (WHERE user_id = $2), 0),
+				count(*) FILTER (WHERE user_id <> $2)
+			FROM history WHERE order_number = $1 AND sum > refunded`
+
+	err := tx.QueryRow(ctx, q, withdraw.Order, userID).Scan(&count, &total, &otherUsers)
+	if err != nil {
+		return fmt.Errorf("cannot get order withdrawals: %w", err)
+	}
+
+	if otherUsers > 0 || count >= limits.MaxPerOrder {
+		return models.ErrOrderWithdrawn
+	}
+
+	if limits.OrderCap > 0 && total+withdraw.Sum > limits.OrderCap {
+		return models.ErrOrderWithdrawalCap
+	}
+
+	var available int
+
+	// удержанные баллы недоступны для списания
+	q = "SELECT coalesce(current, 0) - held FROM balance WHERE user_id = $1 FOR UPDATE"
+
+	err = tx.QueryRow(ctx, q, userID).Scan(&available)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrInsufficientBalance
+		}
+
+		return fmt.Errorf("cannot get balance: %w", err)
+	}
+
+	if available < withdraw.Sum {
+		return models.ErrInsufficientBalance
+	}
+
+	var current int
+
+	q = `UPDATE balance SET current = current - $1, withdraw = coalesce(withdraw, 0) + $1 WHERE user_id = $2
+			RETURNING current`
+
+	err = tx.QueryRow(ctx, q, withdraw.Sum, userID).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("cannot update balance: %w", err)
+	}
+
+	err = recordTransaction(ctx, tx, models.BalanceTransaction{
+		UserID:  userID,
+		Kind:    models.TransactionWithdrawal,
+		Amount:  -withdraw.Sum,
+		Order:   withdraw.Order,
+		Balance: current,
+	})
+	if err != nil {
+		return err
+	}
+
+	parts, err := consumeLots(ctx, tx, userID, withdraw.Sum)
+	if err != nil {
+		return err
+	}
+
+	var historyID int64
+
+	q = "INSERT INTO history (user_id, order_number, sum, processed_at) VALUES ($1, $2, $3, now()) RETURNING id"
+
+	err = tx.QueryRow(ctx, q, userID, withdraw.Order, withdraw.Sum).Scan(&historyID)
+	if err != nil {
+		return fmt.Errorf("cannot insert balance history: %w", err)
+	}
+
+	// израсходованные партии запоминаются, чтобы при возврате вернуть баллы с прежним сроком сгорания
+	for _, p := range parts {
+		q = "INSERT INTO withdrawal_lots (history_id, lot_id, amount, expires_at) VALUES ($1, $2, $3, $4)"
+
+		_, err = tx.Exec(ctx, q, historyID, p.lotID, p.amount, p.expiresAt)
+		if err != nil {
+			return fmt.Errorf("cannot insert withdrawal lot: %w", err)
+		}
+	}
+
+	err = insertEvent(ctx, tx, models.UserEvent{
+		UserID:  userID,
+		Kind:    models.EventWithdrawal,
+		Order:   withdraw.Order,
+		Amount:  withdraw.Sum,
+		Balance: current,
+	})
+	if err != nil {
+		return err
+	}
+
+	return insertWebhookEvent(ctx, tx, models.WebhookWithdrawal, withdraw.Order, withdraw.Sum)
+}
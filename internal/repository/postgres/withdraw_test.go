@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"gophermat/internal/models"
+)
+
+func TestWithdraw(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	alice := addTestUser(t, s, "alice")
+	bob := addTestUser(t, s, "bob")
+
+	addTestAccrual(t, s, alice.ID, "79927398713", 1000)
+	addTestAccrual(t, s, bob.ID, "12345678903", 1000)
+
+	single := models.WithdrawLimits{MaxPerOrder: 1}
+
+	err := s.Withdraw(ctx, alice.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 1001}, single)
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Fatalf("overdraft: got %v, want ErrInsufficientBalance", err)
+	}
+
+	if err := s.Withdraw(ctx, alice.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 400}, single); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+
+	err = s.Withdraw(ctx, alice.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 100}, single)
+	if !errors.Is(err, models.ErrOrderWithdrawn) {
+		t.Fatalf("duplicate order: got %v, want ErrOrderWithdrawn", err)
+	}
+
+	// заказ, оплаченный одним пользователем, не может оплатить другой даже при оплате частями
+	err = s.Withdraw(ctx, bob.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 100}, models.WithdrawLimits{MaxPerOrder: 5})
+	if !errors.Is(err, models.ErrOrderWithdrawn) {
+		t.Fatalf("order of another user: got %v, want ErrOrderWithdrawn", err)
+	}
+
+	if b := testBalance(t, s, alice.ID); b.Current != 600 || b.Withdraw != 400 {
+		t.Errorf("balance = %+v, want current 600, withdraw 400", b)
+	}
+
+	if b := testBalance(t, s, bob.ID); b.Current != 1000 || b.Withdraw != 0 {
+		t.Errorf("balance of other user = %+v, want current 1000, withdraw 0", b)
+	}
+
+	err = s.Withdraw(ctx, alice.ID, models.BalanceWithdraw{Order: "12345678903", Sum: 100}, single)
+	if err != nil {
+		t.Fatalf("withdraw without accrual history: %v", err)
+	}
+
+	charlie := addTestUser(t, s, "charlie")
+
+	err = s.Withdraw(ctx, charlie.ID, models.BalanceWithdraw{Order: "4561261212345467", Sum: 1}, single)
+	if !errors.Is(err, models.ErrInsufficientBalance) {
+		t.Fatalf("user without balance: got %v, want ErrInsufficientBalance", err)
+	}
+}
+
+func TestSplitWithdrawal(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	limits := models.WithdrawLimits{MaxPerOrder: 2, OrderCap: 500}
+
+	if err := s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 300}, limits); err != nil {
+		t.Fatalf("first part: %v", err)
+	}
+
+	err := s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 300}, limits)
+	if !errors.Is(err, models.ErrOrderWithdrawalCap) {
+		t.Fatalf("over cap: got %v, want ErrOrderWithdrawalCap", err)
+	}
+
+	if err := s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 200}, limits); err != nil {
+		t.Fatalf("second part: %v", err)
+	}
+
+	err = s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: "2377225624", Sum: 1}, models.WithdrawLimits{MaxPerOrder: 2})
+	if !errors.Is(err, models.ErrOrderWithdrawn) {
+		t.Fatalf("third part: got %v, want ErrOrderWithdrawn", err)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 500 || b.Withdraw != 500 {
+		t.Errorf("balance = %+v, want current 500, withdraw 500", b)
+	}
+}
+
+// TestConcurrentWithdraw проверяет, что параллельные списания не оплачивают заказ дважды
+// и не уводят баланс в минус.
+func TestConcurrentWithdraw(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	u := addTestUser(t, s, "alice")
+	addTestAccrual(t, s, u.ID, "79927398713", 1000)
+
+	const calls = 10
+
+	limits := models.WithdrawLimits{MaxPerOrder: 1}
+
+	withdraw := func(orders func(i int) string) (ok, withdrawn, insufficient int) {
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+		)
+
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+
+			go func(order string) {
+				defer wg.Done()
+
+				err := s.Withdraw(ctx, u.ID, models.BalanceWithdraw{Order: order, Sum: 300}, limits)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case err == nil:
+					ok++
+				case errors.Is(err, models.ErrOrderWithdrawn):
+					withdrawn++
+				case errors.Is(err, models.ErrInsufficientBalance):
+					insufficient++
+				default:
+					t.Errorf("Withdraw: %v", err)
+				}
+			}(orders(i))
+		}
+
+		wg.Wait()
+
+		return ok, withdrawn, insufficient
+	}
+
+	ok, withdrawn, _ := withdraw(func(int) string { return "2377225624" })
+	if ok != 1 || withdrawn != calls-1 {
+		t.Errorf("same order: %d succeeded, %d rejected as withdrawn, want 1 and %d", ok, withdrawn, calls-1)
+	}
+
+	ok, _, insufficient := withdraw(func(i int) string { return fmt.Sprintf("order-%d", i) })
+	if ok != 2 || insufficient != calls-2 {
+		t.Errorf("different orders: %d succeeded, %d rejected as insufficient, want 2 and %d", ok, insufficient, calls-2)
+	}
+
+	if b := testBalance(t, s, u.ID); b.Current != 100 || b.Withdraw != 900 {
+		t.Errorf("balance = %+v, want current 100, withdraw 900", b)
+	}
+}
@@ -1,7 +1,198 @@
 package settings
 
+import "time"
+
 type Settings struct {
 	Address              string `env:"RUN_ADDRESS"`
 	DatabaseURI          string `env:"DATABASE_URI"`
 	AccrualSystemAddress string `env:"ACCRUAL_SYSTEM_ADDRESS"`
+	// AdminLogins пользователи, которым при запуске назначается роль администратора.
+	AdminLogins []string `env:"ADMIN_LOGINS" envSeparator:","`
+
+	Login       LoginSettings
+	Password    PasswordSettings
+	OIDC        OIDCSettings
+	Adjustment  AdjustmentSettings
+	Idempotency IdempotencySettings
+	Withdraw    WithdrawSettings
+	OrderNumber OrderNumberSettings
+	Points      PointsSettings
+	Tiers       TierSettings
+	Referral    ReferralSettings
+	Transfer    TransferSettings
+	Hold        HoldSettings
+	Events      EventSettings
+	WebSocket   WebSocketSettings
+	Webhook     WebhookSettings
+	Channel     ChannelSettings
+}
+
+// LoginSettings описывает ограничения на попытки входа в систему.
+type LoginSettings struct {
+	// MaxAttempts количество неудачных попыток для логина, после которого он блокируется.
+	MaxAttempts int `env:"LOGIN_MAX_ATTEMPTS" envDefault:"5"`
+	// IPMaxAttempts количество неудачных попыток с одного ip адреса, после которого он блокируется.
+	IPMaxAttempts int `env:"LOGIN_IP_MAX_ATTEMPTS" envDefault:"20"`
+	// DelayAfter количество неудачных попыток, после которого включается прогрессивная задержка.
+	DelayAfter int `env:"LOGIN_DELAY_AFTER" envDefault:"2"`
+	// Delay базовая задержка между попытками, удваивается с каждой следующей неудачей.
+	Delay time.Duration `env:"LOGIN_DELAY" envDefault:"1s"`
+	// Window период, после которого счётчик неудачных попыток сбрасывается.
+	Window time.Duration `env:"LOGIN_WINDOW" envDefault:"15m"`
+	// Lockout время блокировки после превышения количества попыток.
+	Lockout time.Duration `env:"LOGIN_LOCKOUT" envDefault:"15m"`
+	// TrustedProxies адреса и подсети прокси, которым доверяется заголовок X-Forwarded-For.
+	// Для остальных соединений адресом клиента считается адрес сокета.
+	TrustedProxies []string `env:"LOGIN_TRUSTED_PROXIES" envSeparator:","`
+}
+
+// PasswordSettings описывает требования к паролям и сброс пароля.
+type PasswordSettings struct {
+	// MinLen минимальная длина пароля.
+	MinLen int `env:"PASSWORD_MIN_LEN" envDefault:"5"`
+	// Banned список запрещённых распространённых паролей.
+	Banned []string `env:"PASSWORD_BANNED" envSeparator:"," envDefault:"12345,123456,1234567,12345678,123456789,1234567890,password,qwerty,qwerty123,11111,111111,00000,000000,abc123,iloveyou,admin,welcome,letmein"` //nolint:lll
+	// ResetTTL время жизни одноразового токена для сброса пароля.
+	ResetTTL time.Duration `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+	// Hash алгоритм хэширования паролей: argon2id или bcrypt.
+	Hash string `env:"PASSWORD_HASH" envDefault:"argon2id"`
+	// BcryptCost стоимость bcrypt.
+	BcryptCost int `env:"PASSWORD_BCRYPT_COST" envDefault:"10"`
+	// Argon2Time количество проходов argon2id.
+	Argon2Time uint32 `env:"PASSWORD_ARGON2_TIME" envDefault:"3"`
+	// Argon2Memory объём памяти argon2id в KiB.
+	Argon2Memory uint32 `env:"PASSWORD_ARGON2_MEMORY" envDefault:"65536"`
+	// Argon2Threads количество потоков argon2id.
+	Argon2Threads uint8 `env:"PASSWORD_ARGON2_THREADS" envDefault:"2"`
+}
+
+// OIDCSettings описывает вход через внешнего OpenID Connect провайдера. Вход отключён, если не задан Issuer.
+type OIDCSettings struct {
+	Issuer       string        `env:"OIDC_ISSUER"`
+	ClientID     string        `env:"OIDC_CLIENT_ID"`
+	ClientSecret string        `env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string        `env:"OIDC_REDIRECT_URL"`
+	Scopes       []string      `env:"OIDC_SCOPES" envSeparator:"," envDefault:"openid,email,profile"`
+	StateTTL     time.Duration `env:"OIDC_STATE_TTL" envDefault:"10m"`
+}
+
+// AdjustmentSettings описывает ручные изменения баланса сотрудниками.
+type AdjustmentSettings struct {
+	// ApprovalThreshold сумма в баллах, начиная с которой изменение требует подтверждения вторым сотрудником.
+	// 0 отключает подтверждение.
+	ApprovalThreshold float64 `env:"ADJUSTMENT_APPROVAL_THRESHOLD" envDefault:"1000"`
+}
+
+// IdempotencySettings описывает хранение ответов на запросы с заголовком Idempotency-Key.
+type IdempotencySettings struct {
+	// KeyTTL время, в течение которого повторный запрос с тем же ключом получает сохранённый ответ.
+	KeyTTL time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+}
+
+// WithdrawSettings описывает ограничения на списание баллов за один заказ.
+type WithdrawSettings struct {
+	// MaxPerOrder максимальное количество списаний за один заказ. Значение больше 1 разрешает оплату заказа частями.
+	MaxPerOrder int `env:"WITHDRAW_MAX_PER_ORDER" envDefault:"1"`
+	// OrderCap максимальная сумма в баллах всех списаний за один заказ, 0 без ограничения.
+	OrderCap float64 `env:"WITHDRAW_ORDER_CAP" envDefault:"0"`
+}
+
+// OrderNumberSettings описывает схемы номеров заказов магазинов-партнёров.
+type OrderNumberSettings struct {
+	// Schemes схемы в формате [<префикс магазина>=]<схема>[:<параметр>], схема без префикса применяется
+	// к остальным номерам. Схема магазина проверяет номер без префикса магазина.
+	// Поддерживаются luhn, regex:<выражение>, prefix-luhn:<префикс> и alnum.
+	Schemes []string `env:"ORDER_NUMBER_SCHEMES" envSeparator:";" envDefault:"luhn"`
+}
+
+// PointsSettings описывает сгорание баллов. Каждое начисление хранится отдельной партией со своим сроком.
+type PointsSettings struct {
+	// TTL срок жизни начисленных баллов, например 8760h для 12 месяцев. 0 отключает сгорание.
+	TTL time.Duration `env:"POINTS_TTL" envDefault:"0"`
+	// ExpiringSoon период, за который баллы показываются в балансе как скоро сгорающие.
+	ExpiringSoon time.Duration `env:"POINTS_EXPIRING_SOON" envDefault:"720h"`
+	// ExpirationInterval период запуска сжигания просроченных баллов.
+	ExpirationInterval time.Duration `env:"POINTS_EXPIRATION_INTERVAL" envDefault:"1h"`
+}
+
+// TierSettings описывает уровни программы лояльности.
+type TierSettings struct {
+	// Rules уровни в формате <название>:<порог в баллах>:<множитель>, например
+	// bronze:0:1;silver:5000:1.25;gold:20000:1.5. Пустой список отключает уровни.
+	Rules []string `env:"TIERS" envSeparator:";"`
+	// Basis основа для определения уровня: accrued начисленные за заказы баллы, spent потраченные баллы.
+	Basis string `env:"TIER_BASIS" envDefault:"accrued"`
+	// Window скользящий период, за который считаются баллы.
+	Window time.Duration `env:"TIER_WINDOW" envDefault:"8760h"`
+}
+
+// ReferralSettings описывает реферальную программу. Баллы начисляются обоим пользователям
+// после первого обработанного заказа приглашённого.
+type ReferralSettings struct {
+	// ReferrerBonus баллы пригласившему пользователю.
+	ReferrerBonus float64 `env:"REFERRAL_REFERRER_BONUS" envDefault:"100"`
+	// RefereeBonus баллы приглашённому пользователю.
+	RefereeBonus float64 `env:"REFERRAL_REFEREE_BONUS" envDefault:"100"`
+	// MaxPerUser сколько приглашений одного пользователя вознаграждается, 0 без ограничения.
+	MaxPerUser int `env:"REFERRAL_MAX_PER_USER" envDefault:"20"`
+}
+
+// TransferSettings описывает ограничения на переводы баллов между пользователями.
+type TransferSettings struct {
+	// MinAmount минимальная сумма перевода в баллах.
+	MinAmount float64 `env:"TRANSFER_MIN_AMOUNT" envDefault:"1"`
+	// DailyAmount максимальная сумма переводов одного пользователя за сутки, 0 без ограничения.
+	DailyAmount float64 `env:"TRANSFER_DAILY_AMOUNT" envDefault:"5000"`
+	// DailyCount максимальное количество переводов одного пользователя за сутки, 0 без ограничения.
+	DailyCount int `env:"TRANSFER_DAILY_COUNT" envDefault:"10"`
+}
+
+// HoldSettings описывает удержание баллов при оплате заказа до его подтверждения.
+type HoldSettings struct {
+	// TTL время, после которого неподтверждённое удержание снимается автоматически.
+	TTL time.Duration `env:"HOLD_TTL" envDefault:"15m"`
+	// ExpirationInterval период снятия просроченных удержаний.
+	ExpirationInterval time.Duration `env:"HOLD_EXPIRATION_INTERVAL" envDefault:"1m"`
+}
+
+// EventSettings описывает поток событий пользователя.
+type EventSettings struct {
+	// Retention время хранения событий, в течение которого переподключившийся клиент получает пропущенные события.
+	Retention time.Duration `env:"EVENTS_RETENTION" envDefault:"24h"`
+	// KeepAlive период отправки комментария в поток, чтобы прокси не закрывали неактивное соединение.
+	KeepAlive time.Duration `env:"EVENTS_KEEPALIVE" envDefault:"15s"`
+}
+
+// WebSocketSettings описывает соединения WebSocket API.
+type WebSocketSettings struct {
+	// PingInterval период отправки ping клиенту.
+	PingInterval time.Duration `env:"WS_PING_INTERVAL" envDefault:"30s"`
+	// PongTimeout время ожидания pong после ping, после которого соединение считается потерянным.
+	PongTimeout time.Duration `env:"WS_PONG_TIMEOUT" envDefault:"10s"`
+	// SendBuffer количество сообщений в очереди отправки клиенту. Клиент, который не успевает
+	// читать сообщения и переполняет очередь, отключается.
+	SendBuffer int `env:"WS_SEND_BUFFER" envDefault:"64"`
+}
+
+// WebhookSettings описывает отправку событий на адреса магазинов.
+type WebhookSettings struct {
+	// DispatchInterval период проверки новых событий и доставок, время которых наступило.
+	DispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" envDefault:"1s"`
+	// Timeout время ожидания ответа магазина.
+	Timeout time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// MaxAttempts количество попыток, после которого доставка считается неудачной.
+	MaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"10"`
+	// RetryDelay задержка перед второй попыткой, каждая следующая задержка вдвое больше.
+	RetryDelay time.Duration `env:"WEBHOOK_RETRY_DELAY" envDefault:"30s"`
+	// MaxRetryDelay наибольшая задержка между попытками.
+	MaxRetryDelay time.Duration `env:"WEBHOOK_MAX_RETRY_DELAY" envDefault:"1h"`
+	// AllowPrivate разрешает доставку на адреса во внутренней сети, только для локального окружения.
+	AllowPrivate bool `env:"WEBHOOK_ALLOW_PRIVATE"`
+}
+
+// ChannelSettings описывает каналы загрузки заказов, которые учитываются условиями акций.
+type ChannelSettings struct {
+	// Clients ключи клиентов в формате <канал>:<ключ>, например app:<ключ мобильного приложения>.
+	// Канал заказа определяется по ключу из заголовка X-Client-Key, заказ с неизвестным ключом загружается без канала.
+	Clients []string `env:"CHANNEL_CLIENTS" envSeparator:","`
 }